@@ -20,11 +20,22 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemaGroupVersion,
 		&ConsulBackup{},
 		&ConsulBackupList{},
+		&ConsulRestore{},
+		&ConsulRestoreList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemaGroupVersion)
 	return nil
 }
 
+type RestorePhase string
+
+const (
+	RestorePhasePending   RestorePhase = "Pending"
+	RestorePhaseRestoring RestorePhase = "Restoring"
+	RestorePhaseSucceeded RestorePhase = "Succeeded"
+	RestorePhaseFailed    RestorePhase = "Failed"
+)
+
 type ConsulBackup struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata"`
@@ -91,6 +102,72 @@ func (in *ConsulBackupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+type ConsulRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              ConsulRestoreSpec   `json:"spec"`
+	Status            ConsulRestoreStatus `json:"status"`
+}
+
+func (in *ConsulRestore) DeepCopyInto(out *ConsulRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+func (in *ConsulRestore) DeepCopy() *ConsulRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsulRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *ConsulRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+type ConsulRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ConsulRestore `json:"items"`
+}
+
+func (in *ConsulRestoreList) DeepCopyInto(out *ConsulRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		l := make([]ConsulRestore, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&l[i])
+		}
+		out.Items = l
+	}
+}
+
+func (in *ConsulRestoreList) DeepCopy() *ConsulRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsulRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *ConsulRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 type ConsulBackupSpec struct {
 	IntervalInSeconds int                         `json:"intervalInSeconds"`
 	MaxBackups        int                         `json:"maxBackups"`
@@ -144,6 +221,64 @@ func (in *ConsulBackupStatus) DeepCopy() *ConsulBackupStatus {
 	return out
 }
 
+type ConsulRestoreSpec struct {
+	// backup_name is the name of ConsulBackup in the same namespace.
+	//
+	//	The snapshot is read from the storage of ConsulBackup and restored to the service of ConsulBackup.
+	BackupName string `json:"backupName"`
+	// backup_time is the execute time of the history entry of ConsulBackup.
+	//
+	//	If both backup_time and path are empty, the latest succeeded backup will be restored.
+	BackupTime *metav1.Time `json:"backupTime,omitempty"`
+	// path is the object path of the snapshot in the storage.
+	//
+	//	path takes precedence over backup_time.
+	Path string `json:"path,omitempty"`
+}
+
+func (in *ConsulRestoreSpec) DeepCopyInto(out *ConsulRestoreSpec) {
+	*out = *in
+	if in.BackupTime != nil {
+		in, out := &in.BackupTime, &out.BackupTime
+		*out = new(metav1.Time)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+func (in *ConsulRestoreSpec) DeepCopy() *ConsulRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsulRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type ConsulRestoreStatus struct {
+	Phase        RestorePhase `json:"phase"`
+	Path         string       `json:"path,omitempty"`
+	Message      string       `json:"message,omitempty"`
+	RestoredTime *metav1.Time `json:"restoredTime,omitempty"`
+}
+
+func (in *ConsulRestoreStatus) DeepCopyInto(out *ConsulRestoreStatus) {
+	*out = *in
+	if in.RestoredTime != nil {
+		in, out := &in.RestoredTime, &out.RestoredTime
+		*out = new(metav1.Time)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+func (in *ConsulRestoreStatus) DeepCopy() *ConsulRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsulRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 type ConsulBackupStorageSpec struct {
 	MinIO *BackupStorageMinIOSpec `json:"minio,omitempty"`
 	GCS   *BackupStorageGCSSpec   `json:"gcs,omitempty"`
@@ -0,0 +1,366 @@
+package etcdv1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const GroupName = "etcd.f110.dev"
+
+var (
+	GroupVersion       = metav1.GroupVersion{Group: GroupName, Version: "v1alpha1"}
+	SchemeBuilder      = runtime.NewSchemeBuilder(addKnownTypes)
+	AddToScheme        = SchemeBuilder.AddToScheme
+	SchemaGroupVersion = schema.GroupVersion{Group: "etcd.f110.dev", Version: "v1alpha1"}
+)
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemaGroupVersion,
+		&EtcdBackup{},
+		&EtcdBackupList{},
+	)
+	metav1.AddToGroupVersion(scheme, SchemaGroupVersion)
+	return nil
+}
+
+type EtcdBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              EtcdBackupSpec   `json:"spec"`
+	Status            EtcdBackupStatus `json:"status"`
+}
+
+func (in *EtcdBackup) DeepCopyInto(out *EtcdBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+func (in *EtcdBackup) DeepCopy() *EtcdBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *EtcdBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+type EtcdBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []EtcdBackup `json:"items"`
+}
+
+func (in *EtcdBackupList) DeepCopyInto(out *EtcdBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		l := make([]EtcdBackup, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&l[i])
+		}
+		out.Items = l
+	}
+}
+
+func (in *EtcdBackupList) DeepCopy() *EtcdBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *EtcdBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+type EtcdBackupSpec struct {
+	// schedule is the schedule of backup in the cron format.
+	Schedule string `json:"schedule"`
+	// max_backups is the number of backup files which are kept in the storage.
+	//
+	//	If max_backups is zero, the backup file will not be rotated.
+	MaxBackups int `json:"maxBackups"`
+	// endpoints are the endpoints of the etcd cluster.
+	Endpoints  []string              `json:"endpoints"`
+	Credential *EtcdClientCredential `json:"credential,omitempty"`
+	Storage    EtcdBackupStorageSpec `json:"storage"`
+}
+
+func (in *EtcdBackupSpec) DeepCopyInto(out *EtcdBackupSpec) {
+	*out = *in
+	if in.Endpoints != nil {
+		t := make([]string, len(in.Endpoints))
+		copy(t, in.Endpoints)
+		out.Endpoints = t
+	}
+	if in.Credential != nil {
+		in, out := &in.Credential, &out.Credential
+		*out = new(EtcdClientCredential)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Storage.DeepCopyInto(&out.Storage)
+}
+
+func (in *EtcdBackupSpec) DeepCopy() *EtcdBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type EtcdBackupStatus struct {
+	Succeeded           bool                      `json:"succeeded"`
+	LastSucceededTime   *metav1.Time              `json:"lastSucceededTime,omitempty"`
+	BackupStatusHistory []EtcdBackupStatusHistory `json:"backupStatusHistory"`
+}
+
+func (in *EtcdBackupStatus) DeepCopyInto(out *EtcdBackupStatus) {
+	*out = *in
+	if in.LastSucceededTime != nil {
+		in, out := &in.LastSucceededTime, &out.LastSucceededTime
+		*out = new(metav1.Time)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackupStatusHistory != nil {
+		l := make([]EtcdBackupStatusHistory, len(in.BackupStatusHistory))
+		for i := range in.BackupStatusHistory {
+			in.BackupStatusHistory[i].DeepCopyInto(&l[i])
+		}
+		out.BackupStatusHistory = l
+	}
+}
+
+func (in *EtcdBackupStatus) DeepCopy() *EtcdBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type EtcdClientCredential struct {
+	CACertificate *corev1.SecretKeySelector `json:"caCertificate,omitempty"`
+	Certificate   *corev1.SecretKeySelector `json:"certificate,omitempty"`
+	PrivateKey    *corev1.SecretKeySelector `json:"privateKey,omitempty"`
+}
+
+func (in *EtcdClientCredential) DeepCopyInto(out *EtcdClientCredential) {
+	*out = *in
+	if in.CACertificate != nil {
+		in, out := &in.CACertificate, &out.CACertificate
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrivateKey != nil {
+		in, out := &in.PrivateKey, &out.PrivateKey
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+func (in *EtcdClientCredential) DeepCopy() *EtcdClientCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdClientCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type EtcdBackupStorageSpec struct {
+	MinIO *BackupStorageMinIOSpec `json:"minio,omitempty"`
+	GCS   *BackupStorageGCSSpec   `json:"gcs,omitempty"`
+}
+
+func (in *EtcdBackupStorageSpec) DeepCopyInto(out *EtcdBackupStorageSpec) {
+	*out = *in
+	if in.MinIO != nil {
+		in, out := &in.MinIO, &out.MinIO
+		*out = new(BackupStorageMinIOSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(BackupStorageGCSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+func (in *EtcdBackupStorageSpec) DeepCopy() *EtcdBackupStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type EtcdBackupStatusHistory struct {
+	Succeeded   bool         `json:"succeeded,omitempty"`
+	ExecuteTime *metav1.Time `json:"executeTime,omitempty"`
+	Path        string       `json:"path,omitempty"`
+	Message     string       `json:"message,omitempty"`
+}
+
+func (in *EtcdBackupStatusHistory) DeepCopyInto(out *EtcdBackupStatusHistory) {
+	*out = *in
+	if in.ExecuteTime != nil {
+		in, out := &in.ExecuteTime, &out.ExecuteTime
+		*out = new(metav1.Time)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+func (in *EtcdBackupStatusHistory) DeepCopy() *EtcdBackupStatusHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupStatusHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type BackupStorageMinIOSpec struct {
+	Service    *ObjectReference `json:"service,omitempty"`
+	Credential AWSCredential    `json:"credential"`
+	Bucket     string           `json:"bucket"`
+	Path       string           `json:"path"`
+	Secure     bool             `json:"secure,omitempty"`
+}
+
+func (in *BackupStorageMinIOSpec) DeepCopyInto(out *BackupStorageMinIOSpec) {
+	*out = *in
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Credential.DeepCopyInto(&out.Credential)
+}
+
+func (in *BackupStorageMinIOSpec) DeepCopy() *BackupStorageMinIOSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupStorageMinIOSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type BackupStorageGCSSpec struct {
+	Bucket     string         `json:"bucket,omitempty"`
+	Path       string         `json:"path,omitempty"`
+	Credential *GCPCredential `json:"credential,omitempty"`
+}
+
+func (in *BackupStorageGCSSpec) DeepCopyInto(out *BackupStorageGCSSpec) {
+	*out = *in
+	if in.Credential != nil {
+		in, out := &in.Credential, &out.Credential
+		*out = new(GCPCredential)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+func (in *BackupStorageGCSSpec) DeepCopy() *BackupStorageGCSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupStorageGCSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type ObjectReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+	*out = *in
+}
+
+func (in *ObjectReference) DeepCopy() *ObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type AWSCredential struct {
+	AccessKeyID     *corev1.SecretKeySelector `json:"accessKeyID,omitempty"`
+	SecretAccessKey *corev1.SecretKeySelector `json:"secretAccessKey,omitempty"`
+}
+
+func (in *AWSCredential) DeepCopyInto(out *AWSCredential) {
+	*out = *in
+	if in.AccessKeyID != nil {
+		in, out := &in.AccessKeyID, &out.AccessKeyID
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretAccessKey != nil {
+		in, out := &in.SecretAccessKey, &out.SecretAccessKey
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+func (in *AWSCredential) DeepCopy() *AWSCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type GCPCredential struct {
+	ServiceAccountJSON *corev1.SecretKeySelector `json:"serviceAccountJSON,omitempty"`
+}
+
+func (in *GCPCredential) DeepCopyInto(out *GCPCredential) {
+	*out = *in
+	if in.ServiceAccountJSON != nil {
+		in, out := &in.ServiceAccountJSON, &out.ServiceAccountJSON
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+func (in *GCPCredential) DeepCopy() *GCPCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPCredential)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -22,11 +22,23 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&GrafanaList{},
 		&GrafanaUser{},
 		&GrafanaUserList{},
+		&GrafanaDashboard{},
+		&GrafanaDashboardList{},
+		&GrafanaDatasource{},
+		&GrafanaDatasourceList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemaGroupVersion)
 	return nil
 }
 
+type DashboardPermission string
+
+const (
+	DashboardPermissionView  DashboardPermission = "View"
+	DashboardPermissionEdit  DashboardPermission = "Edit"
+	DashboardPermissionAdmin DashboardPermission = "Admin"
+)
+
 type Grafana struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata"`
@@ -159,11 +171,147 @@ func (in *GrafanaUserList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+type GrafanaDashboard struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              GrafanaDashboardSpec   `json:"spec"`
+	Status            GrafanaDashboardStatus `json:"status"`
+}
+
+func (in *GrafanaDashboard) DeepCopyInto(out *GrafanaDashboard) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+func (in *GrafanaDashboard) DeepCopy() *GrafanaDashboard {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDashboard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *GrafanaDashboard) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+type GrafanaDashboardList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []GrafanaDashboard `json:"items"`
+}
+
+func (in *GrafanaDashboardList) DeepCopyInto(out *GrafanaDashboardList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		l := make([]GrafanaDashboard, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&l[i])
+		}
+		out.Items = l
+	}
+}
+
+func (in *GrafanaDashboardList) DeepCopy() *GrafanaDashboardList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDashboardList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *GrafanaDashboardList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+type GrafanaDatasource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              GrafanaDatasourceSpec   `json:"spec"`
+	Status            GrafanaDatasourceStatus `json:"status"`
+}
+
+func (in *GrafanaDatasource) DeepCopyInto(out *GrafanaDatasource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+func (in *GrafanaDatasource) DeepCopy() *GrafanaDatasource {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDatasource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *GrafanaDatasource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+type GrafanaDatasourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []GrafanaDatasource `json:"items"`
+}
+
+func (in *GrafanaDatasourceList) DeepCopyInto(out *GrafanaDatasourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		l := make([]GrafanaDatasource, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&l[i])
+		}
+		out.Items = l
+	}
+}
+
+func (in *GrafanaDatasourceList) DeepCopy() *GrafanaDatasourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDatasourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *GrafanaDatasourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 type GrafanaSpec struct {
 	UserSelector        metav1.LabelSelector         `json:"userSelector"`
 	AdminUser           string                       `json:"adminUser,omitempty"`
 	AdminPasswordSecret *corev1.SecretKeySelector    `json:"adminPasswordSecret,omitempty"`
 	Service             *corev1.LocalObjectReference `json:"service,omitempty"`
+	// dashboard_selector is a selector of GrafanaDashboard.
+	DashboardSelector *metav1.LabelSelector `json:"dashboardSelector,omitempty"`
+	// datasource_selector is a selector of GrafanaDatasource.
+	DatasourceSelector *metav1.LabelSelector `json:"datasourceSelector,omitempty"`
 }
 
 func (in *GrafanaSpec) DeepCopyInto(out *GrafanaSpec) {
@@ -179,6 +327,16 @@ func (in *GrafanaSpec) DeepCopyInto(out *GrafanaSpec) {
 		*out = new(corev1.LocalObjectReference)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DashboardSelector != nil {
+		in, out := &in.DashboardSelector, &out.DashboardSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DatasourceSelector != nil {
+		in, out := &in.DatasourceSelector, &out.DatasourceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 func (in *GrafanaSpec) DeepCopy() *GrafanaSpec {
@@ -192,10 +350,24 @@ func (in *GrafanaSpec) DeepCopy() *GrafanaSpec {
 
 type GrafanaStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// dashboards is a list of UIDs of the dashboards which are managed by the controller.
+	Dashboards []string `json:"dashboards"`
+	// datasources is a list of UIDs of the datasources which are managed by the controller.
+	Datasources []string `json:"datasources"`
 }
 
 func (in *GrafanaStatus) DeepCopyInto(out *GrafanaStatus) {
 	*out = *in
+	if in.Dashboards != nil {
+		t := make([]string, len(in.Dashboards))
+		copy(t, in.Dashboards)
+		out.Dashboards = t
+	}
+	if in.Datasources != nil {
+		t := make([]string, len(in.Datasources))
+		copy(t, in.Datasources)
+		out.Datasources = t
+	}
 }
 
 func (in *GrafanaStatus) DeepCopy() *GrafanaStatus {
@@ -241,3 +413,175 @@ func (in *GrafanaUserStatus) DeepCopy() *GrafanaUserStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+type GrafanaDashboardSpec struct {
+	// json is the JSON model of the dashboard.
+	JSON string `json:"json,omitempty"`
+	// config_map is a reference to the key of ConfigMap which has the JSON model of the dashboard.
+	//
+	//	json and config_map can't be set together.
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty"`
+	// folder is the title of the folder which the dashboard belongs to.
+	//
+	//	The folder will be created if it doesn't exist. If it is empty, the dashboard belongs to General.
+	Folder string `json:"folder,omitempty"`
+	// permissions is a list of permissions of the dashboard.
+	//
+	//	If it is empty, the dashboard inherits the permissions of the folder.
+	Permissions []GrafanaDashboardPermission `json:"permissions"`
+}
+
+func (in *GrafanaDashboardSpec) DeepCopyInto(out *GrafanaDashboardSpec) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Permissions != nil {
+		l := make([]GrafanaDashboardPermission, len(in.Permissions))
+		for i := range in.Permissions {
+			in.Permissions[i].DeepCopyInto(&l[i])
+		}
+		out.Permissions = l
+	}
+}
+
+func (in *GrafanaDashboardSpec) DeepCopy() *GrafanaDashboardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDashboardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type GrafanaDashboardStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// uid is the unique identifier of the dashboard in Grafana.
+	UID string `json:"uid,omitempty"`
+	// version is the version of the dashboard in Grafana.
+	Version int64 `json:"version,omitempty"`
+	// message is the error of the last reconciliation. It is empty if the dashboard has been applied.
+	Message string `json:"message,omitempty"`
+}
+
+func (in *GrafanaDashboardStatus) DeepCopyInto(out *GrafanaDashboardStatus) {
+	*out = *in
+}
+
+func (in *GrafanaDashboardStatus) DeepCopy() *GrafanaDashboardStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDashboardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type GrafanaDatasourceSpec struct {
+	// type is the type of the datasource. e.g. prometheus, loki.
+	Type string `json:"type"`
+	URL  string `json:"url"`
+	// access is the access mode of the datasource. proxy or direct.
+	//
+	//	If access is an empty value, proxy is used.
+	Access        string `json:"access,omitempty"`
+	IsDefault     bool   `json:"isDefault,omitempty"`
+	BasicAuthUser string `json:"basicAuthUser,omitempty"`
+	// basic_auth_password is a reference to the key of Secret which has the password of basic authentication.
+	BasicAuthPassword *corev1.SecretKeySelector `json:"basicAuthPassword,omitempty"`
+	// json_data is the additional settings of the datasource. It must be a JSON object.
+	JSONData string `json:"jsonData,omitempty"`
+	// secure_json_data is a list of the additional secret settings of the datasource.
+	SecureJSONData []GrafanaDatasourceSecureJSONData `json:"secureJsonData"`
+}
+
+func (in *GrafanaDatasourceSpec) DeepCopyInto(out *GrafanaDatasourceSpec) {
+	*out = *in
+	if in.BasicAuthPassword != nil {
+		in, out := &in.BasicAuthPassword, &out.BasicAuthPassword
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecureJSONData != nil {
+		l := make([]GrafanaDatasourceSecureJSONData, len(in.SecureJSONData))
+		for i := range in.SecureJSONData {
+			in.SecureJSONData[i].DeepCopyInto(&l[i])
+		}
+		out.SecureJSONData = l
+	}
+}
+
+func (in *GrafanaDatasourceSpec) DeepCopy() *GrafanaDatasourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDatasourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type GrafanaDatasourceStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// uid is the unique identifier of the datasource in Grafana.
+	UID string `json:"uid,omitempty"`
+	// secret_hash is a hash of the versions of Secrets which were applied to the datasource.
+	SecretHash string `json:"secretHash,omitempty"`
+	// message is the error of the last reconciliation. It is empty if the datasource has been applied.
+	Message string `json:"message,omitempty"`
+}
+
+func (in *GrafanaDatasourceStatus) DeepCopyInto(out *GrafanaDatasourceStatus) {
+	*out = *in
+}
+
+func (in *GrafanaDatasourceStatus) DeepCopy() *GrafanaDatasourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDatasourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type GrafanaDashboardPermission struct {
+	// role is the name of the organization role. Viewer or Editor.
+	Role string `json:"role,omitempty"`
+	// user is the email of the user. role and user can't be set together.
+	User       string              `json:"user,omitempty"`
+	Permission DashboardPermission `json:"permission"`
+}
+
+func (in *GrafanaDashboardPermission) DeepCopyInto(out *GrafanaDashboardPermission) {
+	*out = *in
+}
+
+func (in *GrafanaDashboardPermission) DeepCopy() *GrafanaDashboardPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDashboardPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type GrafanaDatasourceSecureJSONData struct {
+	Key string `json:"key"`
+	// secret is a reference to the key of Secret which has the value.
+	Secret corev1.SecretKeySelector `json:"secret"`
+}
+
+func (in *GrafanaDatasourceSecureJSONData) DeepCopyInto(out *GrafanaDatasourceSecureJSONData) {
+	*out = *in
+	in.Secret.DeepCopyInto(&out.Secret)
+}
+
+func (in *GrafanaDatasourceSecureJSONData) DeepCopy() *GrafanaDatasourceSecureJSONData {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDatasourceSecureJSONData)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -43,6 +43,28 @@ const (
 	BucketPolicyPrivate  BucketPolicy = "Private"
 )
 
+type BucketVersioning string
+
+const (
+	BucketVersioningEnabled   BucketVersioning = "Enabled"
+	BucketVersioningSuspended BucketVersioning = "Suspended"
+)
+
+type BucketRetentionMode string
+
+const (
+	BucketRetentionModeGovernance BucketRetentionMode = "Governance"
+	BucketRetentionModeCompliance BucketRetentionMode = "Compliance"
+)
+
+type PolicyAccess string
+
+const (
+	PolicyAccessReadOnly  PolicyAccess = "ReadOnly"
+	PolicyAccessWriteOnly PolicyAccess = "WriteOnly"
+	PolicyAccessReadWrite PolicyAccess = "ReadWrite"
+)
+
 type ClusterPhase string
 
 const (
@@ -263,11 +285,40 @@ type MinIOBucketSpec struct {
 	Policy BucketPolicy `json:"policy"`
 	// create_index_file is a flag that creates index.html on top of bucket.
 	CreateIndexFile bool `json:"createIndexFile"`
+	// lifecycle is a list of lifecycle rules of the bucket.
+	//
+	//	If lifecycle is not specified, the lifecycle configuration of the bucket is not managed.
+	//	If it is an empty list, the lifecycle configuration of the bucket will be removed.
+	Lifecycle []MinIOBucketLifecycleRule `json:"lifecycle"`
+	// versioning is a state of object versioning. One of Enabled, Suspended.
+	//
+	//	If versioning is an empty value, the versioning of the bucket is not managed.
+	Versioning BucketVersioning `json:"versioning,omitempty"`
+	// object_lock is a configuration of object locking.
+	//
+	//	Object locking can be enabled only when the bucket is created and it also enables versioning.
+	ObjectLock *MinIOBucketObjectLock `json:"objectLock,omitempty"`
+	// quota is a hard quota of the bucket in Gigabytes.
+	//
+	//	If quota is zero, the quota of the bucket is not managed.
+	Quota int `json:"quota,omitempty"`
 }
 
 func (in *MinIOBucketSpec) DeepCopyInto(out *MinIOBucketSpec) {
 	*out = *in
 	in.Selector.DeepCopyInto(&out.Selector)
+	if in.Lifecycle != nil {
+		l := make([]MinIOBucketLifecycleRule, len(in.Lifecycle))
+		for i := range in.Lifecycle {
+			in.Lifecycle[i].DeepCopyInto(&l[i])
+		}
+		out.Lifecycle = l
+	}
+	if in.ObjectLock != nil {
+		in, out := &in.ObjectLock, &out.ObjectLock
+		*out = new(MinIOBucketObjectLock)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 func (in *MinIOBucketSpec) DeepCopy() *MinIOBucketSpec {
@@ -281,10 +332,21 @@ func (in *MinIOBucketSpec) DeepCopy() *MinIOBucketSpec {
 
 type MinIOBucketStatus struct {
 	Ready bool `json:"ready"`
+	// observed_generation is the generation of the spec which was applied to the bucket.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// drifts is a list of settings of the bucket which differ from the spec.
+	Drifts []MinIOBucketDrift `json:"drifts"`
 }
 
 func (in *MinIOBucketStatus) DeepCopyInto(out *MinIOBucketStatus) {
 	*out = *in
+	if in.Drifts != nil {
+		l := make([]MinIOBucketDrift, len(in.Drifts))
+		for i := range in.Drifts {
+			in.Drifts[i].DeepCopyInto(&l[i])
+		}
+		out.Drifts = l
+	}
 }
 
 func (in *MinIOBucketStatus) DeepCopy() *MinIOBucketStatus {
@@ -350,11 +412,32 @@ type MinIOUserSpec struct {
 	Path string `json:"path"`
 	// mount_path is a mount path of KV secrets engine.
 	MountPath string `json:"mountPath"`
+	// policies is a list of statements of the policy which is attached to the user.
+	//
+	//	The policy is created as a canned policy and it is owned by the user.
+	//	If it is empty, the user will not have any policy.
+	Policies []MinIOUserPolicy `json:"policies"`
+	// rotation is a configuration of rotating the access key.
+	//
+	//	If it is empty, the access key will not be rotated.
+	Rotation *MinIOUserKeyRotation `json:"rotation,omitempty"`
 }
 
 func (in *MinIOUserSpec) DeepCopyInto(out *MinIOUserSpec) {
 	*out = *in
 	in.Selector.DeepCopyInto(&out.Selector)
+	if in.Policies != nil {
+		l := make([]MinIOUserPolicy, len(in.Policies))
+		for i := range in.Policies {
+			in.Policies[i].DeepCopyInto(&l[i])
+		}
+		out.Policies = l
+	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(MinIOUserKeyRotation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 func (in *MinIOUserSpec) DeepCopy() *MinIOUserSpec {
@@ -370,10 +453,30 @@ type MinIOUserStatus struct {
 	Ready     bool   `json:"ready"`
 	AccessKey string `json:"accessKey,omitempty"`
 	Vault     bool   `json:"vault,omitempty"`
+	// policy_name is the name of the canned policy which is attached to the user.
+	PolicyName string `json:"policyName,omitempty"`
+	// last_rotated_time is the time when the current access key was created.
+	LastRotatedTime *metav1.Time `json:"lastRotatedTime,omitempty"`
+	// previous_access_key is the access key before rotation.
+	//
+	//	It will be removed after the overlap.
+	PreviousAccessKey string `json:"previousAccessKey,omitempty"`
+	// previous_access_key_expiration_time is the time when the previous access key will be removed.
+	PreviousAccessKeyExpirationTime *metav1.Time `json:"previousAccessKeyExpirationTime,omitempty"`
 }
 
 func (in *MinIOUserStatus) DeepCopyInto(out *MinIOUserStatus) {
 	*out = *in
+	if in.LastRotatedTime != nil {
+		in, out := &in.LastRotatedTime, &out.LastRotatedTime
+		*out = new(metav1.Time)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreviousAccessKeyExpirationTime != nil {
+		in, out := &in.PreviousAccessKeyExpirationTime, &out.PreviousAccessKeyExpirationTime
+		*out = new(metav1.Time)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 func (in *MinIOUserStatus) DeepCopy() *MinIOUserStatus {
@@ -409,3 +512,129 @@ func (in *MinIOClusterBucket) DeepCopy() *MinIOClusterBucket {
 	in.DeepCopyInto(out)
 	return out
 }
+
+type MinIOBucketLifecycleRule struct {
+	ID string `json:"id"`
+	// prefix is a prefix of the object key which the rule applies to.
+	Prefix string `json:"prefix,omitempty"`
+	// expiration_days is the number of days after which the objects are deleted.
+	ExpirationDays int `json:"expirationDays,omitempty"`
+	// noncurrent_expiration_days is the number of days after which the noncurrent versions of the objects are deleted.
+	NoncurrentExpirationDays int `json:"noncurrentExpirationDays,omitempty"`
+	// transition_days is the number of days after which the objects are moved to transition_storage_class.
+	TransitionDays int `json:"transitionDays,omitempty"`
+	// transition_storage_class is the name of the remote tier.
+	TransitionStorageClass string `json:"transitionStorageClass,omitempty"`
+}
+
+func (in *MinIOBucketLifecycleRule) DeepCopyInto(out *MinIOBucketLifecycleRule) {
+	*out = *in
+}
+
+func (in *MinIOBucketLifecycleRule) DeepCopy() *MinIOBucketLifecycleRule {
+	if in == nil {
+		return nil
+	}
+	out := new(MinIOBucketLifecycleRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type MinIOBucketObjectLock struct {
+	// mode is the default retention mode of new objects.
+	//
+	//	If mode is an empty value, the bucket doesn't have the default retention.
+	Mode BucketRetentionMode `json:"mode,omitempty"`
+	// days is the default retention period in days.
+	Days int `json:"days,omitempty"`
+	// years is the default retention period in years. days and years can't be set together.
+	Years int `json:"years,omitempty"`
+}
+
+func (in *MinIOBucketObjectLock) DeepCopyInto(out *MinIOBucketObjectLock) {
+	*out = *in
+}
+
+func (in *MinIOBucketObjectLock) DeepCopy() *MinIOBucketObjectLock {
+	if in == nil {
+		return nil
+	}
+	out := new(MinIOBucketObjectLock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type MinIOBucketDrift struct {
+	// setting is the name of the drifted setting. One of lifecycle, versioning, objectLock and quota.
+	Setting string `json:"setting"`
+	// message describes how the setting differs from the spec.
+	Message string `json:"message"`
+	// corrected is a flag that the setting has been reverted to the spec.
+	Corrected bool `json:"corrected"`
+}
+
+func (in *MinIOBucketDrift) DeepCopyInto(out *MinIOBucketDrift) {
+	*out = *in
+}
+
+func (in *MinIOBucketDrift) DeepCopy() *MinIOBucketDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(MinIOBucketDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type MinIOUserPolicy struct {
+	// bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+	// prefix is a prefix of the object key.
+	//
+	//	If it is an empty value, the statement applies to the whole bucket.
+	Prefix string `json:"prefix,omitempty"`
+	// access is a set of actions which are allowed. One of ReadOnly, WriteOnly and ReadWrite.
+	Access PolicyAccess `json:"access,omitempty"`
+	// actions is a list of additional actions which are allowed. (e.g. s3:GetObjectTagging)
+	Actions []string `json:"actions"`
+}
+
+func (in *MinIOUserPolicy) DeepCopyInto(out *MinIOUserPolicy) {
+	*out = *in
+	if in.Actions != nil {
+		t := make([]string, len(in.Actions))
+		copy(t, in.Actions)
+		out.Actions = t
+	}
+}
+
+func (in *MinIOUserPolicy) DeepCopy() *MinIOUserPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MinIOUserPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+type MinIOUserKeyRotation struct {
+	// interval is the interval of rotating the access key. (e.g. 720h)
+	Interval string `json:"interval"`
+	// overlap is the duration that the previous access key remains valid after rotation.
+	//
+	//	If it is an empty value, the previous access key will be removed immediately.
+	Overlap string `json:"overlap,omitempty"`
+}
+
+func (in *MinIOUserKeyRotation) DeepCopyInto(out *MinIOUserKeyRotation) {
+	*out = *in
+}
+
+func (in *MinIOUserKeyRotation) DeepCopy() *MinIOUserKeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(MinIOUserKeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
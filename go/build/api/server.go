@@ -6,17 +6,14 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/Masterminds/semver/v3"
-	"github.com/google/go-github/v49/github"
 	"go.f110.dev/protoc-ddl/probe"
 	"go.f110.dev/xerrors"
 	"go.uber.org/zap"
@@ -24,6 +21,7 @@ import (
 	"go.f110.dev/mono/go/build/config"
 	"go.f110.dev/mono/go/build/database"
 	"go.f110.dev/mono/go/build/database/dao"
+	"go.f110.dev/mono/go/build/forge"
 	"go.f110.dev/mono/go/enumerable"
 	"go.f110.dev/mono/go/logger"
 	"go.f110.dev/mono/go/storage"
@@ -37,7 +35,8 @@ const (
 )
 
 type Builder interface {
-	Build(ctx context.Context, repo *database.SourceRepository, job *config.Job, revision, bazelVersion, command string, targets, platforms []string, via string, isMainBranch bool) ([]*database.Task, error)
+	Build(ctx context.Context, repo *database.SourceRepository, job *config.Job, revision, bazelVersion, command string, targets, platforms []string, via string, isMainBranch bool, pullRequest int32) ([]*database.Task, error)
+	Cancel(ctx context.Context, task *database.Task) error
 }
 
 type Api struct {
@@ -45,16 +44,16 @@ type Api struct {
 
 	builder           Builder
 	dao               dao.Options
-	githubClient      *github.Client
+	forges            *forge.Set
 	stClient          *storage.MinIO
 	bazelMirrorPrefix string
 }
 
-func NewApi(addr string, builder Builder, dao dao.Options, ghClient *github.Client, stClient *storage.MinIO, bazelMirrorPrefix string) (*Api, error) {
+func NewApi(addr string, builder Builder, dao dao.Options, forges *forge.Set, stClient *storage.MinIO, bazelMirrorPrefix string) (*Api, error) {
 	api := &Api{
 		builder:           builder,
 		dao:               dao,
-		githubClient:      ghClient,
+		forges:            forges,
 		stClient:          stClient,
 		bazelMirrorPrefix: bazelMirrorPrefix,
 	}
@@ -64,6 +63,7 @@ func NewApi(addr string, builder Builder, dao dao.Options, ghClient *github.Clie
 	mux.HandleFunc("/readiness", api.handleReadiness)
 	mux.HandleFunc("/redo", api.handleRedo)
 	mux.HandleFunc("/run", api.handleRun)
+	mux.HandleFunc("/cancel", api.handleCancel)
 	mux.HandleFunc("/webhook", api.handleWebHook)
 	s := &http.Server{
 		Addr:    addr,
@@ -83,79 +83,85 @@ func (a *Api) handleWebHook(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	messageType := github.WebHookType(req)
-	event, err := github.ParseWebHook(messageType, payload)
+	event, err := forge.ParseWebHook(req, payload)
 	if err != nil {
 		logger.Log.Warn("Failed parse webhook's payload", zap.Error(err))
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
+	if event == nil {
+		return
+	}
 
-	switch event := event.(type) {
-	case *github.PushEvent:
-		repo := a.findRepository(req.Context(), event.Repo.GetHTMLURL())
+	switch event.Type {
+	case forge.EventPush:
+		repo := a.findRepository(req.Context(), event.RepositoryURL)
 
-		if isMainBranch(event.GetRef(), event.Repo.GetMasterBranch()) {
+		if isMainBranch(event.Ref, event.DefaultBranch) {
 			if ok, err := a.skipCI(req.Context(), event); ok || err != nil {
-				logger.Log.Info("Skip build", zap.String("repo", event.Repo.GetFullName()), zap.String("commit", event.GetHead()))
+				logger.Log.Info("Skip build", zap.String("repo", event.Repository.FullName()), zap.String("commit", event.Revision))
 				return
 			}
 			if err := a.buildByPushEvent(req.Context(), repo, event, true); err != nil {
-				logger.Log.Warn("Failed to build", zap.String("repo", repo.Name), logger.Error(err))
+				logger.Log.Warn("Failed to build", zap.String("repo", event.Repository.FullName()), logger.Error(err))
 				w.WriteHeader(http.StatusInternalServerError)
 				return
 			}
 		}
 		// Currently, we don't need to build other branch when got PushEvent.
-	case *github.PullRequestEvent:
-		switch event.GetAction() {
-		case "opened":
+	case forge.EventPullRequest:
+		switch event.Action {
+		case forge.ActionOpened:
 			if ok, err := a.allowPullRequest(req.Context(), event); err != nil {
-				logger.Log.Info("Failed check the build permission", zap.String("repo", event.Repo.GetFullName()), zap.Int("number", event.PullRequest.GetNumber()))
+				logger.Log.Info("Failed check the build permission", logger.Error(err), zap.String("repo", event.Repository.FullName()), zap.Int("number", event.Number))
+				w.WriteHeader(http.StatusInternalServerError)
 				return
 			} else if !ok {
 				body := "Sorry, We could not build this pull request. Because building this pull request is not allowed due to security reason.\n\n" +
 					"For author, Thank you for your contribution. We appreciate your work. Please wait for permitting to build this pull request by administrator.\n" +
 					"For administrator, If you are going to allow this pull request, please comment `" + AllowCommand + "`."
-				_, _, err := a.githubClient.Issues.CreateComment(
-					req.Context(),
-					event.Repo.GetOwner().GetLogin(),
-					event.Repo.GetName(),
-					event.PullRequest.GetNumber(),
-					&github.IssueComment{Body: github.String(body)},
-				)
+				client, repo, err := a.forges.Client(event.RepositoryURL)
+				if err == nil {
+					err = client.CreateComment(req.Context(), repo, event.Number, body)
+				}
 				if err != nil {
-					logger.Log.Warn("Failed create the comment", zap.Error(err), zap.String("repo", event.Repo.GetFullName()), zap.Int("number", event.PullRequest.GetNumber()))
+					logger.Log.Warn("Failed create the comment", zap.Error(err), zap.String("repo", event.Repository.FullName()), zap.Int("number", event.Number))
 					w.WriteHeader(http.StatusInternalServerError)
 				}
 				return
 			} else {
 				if ok, err := a.skipCI(req.Context(), event); ok || err != nil {
-					logger.Log.Info("Skip build", zap.String("repo", event.Repo.GetFullName()), zap.Int("number", event.PullRequest.GetNumber()))
+					logger.Log.Info("Skip build", zap.String("repo", event.Repository.FullName()), zap.Int("number", event.Number))
 					return
 				}
-				repo := a.findRepository(req.Context(), event.Repo.GetHTMLURL())
+				repo := a.findRepository(req.Context(), event.RepositoryURL)
 				if err := a.buildByPullRequest(req.Context(), repo, event); err != nil {
 					logger.Log.Warn("Failed build the pull request", zap.Error(err))
 					w.WriteHeader(http.StatusInternalServerError)
 					return
 				}
 			}
-		case "synchronize":
+		case forge.ActionSynchronize:
 			if ok, _ := a.allowPullRequest(req.Context(), event); ok {
 				if ok, err := a.skipCI(req.Context(), event); ok || err != nil {
-					logger.Log.Info("Skip build", zap.String("repo", event.Repo.GetFullName()), zap.Int("number", event.PullRequest.GetNumber()))
+					logger.Log.Info("Skip build", zap.String("repo", event.Repository.FullName()), zap.Int("number", event.Number))
 					return
 				}
-				repo := a.findRepository(req.Context(), event.Repo.GetHTMLURL())
+				repo := a.findRepository(req.Context(), event.RepositoryURL)
 				if err := a.buildByPullRequest(req.Context(), repo, event); err != nil {
-					logger.Log.Warn("Failed build the pull request", zap.Error(err), zap.String("repo", event.Repo.GetFullName()), zap.Int("number", event.PullRequest.GetNumber()))
+					logger.Log.Warn("Failed build the pull request", zap.Error(err), zap.String("repo", event.Repository.FullName()), zap.Int("number", event.Number))
 					w.WriteHeader(http.StatusInternalServerError)
 					return
 				}
 			}
-		case "closed":
-			permits, err := a.dao.PermitPullRequest.ListByRepositoryAndNumber(req.Context(), event.Repo.GetFullName(), int32(event.PullRequest.GetNumber()))
+		case forge.ActionClosed:
+			host, _, err := forge.ParseRepositoryURL(event.RepositoryURL)
+			if err != nil {
+				logger.Log.Info("Failed to parse the url of the repository", logger.Error(err), zap.String("url", event.RepositoryURL))
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			permits, err := a.dao.PermitPullRequest.ListByHostAndRepositoryAndNumber(req.Context(), host, event.Repository.FullName(), int32(event.Number))
 			if err != nil {
 				return
 			}
@@ -169,17 +175,17 @@ func (a *Api) handleWebHook(w http.ResponseWriter, req *http.Request) {
 				return
 			}
 		}
-	case *github.IssueCommentEvent:
+	case forge.EventComment:
 		if err := a.issueComment(req.Context(), event); err != nil {
 			return
 		}
-	case *github.ReleaseEvent:
-		switch event.GetAction() {
-		case "published":
-			repo := a.findRepository(req.Context(), event.Repo.GetHTMLURL())
+	case forge.EventRelease:
+		switch event.Action {
+		case forge.ActionPublished:
+			repo := a.findRepository(req.Context(), event.RepositoryURL)
 
 			if err := a.buildByRelease(req.Context(), repo, event); err != nil {
-				logger.Log.Warn("Failed to build", zap.String("repo", repo.Name), logger.Error(err))
+				logger.Log.Warn("Failed to build", zap.String("repo", event.Repository.FullName()), logger.Error(err))
 				w.WriteHeader(http.StatusInternalServerError)
 				return
 			}
@@ -188,57 +194,52 @@ func (a *Api) handleWebHook(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
-func (a *Api) fetchBuildConfig(ctx context.Context, owner, repoName, revision string, useCommittedConfig bool) (*config.Config, string, error) {
+func (a *Api) fetchBuildConfig(ctx context.Context, repoURL, revision string, useCommittedConfig bool) (*config.Config, string, error) {
+	client, repo, err := a.forges.Client(repoURL)
+	if err != nil {
+		return nil, "", err
+	}
+	return FetchBuildConfig(ctx, client, repo, revision, useCommittedConfig)
+}
+
+// FetchBuildConfig reads the build configuration and the version of bazel from the repository.
+// If useCommittedConfig is false, the configuration will be read from HEAD instead of the revision.
+func FetchBuildConfig(ctx context.Context, client forge.Client, repo *forge.Repository, revision string, useCommittedConfig bool) (*config.Config, string, error) {
+	owner, repoName := repo.Owner, repo.Name
 	// Find the configuration file
 	var commitSHA string
 	if useCommittedConfig {
 		// Read configuration file of the revision
-		commit, _, err := a.githubClient.Git.GetCommit(ctx, owner, repoName, revision)
+		sha, err := client.GetCommit(ctx, repo, revision)
 		if err != nil {
 			return nil, "", xerrors.WithMessagef(err, "failed to get the commit: %s", revision)
 		}
-		commitSHA = commit.GetSHA()
+		commitSHA = sha
 	} else {
 		// If the revision doesn't belong to the main branch, the build configuration will be read from the main branch.
-		commit, _, err := a.githubClient.Repositories.GetCommit(ctx, owner, repoName, "HEAD", nil)
+		sha, err := client.GetCommit(ctx, repo, "HEAD")
 		if err != nil {
 			return nil, "", xerrors.WithMessage(err, "failed to get HEAD commit")
 		}
-		commitSHA = commit.GetSHA()
+		commitSHA = sha
 	}
-	tree, _, err := a.githubClient.Git.GetTree(ctx, owner, repoName, commitSHA, false)
+	files, err := client.GetFiles(ctx, repo, commitSHA, BuildConfigurationFile, BazelVersionFile)
 	if err != nil {
-		return nil, "", xerrors.WithMessagef(err, "failed to get the tree: %s", commitSHA)
-	}
-	var blobSHA, versionBlobSHA string
-	for _, e := range tree.Entries {
-		switch e.GetPath() {
-		case BuildConfigurationFile:
-			blobSHA = e.GetSHA()
-		case BazelVersionFile:
-			versionBlobSHA = e.GetSHA()
-		}
+		return nil, "", xerrors.WithMessagef(err, "failed to get the build configuration: %s", commitSHA)
 	}
-	if blobSHA == "" {
+	buildConfFileBlob, ok := files[BuildConfigurationFile]
+	if !ok {
 		logger.Log.Debug("build configuration file is not found", zap.String("repo", repoName), zap.String("revision", commitSHA))
 		return nil, "", nil
 	}
-	buildConfFileBlob, _, err := a.githubClient.Git.GetBlobRaw(ctx, owner, repoName, blobSHA)
-	if err != nil {
-		logger.Log.Info("Skip build", logger.Error(err), zap.String("owner", owner), zap.String("repo", repoName), zap.String("revision", revision))
-		return nil, "", nil
-	}
 	var bazelVersion string
-	if versionBlobSHA != "" {
-		if blob, _, err := a.githubClient.Git.GetBlobRaw(ctx, owner, repoName, versionBlobSHA); err == nil {
-			bazelVersion = strings.TrimRight(string(blob), "\n")
-		} else {
-			logger.Log.Info("Failed to get the blob of .bazelversion", zap.Error(err))
-		}
+	if blob, ok := files[BazelVersionFile]; ok {
+		bazelVersion = strings.TrimRight(string(blob), "\n")
 	}
 
 	// Parse the configuration file
-	conf, err := config.Read(bytes.NewReader(buildConfFileBlob), owner, repoName)
+	// The namespace of GitLab may have the sub groups. The owner is used as a part of the label value, so it can't contain a slash.
+	conf, err := config.Read(bytes.NewReader(buildConfFileBlob), strings.ReplaceAll(owner, "/", "-"), repoName)
 	if err != nil {
 		return nil, "", err
 	}
@@ -263,41 +264,48 @@ func (a *Api) findRepository(ctx context.Context, repoURL string) *database.Sour
 	return repos[0]
 }
 
-func (a *Api) allowPullRequest(ctx context.Context, event *github.PullRequestEvent) (bool, error) {
-	if event.Repo.Owner.GetLogin() == event.Sender.GetLogin() {
-		logger.Log.Info("The sender of PushRequestEvent is the repository owner", zap.String("owner", event.Repo.Owner.GetLogin()), zap.String("sender", event.Sender.GetLogin()))
-		return true, nil
+func (a *Api) allowPullRequest(ctx context.Context, event *forge.Event) (bool, error) {
+	client, repo, err := a.forges.Client(event.RepositoryURL)
+	if err != nil {
+		return false, err
 	}
 
-	users, err := a.dao.TrustedUser.ListByGithubId(ctx, event.Sender.GetID())
+	// The id of the user is unique only in the forge. Thus, the trusted user and the permission are looked up with the host of the forge.
+	users, err := a.dao.TrustedUser.ListByHostAndGithubId(ctx, client.Host(), event.Sender.ID)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		logger.Log.Warn("Could not get trusted user", zap.Error(err), zap.Int64("sender.id", event.Sender.GetID()))
+		logger.Log.Warn("Could not get trusted user", zap.Error(err), zap.Int64("sender.id", event.Sender.ID))
 		return false, err
 	}
-	if users != nil && len(users) == 1 {
+	if len(users) == 1 {
 		return true, nil
 	}
 
-	permitPullRequest, err := a.dao.PermitPullRequest.ListByRepositoryAndNumber(ctx, event.Repo.GetFullName(), int32(event.PullRequest.GetNumber()))
+	permitPullRequest, err := a.dao.PermitPullRequest.ListByHostAndRepositoryAndNumber(ctx, client.Host(), event.Repository.FullName(), int32(event.Number))
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		logger.Log.Warn("Could not get permit pull request", zap.Error(err), zap.String("repo", event.Repo.GetFullName()), zap.Int("number", event.PullRequest.GetNumber()))
+		logger.Log.Warn("Could not get permit pull request", zap.Error(err), zap.String("repo", event.Repository.FullName()), zap.Int("number", event.Number))
 		return false, err
 	}
-	if permitPullRequest != nil {
+	if len(permitPullRequest) > 0 {
+		return true, nil
+	}
+
+	ok, err := client.HasWritePermission(ctx, repo, event.Sender)
+	if err != nil {
+		logger.Log.Warn("Could not get the permission of the sender", zap.Error(err), zap.String("repo", event.Repository.FullName()), zap.String("sender", event.Sender.Login))
+		return false, err
+	}
+	if ok {
+		logger.Log.Info("The sender of PullRequestEvent has the write permission", zap.String("repo", event.Repository.FullName()), zap.String("sender", event.Sender.Login))
 		return true, nil
 	}
 
 	return false, nil
 }
 
-func (a *Api) skipCI(_ context.Context, e interface{}) (bool, error) {
-	switch event := e.(type) {
-	case *github.PushEvent:
-		if strings.HasPrefix(event.HeadCommit.GetMessage(), SkipCI) {
-			return true, nil
-		}
-	case *github.PullRequestEvent:
-		if strings.HasPrefix(event.PullRequest.GetTitle(), SkipCI) {
+func (a *Api) skipCI(_ context.Context, event *forge.Event) (bool, error) {
+	switch event.Type {
+	case forge.EventPush, forge.EventPullRequest:
+		if strings.HasPrefix(event.Message, SkipCI) {
 			return true, nil
 		}
 	}
@@ -305,12 +313,11 @@ func (a *Api) skipCI(_ context.Context, e interface{}) (bool, error) {
 	return false, nil
 }
 
-func (a *Api) buildByPushEvent(ctx context.Context, repo *database.SourceRepository, event *github.PushEvent, isMainBranch bool) error {
-	owner, repoName, revision := event.Repo.Owner.GetLogin(), event.Repo.GetName(), event.HeadCommit.GetID()
-	conf, bazelVersion, err := a.fetchBuildConfig(ctx, owner, repoName, revision, isMainBranch)
+func (a *Api) buildByPushEvent(ctx context.Context, repo *database.SourceRepository, event *forge.Event, isMainBranch bool) error {
+	owner, repoName, revision := event.Repository.Owner, event.Repository.Name, event.Revision
+	conf, bazelVersion, err := a.fetchBuildConfig(ctx, event.RepositoryURL, revision, isMainBranch)
 	if err != nil {
-		logger.Log.Info("Skip build", logger.Error(err), zap.String("owner", owner), zap.String("repo", repoName), zap.String("revision", revision))
-		return nil
+		return err
 	}
 	if conf == nil {
 		logger.Log.Info("Skip build because build file is not found", zap.String("owner", owner), zap.String("repo", repoName))
@@ -318,19 +325,18 @@ func (a *Api) buildByPushEvent(ctx context.Context, repo *database.SourceReposit
 	}
 	jobs := conf.Job(config.EventPush)
 
-	if err := a.build(ctx, owner, repoName, repo, jobs, bazelVersion, revision, "push", isMainBranch); err != nil {
+	if err := a.build(ctx, owner, repoName, repo, jobs, bazelVersion, revision, "push", isMainBranch, 0); err != nil {
 		return xerrors.WithStack(err)
 	}
 
 	return nil
 }
 
-func (a *Api) buildByPullRequest(ctx context.Context, repo *database.SourceRepository, event *github.PullRequestEvent) error {
-	owner, repoName, revision := event.Repo.Owner.GetLogin(), event.Repo.GetName(), event.PullRequest.Head.GetSHA()
-	conf, bazelVersion, err := a.fetchBuildConfig(ctx, owner, repoName, revision, false)
+func (a *Api) buildByPullRequest(ctx context.Context, repo *database.SourceRepository, event *forge.Event) error {
+	owner, repoName, revision := event.Repository.Owner, event.Repository.Name, event.Revision
+	conf, bazelVersion, err := a.fetchBuildConfig(ctx, event.RepositoryURL, revision, false)
 	if err != nil {
-		logger.Log.Info("Skip build", logger.Error(err), zap.String("owner", owner), zap.String("repo", repoName), zap.String("revision", revision))
-		return nil
+		return err
 	}
 	if conf == nil {
 		logger.Log.Info("Skip build because build file is not found", zap.String("owner", owner), zap.String("repo", repoName))
@@ -338,24 +344,27 @@ func (a *Api) buildByPullRequest(ctx context.Context, repo *database.SourceRepos
 	}
 	jobs := conf.Job(config.EventPullRequest)
 
-	if err := a.build(ctx, owner, repoName, repo, jobs, bazelVersion, revision, "pull_request", false); err != nil {
+	if err := a.build(ctx, owner, repoName, repo, jobs, bazelVersion, revision, "pull_request", false, int32(event.Number)); err != nil {
 		return xerrors.WithStack(err)
 	}
 
 	return nil
 }
 
-func (a *Api) buildByRelease(ctx context.Context, repo *database.SourceRepository, event *github.ReleaseEvent) error {
-	ref, _, err := a.githubClient.Git.GetRef(ctx, event.Repo.Owner.GetLogin(), event.Repo.GetName(), fmt.Sprintf("tags/%s", event.Release.GetTagName()))
+func (a *Api) buildByRelease(ctx context.Context, repo *database.SourceRepository, event *forge.Event) error {
+	client, forgeRepo, err := a.forges.Client(event.RepositoryURL)
 	if err != nil {
-		return xerrors.WithStack(err)
+		return err
+	}
+	revision, err := client.GetTagCommit(ctx, forgeRepo, event.Tag)
+	if err != nil {
+		return err
 	}
 
-	owner, repoName, revision := event.Repo.Owner.GetLogin(), event.Repo.GetName(), ref.Object.GetSHA()
-	conf, bazelVersion, err := a.fetchBuildConfig(ctx, owner, repoName, revision, true)
+	owner, repoName := event.Repository.Owner, event.Repository.Name
+	conf, bazelVersion, err := FetchBuildConfig(ctx, client, forgeRepo, revision, true)
 	if err != nil {
-		logger.Log.Info("Skip build", logger.Error(err), zap.String("owner", owner), zap.String("repo", repoName), zap.String("revision", revision))
-		return nil
+		return err
 	}
 	if conf == nil {
 		logger.Log.Info("Skip build because build file is not found", zap.String("owner", owner), zap.String("repo", repoName))
@@ -363,25 +372,25 @@ func (a *Api) buildByRelease(ctx context.Context, repo *database.SourceRepositor
 	}
 	jobs := conf.Job(config.EventRelease)
 
-	if err := a.build(ctx, owner, repoName, repo, jobs, bazelVersion, revision, "release", false); err != nil {
+	if err := a.build(ctx, owner, repoName, repo, jobs, bazelVersion, revision, "release", false, 0); err != nil {
 		return xerrors.WithStack(err)
 	}
 	return nil
 }
 
-func (a *Api) buildPullRequest(ctx context.Context, repo *database.SourceRepository, owner, repoName string, number int) error {
-	pr, res, err := a.githubClient.PullRequests.Get(ctx, owner, repoName, number)
+func (a *Api) buildPullRequest(ctx context.Context, repo *database.SourceRepository, event *forge.Event) error {
+	client, forgeRepo, err := a.forges.Client(event.RepositoryURL)
 	if err != nil {
-		return xerrors.WithStack(err)
+		return err
 	}
-	if res.StatusCode != http.StatusOK {
-		return xerrors.Define("could not get pr").WithStack()
+	revision, err := client.GetPullRequestHead(ctx, forgeRepo, event.Number)
+	if err != nil {
+		return err
 	}
-	revision := pr.GetHead().GetSHA()
-	conf, bazelVersion, err := a.fetchBuildConfig(ctx, owner, repoName, revision, false)
+	owner, repoName := forgeRepo.Owner, forgeRepo.Name
+	conf, bazelVersion, err := FetchBuildConfig(ctx, client, forgeRepo, revision, false)
 	if err != nil {
-		logger.Log.Info("Skip build", logger.Error(err), zap.String("owner", owner), zap.String("repo", repoName), zap.String("revision", revision))
-		return nil
+		return err
 	}
 	if conf == nil {
 		logger.Log.Info("Skip build because build file is not found", zap.String("owner", owner), zap.String("repo", repoName))
@@ -389,24 +398,29 @@ func (a *Api) buildPullRequest(ctx context.Context, repo *database.SourceReposit
 	}
 	jobs := conf.Job(config.EventPullRequest)
 
-	if err := a.build(ctx, owner, repoName, repo, jobs, bazelVersion, revision, "pr", false); err != nil {
+	if err := a.build(ctx, owner, repoName, repo, jobs, bazelVersion, revision, "pr", false, int32(event.Number)); err != nil {
 		return xerrors.WithStack(err)
 	}
 	return nil
 }
 
-func (a *Api) build(ctx context.Context, owner, repoName string, repo *database.SourceRepository, jobs []*config.Job, bazelVersion, revision, via string, isMainBranch bool) error {
+func (a *Api) build(ctx context.Context, owner, repoName string, repo *database.SourceRepository, jobs []*config.Job, bazelVersion, revision, via string, isMainBranch bool, pullRequest int32) error {
+	// Upstream jobs have to be created before the job which needs them.
+	jobs, err := config.SortJobs(jobs)
+	if err != nil {
+		return err
+	}
 	for _, v := range jobs {
 		// Trigger the job when Command is build or test only.
 		// In other words, If command is run, we are not trigger the job via PushEvent.
 		switch v.Command {
-		case "build", "test", "run":
+		case "build", "test", "run", "coverage":
 		default:
 			logger.Log.Warn("Skip creating job", zap.String("command", v.Command))
 			continue
 		}
 
-		if _, err := a.builder.Build(ctx, repo, v, revision, bazelVersion, v.Command, v.Targets, v.Platforms, via, isMainBranch); err != nil {
+		if _, err := a.builder.Build(ctx, repo, v, revision, bazelVersion, v.Command, v.Targets, v.Platforms, via, isMainBranch, pullRequest); err != nil {
 			logger.Log.Warn("Failed start job", zap.Error(err), zap.String("owner", owner), zap.String("repo", repoName))
 			return xerrors.WithStack(err)
 		}
@@ -416,11 +430,15 @@ func (a *Api) build(ctx context.Context, owner, repoName string, repo *database.
 	return nil
 }
 
-func (a *Api) issueComment(ctx context.Context, event *github.IssueCommentEvent) error {
-	switch event.GetAction() {
-	case "created":
-		if strings.Contains(event.Comment.GetBody(), AllowCommand) {
-			users, err := a.dao.TrustedUser.ListByGithubId(ctx, event.Sender.GetID())
+func (a *Api) issueComment(ctx context.Context, event *forge.Event) error {
+	switch event.Action {
+	case forge.ActionCreated:
+		if strings.Contains(event.Comment, AllowCommand) {
+			client, forgeRepo, err := a.forges.Client(event.RepositoryURL)
+			if err != nil {
+				return err
+			}
+			users, err := a.dao.TrustedUser.ListByHostAndGithubId(ctx, client.Host(), event.Sender.ID)
 			if err != nil {
 				return xerrors.WithStack(err)
 			}
@@ -429,13 +447,14 @@ func (a *Api) issueComment(ctx context.Context, event *github.IssueCommentEvent)
 			}
 			user := users[0]
 			if user == nil {
-				logger.Log.Info("Skip handling comment due to user is not trusted user", zap.String("user", event.Sender.GetLogin()))
+				logger.Log.Info("Skip handling comment due to user is not trusted user", zap.String("user", event.Sender.Login))
 				return nil
 			}
 
 			_, err = a.dao.PermitPullRequest.Create(ctx, &database.PermitPullRequest{
-				Repository: event.Repo.GetFullName(),
-				Number:     int32(event.Issue.GetNumber()),
+				Repository: event.Repository.FullName(),
+				Number:     int32(event.Number),
+				Host:       client.Host(),
 			})
 			if err != nil {
 				return xerrors.WithStack(err)
@@ -443,19 +462,12 @@ func (a *Api) issueComment(ctx context.Context, event *github.IssueCommentEvent)
 
 			body := "Understood. This pull request added to allow list.\n" +
 				"We are going to build the job."
-			_, _, err = a.githubClient.Issues.CreateComment(
-				ctx,
-				event.Repo.GetOwner().GetLogin(),
-				event.Repo.GetName(),
-				event.Issue.GetNumber(),
-				&github.IssueComment{Body: github.String(body)},
-			)
-			if err != nil {
-				return xerrors.WithStack(err)
+			if err := client.CreateComment(ctx, forgeRepo, event.Number, body); err != nil {
+				return err
 			}
 
-			repo := a.findRepository(ctx, event.Repo.GetHTMLURL())
-			if err := a.buildPullRequest(ctx, repo, event.Repo.Owner.GetLogin(), event.Repo.GetName(), event.Issue.GetNumber()); err != nil {
+			repo := a.findRepository(ctx, event.RepositoryURL)
+			if err := a.buildPullRequest(ctx, repo, event); err != nil {
 				return xerrors.WithStack(err)
 			}
 		}
@@ -514,6 +526,7 @@ func (a *Api) handleRedo(w http.ResponseWriter, req *http.Request) {
 		jobConfiguration.Platforms,
 		"api",
 		false,
+		task.PullRequest,
 	)
 	if err != nil {
 		logger.Log.Warn("Failed build job", zap.Error(err))
@@ -527,6 +540,46 @@ func (a *Api) handleRedo(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+func (a *Api) handleCancel(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := req.ParseForm(); err != nil {
+		logger.Log.Info("Failed parse form", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	taskId, err := strconv.Atoi(req.FormValue("task_id"))
+	if err != nil {
+		logger.Log.Info("Failed parse task id", zap.Error(err), zap.String("task_id", req.FormValue("task_id")))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	task, err := a.dao.Task.Select(req.Context(), int32(taskId))
+	if err != nil {
+		logger.Log.Info("Task is not found", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if task.FinishedAt != nil {
+		logger.Log.Info("Task has already finished", zap.Int32("task_id", task.Id))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := a.builder.Cancel(req.Context(), task); err != nil {
+		logger.Log.Warn("Failed cancel the task", zap.Error(err), zap.Int32("task_id", task.Id))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	logger.Log.Info("Success cancel the task", zap.Int32("task_id", task.Id))
+}
+
 func (a *Api) handleRun(w http.ResponseWriter, req *http.Request) {
 	if req.Method != http.MethodPost {
 		w.WriteHeader(http.StatusMethodNotAllowed)
@@ -551,35 +604,33 @@ func (a *Api) handleRun(w http.ResponseWriter, req *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
-	u, err := url.Parse(repo.Url)
+	client, forgeRepo, err := a.forges.Client(repo.Url)
 	if err != nil {
-		logger.Log.Info("Failed to parse repository URL", logger.Error(err), zap.String("url", repo.Url))
+		logger.Log.Info("Failed to find the forge of the repository", logger.Error(err), zap.String("url", repo.Url))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
-	if u.Hostname() != "github.com" {
-		logger.Log.Info("The repository is not hosted github.com")
+	defaultBranch, err := client.GetDefaultBranch(req.Context(), forgeRepo)
+	if err != nil {
+		logger.Log.Info("Failed to get the information of repository", logger.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
-	// u.Path is /owner/repo if URL is github.com.
-	s := strings.Split(u.Path, "/")
-	owner, repoName := s[1], s[2]
-	githubRepo, _, err := a.githubClient.Repositories.Get(req.Context(), owner, repoName)
+	revision, err := client.GetCommit(req.Context(), forgeRepo, defaultBranch)
 	if err != nil {
-		logger.Log.Info("Failed to get the information of repository from github", logger.Error(err))
+		logger.Log.Info("Failed to get the head of the default branch", logger.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
-	branch, _, err := a.githubClient.Repositories.GetBranch(req.Context(), owner, repoName, githubRepo.GetDefaultBranch(), false)
+	conf, bazelVersion, err := FetchBuildConfig(req.Context(), client, forgeRepo, revision, true)
 	if err != nil {
-		logger.Log.Info("Failed to branch", logger.Error(err))
+		logger.Log.Info("Failed to get the build configuration", logger.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
-	conf, bazelVersion, err := a.fetchBuildConfig(req.Context(), owner, repoName, branch.Commit.GetSHA(), true)
-	if err != nil {
-		logger.Log.Info("Skip build", logger.Error(err))
-		w.WriteHeader(http.StatusBadRequest)
+	if conf == nil {
+		logger.Log.Info("The build configuration is not found", zap.String("repo", repo.Name))
+		w.WriteHeader(http.StatusNotFound)
 		return
 	}
 	var job *config.Job
@@ -604,16 +655,18 @@ func (a *Api) handleRun(w http.ResponseWriter, req *http.Request) {
 		req.Context(),
 		repo,
 		job,
-		githubRepo.GetDefaultBranch(),
+		defaultBranch,
 		bazelVersion,
 		job.Command,
 		job.Targets,
 		job.Platforms,
 		"manual",
 		false,
+		0,
 	)
 	if err != nil {
 		logger.Log.Warn("Failed to start building job", logger.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
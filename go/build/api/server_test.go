@@ -22,22 +22,29 @@ import (
 	"go.f110.dev/mono/go/build/database"
 	"go.f110.dev/mono/go/build/database/dao"
 	"go.f110.dev/mono/go/build/database/dao/daotest"
+	"go.f110.dev/mono/go/build/forge"
 	"go.f110.dev/mono/go/logger"
 )
 
 type MockBuilder struct {
-	jobs   []*config.Job
-	called bool
+	jobs     []*config.Job
+	called   bool
+	canceled []*database.Task
 }
 
 var _ Builder = &MockBuilder{}
 
-func (m *MockBuilder) Build(_ context.Context, repo *database.SourceRepository, job *config.Job, revision, bazelVersion, command string, targets, platforms []string, via string, isMainBranch bool) ([]*database.Task, error) {
+func (m *MockBuilder) Build(_ context.Context, repo *database.SourceRepository, job *config.Job, revision, bazelVersion, command string, targets, platforms []string, via string, isMainBranch bool, pullRequest int32) ([]*database.Task, error) {
 	m.jobs = append(m.jobs, job)
 	m.called = true
 	return []*database.Task{}, nil
 }
 
+func (m *MockBuilder) Cancel(_ context.Context, task *database.Task) error {
+	m.canceled = append(m.canceled, task)
+	return nil
+}
+
 type MockTransport struct {
 	req   []*http.Request
 	res   []*http.Response
@@ -130,20 +137,26 @@ func TestGithubWebHook(t *testing.T) {
 			mock, s, builder, w, req := setup(t)
 
 			mockTransport := &MockTransport{res: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"permission":"read"}`)),
+				},
 				{
 					StatusCode: http.StatusOK,
 					Body:       io.NopCloser(strings.NewReader("{}")),
 				},
 			}}
-			s.githubClient = github.NewClient(&http.Client{Transport: mockTransport})
+			s.forges = forge.NewSet(forge.NewGitHub(github.NewClient(&http.Client{Transport: mockTransport})))
 
-			mock.TrustedUser.RegisterListByGithubId(2178441, nil, sql.ErrNoRows)
-			mock.PermitPullRequest.RegisterListByRepositoryAndNumber("f110/ops", 28, nil, sql.ErrNoRows)
+			mock.TrustedUser.RegisterListByHostAndGithubId("github.com", 2178441, nil, sql.ErrNoRows)
+			mock.PermitPullRequest.RegisterListByHostAndRepositoryAndNumber("github.com", "f110/ops", 28, nil, sql.ErrNoRows)
 
 			s.handleWebHook(w, req)
 
 			assert.False(t, builder.called)
-			reqBody, err := io.ReadAll(mockTransport.req[0].Body)
+			require.Len(t, mockTransport.req, 2)
+			assert.Equal(t, "/repos/f110/ops/collaborators/f110_test/permission", mockTransport.req[0].URL.Path)
+			reqBody, err := io.ReadAll(mockTransport.req[1].Body)
 			require.NoError(t, err)
 			apiReq := &github.IssueComment{}
 			err = json.Unmarshal(reqBody, apiReq)
@@ -157,7 +170,8 @@ func TestGithubWebHook(t *testing.T) {
 
 			mock, s, builder, w, req := setup(t)
 
-			mock.TrustedUser.RegisterListByGithubId(
+			mock.TrustedUser.RegisterListByHostAndGithubId(
+				"github.com",
 				trustedUser.GithubId,
 				[]*database.TrustedUser{trustedUser},
 				nil,
@@ -188,7 +202,7 @@ func TestGithubWebHook(t *testing.T) {
 )`)),
 				},
 			}}
-			s.githubClient = github.NewClient(&http.Client{Transport: mockTransport})
+			s.forges = forge.NewSet(forge.NewGitHub(github.NewClient(&http.Client{Transport: mockTransport})))
 
 			s.handleWebHook(w, req)
 
@@ -196,6 +210,54 @@ func TestGithubWebHook(t *testing.T) {
 			assert.Len(t, builder.jobs, 1)
 		})
 
+		t.Run("Collaborator", func(t *testing.T) {
+			t.Parallel()
+
+			mock, s, builder, w, req := setup(t)
+
+			mockTransport := &MockTransport{res: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"permission":"write"}`)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"sha":"9697650793febd8884fe38a84365067624efacab"}`)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"tree":[{"path":"build.star","sha":"buildstarsha"}]}`)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`job(
+	name = "foo",
+	event = ["pull_request"],
+	command = "test",
+	targets = ["//..."],
+	platforms = ["linux_amd64"],
+)`)),
+				},
+			}}
+			s.forges = forge.NewSet(forge.NewGitHub(github.NewClient(&http.Client{Transport: mockTransport})))
+
+			// The user who is trusted on the other forge which has the same id must not be allowed.
+			mock.TrustedUser.RegisterListByHostAndGithubId("gitea.example.com", 2178441, []*database.TrustedUser{trustedUser}, nil)
+			mock.TrustedUser.RegisterListByHostAndGithubId("github.com", 2178441, nil, sql.ErrNoRows)
+			mock.PermitPullRequest.RegisterListByHostAndRepositoryAndNumber("github.com", "f110/ops", 28, nil, sql.ErrNoRows)
+			mock.Repository.RegisterListByUrl(
+				opsRepository.Url,
+				[]*database.SourceRepository{opsRepository},
+				nil,
+			)
+
+			s.handleWebHook(w, req)
+
+			assert.Equal(t, "/repos/f110/ops/collaborators/f110_test/permission", mockTransport.req[0].URL.Path)
+			assert.True(t, builder.called)
+			assert.Len(t, builder.jobs, 1)
+		})
+
 		t.Run("PermitPullRequest", func(t *testing.T) {
 			t.Parallel()
 
@@ -221,10 +283,10 @@ func TestGithubWebHook(t *testing.T) {
 )`)),
 				},
 			}}
-			s.githubClient = github.NewClient(&http.Client{Transport: mockTransport})
+			s.forges = forge.NewSet(forge.NewGitHub(github.NewClient(&http.Client{Transport: mockTransport})))
 
-			mock.TrustedUser.RegisterListByGithubId(trustedUser.GithubId, nil, sql.ErrNoRows)
-			mock.PermitPullRequest.RegisterListByRepositoryAndNumber("f110/ops", 28,
+			mock.TrustedUser.RegisterListByHostAndGithubId("github.com", trustedUser.GithubId, nil, sql.ErrNoRows)
+			mock.PermitPullRequest.RegisterListByHostAndRepositoryAndNumber("github.com", "f110/ops", 28,
 				[]*database.PermitPullRequest{{Id: 1, Repository: "f110/ops", Number: 28, CreatedAt: time.Now()}},
 				nil,
 			)
@@ -252,7 +314,8 @@ func TestGithubWebHook(t *testing.T) {
 			PermitPullRequest: mock.PermitPullRequest,
 		}
 
-		mock.TrustedUser.RegisterListByGithubId(
+		mock.TrustedUser.RegisterListByHostAndGithubId(
+			"github.com",
 			trustedUser.GithubId,
 			[]*database.TrustedUser{trustedUser},
 			nil,
@@ -286,7 +349,7 @@ func TestGithubWebHook(t *testing.T) {
 			},
 		}}
 
-		s, err := NewApi("", builder, daos, github.NewClient(&http.Client{Transport: mockTransport}), nil, "")
+		s, err := NewApi("", builder, daos, forge.NewSet(forge.NewGitHub(github.NewClient(&http.Client{Transport: mockTransport}))), nil, "")
 		require.NoError(t, err)
 		body, err := os.ReadFile("testdata/pull_request_synchronize.json")
 		require.NoError(t, err)
@@ -310,8 +373,8 @@ func TestGithubWebHook(t *testing.T) {
 			PermitPullRequest: mock.PermitPullRequest,
 		}
 
-		mock.TrustedUser.RegisterListByGithubId(trustedUser.GithubId, nil, sql.ErrNoRows)
-		mock.PermitPullRequest.RegisterListByRepositoryAndNumber("f110/sandbox", 2,
+		mock.TrustedUser.RegisterListByHostAndGithubId("github.com", trustedUser.GithubId, nil, sql.ErrNoRows)
+		mock.PermitPullRequest.RegisterListByHostAndRepositoryAndNumber("github.com", "f110/sandbox", 2,
 			[]*database.PermitPullRequest{{Id: 1, Repository: "f110/sandbox", Number: 2, CreatedAt: time.Now()}},
 			nil,
 		)
@@ -344,7 +407,7 @@ func TestGithubWebHook(t *testing.T) {
 			PermitPullRequest: mock.PermitPullRequest,
 		}
 
-		mock.TrustedUser.RegisterListByGithubId(trustedUser.GithubId, []*database.TrustedUser{trustedUser}, nil)
+		mock.TrustedUser.RegisterListByHostAndGithubId("github.com", trustedUser.GithubId, []*database.TrustedUser{trustedUser}, nil)
 		mock.Repository.RegisterListByUrl(
 			opsRepository.Url,
 			[]*database.SourceRepository{opsRepository},
@@ -382,7 +445,7 @@ func TestGithubWebHook(t *testing.T) {
 			},
 		}}
 
-		s, err := NewApi("", builder, daos, github.NewClient(&http.Client{Transport: mockTransport}), nil, "")
+		s, err := NewApi("", builder, daos, forge.NewSet(forge.NewGitHub(github.NewClient(&http.Client{Transport: mockTransport}))), nil, "")
 		require.NoError(t, err)
 		body, err := ioutil.ReadFile("testdata/issue_comment.json")
 		require.NoError(t, err)
@@ -396,6 +459,7 @@ func TestGithubWebHook(t *testing.T) {
 		called := mock.PermitPullRequest.Called("Create")
 		require.Len(t, called, 1)
 		assert.Equal(t, "f110/ops", called[0].Args["permitPullRequest"].(*database.PermitPullRequest).Repository)
+		assert.Equal(t, "github.com", called[0].Args["permitPullRequest"].(*database.PermitPullRequest).Host)
 	})
 
 	t.Run("PublishRelease", func(t *testing.T) {
@@ -442,7 +506,7 @@ func TestGithubWebHook(t *testing.T) {
 			},
 		}}
 
-		s, err := NewApi("", builder, daos, github.NewClient(&http.Client{Transport: mockTransport}), nil, "")
+		s, err := NewApi("", builder, daos, forge.NewSet(forge.NewGitHub(github.NewClient(&http.Client{Transport: mockTransport}))), nil, "")
 		require.NoError(t, err)
 		body, err := os.ReadFile("testdata/release_published.json")
 		require.NoError(t, err)
@@ -455,3 +519,124 @@ func TestGithubWebHook(t *testing.T) {
 		assert.True(t, builder.called)
 	})
 }
+
+func TestHandleCancel(t *testing.T) {
+	logger.SetLogLevel("debug")
+	logger.Init()
+
+	now := time.Now()
+	mock := newMock()
+	mock.Task.RegisterSelect(1, &database.Task{Id: 1, StartAt: &now})
+	mock.Task.RegisterSelect(2, &database.Task{Id: 2, StartAt: &now, FinishedAt: &now})
+	builder := &MockBuilder{}
+	s, err := NewApi("", builder, dao.Options{Task: mock.Task}, nil, nil, "")
+	require.NoError(t, err)
+
+	t.Run("Running", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "http://localhost:8080/cancel", strings.NewReader("task_id=1"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		s.handleCancel(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, builder.canceled, 1)
+		assert.Equal(t, int32(1), builder.canceled[0].Id)
+	})
+
+	t.Run("Finished", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "http://localhost:8080/cancel", strings.NewReader("task_id=2"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		s.handleCancel(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Len(t, builder.canceled, 1)
+	})
+}
+
+func TestGiteaWebHook(t *testing.T) {
+	logger.SetLogLevel("debug")
+	logger.Init()
+
+	setup := func(t *testing.T) (*mockDAO, *MockBuilder, *[]string, *Api, *http.Request) {
+		var requested []string
+		stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			requested = append(requested, req.Method+" "+req.URL.Path)
+			switch req.Method + " " + req.URL.Path {
+			case "GET /api/v1/repos/f110/ops":
+				io.WriteString(w, `{"default_branch":"master"}`)
+			case "GET /api/v1/repos/f110/ops/git/commits/master":
+				io.WriteString(w, `{"sha":"a2b3c4d51f1c4e3e1b9f1bb8c1d4c0f1c8f2d4e1"}`)
+			case "GET /api/v1/repos/f110/ops/raw/build.star":
+				io.WriteString(w, `job(
+	name = "foo",
+	event = ["pull_request"],
+	command = "test",
+	targets = ["//..."],
+	platforms = ["linux_amd64"],
+)`)
+			case "POST /api/v1/repos/f110/ops/issues/3/comments":
+				w.WriteHeader(http.StatusCreated)
+				io.WriteString(w, `{}`)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		t.Cleanup(stub.Close)
+
+		d := newMock()
+		daos := dao.Options{
+			Repository:        d.Repository,
+			Task:              d.Task,
+			TrustedUser:       d.TrustedUser,
+			PermitPullRequest: d.PermitPullRequest,
+		}
+		// The client is chosen by the host of the repository URL. Thus, the URL has to be pointed to the stub server.
+		opsRepository := &database.SourceRepository{
+			Id:       1,
+			Url:      stub.URL + "/f110/ops",
+			CloneUrl: stub.URL + "/f110/ops.git",
+			Name:     "ops",
+		}
+		d.Repository.RegisterListByUrl(opsRepository.Url, []*database.SourceRepository{opsRepository}, nil)
+		gitea, err := forge.NewGitea(stub.URL, "token", nil)
+		require.NoError(t, err)
+
+		builder := &MockBuilder{}
+		s, err := NewApi("", builder, daos, forge.NewSet(gitea), nil, "")
+		require.NoError(t, err)
+
+		body, err := os.ReadFile("testdata/gitea_pull_request_opened.json")
+		require.NoError(t, err)
+		body = bytes.ReplaceAll(body, []byte("https://gitea.example.com"), []byte(stub.URL))
+		req := httptest.NewRequest(http.MethodPost, "http://localhost:8080/webhook", bytes.NewReader(body))
+		req.Header.Set("X-Gitea-Event", "pull_request")
+		req.Header.Set("X-GitHub-Event", "pull_request")
+		return d, builder, &requested, s, req
+	}
+
+	t.Run("TrustedUser", func(t *testing.T) {
+		mock, builder, _, s, req := setup(t)
+		mock.TrustedUser.RegisterListByHostAndGithubId("127.0.0.1", 5, []*database.TrustedUser{{Id: 1, GithubId: 5, Username: "octocat"}}, nil)
+
+		w := httptest.NewRecorder()
+		s.handleWebHook(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, builder.called)
+		assert.Len(t, builder.jobs, 1)
+	})
+
+	t.Run("NotTrustedUser", func(t *testing.T) {
+		mock, builder, requested, s, req := setup(t)
+		mock.TrustedUser.RegisterListByHostAndGithubId("127.0.0.1", 5, nil, sql.ErrNoRows)
+		mock.PermitPullRequest.RegisterListByHostAndRepositoryAndNumber("127.0.0.1", "f110/ops", 3, nil, sql.ErrNoRows)
+
+		w := httptest.NewRecorder()
+		s.handleWebHook(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, builder.called)
+		assert.Equal(t, []string{"GET /api/v1/repos/f110/ops/collaborators/octocat/permission", "POST /api/v1/repos/f110/ops/issues/3/comments"}, *requested)
+	})
+}
@@ -34,6 +34,26 @@ func redoTask(endpoint string, taskId int, via string) error {
 	return nil
 }
 
+func cancelTask(endpoint string, taskId int) error {
+	v := &url.Values{}
+	v.Add("task_id", strconv.Itoa(taskId))
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/cancel", endpoint), strings.NewReader(v.Encode()))
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return xerrors.Definef("failed cancel task: %s", res.Status).WithStack()
+	}
+	return nil
+}
+
 func Job(rootCmd *cli.Command) {
 	job := &cli.Command{
 		Use: "job",
@@ -53,5 +73,16 @@ func Job(rootCmd *cli.Command) {
 	redo.Flags().String("via", "Via").Var(&via)
 	job.AddCommand(redo)
 
+	taskId := 0
+	cancel := &cli.Command{
+		Use: "cancel",
+		Run: func(ctx context.Context, _ *cli.Command, _ []string) error {
+			return cancelTask(endpoint, taskId)
+		},
+	}
+	cancel.Flags().String("endpoint", "API Endpoint").Var(&endpoint)
+	cancel.Flags().Int("task-id", "Cancel task id").Var(&taskId)
+	job.AddCommand(cancel)
+
 	rootCmd.AddCommand(job)
 }
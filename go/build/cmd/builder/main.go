@@ -3,7 +3,9 @@ package builder
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -28,7 +30,9 @@ import (
 	"go.f110.dev/mono/go/build/coordinator"
 	"go.f110.dev/mono/go/build/database"
 	"go.f110.dev/mono/go/build/database/dao"
+	"go.f110.dev/mono/go/build/forge"
 	"go.f110.dev/mono/go/build/gc"
+	"go.f110.dev/mono/go/build/scheduler"
 	"go.f110.dev/mono/go/build/watcher"
 	"go.f110.dev/mono/go/cli"
 	"go.f110.dev/mono/go/ctxutil"
@@ -51,6 +55,12 @@ type Options struct {
 	GithubInstallationId    int64
 	GithubPrivateKeyFile    string
 	GithubAppSecretName     string
+	GiteaURL                string
+	GiteaTokenFile          string
+	GiteaTokenSecretName    string
+	GitLabURL               string
+	GitLabTokenFile         string
+	GitLabTokenSecretName   string
 	MinIOEndpoint           string
 	MinIOName               string
 	MinIONamespace          string
@@ -58,6 +68,7 @@ type Options struct {
 	MinIOBucket             string
 	MinIOAccessKey          string
 	MinIOSecretAccessKey    string
+	ArtifactSecretName      string
 	ServiceAccountTokenFile string
 	VaultAddr               string
 	VaultTokenFile          string
@@ -87,6 +98,7 @@ type Options struct {
 	TaskCPULimit               string
 	TaskMemoryLimit            string
 	WithGC                     bool
+	WithScheduler              bool
 
 	Dev   bool
 	Debug bool
@@ -108,7 +120,7 @@ type process struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
-	ghClient                  *github.Client
+	forges                    *forge.Set
 	kubeClient                *kubernetes.Clientset
 	secretStoreClient         *secretstoreclient.Clientset
 	restCfg                   *rest.Config
@@ -150,7 +162,30 @@ func (p *process) init(ctx context.Context) (fsm.State, error) {
 	if err != nil {
 		return fsm.Error(err)
 	}
-	p.ghClient = github.NewClient(&http.Client{Transport: githubutil.NewTransportWithApp(http.DefaultTransport, app)})
+	clients := []forge.Client{forge.NewGitHub(github.NewClient(&http.Client{Transport: githubutil.NewTransportWithApp(http.DefaultTransport, app)}))}
+	if p.opt.GiteaURL != "" {
+		token, err := readTokenFile(p.opt.GiteaTokenFile)
+		if err != nil {
+			return fsm.Error(err)
+		}
+		c, err := forge.NewGitea(p.opt.GiteaURL, token, nil)
+		if err != nil {
+			return fsm.Error(err)
+		}
+		clients = append(clients, c)
+	}
+	if p.opt.GitLabURL != "" {
+		token, err := readTokenFile(p.opt.GitLabTokenFile)
+		if err != nil {
+			return fsm.Error(err)
+		}
+		c, err := forge.NewGitLab(p.opt.GitLabURL, token, nil)
+		if err != nil {
+			return fsm.Error(err)
+		}
+		clients = append(clients, c)
+	}
+	p.forges = forge.NewSet(clients...)
 
 	if p.opt.Dev {
 		logger.Log.Info("Start without kube-apiserver. All of integrations with kube-apiserver will be disabled.")
@@ -293,6 +328,18 @@ func (p *process) setup(_ context.Context) (fsm.State, error) {
 			p.opt.TaskMemoryLimit,
 		)
 	}
+	// The artifacts are uploaded from the job. Thus, the endpoint has to be reachable from the pod.
+	var artifactEndpoint string
+	if p.opt.ArtifactSecretName != "" {
+		if p.opt.MinIOEndpoint != "" {
+			artifactEndpoint = p.opt.MinIOEndpoint
+			if !strings.HasPrefix(artifactEndpoint, "http") {
+				artifactEndpoint = "http://" + artifactEndpoint
+			}
+		} else {
+			artifactEndpoint = fmt.Sprintf("http://%s-hl-svc.%s.svc:%d", p.opt.MinIOName, p.opt.MinIONamespace, p.opt.MinIOPort)
+		}
+	}
 	bazelOpt := coordinator.NewBazelOptions(
 		p.opt.RemoteCache,
 		p.opt.RemoteAssetApi,
@@ -305,13 +352,16 @@ func (p *process) setup(_ context.Context) (fsm.State, error) {
 		p.opt.GithubAppId,
 		p.opt.GithubInstallationId,
 		p.opt.GithubAppSecretName,
+		artifactEndpoint,
+		p.opt.ArtifactSecretName,
+		p.forgeTokenSecrets(),
 	)
 	c, err := coordinator.NewBazelBuilder(
 		p.opt.DashboardUrl,
 		kubernetesOpt,
 		p.dao,
 		p.opt.Namespace,
-		p.ghClient,
+		p.forges,
 		p.opt.MinIOBucket,
 		minioOpt,
 		bazelOpt,
@@ -327,8 +377,27 @@ func (p *process) setup(_ context.Context) (fsm.State, error) {
 	return fsm.Next(stateStartApiServer)
 }
 
+// forgeTokenSecrets returns the names of Secret which has the access token for cloning the private repository.
+func (p *process) forgeTokenSecrets() map[string]string {
+	secrets := make(map[string]string)
+	for _, v := range []struct{ URL, SecretName string }{
+		{URL: p.opt.GiteaURL, SecretName: p.opt.GiteaTokenSecretName},
+		{URL: p.opt.GitLabURL, SecretName: p.opt.GitLabTokenSecretName},
+	} {
+		if v.URL == "" || v.SecretName == "" {
+			continue
+		}
+		u, err := url.Parse(v.URL)
+		if err != nil {
+			continue
+		}
+		secrets[u.Hostname()] = v.SecretName
+	}
+	return secrets
+}
+
 func (p *process) startApiServer(_ context.Context) (fsm.State, error) {
-	apiServer, err := api.NewApi(p.opt.Addr, p.bazelBuilder, p.dao, p.ghClient, storage.NewMinIOStorage(p.opt.BazelMirrorBucket, p.bazelMirrorMinIOOpt), p.opt.BazelMirrorPrefix)
+	apiServer, err := api.NewApi(p.opt.Addr, p.bazelBuilder, p.dao, p.forges, storage.NewMinIOStorage(p.opt.BazelMirrorBucket, p.bazelMirrorMinIOOpt), p.opt.BazelMirrorPrefix)
 	if err != nil {
 		return fsm.Error(xerrors.WithStack(err))
 	}
@@ -415,6 +484,14 @@ func (p *process) startWorker(_ context.Context) (fsm.State, error) {
 		}()
 	}
 
+	if p.opt.WithScheduler {
+		s := scheduler.NewScheduler(1*time.Minute, p.dao, p.bazelBuilder, p.forges)
+		go func() {
+			logger.Log.Info("Start Scheduler")
+			s.Start(p.ctx)
+		}()
+	}
+
 	return fsm.Wait()
 }
 
@@ -428,6 +505,17 @@ func (p *process) shutdown(ctx context.Context) (fsm.State, error) {
 	return fsm.Finish()
 }
 
+func readTokenFile(p string) (string, error) {
+	if p == "" {
+		return "", nil
+	}
+	buf, err := os.ReadFile(p)
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	return strings.TrimSpace(string(buf)), nil
+}
+
 func builder(ctx context.Context, opt Options) error {
 	p := newProcess(opt)
 
@@ -477,6 +565,12 @@ func AddCommand(rootCmd *cli.Command) {
 	fs.Int64("github-app-id", "GitHub App id").Var(&opt.GithubAppId)
 	fs.Int64("github-installation-id", "GitHub Installation id").Var(&opt.GithubInstallationId)
 	fs.String("github-private-key-file", "PrivateKey file path of GitHub App").Var(&opt.GithubPrivateKeyFile)
+	fs.String("gitea-url", "The URL of Gitea (e.g. https://gitea.example.com)").Var(&opt.GiteaURL)
+	fs.String("gitea-token-file", "The file path of the access token of Gitea").Var(&opt.GiteaTokenFile)
+	fs.String("gitea-token-secret-name", "The name of Secret which contains the access token of Gitea for cloning the private repository").Var(&opt.GiteaTokenSecretName)
+	fs.String("gitlab-url", "The URL of GitLab (e.g. https://gitlab.com)").Var(&opt.GitLabURL)
+	fs.String("gitlab-token-file", "The file path of the access token of GitLab").Var(&opt.GitLabTokenFile)
+	fs.String("gitlab-token-secret-name", "The name of Secret which contains the access token of GitLab for cloning the private repository").Var(&opt.GitLabTokenSecretName)
 	fs.String("addr", "Listen addr which will be served API").Var(&opt.Addr).Default("127.0.0.1:8081")
 	fs.String("dashboard", "URL of dashboard").Var(&opt.DashboardUrl).Default("http://localhost")
 	fs.String("builder-api", "URL of the api of builder").Var(&opt.BuilderApiUrl).Default("http://localhost")
@@ -488,6 +582,7 @@ func AddCommand(rootCmd *cli.Command) {
 	fs.String("minio-bucket", "The bucket name that will be used a log storage").Var(&opt.MinIOBucket).Default("logs")
 	fs.String("minio-access-key", "The access key").Var(&opt.MinIOAccessKey)
 	fs.String("minio-secret-access-key", "The secret access key").Var(&opt.MinIOSecretAccessKey)
+	fs.String("artifact-secret-name", "The name of Secret which contains the access key and the secret access key of MinIO for uploading artifacts. If this value is empty, artifacts are not uploaded.").Var(&opt.ArtifactSecretName)
 	fs.String("service-account-token-file", "A file path that contains JWT token").Var(&opt.ServiceAccountTokenFile).Default("/var/run/secrets/kubernetes.io/serviceaccount/token")
 	fs.String("vault-addr", "The vault URL").Var(&opt.VaultAddr)
 	fs.String("vault-token-file", "The token for Vault").Var(&opt.VaultTokenFile)
@@ -513,6 +608,7 @@ func AddCommand(rootCmd *cli.Command) {
 	fs.String("task-cpu-limit", "Task cpu limit. If the job set the limit, It will used the job defined value.").Var(&opt.TaskCPULimit).Default("1000m")
 	fs.String("task-memory-limit", "Task memory limit. If the job set the limit, It will used the job defined value.").Var(&opt.TaskMemoryLimit).Default("4096Mi")
 	fs.Bool("with-gc", "Enable GC for the job").Var(&opt.WithGC)
+	fs.Bool("with-scheduler", "Enable the scheduler for the job which has the schedule").Var(&opt.WithScheduler)
 	fs.Bool("debug", "Enable debugging mode").Var(&opt.Debug)
 
 	rootCmd.AddCommand(cmd)
@@ -12,6 +12,7 @@ import (
 	"go.f110.dev/xerrors"
 	"go.uber.org/zap"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"go.f110.dev/mono/go/build/database/dao"
@@ -46,23 +47,32 @@ func dashboard(ctx context.Context, opt Options) error {
 		return xerrors.WithStack(err)
 	}
 
-	var minioOpt storage.MinIOOptions
-	if opt.MinIOEndpoint != "" {
-		storage.NewMinIOOptionsViaEndpoint(opt.MinIOEndpoint, "", opt.MinIOAccessKey, opt.MinIOSecretAccessKey)
-	} else {
-		cfg, err := clientcmd.BuildConfigFromFlags("", "")
+	var cfg *rest.Config
+	var kubeClient kubernetes.Interface
+	if opt.MinIOEndpoint == "" || opt.BuildNamespace != "" {
+		c, err := clientcmd.BuildConfigFromFlags("", "")
 		if err != nil {
 			return xerrors.WithStack(err)
 		}
-
-		kubeClient, err := kubernetes.NewForConfig(cfg)
+		k, err := kubernetes.NewForConfig(c)
 		if err != nil {
 			return xerrors.WithStack(err)
 		}
+		cfg, kubeClient = c, k
+	}
+
+	var minioOpt storage.MinIOOptions
+	if opt.MinIOEndpoint != "" {
+		storage.NewMinIOOptionsViaEndpoint(opt.MinIOEndpoint, "", opt.MinIOAccessKey, opt.MinIOSecretAccessKey)
+	} else {
 		minioOpt = storage.NewMinIOOptionsViaService(kubeClient, cfg, opt.MinIOName, opt.MinIONamespace, opt.MinIOPort, opt.MinIOAccessKey, opt.MinIOSecretAccessKey, opt.Dev)
 	}
 
-	d := web.NewDashboard(opt.Addr, dao.NewOptions(conn), opt.ApiHost, opt.InternalApi, opt.MinIOBucket, minioOpt)
+	var logClient kubernetes.Interface
+	if opt.BuildNamespace != "" {
+		logClient = kubeClient
+	}
+	d := web.NewDashboard(opt.Addr, dao.NewOptions(conn), opt.ApiHost, opt.InternalApi, opt.MinIOBucket, minioOpt, logClient, opt.BuildNamespace)
 
 	go func() {
 		<-cCtx.Done()
@@ -99,6 +109,7 @@ type Options struct {
 	MinIOBucket          string
 	MinIOAccessKey       string
 	MinIOSecretAccessKey string
+	BuildNamespace       string
 }
 
 func AddCommand(rootCmd *cli.Command) {
@@ -123,6 +134,7 @@ func AddCommand(rootCmd *cli.Command) {
 	fs.String("minio-bucket", "The bucket name that will be used a log storage").Var(&opt.MinIOBucket).Default("logs")
 	fs.String("minio-access-key", "The access key").Var(&opt.MinIOAccessKey)
 	fs.String("minio-secret-access-key", "The secret access key").Var(&opt.MinIOSecretAccessKey)
+	fs.String("build-namespace", "The namespace of the build pods. If this value is empty, the log of the running task is not available.").Var(&opt.BuildNamespace)
 
 	rootCmd.AddCommand(cmd)
 }
@@ -0,0 +1,181 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"go.f110.dev/xerrors"
+	"google.golang.org/protobuf/encoding/protodelim"
+
+	"go.f110.dev/mono/go/bazel/buildeventstream"
+	"go.f110.dev/mono/go/cli"
+	"go.f110.dev/mono/go/storage"
+)
+
+const (
+	// ArtifactAccessKeyEnv is the name of the environment variable which has the access key of the object storage.
+	ArtifactAccessKeyEnv = "ARTIFACT_ACCESS_KEY"
+	// ArtifactSecretAccessKeyEnv is the name of the environment variable which has the secret access key of the object storage.
+	ArtifactSecretAccessKeyEnv = "ARTIFACT_SECRET_ACCESS_KEY"
+)
+
+type ArtifactReport struct {
+	Artifacts []Artifact `json:"artifacts"`
+}
+
+type Artifact struct {
+	// Name is the path of the file that is relative from bazel-bin.
+	Name string `json:"name"`
+	// Path is the name of the object in the bucket.
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+type ArtifactCommand struct {
+	eventBinaryFile string
+	startUpTimeout  time.Duration
+	workDir         string
+	patterns        []string
+	prefix          string
+
+	endpoint string
+	region   string
+	bucket   string
+}
+
+func NewArtifactCommand() *ArtifactCommand {
+	return &ArtifactCommand{}
+}
+
+func (a *ArtifactCommand) Name() string {
+	return "artifact"
+}
+
+func (a *ArtifactCommand) SetFlags(fs *cli.FlagSet) {
+	fs.String("event-binary-file", "The path of the event file").Var(&a.eventBinaryFile)
+	fs.Duration("startup-timeout", "Time of of start-up").Var(&a.startUpTimeout)
+	fs.String("work-dir", "Working directory").Var(&a.workDir)
+	fs.StringArray("pattern", "The pattern of the artifact. The pattern is a relative path from bazel-bin").Var(&a.patterns)
+	fs.String("prefix", "The prefix of the object name").Var(&a.prefix)
+	fs.String("endpoint", "The endpoint of the object storage").Var(&a.endpoint)
+	fs.String("region", "The region of the object storage").Var(&a.region).Default("us-east-1")
+	fs.String("bucket", "The bucket name").Var(&a.bucket)
+}
+
+// Run waits for the build to finish and uploads the files which are matched to the patterns.
+// The credential of the object storage is read from ArtifactAccessKeyEnv and ArtifactSecretAccessKeyEnv.
+// The list of uploaded files is written to stdout.
+func (a *ArtifactCommand) Run(ctx context.Context) error {
+	r, err := openEventBinaryFile(a.eventBinaryFile, a.startUpTimeout)
+	if err != nil {
+		return err
+	}
+
+	var success bool
+	var msg buildeventstream.BuildEvent
+Read:
+	for {
+		err := protodelim.UnmarshalFrom(r, &msg)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+
+		if _, ok := msg.Id.Id.(*buildeventstream.BuildEventId_BuildFinished); ok {
+			if payload, ok := msg.Payload.(*buildeventstream.BuildEvent_Finished); ok {
+				success = payload.Finished.GetExitCode().GetCode() == 0
+			}
+			break Read
+		}
+	}
+
+	report := ArtifactReport{}
+	if !success {
+		// The outputs of the failed build are not uploaded.
+		return json.NewEncoder(os.Stdout).Encode(report)
+	}
+
+	bazelBin, err := filepath.EvalSymlinks(filepath.Join(a.workDir, "bazel-bin"))
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	files, err := MatchArtifacts(bazelBin, a.patterns)
+	if err != nil {
+		return err
+	}
+
+	opt := storage.NewS3OptionToExternal(a.endpoint, a.region, os.Getenv(ArtifactAccessKeyEnv), os.Getenv(ArtifactSecretAccessKeyEnv))
+	opt.PathStyle = true
+	s3 := storage.NewS3(a.bucket, opt)
+	for _, name := range files {
+		f, err := os.Open(filepath.Join(bazelBin, name))
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		info, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return xerrors.WithStack(err)
+		}
+		objectName := path.Join(a.prefix, name)
+		if err := s3.PutReader(ctx, objectName, f); err != nil {
+			f.Close()
+			return err
+		}
+		f.Close()
+		report.Artifacts = append(report.Artifacts, Artifact{Name: name, Path: objectName, Size: info.Size()})
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(report)
+}
+
+// MatchArtifacts returns the relative paths of the files under root which are matched to any pattern.
+// The symbolic link is followed if it points to a regular file.
+func MatchArtifacts(root string, patterns []string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			info, err := os.Stat(p)
+			if err != nil || !info.Mode().IsRegular() {
+				return nil
+			}
+		} else if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, rel); ok {
+				files = append(files, rel)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
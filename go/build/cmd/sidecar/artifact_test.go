@@ -0,0 +1,27 @@
+package sidecar
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchArtifacts(t *testing.T) {
+	root := t.TempDir()
+	for _, v := range []string{"cmd/foo/foo_/foo", "cmd/bar/bar_/bar", "cmd/foo/foo.a", "docs/index.html"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(v)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, v), []byte(v), 0644))
+	}
+	require.NoError(t, os.Symlink(filepath.Join(root, "docs/index.html"), filepath.Join(root, "docs/link.html")))
+
+	files, err := MatchArtifacts(root, []string{"cmd/*/*_/*", "docs/*.html"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cmd/bar/bar_/bar", "cmd/foo/foo_/foo", "docs/index.html", "docs/link.html"}, files)
+
+	files, err = MatchArtifacts(root, []string{"notfound"})
+	require.NoError(t, err)
+	assert.Len(t, files, 0)
+}
@@ -12,6 +12,7 @@ type CloneCommand struct {
 	AppId          int64
 	InstallationId int64
 	PrivateKeyFile string
+	TokenFile      string
 	Repo           string
 	Commit         string
 }
@@ -29,10 +30,14 @@ func (c *CloneCommand) SetFlags(fs *cli.FlagSet) {
 	fs.Int64("github-app-id", "GitHub App Id").Var(&c.AppId)
 	fs.Int64("github-installation-id", "GitHub Installation Id").Var(&c.InstallationId)
 	fs.String("private-key-file", "GitHub app private key file").Var(&c.PrivateKeyFile)
+	fs.String("token-file", "The file path of the access token. If this is set, the repository is cloned with the token instead of GitHub App").Var(&c.TokenFile)
 	fs.String("url", "Repository url (e.g. git@github.com:octocat/example.git)").Var(&c.Repo)
 	fs.String("commit", "Specify commit").Shorthand("b").Var(&c.Commit)
 }
 
 func (c *CloneCommand) Run(ctx context.Context) error {
+	if c.TokenFile != "" {
+		return git.CloneWithToken(ctx, c.TokenFile, c.WorkDir, c.Repo, c.Commit)
+	}
 	return git.Clone(ctx, c.AppId, c.InstallationId, c.PrivateKeyFile, c.WorkDir, c.Repo, c.Commit)
 }
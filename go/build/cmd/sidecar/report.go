@@ -1,12 +1,15 @@
 package sidecar
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
 	"io"
 	"os"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
@@ -19,7 +22,8 @@ import (
 )
 
 type TestReport struct {
-	Tests []TestSummary `json:"tests"`
+	Tests    []TestSummary   `json:"tests"`
+	Coverage *CoverageReport `json:"coverage,omitempty"`
 }
 
 type TestStatus string
@@ -30,6 +34,8 @@ const (
 	TestStatusFailed TestStatus = "failed"
 )
 
+const coverageReportTimeout = 30 * time.Second
+
 type TestSummary struct {
 	Label  string     `json:"label"`
 	Status TestStatus `json:"status"`
@@ -38,9 +44,20 @@ type TestSummary struct {
 	StartAt  time.Time `json:"start_at"`
 }
 
+type CoverageReport struct {
+	Files []FileCoverage `json:"files"`
+}
+
+type FileCoverage struct {
+	Path       string `json:"path"`
+	LinesFound int32  `json:"lines_found"`
+	LinesHit   int32  `json:"lines_hit"`
+}
+
 type TestReportCommand struct {
-	eventBinaryFile string
-	startUpTimeout  time.Duration
+	eventBinaryFile    string
+	coverageReportFile string
+	startUpTimeout     time.Duration
 }
 
 func NewTestReportCommand() *TestReportCommand {
@@ -54,6 +71,7 @@ func (b *TestReportCommand) Name() string {
 func (b *TestReportCommand) SetFlags(fs *cli.FlagSet) {
 	fs.String("event-binary-file", "The path of the event file").Var(&b.eventBinaryFile)
 	fs.Duration("startup-timeout", "Time of of start-up").Var(&b.startUpTimeout)
+	fs.String("coverage-report-file", "The path of the combined coverage report in LCOV format").Var(&b.coverageReportFile)
 }
 
 type testDuration struct {
@@ -65,34 +83,7 @@ type testDuration struct {
 }
 
 func (b *TestReportCommand) Run(_ context.Context) error {
-	ch := make(chan struct{})
-	go func() {
-		for {
-			if _, err := os.Lstat(b.eventBinaryFile); err == nil {
-				close(ch)
-				break
-			}
-		}
-	}()
-	select {
-	case <-ch:
-	case <-time.After(b.startUpTimeout):
-		return xerrors.Definef("could not find event binary file in %v", b.startUpTimeout).WithStack()
-	}
-
-	watcher, err := fsnotify.NewWatcher()
-	if err != nil {
-		return xerrors.WithStack(err)
-	}
-	if err := watcher.Add(b.eventBinaryFile); err != nil {
-		return xerrors.WithStack(err)
-	}
-
-	f, err := os.Open(b.eventBinaryFile)
-	if err != nil {
-		return xerrors.WithStack(err)
-	}
-	r, err := file.NewTailReader(f)
+	r, err := openEventBinaryFile(b.eventBinaryFile, b.startUpTimeout)
 	if err != nil {
 		return err
 	}
@@ -148,8 +139,137 @@ Read:
 		report.Tests = append(report.Tests, TestSummary{Label: v.Label, Status: status, Duration: v.Duration.Milliseconds(), StartAt: v.Start})
 	}
 
+	if b.coverageReportFile != "" {
+		coverage, err := b.readCoverageReport()
+		if err != nil {
+			return err
+		}
+		report.Coverage = coverage
+	}
+
 	if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
 		return err
 	}
 	return nil
 }
+
+// openEventBinaryFile waits for the event file to be created and returns the reader which follows the file.
+func openEventBinaryFile(name string, timeout time.Duration) (*file.TailReader, error) {
+	ch := make(chan struct{})
+	go func() {
+		for {
+			if _, err := os.Lstat(name); err == nil {
+				close(ch)
+				break
+			}
+		}
+	}()
+	select {
+	case <-ch:
+	case <-time.After(timeout):
+		return nil, xerrors.Definef("could not find event binary file in %v", timeout).WithStack()
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	if err := watcher.Add(name); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	r, err := file.NewTailReader(f)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// readCoverageReport reads the combined coverage report.
+// The report may be written after the build has finished, thus readCoverageReport waits for a while.
+func (b *TestReportCommand) readCoverageReport() (*CoverageReport, error) {
+	var f *os.File
+	deadline := time.Now().Add(coverageReportTimeout)
+	for {
+		file, err := os.Open(b.coverageReportFile)
+		if err == nil {
+			f = file
+			break
+		}
+		if !os.IsNotExist(err) {
+			return nil, xerrors.WithStack(err)
+		}
+		if time.Now().After(deadline) {
+			// The report is not generated if there is no test target.
+			return nil, nil
+		}
+		time.Sleep(time.Second)
+	}
+	defer f.Close()
+
+	return ParseLCOV(f)
+}
+
+// ParseLCOV parses the coverage data in LCOV format and summarizes it by the source file.
+func ParseLCOV(r io.Reader) (*CoverageReport, error) {
+	report := &CoverageReport{}
+	var current *FileCoverage
+	var found, hit int32
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		key, value, _ := strings.Cut(line, ":")
+		switch key {
+		case "SF":
+			current = &FileCoverage{Path: value}
+			found, hit = 0, 0
+		case "DA":
+			// DA:<line number>,<execution count>[,<checksum>]
+			if current == nil {
+				continue
+			}
+			v := strings.Split(value, ",")
+			if len(v) < 2 {
+				continue
+			}
+			found++
+			if c, err := strconv.ParseInt(v[1], 10, 64); err == nil && c > 0 {
+				hit++
+			}
+		case "LF":
+			if current != nil {
+				if n, err := strconv.ParseInt(value, 10, 32); err == nil {
+					current.LinesFound = int32(n)
+				}
+			}
+		case "LH":
+			if current != nil {
+				if n, err := strconv.ParseInt(value, 10, 32); err == nil {
+					current.LinesHit = int32(n)
+				}
+			}
+		case "end_of_record":
+			if current == nil {
+				continue
+			}
+			// LF and LH are optional. If they are not present, use the value which is counted from DA.
+			if current.LinesFound == 0 {
+				current.LinesFound = found
+				current.LinesHit = hit
+			}
+			report.Files = append(report.Files, *current)
+			current = nil
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	sort.Slice(report.Files, func(i, j int) bool { return report.Files[i].Path < report.Files[j].Path })
+
+	return report, nil
+}
@@ -0,0 +1,35 @@
+package sidecar
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLCOV(t *testing.T) {
+	data := `SF:go/build/config/config.go
+FN:10,Read
+DA:10,1
+DA:11,1
+DA:12,0
+LH:2
+LF:3
+end_of_record
+SF:go/build/api/server.go
+DA:20,0
+DA:21,3
+end_of_record
+`
+	report, err := ParseLCOV(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, report.Files, 2)
+
+	assert.Equal(t, "go/build/api/server.go", report.Files[0].Path)
+	assert.Equal(t, int32(2), report.Files[0].LinesFound)
+	assert.Equal(t, int32(1), report.Files[0].LinesHit)
+	assert.Equal(t, "go/build/config/config.go", report.Files[1].Path)
+	assert.Equal(t, int32(3), report.Files[1].LinesFound)
+	assert.Equal(t, int32(2), report.Files[1].LinesHit)
+}
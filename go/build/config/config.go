@@ -9,11 +9,16 @@ import (
 	"hash/crc32"
 	"io"
 	"log"
+	"path"
 	"reflect"
+	"sort"
 	"strings"
 
+	"github.com/robfig/cron/v3"
 	"go.f110.dev/xerrors"
 	"go.starlark.net/starlark"
+
+	"go.f110.dev/mono/go/build/database"
 )
 
 func init() {
@@ -53,6 +58,76 @@ func (c *Config) Job(event EventType) []*Job {
 	return jobs
 }
 
+// IsValid checks the relationship between jobs.
+func (c *Config) IsValid() error {
+	// The same name can be used by multiple jobs unless the name is referenced by needs.
+	names := make(map[string]int)
+	for _, v := range c.Jobs {
+		names[v.Name]++
+	}
+	for _, v := range c.Jobs {
+		for _, n := range v.Needs {
+			if n == v.Name {
+				return xerrors.Definef("%s can't depend on itself", v.Name).WithStack()
+			}
+			if names[n] == 0 {
+				return xerrors.Definef("%s needs %s but the job is not found", v.Name, n).WithStack()
+			} else if names[n] > 1 {
+				return xerrors.Definef("%s needs %s but the name is used by multiple jobs", v.Name, n).WithStack()
+			}
+		}
+	}
+	if _, err := SortJobs(c.Jobs); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SortJobs returns the jobs in the order of dependencies.
+// The upstream job is always placed before the job which needs it.
+// The dependency to the job which isn't included in jobs will be ignored.
+func SortJobs(jobs []*Job) ([]*Job, error) {
+	jobMap := make(map[string]*Job)
+	for _, v := range jobs {
+		jobMap[v.Name] = v
+	}
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[*Job]int)
+	sorted := make([]*Job, 0, len(jobs))
+	var visit func(j *Job) error
+	visit = func(j *Job) error {
+		switch state[j] {
+		case visiting:
+			return xerrors.Definef("circular dependency is detected at %s", j.Name).WithStack()
+		case visited:
+			return nil
+		}
+		state[j] = visiting
+		for _, n := range j.Needs {
+			if v, ok := jobMap[n]; ok {
+				if err := visit(v); err != nil {
+					return err
+				}
+			}
+		}
+		state[j] = visited
+		sorted = append(sorted, j)
+		return nil
+	}
+	for _, v := range jobs {
+		if err := visit(v); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
+
 type Secret struct {
 	MountPath  string `attr:"mount_path"`
 	VaultMount string `attr:"vault_mount"`
@@ -131,14 +206,27 @@ type Job struct {
 	Platforms    []string `attr:"platforms"`
 	Targets      []string `attr:"targets"`
 	Args         []string `attr:"args,allowempty"`
+	// Additional flags for bazel command
+	Flags []string `attr:"flags,allowempty"`
+	// The patterns of the files in bazel-bin which are uploaded after the build
+	Artifacts []string `attr:"artifacts,allowempty"`
 	// Do not allow parallelized build in this job
 	Exclusive bool `attr:"exclusive,allowempty"`
+	// If true, the unfinished tasks of the pull request are canceled when a newer revision is pushed
+	CancelSuperseded bool `attr:"cancel_superseded,allowempty"`
+	// The number of times to re-run the failed tests before the task is marked as failed
+	RetryFlaky int `attr:"retry_flaky,allowempty"`
 	// The name of config
 	ConfigName string `attr:"config_name,allowempty"`
 	// Job schedule
-	Schedule string           `attr:"schedule,allowempty"`
-	Secrets  []starlark.Value `attr:"secrets,allowempty"`
-	Env      map[string]any   `attr:"env,allowempty"`
+	Schedule string `attr:"schedule,allowempty"`
+	// The name of jobs which have to succeed before this job at the same revision
+	Needs   []string         `attr:"needs,allowempty"`
+	Secrets []starlark.Value `attr:"secrets,allowempty"`
+	Env     map[string]any   `attr:"env,allowempty"`
+	// Matrix expands the job into the cartesian product of the values.
+	// The key is one of MatrixKeyBazelVersion and MatrixKeyConfigName.
+	Matrix map[string][]string `attr:"matrix,allowempty"`
 
 	RepositoryOwner string
 	RepositoryName  string
@@ -190,7 +278,7 @@ func (j *Job) IsValid() error {
 	}
 
 	switch j.Command {
-	case "test":
+	case "test", "build", "coverage":
 	case "run":
 		if len(j.Targets) != 1 {
 			return xerrors.Define("can't specify multiple targets if the command is run").WithStack()
@@ -202,9 +290,188 @@ func (j *Job) IsValid() error {
 	if j.Args != nil && j.Command != "run" {
 		return xerrors.Definef("specifying argument is not allowed in %s command", j.Command).WithStack()
 	}
+	for _, v := range j.Flags {
+		if err := validateFlag(j.Command, v); err != nil {
+			return xerrors.WithMessagef(err, "invalid flag at %s", j.Name)
+		}
+	}
+
+	if j.RetryFlaky < 0 {
+		return xerrors.Definef("retry_flaky must not be negative at %s", j.Name).WithStack()
+	}
+	if j.RetryFlaky > 0 && j.Command != "test" && j.Command != "coverage" {
+		return xerrors.Definef("retry_flaky is not allowed in %s command", j.Command).WithStack()
+	}
+
+	for _, v := range j.Artifacts {
+		if err := validateArtifactPattern(v); err != nil {
+			return xerrors.WithMessagef(err, "invalid artifact at %s", j.Name)
+		}
+	}
+
+	if j.Schedule != "" {
+		if _, err := j.ParseSchedule(); err != nil {
+			return err
+		}
+	}
+
+	for k, v := range j.Matrix {
+		switch k {
+		case MatrixKeyBazelVersion, MatrixKeyConfigName:
+		default:
+			return xerrors.Definef("%s is not supported key of matrix at %s", k, j.Name).WithStack()
+		}
+		if len(v) == 0 {
+			return xerrors.Definef("the value of %s in matrix is empty at %s", k, j.Name).WithStack()
+		}
+		for _, s := range v {
+			if s == "" || strings.ContainsAny(s, ",=") {
+				return xerrors.Definef("invalid value of %s in matrix at %s: %q", k, j.Name, s).WithStack()
+			}
+		}
+	}
+	return nil
+}
+
+const (
+	MatrixKeyBazelVersion = "bazel_version"
+	MatrixKeyConfigName   = "config_name"
+)
+
+// MatrixCombination is the one of combinations of the matrix.
+type MatrixCombination map[string]string
+
+// String returns the combination as "key=value,key=value" which is sorted by the key.
+func (m MatrixCombination) String() string {
+	if len(m) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	s := make([]string, len(keys))
+	for i, k := range keys {
+		s[i] = k + "=" + m[k]
+	}
+	return strings.Join(s, ",")
+}
+
+// ExpandMatrix returns all combinations of the matrix.
+// If the job doesn't have the matrix, ExpandMatrix returns the single empty combination.
+func (j *Job) ExpandMatrix() []MatrixCombination {
+	combinations := []MatrixCombination{nil}
+	if len(j.Matrix) == 0 {
+		return combinations
+	}
+
+	keys := make([]string, 0, len(j.Matrix))
+	for k := range j.Matrix {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		var expanded []MatrixCombination
+		for _, c := range combinations {
+			for _, v := range j.Matrix[k] {
+				n := make(MatrixCombination, len(c)+1)
+				for ck, cv := range c {
+					n[ck] = cv
+				}
+				n[k] = v
+				expanded = append(expanded, n)
+			}
+		}
+		combinations = expanded
+	}
+	return combinations
+}
+
+var (
+	// managedFlags are the flags which are set by the builder.
+	managedFlags = []string{"platforms", "config", "remote_cache", "experimental_remote_downloader", "build_event_binary_file", "cache_test_results", "remote_upload_local_results"}
+	// testFlags are the flags which are available only in test and coverage command.
+	testFlags = []string{"test_", "flaky_test_attempts", "runs_per_test"}
+	// coverageFlags are the flags which are available only in coverage command.
+	coverageFlags = []string{"instrumentation_filter", "instrument_test_targets", "combined_report", "coverage_report_generator", "experimental_split_coverage_postprocessing"}
+)
+
+// validateArtifactPattern checks the pattern of the artifact.
+// The pattern is a relative path from bazel-bin and the syntax is the same as path.Match.
+func validateArtifactPattern(pattern string) error {
+	if pattern == "" {
+		return xerrors.Define("the pattern of the artifact is empty").WithStack()
+	}
+	if path.IsAbs(pattern) {
+		return xerrors.Definef("the pattern of the artifact must be a relative path: %s", pattern).WithStack()
+	}
+	for _, v := range strings.Split(pattern, "/") {
+		if v == ".." {
+			return xerrors.Definef("the pattern of the artifact can't point outside of bazel-bin: %s", pattern).WithStack()
+		}
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return xerrors.WithMessagef(err, "%s", pattern)
+	}
+	return nil
+}
+
+func validateFlag(command, flag string) error {
+	if !strings.HasPrefix(flag, "--") {
+		return xerrors.Definef("%s is not a flag", flag).WithStack()
+	}
+	name := strings.TrimPrefix(flag, "--")
+	if i := strings.Index(name, "="); i > 0 {
+		name = name[:i]
+	}
+	// The boolean flag can be negated by "no" prefix.
+	if n := strings.TrimPrefix(name, "no"); n != name && isKnownFlag(n) {
+		name = n
+	}
+
+	for _, v := range managedFlags {
+		if name == v {
+			return xerrors.Definef("--%s can't be specified because the builder sets it", name).WithStack()
+		}
+	}
+	for _, v := range testFlags {
+		if strings.HasPrefix(name, v) && command != "test" && command != "coverage" {
+			return xerrors.Definef("--%s is not allowed in %s command", name, command).WithStack()
+		}
+	}
+	for _, v := range coverageFlags {
+		if name == v && command != "coverage" {
+			return xerrors.Definef("--%s is not allowed in %s command", name, command).WithStack()
+		}
+	}
+	if name == "run_under" && command != "run" && command != "test" {
+		return xerrors.Definef("--%s is not allowed in %s command", name, command).WithStack()
+	}
+
 	return nil
 }
 
+func isKnownFlag(name string) bool {
+	for _, list := range [][]string{managedFlags, testFlags, coverageFlags} {
+		for _, v := range list {
+			if name == v || (strings.HasSuffix(v, "_") && strings.HasPrefix(name, v)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParseSchedule parses Schedule as the standard cron expression.
+func (j *Job) ParseSchedule() (cron.Schedule, error) {
+	s, err := cron.ParseStandard(j.Schedule)
+	if err != nil {
+		return nil, xerrors.WithMessagef(err, "invalid schedule at %s", j.Name)
+	}
+	return s, nil
+}
+
 func MarshalJob(j *Job) ([]byte, error) {
 	buf := new(bytes.Buffer)
 	if err := gob.NewEncoder(buf).Encode(j); err != nil {
@@ -227,6 +494,22 @@ func UnmarshalJob(b []byte, j *Job) error {
 	return nil
 }
 
+// DecodeJobConfiguration returns the job configuration of the task.
+// JobConfiguration which is written by the old version takes precedence over ParsedJobConfiguration.
+func DecodeJobConfiguration(task *database.Task) (*Job, error) {
+	j := &Job{}
+	if task.JobConfiguration != nil && len(*task.JobConfiguration) > 0 {
+		if err := UnmarshalJob([]byte(*task.JobConfiguration), j); err != nil {
+			return nil, err
+		}
+	} else if len(task.ParsedJobConfiguration) > 0 {
+		if err := UnmarshalJob(task.ParsedJobConfiguration, j); err != nil {
+			return nil, err
+		}
+	}
+	return j, nil
+}
+
 func Read(r io.Reader, owner, repo string) (*Config, error) {
 	config := &Config{RepositoryOwner: owner, RepositoryName: repo}
 
@@ -297,6 +580,9 @@ func Read(r io.Reader, owner, repo string) (*Config, error) {
 	if err != nil {
 		return nil, xerrors.WithStack(err)
 	}
+	if err := config.IsValid(); err != nil {
+		return nil, err
+	}
 	return config, nil
 }
 
@@ -316,6 +602,17 @@ func setValue(ft reflect.Type, fv reflect.Value, val starlark.Value) error {
 		}
 		fv.SetBool(bool(v))
 		return nil
+	case reflect.Int:
+		v, ok := val.(starlark.Int)
+		if !ok {
+			return xerrors.Definef("expect starlark.Int field: %T", val).WithStack()
+		}
+		i, ok := v.Int64()
+		if !ok {
+			return xerrors.Definef("the value is too large: %s", v.String()).WithStack()
+		}
+		fv.SetInt(i)
+		return nil
 	case reflect.Slice:
 		v, ok := val.(*starlark.List)
 		if !ok {
@@ -345,6 +642,23 @@ func setValue(ft reflect.Type, fv reflect.Value, val starlark.Value) error {
 		if !ok {
 			return xerrors.Definef("expect *starlark.Dict field: %T", val).WithStack()
 		}
+		if ft.Elem().Kind() == reflect.Slice {
+			m := reflect.MakeMap(ft)
+			for _, t := range v.Items() {
+				k, ok := t.Index(0).(starlark.String)
+				if !ok {
+					return xerrors.Definef("the type of the key is not string: %T", t.Index(0)).WithStack()
+				}
+				item := reflect.New(ft.Elem()).Elem()
+				if err := setValue(ft.Elem(), item, t.Index(1)); err != nil {
+					return err
+				}
+				m.SetMapIndex(reflect.ValueOf(k.GoString()), item)
+			}
+			fv.Set(m)
+			return nil
+		}
+
 		m := make(map[string]any)
 		for _, t := range v.Items() {
 			k, ok := t.Index(0).(starlark.String)
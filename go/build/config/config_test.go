@@ -31,6 +31,8 @@ func TestReadConfig(t *testing.T) {
         "-//vendor/github.com/go-enry/go-oniguruma/...",
     ],
 	exclusive = True,
+	cancel_superseded = True,
+	retry_flaky = 2,
 	config_name = "ci",
     secrets = [
         secret(mount_path = "/var/vault/provider", vault_mount = "secrets/", vault_path = "github", vault_key = "token"),
@@ -58,6 +60,8 @@ func TestReadConfig(t *testing.T) {
 	assert.Equal(t, "8Gi", conf.Jobs[0].MemoryLimit)
 	assert.Equal(t, "ci", conf.Jobs[0].ConfigName)
 	assert.True(t, conf.Jobs[0].Exclusive)
+	assert.True(t, conf.Jobs[0].CancelSuperseded)
+	assert.Equal(t, 2, conf.Jobs[0].RetryFlaky)
 	assert.Equal(t, []string{"@io_bazel_rules_go//go/toolchain:linux_amd64"}, conf.Jobs[0].Platforms)
 	assert.Equal(t,
 		[]string{
@@ -113,6 +117,65 @@ func TestRead_AllRequiredFieldsAreNotPresent(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestRead_Needs(t *testing.T) {
+	job := func(name string, needs ...string) string {
+		var n string
+		if len(needs) > 0 {
+			n = fmt.Sprintf("    needs = [\"%s\"],\n", strings.Join(needs, "\", \""))
+		}
+		return fmt.Sprintf(`job(
+    name = "%s",
+    event = ["push"],
+    command = "test",
+    targets = ["//..."],
+    platforms = ["@io_bazel_rules_go//go/toolchain:linux_amd64"],
+%s)
+`, name, n)
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		conf, err := Read(strings.NewReader(job("deploy", "test", "lint")+job("test")+job("lint", "test")), "", "")
+		require.NoError(t, err)
+		require.Len(t, conf.Jobs, 3)
+		assert.Equal(t, []string{"test", "lint"}, conf.Jobs[0].Needs)
+
+		sorted, err := SortJobs(conf.Jobs)
+		require.NoError(t, err)
+		var names []string
+		for _, v := range sorted {
+			names = append(names, v.Name)
+		}
+		assert.Equal(t, []string{"test", "lint", "deploy"}, names)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Read(strings.NewReader(job("deploy", "test")), "", "")
+		require.Error(t, err)
+	})
+
+	t.Run("Circular", func(t *testing.T) {
+		_, err := Read(strings.NewReader(job("a", "c")+job("b", "a")+job("c", "b")), "", "")
+		require.Error(t, err)
+	})
+
+	t.Run("Itself", func(t *testing.T) {
+		_, err := Read(strings.NewReader(job("a", "a")), "", "")
+		require.Error(t, err)
+	})
+
+	t.Run("DuplicateName", func(t *testing.T) {
+		// The duplicated name is allowed as long as no job needs it.
+		conf, err := Read(strings.NewReader(job("test")+job("test")+job("deploy", "lint")+job("lint")), "", "")
+		require.NoError(t, err)
+		sorted, err := SortJobs(conf.Jobs)
+		require.NoError(t, err)
+		assert.Len(t, sorted, 4)
+
+		_, err = Read(strings.NewReader(job("test")+job("test")+job("deploy", "test")), "", "")
+		require.Error(t, err)
+	})
+}
+
 func TestJob(t *testing.T) {
 	valid := &Job{
 		Name:      t.Name(),
@@ -133,6 +196,22 @@ func TestJob(t *testing.T) {
 		{Job: func(j *Job) { j.Targets = nil }},
 		{Job: func(j *Job) { j.Command = "test"; j.Args = []string{"--verbose"} }},
 		{Job: func(j *Job) { j.Command = "run"; j.Targets = []string{"//:test", "//:run"} }},
+		{Job: func(j *Job) { j.Schedule = "every day" }},
+		{Job: func(j *Job) { j.Command = "build"; j.Args = []string{"--verbose"} }},
+		{Job: func(j *Job) { j.Flags = []string{"keep_going"} }},
+		{Job: func(j *Job) { j.Flags = []string{"--platforms=@io_bazel_rules_go//go/toolchain:linux_arm64"} }},
+		{Job: func(j *Job) { j.Command = "coverage"; j.Flags = []string{"--nocache_test_results"} }},
+		{Job: func(j *Job) { j.Command = "build"; j.Flags = []string{"--test_output=all"} }},
+		{Job: func(j *Job) { j.Command = "test"; j.Flags = []string{"--instrumentation_filter=//go/..."} }},
+		{Job: func(j *Job) { j.Command = "build"; j.Flags = []string{"--run_under=//tools:wrapper"} }},
+		{Job: func(j *Job) { j.Artifacts = []string{"/etc/passwd"} }},
+		{Job: func(j *Job) { j.Artifacts = []string{"../bazel-out/*"} }},
+		{Job: func(j *Job) { j.Artifacts = []string{"cmd/[a-"} }},
+		{Job: func(j *Job) { j.RetryFlaky = -1 }},
+		{Job: func(j *Job) { j.Command = "build"; j.RetryFlaky = 2 }},
+		{Job: func(j *Job) { j.Matrix = map[string][]string{"os": {"linux"}} }},
+		{Job: func(j *Job) { j.Matrix = map[string][]string{MatrixKeyBazelVersion: {}} }},
+		{Job: func(j *Job) { j.Matrix = map[string][]string{MatrixKeyConfigName: {"ci,race"}} }},
 	}
 
 	require.Nil(t, valid.IsValid())
@@ -149,6 +228,68 @@ func TestJob(t *testing.T) {
 	}
 }
 
+func TestJob_Matrix(t *testing.T) {
+	conf, err := Read(strings.NewReader(`job(
+    name = "test",
+    event = ["push"],
+    command = "test",
+    targets = ["//..."],
+    platforms = ["@io_bazel_rules_go//go/toolchain:linux_amd64"],
+    matrix = {
+        "bazel_version": ["6.4.0", "7.0.0"],
+        "config_name": ["ci", "race"],
+    },
+)
+`), "", "")
+	require.NoError(t, err)
+	require.Len(t, conf.Jobs, 1)
+	job := conf.Jobs[0]
+	assert.Equal(t, map[string][]string{
+		MatrixKeyBazelVersion: {"6.4.0", "7.0.0"},
+		MatrixKeyConfigName:   {"ci", "race"},
+	}, job.Matrix)
+
+	var combinations []string
+	for _, v := range job.ExpandMatrix() {
+		combinations = append(combinations, v.String())
+	}
+	assert.Equal(t, []string{
+		"bazel_version=6.4.0,config_name=ci",
+		"bazel_version=6.4.0,config_name=race",
+		"bazel_version=7.0.0,config_name=ci",
+		"bazel_version=7.0.0,config_name=race",
+	}, combinations)
+
+	noMatrix := &Job{}
+	assert.Equal(t, []MatrixCombination{nil}, noMatrix.ExpandMatrix())
+}
+
+func TestJob_Flags(t *testing.T) {
+	cases := []struct {
+		Command string
+		Flags   []string
+	}{
+		{Command: "build", Flags: []string{"--keep_going", "--nostamp", "--build_tests_only"}},
+		{Command: "test", Flags: []string{"--test_output=errors", "--flaky_test_attempts=3"}},
+		{Command: "coverage", Flags: []string{"--instrumentation_filter=//go/...", "--test_output=errors", "--combined_report=lcov"}},
+		{Command: "run", Flags: []string{"--run_under=//tools:wrapper"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Command, func(t *testing.T) {
+			j := &Job{
+				Name:      t.Name(),
+				Event:     []EventType{EventPush},
+				Command:   tc.Command,
+				Platforms: []string{"@io_bazel_rules_go//go/toolchain:linux_amd64"},
+				Targets:   []string{"//:test"},
+				Flags:     tc.Flags,
+			}
+			assert.NoError(t, j.IsValid())
+		})
+	}
+}
+
 func TestMarshalJob(t *testing.T) {
 	raw := `job(
     name = "publish_zoekt_indexer",
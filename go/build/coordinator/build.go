@@ -7,13 +7,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/url"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/google/go-github/v49/github"
 	"go.f110.dev/xerrors"
 	"go.uber.org/zap"
 	batchv1 "k8s.io/api/batch/v1"
@@ -36,8 +34,10 @@ import (
 	"go.f110.dev/mono/go/build/config"
 	"go.f110.dev/mono/go/build/database"
 	"go.f110.dev/mono/go/build/database/dao"
+	"go.f110.dev/mono/go/build/forge"
 	"go.f110.dev/mono/go/build/watcher"
 	"go.f110.dev/mono/go/ctxutil"
+	"go.f110.dev/mono/go/enumerable"
 	"go.f110.dev/mono/go/k8s/k8smanifest"
 	"go.f110.dev/mono/go/logger"
 	"go.f110.dev/mono/go/storage"
@@ -55,15 +55,24 @@ const (
 
 	labelKeyRepoId = "build.f110.dev/repo-id"
 	labelKeyJobId  = "build.f110.dev/job-id"
-	labelKeyTaskId = "build.f110.dev/task-id"
 	labelKeyCtrlBy = "build.f110.dev/control-by"
 
 	jobTimeout = 1 * time.Hour
 	jobType    = "bazelBuilder"
 )
 
+const (
+	// LabelKeyTaskId is the label key of the build pod which has the id of the task.
+	LabelKeyTaskId = "build.f110.dev/task-id"
+	// PreProcessContainerName is the name of the init container which prepares the workspace.
+	PreProcessContainerName = "pre-process"
+	// BuildContainerName is the name of the container which runs bazel.
+	BuildContainerName = "main"
+)
+
 var (
 	ErrOtherTaskIsRunning = xerrors.Define("coordinator: Other task is running")
+	ErrTaskIsCanceled     = xerrors.Define("coordinator: The task has been canceled")
 )
 
 type KubernetesOptions struct {
@@ -107,9 +116,16 @@ type BazelOptions struct {
 	GithubAppId          int64
 	GithubInstallationId int64
 	GithubAppSecretName  string
+	// ArtifactEndpoint is the endpoint of the object storage which is accessed from the job.
+	ArtifactEndpoint string
+	// ArtifactSecretName is the name of the secret which has the credential of the object storage.
+	ArtifactSecretName string
+	// ForgeTokenSecrets is the map of the host of the forge and the name of the secret which has the access token.
+	// The secret is used for cloning the private repository which is not hosted on github.com.
+	ForgeTokenSecrets map[string]string
 }
 
-func NewBazelOptions(remoteCache string, enableRemoteAssetApi bool, sidecarImage, bazelImage string, useBazelisk bool, defaultVersion, bazelMirrorURL string, pullAlways bool, githubAppId, githubInstallationId int64, githubAppSecretName string) BazelOptions {
+func NewBazelOptions(remoteCache string, enableRemoteAssetApi bool, sidecarImage, bazelImage string, useBazelisk bool, defaultVersion, bazelMirrorURL string, pullAlways bool, githubAppId, githubInstallationId int64, githubAppSecretName, artifactEndpoint, artifactSecretName string, forgeTokenSecrets map[string]string) BazelOptions {
 	return BazelOptions{
 		RemoteCache:          remoteCache,
 		EnableRemoteAssetApi: enableRemoteAssetApi,
@@ -122,6 +138,9 @@ func NewBazelOptions(remoteCache string, enableRemoteAssetApi bool, sidecarImage
 		GithubAppId:          githubAppId,
 		GithubInstallationId: githubInstallationId,
 		GithubAppSecretName:  githubAppSecretName,
+		ArtifactEndpoint:     artifactEndpoint,
+		ArtifactSecretName:   artifactSecretName,
+		ForgeTokenSecrets:    forgeTokenSecrets,
 	}
 }
 
@@ -170,6 +189,75 @@ func (tq *taskQueue) DequeueById(id string) *database.Task {
 	}
 }
 
+func (tq *taskQueue) IsQueued(task *database.Task) bool {
+	tq.mu.Lock()
+	defer tq.mu.Unlock()
+
+	for _, q := range tq.queues {
+		for _, v := range q {
+			if v.Id == task.Id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Remove removes the task from the queue. Remove returns true if the task was in the queue.
+func (tq *taskQueue) Remove(task *database.Task) bool {
+	tq.mu.Lock()
+	defer tq.mu.Unlock()
+
+	for k, q := range tq.queues {
+		for i, v := range q {
+			if v.Id == task.Id {
+				tq.queues[k] = append(q[:i:i], q[i+1:]...)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// keyedMutex is the set of mutexes which are identified by the key.
+// The zero value is ready to use.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedMutexEntry
+}
+
+type keyedMutexEntry struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Lock locks the mutex of the key and returns the function to unlock it.
+func (k *keyedMutex) Lock(key string) func() {
+	k.mu.Lock()
+	if k.locks == nil {
+		k.locks = make(map[string]*keyedMutexEntry)
+	}
+	e, ok := k.locks[key]
+	if !ok {
+		e = &keyedMutexEntry{}
+		k.locks[key] = e
+	}
+	e.refs++
+	k.mu.Unlock()
+
+	e.mu.Lock()
+	return func() {
+		e.mu.Unlock()
+
+		k.mu.Lock()
+		e.refs--
+		if e.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
+
 type BazelBuilder struct {
 	Namespace    string
 	dashboardUrl string
@@ -183,7 +271,7 @@ type BazelBuilder struct {
 	jobBuilder        *JobBuilder
 
 	dao                    dao.Options
-	githubClient           *github.Client
+	forges                 *forge.Set
 	minio                  *storage.MinIO
 	vaultAddr              string
 	sidecarImage           string
@@ -194,6 +282,8 @@ type BazelBuilder struct {
 	dev                    bool
 
 	taskQueue *taskQueue
+	// dispatchMu serializes dispatching the dependent tasks of the same revision.
+	dispatchMu keyedMutex
 }
 
 func NewBazelBuilder(
@@ -201,7 +291,7 @@ func NewBazelBuilder(
 	kOpt KubernetesOptions,
 	daoOpt dao.Options,
 	namespace string,
-	ghClient *github.Client,
+	forges *forge.Set,
 	bucket string,
 	minIOOpt storage.MinIOOptions,
 	bazelOpt BazelOptions,
@@ -223,7 +313,7 @@ func NewBazelBuilder(
 		client:            kOpt.Client,
 		secretStoreClient: kOpt.SecretStoreClient,
 		dao:               daoOpt,
-		githubClient:      ghClient,
+		forges:            forges,
 		minio:             storage.NewMinIOStorage(bucket, minIOOpt),
 		vaultClient:       vaultClient,
 		dev:               dev,
@@ -243,6 +333,9 @@ func NewBazelBuilder(
 		b.jobBuilder.DefaultBazelVersion(defaultBazelVersion)
 	}
 	b.jobBuilder.GitHubApp(bazelOpt.GithubAppId, bazelOpt.GithubInstallationId, bazelOpt.GithubAppSecretName)
+	for host, secretName := range bazelOpt.ForgeTokenSecrets {
+		b.jobBuilder.ForgeToken(host, secretName)
+	}
 	if bazelOpt.PullAlways {
 		b.jobBuilder.PullAlways()
 	}
@@ -258,6 +351,9 @@ func NewBazelBuilder(
 	if bazelOpt.EnableRemoteAssetApi {
 		b.jobBuilder.EnableRemoteAssetAPI()
 	}
+	if bazelOpt.ArtifactEndpoint != "" {
+		b.jobBuilder.ArtifactStorage(bazelOpt.ArtifactEndpoint, bucket, bazelOpt.ArtifactSecretName)
+	}
 	defaultCPULimit := resource.MustParse(defaultCPULimit)
 	defaultMemoryLimit := resource.MustParse(defaultMemoryLimit)
 	if kOpt.DefaultCPULimit != "" {
@@ -283,18 +379,49 @@ func NewBazelBuilder(
 		watcher.Router.Add(jobType, b.syncJob)
 	}
 
-	pendingTasks, err := b.dao.Task.ListPending(context.Background())
+	if err := b.resumePendingTasks(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// resumePendingTasks enqueues the pending tasks.
+// The task which needs other jobs is re-evaluated against the upstream tasks
+// because the upstream tasks may have finished while the process was stopped.
+func (b *BazelBuilder) resumePendingTasks(ctx context.Context) error {
+	pendingTasks, err := b.dao.Task.ListPending(ctx)
 	if err != nil {
-		return nil, xerrors.WithStack(err)
+		return xerrors.WithStack(err)
+	}
+	type revisionKey struct {
+		RepositoryId int32
+		Revision     string
 	}
+	dependents := make(map[revisionKey]*database.SourceRepository)
 	for _, v := range pendingTasks {
+		// The task which was canceled before starting has no StartAt.
+		if v.FinishedAt != nil || v.Canceled {
+			continue
+		}
+		if jobConfiguration, err := config.DecodeJobConfiguration(v); err == nil && len(jobConfiguration.Needs) > 0 {
+			if v.Repository != nil {
+				dependents[revisionKey{RepositoryId: v.RepositoryId, Revision: v.Revision}] = v.Repository
+			}
+			continue
+		}
 		b.taskQueue.EnqueueById(v.JobName, v)
 	}
 
-	return b, nil
+	for k, repo := range dependents {
+		if err := b.dispatchDependentTasks(ctx, repo, k.Revision); err != nil {
+			logger.Log.Warn("Failed to dispatch the dependent tasks", zap.Error(err), zap.String("repo", repo.Name), zap.String("revision", k.Revision))
+		}
+	}
+	return nil
 }
 
-func (b *BazelBuilder) Build(ctx context.Context, repo *database.SourceRepository, job *config.Job, revision, bazelVersion, command string, targets, platforms []string, via string, isMainBranch bool) ([]*database.Task, error) {
+func (b *BazelBuilder) Build(ctx context.Context, repo *database.SourceRepository, job *config.Job, revision, bazelVersion, command string, targets, platforms []string, via string, isMainBranch bool, pullRequest int32) ([]*database.Task, error) {
 	var tasks []*database.Task
 	defer func() {
 		for _, task := range tasks {
@@ -310,60 +437,233 @@ func (b *BazelBuilder) Build(ctx context.Context, repo *database.SourceRepositor
 	if err != nil {
 		return nil, xerrors.WithStack(err)
 	}
-	for _, v := range taskList {
+	if v := runningTask(taskList, job); v != nil {
+		logger.Log.Warn("Other task is still running", zap.Int32("task_id", v.Id))
+		return nil, nil
+	}
+
+	jobConfiguration, err := config.MarshalJob(job)
+	if err != nil {
+		return nil, err
+	}
+	t := strings.Join(targets, "\n")
+	combinations := job.ExpandMatrix()
+	// The tasks which are expanded from the single job are grouped by the id of the first task.
+	grouped := len(combinations)*len(platforms) > 1
+	var groupId int32
+	for _, combination := range combinations {
+		for _, platform := range platforms {
+			task, err := b.createTask(ctx, &database.Task{
+				RepositoryId:           repo.Id,
+				JobName:                job.Name,
+				ParsedJobConfiguration: jobConfiguration,
+				Revision:               revision,
+				IsTrunk:                isMainBranch,
+				BazelVersion:           bazelVersion,
+				Command:                command,
+				Targets:                t,
+				Platform:               platform,
+				Via:                    via,
+				ConfigName:             job.ConfigName,
+				PullRequest:            pullRequest,
+				Matrix:                 combination.String(),
+				GroupId:                groupId,
+			}, combination)
+			if err != nil {
+				return nil, err
+			}
+			if grouped && groupId == 0 {
+				groupId = task.Id
+				task.GroupId = groupId
+				if err := b.dao.Task.Update(ctx, task); err != nil {
+					return nil, xerrors.WithStack(err)
+				}
+			}
+			tasks = append(tasks, task)
+
+			if err := b.startTask(ctx, repo, job, task); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(job.Needs) > 0 {
+		// Upstream jobs may have already finished.
+		if err := b.dispatchDependentTasks(ctx, repo, revision); err != nil {
+			return tasks, err
+		}
+	}
+
+	return tasks, nil
+}
+
+// runningTask returns the task of the job which is still running.
+// tasks have to be sorted in descending order of the creation time. The task which exceeds the timeout is not treated as running.
+func runningTask(tasks []*database.Task, job *config.Job) *database.Task {
+	for _, v := range tasks {
 		if time.Since(v.CreatedAt) > jobTimeout {
 			break
 		}
+		// The task of the other job doesn't block the job.
+		if v.JobName != job.Name {
+			continue
+		}
 		if v.FinishedAt == nil {
-			logger.Log.Warn("Other task is still running", zap.Int32("task_id", v.Id))
-			return nil, nil
+			return v
 		}
 	}
+	return nil
+}
 
-	jobConfiguration, err := config.MarshalJob(job)
+// createTask creates the task. The values of the matrix override the bazel version and the name of config.
+func (b *BazelBuilder) createTask(ctx context.Context, task *database.Task, combination config.MatrixCombination) (*database.Task, error) {
+	if v, ok := combination[config.MatrixKeyBazelVersion]; ok {
+		task.BazelVersion = v
+	}
+	if v, ok := combination[config.MatrixKeyConfigName]; ok {
+		task.ConfigName = v
+	}
+	task, err := b.dao.Task.Create(ctx, task)
 	if err != nil {
-		return nil, err
+		return nil, xerrors.WithStack(err)
 	}
-	t := strings.Join(targets, "\n")
-	for _, platform := range platforms {
-		task, err := b.dao.Task.Create(ctx, &database.Task{
-			RepositoryId:           repo.Id,
-			JobName:                job.Name,
-			ParsedJobConfiguration: jobConfiguration,
-			Revision:               revision,
-			IsTrunk:                isMainBranch,
-			BazelVersion:           bazelVersion,
-			Command:                command,
-			Targets:                t,
-			Platform:               platform,
-			Via:                    via,
-			ConfigName:             job.ConfigName,
-		})
-		if err != nil {
-			return nil, xerrors.WithStack(err)
+	return task, nil
+}
+
+// startTask starts the task which is created by Build.
+// If other task of the job is running, the task is enqueued.
+func (b *BazelBuilder) startTask(ctx context.Context, repo *database.SourceRepository, job *config.Job, task *database.Task) error {
+	if job.CancelSuperseded && task.PullRequest > 0 {
+		if err := b.cancelSupersededTasks(ctx, repo, job, task); err != nil {
+			logger.Log.Warn("Failed to cancel superseded tasks", zap.Error(err), zap.Int32("task.id", task.Id))
 		}
-		tasks = append(tasks, task)
+	}
 
-		if err := b.buildJob(ctx, repo, job, task); err != nil {
-			if errors.Is(err, ErrOtherTaskIsRunning) {
-				logger.Log.Info("Enqueue the task", zap.Int32("task.id", task.Id))
-				b.taskQueue.Enqueue(job, task)
-				return tasks, nil
+	if len(job.Needs) > 0 {
+		// The task will be started after all upstream jobs have succeeded.
+		logger.Log.Info("The task is waiting for upstream jobs", zap.Int32("task.id", task.Id), zap.Strings("needs", job.Needs))
+		if job.GitHubStatus {
+			if err := b.updateCommitStatus(ctx, repo, job, task, forge.StatusPending, ""); err != nil {
+				logger.Log.Warn("Failure update the status of github", zap.Error(err), zap.Int32("task.id", task.Id))
 			}
+		}
+		return nil
+	}
 
-			task.Success = false
-			task.FinishedAt = varptr.Ptr(time.Now())
-			return nil, xerrors.WithStack(err)
+	if err := b.buildJob(ctx, repo, job, task); err != nil {
+		if errors.Is(err, ErrOtherTaskIsRunning) {
+			logger.Log.Info("Enqueue the task", zap.Int32("task.id", task.Id))
+			b.taskQueue.Enqueue(job, task)
+			return nil
 		}
 
-		if job.GitHubStatus {
-			if err := b.updateGithubStatus(ctx, repo, job, task, "pending"); err != nil {
-				logger.Log.Warn("Failure update the status of github", zap.Error(err), zap.Int32("task.id", task.Id))
+		task.Success = false
+		task.FinishedAt = varptr.Ptr(time.Now())
+		return xerrors.WithStack(err)
+	}
+
+	if job.GitHubStatus {
+		if err := b.updateCommitStatus(ctx, repo, job, task, forge.StatusPending, ""); err != nil {
+			logger.Log.Warn("Failure update the status of github", zap.Error(err), zap.Int32("task.id", task.Id))
+		}
+	}
+	return nil
+}
+
+// Cancel stops the task which has not finished yet.
+// If the task is running, the job is deleted. The canceled task is distinguished from the failed task by Canceled.
+func (b *BazelBuilder) Cancel(ctx context.Context, task *database.Task) error {
+	if task.FinishedAt != nil {
+		return xerrors.Definef("the task has already finished: %d", task.Id).WithStack()
+	}
+	repo := task.Repository
+	if repo == nil {
+		r, err := b.dao.Repository.Select(ctx, task.RepositoryId)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		repo = r
+	}
+	jobConfiguration, err := config.DecodeJobConfiguration(task)
+	if err != nil {
+		return err
+	}
+
+	if b.taskQueue.Remove(task) {
+		logger.Log.Info("Remove the task from the queue", zap.Int32("task.id", task.Id))
+	}
+	if task.StartAt != nil && !b.IsStub() {
+		jobs, err := b.jobLister.Jobs(b.Namespace).List(labels.SelectorFromSet(labels.Set{LabelKeyTaskId: strconv.Itoa(int(task.Id))}))
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		for _, v := range jobs {
+			logger.Log.Info("Delete the job due to cancellation", zap.String("job.name", v.Name), zap.Int32("task.id", task.Id))
+			if err := b.teardownJob(ctx, v); err != nil {
+				return err
 			}
 		}
 	}
 
-	return tasks, nil
+	task.Success = false
+	task.Canceled = true
+	task.FinishedAt = varptr.Ptr(time.Now())
+	if err := b.dao.Task.Update(ctx, task); err != nil {
+		return xerrors.WithStack(err)
+	}
+	logger.Log.Info("The task has been canceled", zap.Int32("task.id", task.Id))
+
+	if jobConfiguration.GitHubStatus {
+		if err := b.updateCommitStatus(ctx, repo, jobConfiguration, task, forge.StatusError, ""); err != nil {
+			logger.Log.Warn("Failure update the status of github", zap.Error(err), zap.Int32("task.id", task.Id))
+		}
+	}
+	if err := b.dispatchDependentTasks(ctx, repo, task.Revision); err != nil {
+		logger.Log.Warn("Failed to dispatch dependent tasks", zap.Error(err), zap.Int32("task.id", task.Id))
+	}
+	if task.StartAt != nil {
+		b.startFollowTask(ctx, repo, jobConfiguration, task)
+	}
+	return nil
+}
+
+// cancelSupersededTasks cancels the unfinished tasks of the same pull request and the job which were created for the other revision.
+// The canceled task records the id of the task which supersedes it.
+func (b *BazelBuilder) cancelSupersededTasks(ctx context.Context, repo *database.SourceRepository, job *config.Job, task *database.Task) error {
+	// The task for the old revision (e.g. redoing the old task) must not cancel the task for the newer revision.
+	// Thus, only the task for the head of the pull request can supersede the other tasks.
+	client, forgeRepo, err := b.forges.Client(repo.Url)
+	if err != nil {
+		return err
+	}
+	head, err := client.GetPullRequestHead(ctx, forgeRepo, int(task.PullRequest))
+	if err != nil {
+		return err
+	}
+	if head != task.Revision {
+		logger.Log.Debug("Skip canceling superseded tasks because the revision is not the head of the pull request", zap.Int32("task.id", task.Id), zap.String("head", head))
+		return nil
+	}
+
+	tasks, err := b.dao.Task.ListByPullRequest(ctx, task.RepositoryId, task.PullRequest)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	for _, v := range tasks {
+		if v.Id >= task.Id || v.FinishedAt != nil {
+			continue
+		}
+		if v.JobName != job.Name || taskVariant(v) != taskVariant(task) || v.Revision == task.Revision {
+			continue
+		}
+
+		logger.Log.Info("Cancel the superseded task", zap.Int32("task.id", v.Id), zap.Int32("superseded_by", task.Id))
+		v.SupersededBy = task.Id
+		if err := b.Cancel(ctx, v); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (b *BazelBuilder) IsStub() bool {
@@ -390,7 +690,7 @@ func (b *BazelBuilder) syncJob(job *batchv1.Job) error {
 		return err
 	}
 
-	taskId := job.Labels[labelKeyTaskId]
+	taskId := job.Labels[LabelKeyTaskId]
 	task, err := b.getTask(taskId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -402,17 +702,10 @@ func (b *BazelBuilder) syncJob(job *batchv1.Job) error {
 		}
 		return xerrors.WithStack(err)
 	}
-	jobConfiguration := &config.Job{}
-	if task.JobConfiguration != nil && len(*task.JobConfiguration) > 0 {
-		if err := config.UnmarshalJob([]byte(*task.JobConfiguration), jobConfiguration); err != nil {
-			logger.Log.Warn("Failed to decode json", zap.Int32("task.id", task.Id))
-			return nil
-		}
-	} else if len(task.ParsedJobConfiguration) > 0 {
-		if err := config.UnmarshalJob(task.ParsedJobConfiguration, jobConfiguration); err != nil {
-			logger.Log.Warn("Failed to decode json", zap.Int32("task.id", task.Id))
-			return nil
-		}
+	jobConfiguration, err := config.DecodeJobConfiguration(task)
+	if err != nil {
+		logger.Log.Warn("Failed to decode json", zap.Int32("task.id", task.Id))
+		return nil
 	}
 
 	if task.FinishedAt != nil {
@@ -433,6 +726,9 @@ func (b *BazelBuilder) syncJob(job *batchv1.Job) error {
 		if err := b.dao.Task.Update(context.Background(), task); err != nil {
 			return xerrors.WithStack(err)
 		}
+		if err := b.dispatchDependentTasks(ctx, repo, task.Revision); err != nil {
+			logger.Log.Warn("Failed to dispatch dependent tasks", zap.Error(err), zap.Int32("task.id", task.Id))
+		}
 		return nil
 	}
 
@@ -473,20 +769,182 @@ func (b *BazelBuilder) syncJob(job *batchv1.Job) error {
 		return xerrors.WithStack(err)
 	}
 
-	if followTask := b.taskQueue.DequeueById(task.JobName); followTask != nil {
+	if task.FinishedAt != nil {
+		if err := b.dispatchDependentTasks(ctx, repo, task.Revision); err != nil {
+			logger.Log.Warn("Failed to dispatch dependent tasks", zap.Error(err), zap.Int32("task.id", task.Id))
+		}
+	}
+
+	b.startFollowTask(ctx, repo, jobConfiguration, task)
+	return nil
+}
+
+// startFollowTask starts the next task in the queue after the task has finished.
+func (b *BazelBuilder) startFollowTask(ctx context.Context, repo *database.SourceRepository, jobConfiguration *config.Job, task *database.Task) {
+	for {
+		followTask := b.taskQueue.DequeueById(task.JobName)
+		if followTask == nil {
+			return
+		}
+
 		logger.Log.Info("Dequeue the task", zap.Int32("task.id", followTask.Id))
 		if err := b.buildJob(ctx, repo, jobConfiguration, followTask); err != nil {
+			if errors.Is(err, ErrTaskIsCanceled) {
+				logger.Log.Info("Skip the canceled task", zap.Int32("task.id", followTask.Id))
+				continue
+			}
 			logger.Log.Warn("Failed starting follow task. You have to start a task manually", zap.Error(err), zap.String("job.name", task.JobName), zap.Int32("task.id", task.Id))
-			return nil
+			return
 		}
 		if err := b.dao.Task.Update(context.Background(), followTask); err != nil {
 			logger.Log.Warn("Failed update the task", zap.Error(err), zap.Int32("task.id", followTask.Id))
 		}
+		return
+	}
+}
+
+type upstreamState int
+
+const (
+	upstreamRunning upstreamState = iota
+	upstreamSucceeded
+	upstreamFailed
+)
+
+// dispatchDependentTasks starts or cancels the pending tasks which need other jobs at the revision.
+// The pending task will be started after all tasks of the upstream jobs have succeeded.
+// If any task of the upstream jobs has failed or has been canceled, the pending task will be canceled.
+func (b *BazelBuilder) dispatchDependentTasks(ctx context.Context, repo *database.SourceRepository, revision string) error {
+	// dispatchDependentTasks is called from the informer, the API and the cancellation concurrently.
+	// The tasks are read after taking the lock so that the same task is never started twice.
+	unlock := b.dispatchMu.Lock(fmt.Sprintf("%d/%s", repo.Id, revision))
+	defer unlock()
+
+	for {
+		tasks, err := b.dao.Task.ListByRevision(ctx, repo.Id, revision)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		latestTasks := latestTasksByJob(tasks)
+
+		canceled := false
+		for _, platforms := range latestTasks {
+			for _, task := range platforms {
+				if task.StartAt != nil || task.FinishedAt != nil || b.taskQueue.IsQueued(task) {
+					continue
+				}
+				jobConfiguration, err := config.DecodeJobConfiguration(task)
+				if err != nil {
+					logger.Log.Warn("Failed to decode the job configuration", zap.Error(err), zap.Int32("task.id", task.Id))
+					continue
+				}
+				if len(jobConfiguration.Needs) == 0 {
+					continue
+				}
+
+				switch checkUpstream(jobConfiguration.Needs, latestTasks) {
+				case upstreamSucceeded:
+					if err := b.startDependentTask(ctx, repo, jobConfiguration, task); err != nil {
+						logger.Log.Warn("Failed to start the task", zap.Error(err), zap.Int32("task.id", task.Id))
+					}
+				case upstreamFailed:
+					if err := b.cancelDependentTask(ctx, repo, jobConfiguration, task); err != nil {
+						return err
+					}
+					canceled = true
+				}
+			}
+		}
+
+		// Canceling the task may cause canceling the downstream tasks.
+		if !canceled {
+			return nil
+		}
+	}
+}
+
+func (b *BazelBuilder) startDependentTask(ctx context.Context, repo *database.SourceRepository, job *config.Job, task *database.Task) error {
+	if err := b.buildJob(ctx, repo, job, task); err != nil {
+		if errors.Is(err, ErrOtherTaskIsRunning) {
+			logger.Log.Info("Enqueue the task", zap.Int32("task.id", task.Id))
+			b.taskQueue.Enqueue(job, task)
+			return nil
+		}
+
+		task.Success = false
+		task.FinishedAt = varptr.Ptr(time.Now())
+		if err := b.dao.Task.Update(ctx, task); err != nil {
+			logger.Log.Warn("Failed update the task", zap.Error(err), zap.Int32("task.id", task.Id))
+		}
+		return err
+	}
+	logger.Log.Info("Start the task because upstream jobs have succeeded", zap.Int32("task.id", task.Id))
+	if err := b.dao.Task.Update(ctx, task); err != nil {
+		return xerrors.WithStack(err)
+	}
+	return nil
+}
+
+func (b *BazelBuilder) cancelDependentTask(ctx context.Context, repo *database.SourceRepository, job *config.Job, task *database.Task) error {
+	logger.Log.Info("Cancel the task because upstream jobs have not succeeded", zap.Int32("task.id", task.Id))
+	task.Success = false
+	task.Canceled = true
+	task.FinishedAt = varptr.Ptr(time.Now())
+	if err := b.dao.Task.Update(ctx, task); err != nil {
+		return xerrors.WithStack(err)
 	}
 
+	if job.GitHubStatus {
+		if err := b.updateCommitStatus(ctx, repo, job, task, forge.StatusError, ""); err != nil {
+			logger.Log.Warn("Failure update the status of github", zap.Error(err), zap.Int32("task.id", task.Id))
+		}
+	}
 	return nil
 }
 
+// latestTasksByJob returns the latest task of each job and variant.
+func latestTasksByJob(tasks []*database.Task) map[string]map[string]*database.Task {
+	latest := make(map[string]map[string]*database.Task)
+	for _, v := range tasks {
+		if _, ok := latest[v.JobName]; !ok {
+			latest[v.JobName] = make(map[string]*database.Task)
+		}
+		if t, ok := latest[v.JobName][taskVariant(v)]; ok && t.Id > v.Id {
+			continue
+		}
+		latest[v.JobName][taskVariant(v)] = v
+	}
+	return latest
+}
+
+// taskVariant returns the key which distinguishes the tasks of the same job at the same revision.
+func taskVariant(task *database.Task) string {
+	if task.Matrix == "" {
+		return task.Platform
+	}
+	return task.Platform + "/" + task.Matrix
+}
+
+func checkUpstream(needs []string, latestTasks map[string]map[string]*database.Task) upstreamState {
+	state := upstreamSucceeded
+	for _, name := range needs {
+		platforms, ok := latestTasks[name]
+		if !ok || len(platforms) == 0 {
+			// The upstream job has not been triggered at the revision.
+			return upstreamFailed
+		}
+		for _, v := range platforms {
+			switch {
+			case v.FinishedAt == nil:
+				state = upstreamRunning
+			case !v.Success:
+				return upstreamFailed
+			}
+		}
+	}
+	return state
+}
+
 func (b *BazelBuilder) teardownJob(ctx context.Context, job *batchv1.Job) error {
 	if err := b.client.BatchV1().Jobs(job.Namespace).Delete(ctx, job.Name, metav1.DeleteOptions{}); err != nil {
 		return xerrors.WithStack(err)
@@ -544,6 +1002,9 @@ func (b *BazelBuilder) getTask(taskId string) (*database.Task, error) {
 }
 
 func (b *BazelBuilder) buildJob(ctx context.Context, repo *database.SourceRepository, job *config.Job, task *database.Task) error {
+	if task.Canceled {
+		return ErrTaskIsCanceled.WithStack()
+	}
 	if job.Exclusive && b.isRunningJob(job) {
 		return ErrOtherTaskIsRunning.WithStack()
 	}
@@ -680,7 +1141,7 @@ func (b *BazelBuilder) postProcess(ctx context.Context, job *batchv1.Job, repo *
 	buf.Write(rawLog)
 
 	var testReport []byte
-	if jobConfiguration.Command == "test" && task.IsTrunk {
+	if needReport(jobConfiguration, task) {
 		logReq = b.client.CoreV1().Pods(b.Namespace).GetLogs(buildPod.Name, &corev1.PodLogOptions{Container: b.jobBuilder.ReportContainerName})
 		rawLog, err = logReq.DoRaw(ctx)
 		if err != nil {
@@ -689,6 +1150,16 @@ func (b *BazelBuilder) postProcess(ctx context.Context, job *batchv1.Job, repo *
 		testReport = rawLog
 	}
 
+	var artifactReport []byte
+	if b.jobBuilder.artifactEndpoint != "" && len(jobConfiguration.Artifacts) > 0 {
+		logReq = b.client.CoreV1().Pods(b.Namespace).GetLogs(buildPod.Name, &corev1.PodLogOptions{Container: b.jobBuilder.ArtifactContainerName})
+		rawLog, err = logReq.DoRaw(ctx)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		artifactReport = rawLog
+	}
+
 	if err := b.minio.Put(context.Background(), job.Name, buf.Bytes()); err != nil {
 		return xerrors.WithStack(err)
 	}
@@ -719,18 +1190,49 @@ func (b *BazelBuilder) postProcess(ctx context.Context, job *batchv1.Job, repo *
 		}
 	}
 
+	var failedTests []string
 	if len(testReport) > 0 {
-		if err := b.updateTestReport(ctx, testReport, repo, task); err != nil {
+		f, err := b.updateTestReport(ctx, testReport, repo, task)
+		if err != nil {
 			logger.Log.Warn("Failed to parse the report json", logger.Error(err))
 		}
+		failedTests = f
+	}
+	if len(artifactReport) > 0 {
+		if err := b.updateArtifacts(ctx, artifactReport, repo, task); err != nil {
+			logger.Log.Warn("Failed to parse the artifact json", logger.Error(err))
+		}
+	}
+
+	if !success && len(failedTests) > 0 && task.Attempt < int32(jobConfiguration.RetryFlaky) {
+		retryTask, err := b.retryFailedTests(ctx, repo, jobConfiguration, task, failedTests)
+		if err != nil {
+			logger.Log.Warn("Failed to retry the failed tests", logger.Error(err), zap.Int32("task.id", task.Id))
+		} else {
+			// The status of the commit will be updated by the retry task.
+			logger.Log.Info("Retry the failed tests", zap.Int32("task.id", task.Id), zap.Int32("retry_task.id", retryTask.Id), zap.Strings("targets", failedTests))
+			return nil
+		}
 	}
 
 	if jobConfiguration.GitHubStatus {
-		state := "success"
+		state := forge.StatusSuccess
 		if !success {
-			state = "failure"
+			state = forge.StatusFailure
+		}
+		var description string
+		if task.GroupId > 0 {
+			groupState, groupDescription, err := b.groupCommitStatus(ctx, task, success)
+			if err != nil {
+				return err
+			}
+			if groupState == forge.StatusPending {
+				// The status of the commit will be updated by the last task of the group.
+				return nil
+			}
+			state, description = groupState, groupDescription
 		}
-		if err := b.updateGithubStatus(context.Background(), repo, jobConfiguration, task, state); err != nil {
+		if err := b.updateCommitStatus(context.Background(), repo, jobConfiguration, task, state, description); err != nil {
 			return xerrors.WithStack(err)
 		}
 	}
@@ -738,14 +1240,33 @@ func (b *BazelBuilder) postProcess(ctx context.Context, job *batchv1.Job, repo *
 	return nil
 }
 
-func (b *BazelBuilder) updateTestReport(ctx context.Context, reportJSON []byte, repo *database.SourceRepository, task *database.Task) error {
+// updateTestReport records the result of the tests and returns the labels of the failed tests.
+func (b *BazelBuilder) updateTestReport(ctx context.Context, reportJSON []byte, repo *database.SourceRepository, task *database.Task) ([]string, error) {
 	var report sidecar.TestReport
 	if err := json.Unmarshal(reportJSON, &report); err != nil {
-		return xerrors.WithStack(err)
+		return nil, xerrors.WithStack(err)
+	}
+
+	var failedTests []string
+	for _, s := range report.Tests {
+		if s.Status == sidecar.TestStatusFailed {
+			failedTests = append(failedTests, s.Label)
+		}
+	}
+
+	if report.Coverage != nil {
+		if err := b.updateCoverageReport(ctx, report.Coverage, repo, task); err != nil {
+			return failedTests, err
+		}
+	}
+	// The result of the test is recorded at the trunk only.
+	if !task.IsTrunk {
+		return failedTests, nil
 	}
 
 	task.ExecutedTestsCount = int32(len(report.Tests))
 	var succeededCount int32
+	var testReports []*database.TestReport
 	for _, s := range report.Tests {
 		status := database.TestStatusFailed
 		switch s.Status {
@@ -757,7 +1278,7 @@ func (b *BazelBuilder) updateTestReport(ctx context.Context, reportJSON []byte,
 			status = database.TestStatusFlaky
 		}
 
-		_, err := b.dao.TestReport.Create(ctx, &database.TestReport{
+		r, err := b.dao.TestReport.Create(ctx, &database.TestReport{
 			RepositoryId: repo.Id,
 			Label:        s.Label,
 			TaskId:       task.Id,
@@ -766,47 +1287,237 @@ func (b *BazelBuilder) updateTestReport(ctx context.Context, reportJSON []byte,
 			StartAt:      s.StartAt,
 		})
 		if err != nil {
-			return xerrors.WithStack(err)
+			return failedTests, xerrors.WithStack(err)
 		}
+		testReports = append(testReports, r)
 	}
 	task.SucceededTestsCount = succeededCount
 
-	return nil
+	if err := b.detectFlakyTests(ctx, repo, task, testReports); err != nil {
+		return failedTests, err
+	}
+
+	return failedTests, nil
 }
 
-func (b *BazelBuilder) updateGithubStatus(ctx context.Context, repo *database.SourceRepository, job *config.Job, task *database.Task, state string) error {
-	if task.Revision == "" {
+// detectFlakyTests flags the tests which have both passed and failed at the same revision.
+// The test which is marked as flaky by Bazel is also flagged.
+func (b *BazelBuilder) detectFlakyTests(ctx context.Context, repo *database.SourceRepository, task *database.Task, reports []*database.TestReport) error {
+	if len(reports) == 0 {
 		return nil
 	}
 
-	u, err := url.Parse(repo.Url)
+	passed := make(map[string]bool)
+	failed := make(map[string]bool)
+	record := func(r *database.TestReport) {
+		switch r.Status {
+		case database.TestStatusPassed:
+			passed[r.Label] = true
+		case database.TestStatusFailed:
+			failed[r.Label] = true
+		case database.TestStatusFlaky:
+			passed[r.Label] = true
+			failed[r.Label] = true
+		}
+	}
+	for _, v := range reports {
+		record(v)
+	}
+
+	tasks, err := b.dao.Task.ListByRevision(ctx, repo.Id, task.Revision)
 	if err != nil {
 		return xerrors.WithStack(err)
 	}
-	if u.Hostname() != "github.com" {
-		logger.Log.Warn("Expect to update a status of github. but repository url is not github.com", zap.String("url", repo.Url))
+	for _, t := range tasks {
+		if t.Id == task.Id || t.JobName != task.JobName {
+			continue
+		}
+		r, err := b.dao.TestReport.ListByTaskId(ctx, t.Id)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		for _, v := range r {
+			if _, ok := passed[v.Label]; !ok {
+				if _, ok := failed[v.Label]; !ok {
+					// The test is not executed in this task.
+					continue
+				}
+			}
+			record(v)
+		}
+	}
+
+	for _, v := range reports {
+		if !passed[v.Label] || !failed[v.Label] {
+			continue
+		}
+		flakyTests, err := b.dao.FlakyTest.ListByLabel(ctx, repo.Id, v.Label)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		if enumerable.Index(flakyTests, func(f *database.FlakyTest) bool { return f.Revision == task.Revision }) >= 0 {
+			continue
+		}
+		logger.Log.Info("Found the flaky test", zap.String("label", v.Label), zap.String("revision", task.Revision))
+		if _, err := b.dao.FlakyTest.Create(ctx, &database.FlakyTest{RepositoryId: repo.Id, Label: v.Label, Revision: task.Revision}); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// groupCommitStatus returns the aggregated state of the group which the task belongs to.
+// The state is pending until the latest tasks of all variants have finished.
+func (b *BazelBuilder) groupCommitStatus(ctx context.Context, task *database.Task, success bool) (forge.StatusState, string, error) {
+	tasks, err := b.dao.Task.ListByGroupId(ctx, task.GroupId)
+	if err != nil {
+		return "", "", xerrors.WithStack(err)
+	}
+	latest := make(map[string]*database.Task)
+	for _, v := range tasks {
+		if t, ok := latest[taskVariant(v)]; ok && t.Id > v.Id {
+			continue
+		}
+		latest[taskVariant(v)] = v
+	}
+
+	var succeeded int
+	for _, v := range latest {
+		if v.Id == task.Id {
+			if success {
+				succeeded++
+			}
+			continue
+		}
+		if v.FinishedAt == nil {
+			return forge.StatusPending, "", nil
+		}
+		if v.Success {
+			succeeded++
+		}
+	}
+
+	state := forge.StatusSuccess
+	if succeeded != len(latest) {
+		state = forge.StatusFailure
+	}
+	return state, fmt.Sprintf("%d of %d tasks succeeded", succeeded, len(latest)), nil
+}
+
+// retryFailedTests creates the task which re-runs only the failed tests of the task.
+func (b *BazelBuilder) retryFailedTests(ctx context.Context, repo *database.SourceRepository, job *config.Job, task *database.Task, targets []string) (*database.Task, error) {
+	retryTask, err := b.dao.Task.Create(ctx, &database.Task{
+		RepositoryId:           task.RepositoryId,
+		JobName:                task.JobName,
+		ParsedJobConfiguration: task.ParsedJobConfiguration,
+		Revision:               task.Revision,
+		IsTrunk:                task.IsTrunk,
+		BazelVersion:           task.BazelVersion,
+		Command:                task.Command,
+		Targets:                strings.Join(targets, "\n"),
+		Platform:               task.Platform,
+		Via:                    "retry",
+		ConfigName:             task.ConfigName,
+		PullRequest:            task.PullRequest,
+		Attempt:                task.Attempt + 1,
+		RetryOf:                task.Id,
+		Matrix:                 task.Matrix,
+		GroupId:                task.GroupId,
+	})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	if err := b.buildJob(ctx, repo, job, retryTask); err != nil {
+		if errors.Is(err, ErrOtherTaskIsRunning) {
+			logger.Log.Info("Enqueue the task", zap.Int32("task.id", retryTask.Id))
+			b.taskQueue.Enqueue(job, retryTask)
+			return retryTask, nil
+		}
+
+		retryTask.Success = false
+		retryTask.FinishedAt = varptr.Ptr(time.Now())
+		if err := b.dao.Task.Update(ctx, retryTask); err != nil {
+			logger.Log.Warn("Failed update the task", zap.Error(err), zap.Int32("task.id", retryTask.Id))
+		}
+		return nil, err
+	}
+	if err := b.dao.Task.Update(ctx, retryTask); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	return retryTask, nil
+}
+
+func (b *BazelBuilder) updateCoverageReport(ctx context.Context, report *sidecar.CoverageReport, repo *database.SourceRepository, task *database.Task) error {
+	var linesFound, linesHit int32
+	for _, v := range report.Files {
+		linesFound += v.LinesFound
+		linesHit += v.LinesHit
+
+		_, err := b.dao.CoverageReport.Create(ctx, &database.CoverageReport{
+			RepositoryId: repo.Id,
+			TaskId:       task.Id,
+			Path:         v.Path,
+			LinesFound:   v.LinesFound,
+			LinesHit:     v.LinesHit,
+		})
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	task.LinesFound = linesFound
+	task.LinesHit = linesHit
+
+	return nil
+}
+
+func (b *BazelBuilder) updateArtifacts(ctx context.Context, reportJSON []byte, repo *database.SourceRepository, task *database.Task) error {
+	var report sidecar.ArtifactReport
+	if err := json.Unmarshal(reportJSON, &report); err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	for _, v := range report.Artifacts {
+		_, err := b.dao.Artifact.Create(ctx, &database.Artifact{
+			RepositoryId: repo.Id,
+			TaskId:       task.Id,
+			Name:         v.Name,
+			Path:         v.Path,
+			Size:         v.Size,
+		})
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+func (b *BazelBuilder) updateCommitStatus(ctx context.Context, repo *database.SourceRepository, job *config.Job, task *database.Task, state forge.StatusState, description string) error {
+	if task.Revision == "" {
+		return nil
+	}
+
+	client, forgeRepo, err := b.forges.Client(repo.Url)
+	if err != nil {
+		logger.Log.Warn("Expect to update a status of the commit. but the forge of the repository is not found", zap.String("url", repo.Url), logger.Error(err))
 		return nil
 	}
-	// u.Path is /owner/repo if URL is github.com.
-	s := strings.Split(u.Path, "/")
-	owner, repoName := s[1], s[2]
 
 	targetUrl := ""
-	if state == "success" || state == "failure" {
+	if state == forge.StatusSuccess || state == forge.StatusFailure || state == forge.StatusError {
 		targetUrl = fmt.Sprintf("%s/task/%d", b.dashboardUrl, task.Id)
 	}
+	if task.Canceled {
+		description = "Canceled"
+	}
 
-	_, _, err = b.githubClient.Repositories.CreateStatus(
-		ctx,
-		owner,
-		repoName,
-		task.Revision,
-		&github.RepoStatus{
-			State:     github.String(state),
-			Context:   github.String(fmt.Sprintf("%s %s", task.Command, job.Name)),
-			TargetURL: github.String(targetUrl),
-		},
-	)
+	err = client.CreateStatus(ctx, forgeRepo, task.Revision, &forge.CommitStatus{
+		State:       state,
+		Context:     fmt.Sprintf("%s %s", task.Command, job.Name),
+		TargetURL:   targetUrl,
+		Description: description,
+	})
 	if err != nil {
 		return xerrors.WithStack(err)
 	}
@@ -0,0 +1,367 @@
+package coordinator
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.f110.dev/mono/go/build/config"
+	"go.f110.dev/mono/go/build/database"
+	"go.f110.dev/mono/go/build/database/dao"
+	"go.f110.dev/mono/go/build/database/dao/daotest"
+	"go.f110.dev/mono/go/build/forge"
+	"go.f110.dev/mono/go/varptr"
+)
+
+func TestCheckUpstream(t *testing.T) {
+	now := time.Now()
+	tasks := []*database.Task{
+		{Id: 1, JobName: "test", Platform: "linux_amd64", FinishedAt: &now, Success: false},
+		{Id: 2, JobName: "test", Platform: "linux_amd64", FinishedAt: &now, Success: true},
+		{Id: 3, JobName: "test", Platform: "linux_arm64", FinishedAt: &now, Success: true},
+		{Id: 4, JobName: "lint", Platform: "linux_amd64"},
+		{Id: 5, JobName: "vet", Platform: "linux_amd64", FinishedAt: &now, Success: false},
+	}
+	latest := latestTasksByJob(tasks)
+	assert.Equal(t, int32(2), latest["test"]["linux_amd64"].Id)
+
+	assert.Equal(t, upstreamSucceeded, checkUpstream([]string{"test"}, latest))
+	assert.Equal(t, upstreamRunning, checkUpstream([]string{"test", "lint"}, latest))
+	assert.Equal(t, upstreamFailed, checkUpstream([]string{"lint", "vet"}, latest))
+	assert.Equal(t, upstreamFailed, checkUpstream([]string{"build"}, latest))
+}
+
+func TestBazelBuilder_DispatchDependentTasks(t *testing.T) {
+	repo := &database.SourceRepository{Id: 1, Name: "sandbox", Url: "https://github.com/f110/sandbox"}
+	newTask := func(id int32, job *config.Job) *database.Task {
+		b, err := config.MarshalJob(job)
+		require.NoError(t, err)
+		return &database.Task{Id: id, RepositoryId: repo.Id, JobName: job.Name, Revision: "abcdef", Platform: "linux_amd64", ParsedJobConfiguration: b}
+	}
+
+	upstream := newTask(1, &config.Job{Name: "test"})
+	upstream.StartAt = varptr.Ptr(time.Now())
+	upstream.FinishedAt = varptr.Ptr(time.Now())
+	downstream := newTask(2, &config.Job{Name: "deploy", Needs: []string{"test"}})
+	notify := newTask(3, &config.Job{Name: "notify", Needs: []string{"deploy"}})
+
+	mockTask := daotest.NewTask()
+	mockTask.RegisterListByRevision(repo.Id, "abcdef", []*database.Task{upstream, downstream, notify}, nil)
+	b := &BazelBuilder{dao: dao.Options{Task: mockTask}, taskQueue: newTaskQueue()}
+
+	err := b.dispatchDependentTasks(context.Background(), repo, "abcdef")
+	require.NoError(t, err)
+
+	// The downstream tasks are canceled transitively because the upstream task has failed.
+	assert.True(t, downstream.Canceled)
+	assert.NotNil(t, downstream.FinishedAt)
+	assert.Nil(t, downstream.StartAt)
+	assert.True(t, notify.Canceled)
+	assert.NotNil(t, notify.FinishedAt)
+	assert.Len(t, mockTask.Called("Update"), 2)
+
+	t.Run("Concurrently", func(t *testing.T) {
+		upstream := newTask(1, &config.Job{Name: "test"})
+		upstream.StartAt = varptr.Ptr(time.Now())
+		upstream.FinishedAt = varptr.Ptr(time.Now())
+		downstream := newTask(2, &config.Job{Name: "deploy", Needs: []string{"test"}})
+
+		mockTask := daotest.NewTask()
+		mockTask.RegisterListByRevision(repo.Id, "abcdef", []*database.Task{upstream, downstream}, nil)
+		b := &BazelBuilder{dao: dao.Options{Task: mockTask}, taskQueue: newTaskQueue()}
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, b.dispatchDependentTasks(context.Background(), repo, "abcdef"))
+			}()
+		}
+		wg.Wait()
+
+		// The task is handled only once.
+		assert.Len(t, mockTask.Called("Update"), 1)
+	})
+}
+
+func TestBazelBuilder_ResumePendingTasks(t *testing.T) {
+	repo := &database.SourceRepository{Id: 1, Name: "sandbox", Url: "https://github.com/f110/sandbox"}
+	newTask := func(id int32, job *config.Job) *database.Task {
+		b, err := config.MarshalJob(job)
+		require.NoError(t, err)
+		return &database.Task{Id: id, RepositoryId: repo.Id, Repository: repo, JobName: job.Name, Revision: "abcdef", Platform: "linux_amd64", ParsedJobConfiguration: b}
+	}
+
+	// The upstream task has failed while the process was stopped.
+	upstream := newTask(1, &config.Job{Name: "test"})
+	upstream.StartAt = varptr.Ptr(time.Now())
+	upstream.FinishedAt = varptr.Ptr(time.Now())
+	downstream := newTask(2, &config.Job{Name: "deploy", Needs: []string{"test"}})
+	pending := newTask(3, &config.Job{Name: "lint"})
+
+	mockTask := daotest.NewTask()
+	mockTask.RegisterListPending([]*database.Task{downstream, pending}, nil)
+	mockTask.RegisterListByRevision(repo.Id, "abcdef", []*database.Task{upstream, downstream, pending}, nil)
+	b := &BazelBuilder{dao: dao.Options{Task: mockTask}, taskQueue: newTaskQueue()}
+
+	err := b.resumePendingTasks(context.Background())
+	require.NoError(t, err)
+
+	assert.True(t, b.taskQueue.IsQueued(pending))
+	assert.False(t, b.taskQueue.IsQueued(downstream))
+	assert.True(t, downstream.Canceled)
+	assert.NotNil(t, downstream.FinishedAt)
+
+	t.Run("Canceled", func(t *testing.T) {
+		// The task was canceled while waiting in the queue, and then the builder was restarted.
+		canceled := newTask(4, &config.Job{Name: "lint"})
+		canceled.Canceled = true
+		canceled.FinishedAt = varptr.Ptr(time.Now())
+
+		mockTask := daotest.NewTask()
+		mockTask.RegisterListPending([]*database.Task{canceled}, nil)
+		b := &BazelBuilder{dao: dao.Options{Task: mockTask}, taskQueue: newTaskQueue()}
+
+		err := b.resumePendingTasks(context.Background())
+		require.NoError(t, err)
+		assert.False(t, b.taskQueue.IsQueued(canceled))
+
+		err = b.buildJob(context.Background(), repo, &config.Job{Name: "lint"}, canceled)
+		assert.ErrorIs(t, err, ErrTaskIsCanceled)
+	})
+}
+
+func TestRunningTask(t *testing.T) {
+	now := time.Now()
+	job := &config.Job{Name: "test"}
+
+	// The running task of the other job doesn't block the job.
+	tasks := []*database.Task{
+		{Id: 3, JobName: "lint", CreatedAt: now},
+		{Id: 2, JobName: "test", CreatedAt: now, FinishedAt: &now},
+	}
+	assert.Nil(t, runningTask(tasks, job))
+
+	tasks = append([]*database.Task{{Id: 4, JobName: "test", CreatedAt: now}}, tasks...)
+	assert.Equal(t, int32(4), runningTask(tasks, job).Id)
+
+	// The task which exceeds the timeout is not running.
+	tasks = []*database.Task{{Id: 1, JobName: "test", CreatedAt: now.Add(-jobTimeout - time.Minute)}}
+	assert.Nil(t, runningTask(tasks, job))
+}
+
+func TestBazelBuilder_UpdateArtifacts(t *testing.T) {
+	repo := &database.SourceRepository{Id: 1, Name: "sandbox"}
+	task := &database.Task{Id: 100, RepositoryId: repo.Id}
+
+	mockArtifact := daotest.NewArtifact()
+	b := &BazelBuilder{dao: dao.Options{Artifact: mockArtifact}}
+	err := b.updateArtifacts(context.Background(), []byte(`{"artifacts":[{"name":"cmd/foo/foo_/foo","path":"artifacts/100/cmd/foo/foo_/foo","size":1024}]}`), repo, task)
+	require.NoError(t, err)
+
+	created := mockArtifact.Called("Create")
+	require.Len(t, created, 1)
+	artifact := created[0].Args["artifact"].(*database.Artifact)
+	assert.Equal(t, int32(100), artifact.TaskId)
+	assert.Equal(t, "cmd/foo/foo_/foo", artifact.Name)
+	assert.Equal(t, "artifacts/100/cmd/foo/foo_/foo", artifact.Path)
+	assert.Equal(t, int64(1024), artifact.Size)
+}
+
+func TestBazelBuilder_Cancel(t *testing.T) {
+	repo := &database.SourceRepository{Id: 1, Name: "sandbox", Url: "https://github.com/f110/sandbox"}
+	b, err := config.MarshalJob(&config.Job{Name: "test"})
+	require.NoError(t, err)
+	task := &database.Task{Id: 1, RepositoryId: repo.Id, Repository: repo, JobName: "test", Revision: "abcdef", ParsedJobConfiguration: b}
+
+	mockTask := daotest.NewTask()
+	mockTask.RegisterListByRevision(repo.Id, "abcdef", []*database.Task{task}, nil)
+	builder := &BazelBuilder{dao: dao.Options{Task: mockTask}, taskQueue: newTaskQueue()}
+	builder.taskQueue.EnqueueById("test", task)
+
+	err = builder.Cancel(context.Background(), task)
+	require.NoError(t, err)
+	assert.True(t, task.Canceled)
+	assert.False(t, task.Success)
+	assert.NotNil(t, task.FinishedAt)
+	assert.False(t, builder.taskQueue.IsQueued(task))
+	assert.Len(t, mockTask.Called("Update"), 1)
+
+	// The finished task can't be canceled.
+	err = builder.Cancel(context.Background(), task)
+	assert.Error(t, err)
+}
+
+func TestBazelBuilder_CancelSupersededTasks(t *testing.T) {
+	// The head of the pull request is 123456.
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method + " " + req.URL.Path {
+		case "GET /api/v1/repos/f110/sandbox/pulls/10":
+			io.WriteString(w, `{"number":10,"head":{"sha":"123456"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer stub.Close()
+	gitea, err := forge.NewGitea(stub.URL, "", nil)
+	require.NoError(t, err)
+
+	repo := &database.SourceRepository{Id: 1, Name: "sandbox", Url: stub.URL + "/f110/sandbox"}
+	job := &config.Job{Name: "test", CancelSuperseded: true}
+	b, err := config.MarshalJob(job)
+	require.NoError(t, err)
+	now := time.Now()
+	newTask := func(id int32, jobName, revision string, finishedAt *time.Time) *database.Task {
+		return &database.Task{Id: id, RepositoryId: repo.Id, Repository: repo, JobName: jobName, Revision: revision, PullRequest: 10, ParsedJobConfiguration: b, FinishedAt: finishedAt}
+	}
+
+	t.Run("Head", func(t *testing.T) {
+		running := newTask(1, "test", "abcdef", nil)
+		finished := newTask(2, "test", "abcdef", &now)
+		otherJob := newTask(3, "lint", "abcdef", nil)
+		task := newTask(4, "test", "123456", nil)
+
+		mockTask := daotest.NewTask()
+		mockTask.RegisterListByPullRequest(repo.Id, 10, []*database.Task{running, finished, otherJob, task}, nil)
+		mockTask.RegisterListByRevision(repo.Id, "abcdef", []*database.Task{running, finished, otherJob}, nil)
+		builder := &BazelBuilder{dao: dao.Options{Task: mockTask}, taskQueue: newTaskQueue(), forges: forge.NewSet(gitea)}
+
+		err := builder.cancelSupersededTasks(context.Background(), repo, job, task)
+		require.NoError(t, err)
+		assert.True(t, running.Canceled)
+		assert.Equal(t, int32(4), running.SupersededBy)
+		assert.False(t, finished.Canceled)
+		assert.False(t, otherJob.Canceled)
+		assert.Equal(t, int32(0), otherJob.SupersededBy)
+		assert.False(t, task.Canceled)
+		assert.Len(t, mockTask.Called("Update"), 1)
+	})
+
+	t.Run("RedoOldRevision", func(t *testing.T) {
+		running := newTask(1, "test", "123456", nil)
+		redo := newTask(2, "test", "abcdef", nil)
+
+		mockTask := daotest.NewTask()
+		mockTask.RegisterListByPullRequest(repo.Id, 10, []*database.Task{running, redo}, nil)
+		builder := &BazelBuilder{dao: dao.Options{Task: mockTask}, taskQueue: newTaskQueue(), forges: forge.NewSet(gitea)}
+
+		err := builder.cancelSupersededTasks(context.Background(), repo, job, redo)
+		require.NoError(t, err)
+		assert.False(t, running.Canceled)
+		assert.Equal(t, int32(0), running.SupersededBy)
+		assert.Len(t, mockTask.Called("Update"), 0)
+	})
+}
+
+func TestBazelBuilder_UpdateTestReport(t *testing.T) {
+	repo := &database.SourceRepository{Id: 1, Name: "sandbox"}
+	now := time.Now()
+	previous := &database.Task{Id: 1, RepositoryId: repo.Id, JobName: "test", Revision: "abcdef", IsTrunk: true, FinishedAt: &now}
+	task := &database.Task{Id: 2, RepositoryId: repo.Id, JobName: "test", Revision: "abcdef", IsTrunk: true}
+
+	mockTask := daotest.NewTask()
+	mockTask.RegisterListByRevision(repo.Id, "abcdef", []*database.Task{previous, task}, nil)
+	mockTestReport := daotest.NewTestReport()
+	mockTestReport.RegisterListByTaskId(previous.Id, []*database.TestReport{
+		{Label: "//:foo_test", Status: database.TestStatusFailed},
+		{Label: "//:bar_test", Status: database.TestStatusPassed},
+	}, nil)
+	mockFlakyTest := daotest.NewFlakyTest()
+	mockFlakyTest.RegisterListByLabel(repo.Id, "//:foo_test", []*database.FlakyTest{}, nil)
+	mockFlakyTest.RegisterListByLabel(repo.Id, "//:bar_test", []*database.FlakyTest{{Label: "//:bar_test", Revision: "123456"}}, nil)
+	mockFlakyTest.RegisterListByLabel(repo.Id, "//:baz_test", []*database.FlakyTest{{Label: "//:baz_test", Revision: "abcdef"}}, nil)
+	b := &BazelBuilder{dao: dao.Options{Task: mockTask, TestReport: mockTestReport, FlakyTest: mockFlakyTest}}
+
+	failedTests, err := b.updateTestReport(context.Background(), []byte(`{"tests":[{"label":"//:foo_test","status":"passed"},{"label":"//:bar_test","status":"failed"},{"label":"//:baz_test","status":"flaky"}]}`), repo, task)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"//:bar_test"}, failedTests)
+	assert.Equal(t, int32(3), task.ExecutedTestsCount)
+	assert.Equal(t, int32(2), task.SucceededTestsCount)
+	assert.Len(t, mockTestReport.Called("Create"), 3)
+
+	// //:foo_test and //:bar_test have both passed and failed at the same revision.
+	// //:baz_test has already been flagged.
+	created := mockFlakyTest.Called("Create")
+	require.Len(t, created, 2)
+	assert.Equal(t, "//:foo_test", created[0].Args["flakyTest"].(*database.FlakyTest).Label)
+	assert.Equal(t, "//:bar_test", created[1].Args["flakyTest"].(*database.FlakyTest).Label)
+	assert.Equal(t, "abcdef", created[1].Args["flakyTest"].(*database.FlakyTest).Revision)
+}
+
+func TestBazelBuilder_RetryFailedTests(t *testing.T) {
+	repo := &database.SourceRepository{Id: 1, Name: "sandbox", CloneUrl: "https://github.com/f110/sandbox.git"}
+	job := &config.Job{Name: "test", Command: "test", Targets: []string{"//..."}, Platforms: []string{"linux_amd64"}, RetryFlaky: 2, RepositoryOwner: "f110", RepositoryName: "sandbox"}
+	b, err := config.MarshalJob(job)
+	require.NoError(t, err)
+	task := &database.Task{Id: 1, RepositoryId: repo.Id, JobName: "test", Revision: "abcdef", Command: "test", Targets: "//...", Platform: "linux_amd64", PullRequest: 10, ParsedJobConfiguration: b}
+
+	mockTask := daotest.NewTask()
+	builder := &BazelBuilder{
+		dao:        dao.Options{Task: mockTask},
+		taskQueue:  newTaskQueue(),
+		jobBuilder: NewJobBuilder("default", "bazel", "sidecar"),
+	}
+
+	retryTask, err := builder.retryFailedTests(context.Background(), repo, job, task, []string{"//:foo_test", "//:bar_test"})
+	require.NoError(t, err)
+	assert.Equal(t, "//:foo_test\n//:bar_test", retryTask.Targets)
+	assert.Equal(t, int32(1), retryTask.Attempt)
+	assert.Equal(t, int32(1), retryTask.RetryOf)
+	assert.Equal(t, int32(10), retryTask.PullRequest)
+	assert.Equal(t, "retry", retryTask.Via)
+	assert.NotNil(t, retryTask.StartAt)
+	assert.Len(t, mockTask.Called("Create"), 1)
+	assert.Len(t, mockTask.Called("Update"), 1)
+}
+
+func TestBazelBuilder_GroupCommitStatus(t *testing.T) {
+	now := time.Now()
+	newTask := func(id int32, matrix string, success bool, finishedAt *time.Time) *database.Task {
+		return &database.Task{Id: id, JobName: "test", Platform: "linux_amd64", Matrix: matrix, GroupId: 1, Success: success, FinishedAt: finishedAt}
+	}
+	first := newTask(1, "bazel_version=6.4.0", true, &now)
+	second := newTask(2, "bazel_version=7.0.0", false, &now)
+	running := newTask(3, "bazel_version=7.1.0", false, nil)
+
+	t.Run("Running", func(t *testing.T) {
+		mockTask := daotest.NewTask()
+		mockTask.RegisterListByGroupId(1, []*database.Task{first, second, running}, nil)
+		builder := &BazelBuilder{dao: dao.Options{Task: mockTask}}
+
+		state, _, err := builder.groupCommitStatus(context.Background(), first, true)
+		require.NoError(t, err)
+		assert.Equal(t, forge.StatusPending, state)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		mockTask := daotest.NewTask()
+		mockTask.RegisterListByGroupId(1, []*database.Task{first, second, running}, nil)
+		builder := &BazelBuilder{dao: dao.Options{Task: mockTask}}
+
+		// The current task is not finished in the database yet.
+		state, description, err := builder.groupCommitStatus(context.Background(), running, true)
+		require.NoError(t, err)
+		assert.Equal(t, forge.StatusFailure, state)
+		assert.Equal(t, "2 of 3 tasks succeeded", description)
+	})
+
+	t.Run("SucceededByRetry", func(t *testing.T) {
+		retry := newTask(4, "bazel_version=7.0.0", false, nil)
+		mockTask := daotest.NewTask()
+		mockTask.RegisterListByGroupId(1, []*database.Task{first, second, retry}, nil)
+		builder := &BazelBuilder{dao: dao.Options{Task: mockTask}}
+
+		state, description, err := builder.groupCommitStatus(context.Background(), retry, true)
+		require.NoError(t, err)
+		assert.Equal(t, forge.StatusSuccess, state)
+		assert.Equal(t, "2 of 2 tasks succeeded", description)
+	})
+}
@@ -2,6 +2,7 @@ package coordinator
 
 import (
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -14,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	secretsstorev1 "sigs.k8s.io/secrets-store-csi-driver/apis/v1"
 
+	"go.f110.dev/mono/go/build/cmd/sidecar"
 	"go.f110.dev/mono/go/build/config"
 	"go.f110.dev/mono/go/build/database"
 	"go.f110.dev/mono/go/build/watcher"
@@ -37,20 +39,29 @@ type JobBuilder struct {
 	githubAppId          int64
 	githubInstallationId int64
 	githubAppSecretName  string
+	forgeTokenSecrets    map[string]string
 	defaultCPULimit      resource.Quantity
 	defaultMemoryLimit   resource.Quantity
 	remoteCache          string
 	remoteAssetAPI       bool
 	vaultAddr            string
+	artifactEndpoint     string
+	artifactBucket       string
+	artifactSecretName   string
 
 	PreProcessContainerName string
 	BuildContainerName      string
 	ReportContainerName     string
+	ArtifactContainerName   string
 
-	reportContainer *corev1.Container
-	workDirVolume   *k8sfactory.VolumeSource
-	// commDirVolume is a empty dir volume for sharing Build Event protocol file between main and report container.
+	reportContainer   *corev1.Container
+	artifactContainer *corev1.Container
+	workDirVolume     *k8sfactory.VolumeSource
+	// commDirVolume is a empty dir volume for sharing Build Event protocol file between main and sidecar containers.
 	commDirVolume *k8sfactory.VolumeSource
+	// outputVolume is a empty dir volume for the output of bazel.
+	// The report container reads the coverage report and the artifact container reads the outputs from this volume.
+	outputVolume *k8sfactory.VolumeSource
 
 	sa                       *corev1.ServiceAccount
 	mainContainer            *corev1.Container
@@ -66,20 +77,21 @@ func NewJobBuilder(ns, bazelImage, sidecar string) *JobBuilder {
 		namespace:               ns,
 		bazelImage:              bazelImage,
 		sidecarImage:            sidecar,
-		PreProcessContainerName: "pre-process",
-		BuildContainerName:      "main",
+		PreProcessContainerName: PreProcessContainerName,
+		BuildContainerName:      BuildContainerName,
 		ReportContainerName:     "report",
+		ArtifactContainerName:   "artifact",
 
 		workDirVolume: workDir,
 		mainContainer: k8sfactory.ContainerFactory(nil,
-			k8sfactory.Name("main"),
+			k8sfactory.Name(BuildContainerName),
 			k8sfactory.PullPolicy(corev1.PullIfNotPresent),
 			k8sfactory.WorkDir(workDir.Mount.MountPath),
 			k8sfactory.Volume(workDir),
 			k8sfactory.EnvVar("WORKSPACE", workDir.Mount.MountPath),
 		),
 		preProcessContainer: k8sfactory.ContainerFactory(nil,
-			k8sfactory.Name("pre-process"),
+			k8sfactory.Name(PreProcessContainerName),
 			k8sfactory.Image(sidecar, nil),
 			k8sfactory.PullPolicy(corev1.PullIfNotPresent),
 			k8sfactory.Volume(workDir),
@@ -130,6 +142,15 @@ func (j *JobBuilder) EnableRemoteCache(addr string) {
 	j.remoteCache = addr
 }
 
+// ForgeToken sets the name of Secret which has the access token for cloning the private repository on the host.
+// The secret has to have the token as "token". The private repository on github.com is always cloned via GitHub App.
+func (j *JobBuilder) ForgeToken(host, secretName string) {
+	if j.forgeTokenSecrets == nil {
+		j.forgeTokenSecrets = make(map[string]string)
+	}
+	j.forgeTokenSecrets[host] = secretName
+}
+
 func (j *JobBuilder) EnableRemoteAssetAPI() {
 	j.remoteAssetAPI = true
 }
@@ -138,6 +159,14 @@ func (j *JobBuilder) Vault(addr string) {
 	j.vaultAddr = addr
 }
 
+// ArtifactStorage enables uploading the artifacts.
+// The secret has to have the access key as "accesskey" and the secret access key as "secretkey".
+func (j *JobBuilder) ArtifactStorage(endpoint, bucket, secretName string) {
+	j.artifactEndpoint = endpoint
+	j.artifactBucket = bucket
+	j.artifactSecretName = secretName
+}
+
 func (j *JobBuilder) Clone() *JobBuilder {
 	return &JobBuilder{
 		namespace:               j.namespace,
@@ -148,16 +177,21 @@ func (j *JobBuilder) Clone() *JobBuilder {
 		PreProcessContainerName: j.PreProcessContainerName,
 		BuildContainerName:      j.BuildContainerName,
 		ReportContainerName:     j.ReportContainerName,
+		ArtifactContainerName:   j.ArtifactContainerName,
 		useBazelisk:             j.useBazelisk,
 		pullAlways:              j.pullAlways,
 		githubAppId:             j.githubAppId,
 		githubInstallationId:    j.githubInstallationId,
 		githubAppSecretName:     j.githubAppSecretName,
+		forgeTokenSecrets:       j.forgeTokenSecrets,
 		defaultCPULimit:         j.defaultCPULimit,
 		defaultMemoryLimit:      j.defaultMemoryLimit,
 		remoteCache:             j.remoteCache,
 		remoteAssetAPI:          j.remoteAssetAPI,
 		vaultAddr:               j.vaultAddr,
+		artifactEndpoint:        j.artifactEndpoint,
+		artifactBucket:          j.artifactBucket,
+		artifactSecretName:      j.artifactSecretName,
 
 		workDirVolume:       j.workDirVolume,
 		mainContainer:       j.mainContainer,
@@ -225,7 +259,15 @@ func (j *JobBuilder) Repo(repo *database.SourceRepository) *JobBuilder {
 	}
 	j.repo = repo
 
-	if j.repo.Private {
+	if secretName := j.forgeTokenSecret(); secretName != "" {
+		forgeSecretVolume := k8sfactory.NewSecretVolumeSource(
+			"forge-secret",
+			"/etc/forge",
+			k8sfactory.SecretFactory(nil, k8sfactory.Name(secretName)),
+		)
+		j.preProcessContainer = k8sfactory.ContainerFactory(j.preProcessContainer, k8sfactory.Volume(forgeSecretVolume))
+		j.buildPod = k8sfactory.PodFactory(j.buildPod, k8sfactory.Volume(forgeSecretVolume))
+	} else if j.repo.Private {
 		githubSecretVolume := k8sfactory.NewSecretVolumeSource(
 			"github-secret",
 			"/etc/github",
@@ -247,19 +289,24 @@ func (j *JobBuilder) Task(task *database.Task) *JobBuilder {
 	}
 	j.task = task
 	j.makeReportContainer()
+	j.makeArtifactContainer()
 	j.injectSecret()
 
 	if j.mainContainer.Image == "" {
 		imageTag := j.defaultBazelVersion
 		if j.useBazelisk {
 			imageTag = "bazelisk"
+			// The version of the task may be overridden by the matrix. Thus, bazelisk has to be told the version explicitly.
+			if j.task.BazelVersion != "" {
+				j.mainContainer = k8sfactory.ContainerFactory(j.mainContainer, k8sfactory.EnvVar("USE_BAZEL_VERSION", j.task.BazelVersion))
+			}
 		} else if j.task.BazelVersion != "" {
 			imageTag = j.task.BazelVersion
 		}
 		j.mainContainer = k8sfactory.ContainerFactory(j.mainContainer, k8sfactory.Image(fmt.Sprintf("%s:%s", j.bazelImage, imageTag), nil))
 	}
 
-	j.buildPod = k8sfactory.PodFactory(j.buildPod, k8sfactory.Labels(map[string]string{labelKeyTaskId: strconv.Itoa(int(j.task.Id))}))
+	j.buildPod = k8sfactory.PodFactory(j.buildPod, k8sfactory.Labels(map[string]string{LabelKeyTaskId: strconv.Itoa(int(j.task.Id))}))
 	return j
 }
 
@@ -289,7 +336,9 @@ func (j *JobBuilder) Build() ([]runtime.Object, error) {
 	if j.task.Revision != "" {
 		preProcessArgs = append(preProcessArgs, "--commit="+j.task.Revision)
 	}
-	if j.repo.Private {
+	if j.forgeTokenSecret() != "" {
+		preProcessArgs = append(preProcessArgs, "--token-file=/etc/forge/token")
+	} else if j.repo.Private {
 		preProcessArgs = append(preProcessArgs,
 			fmt.Sprintf("--github-app-id=%d", j.githubAppId),
 			fmt.Sprintf("--github-installation-id=%d", j.githubInstallationId),
@@ -298,7 +347,11 @@ func (j *JobBuilder) Build() ([]runtime.Object, error) {
 	}
 	preProcessContainer := k8sfactory.ContainerFactory(j.preProcessContainer, k8sfactory.Args(preProcessArgs...))
 
-	args := []string{j.task.Command}
+	var args []string
+	if j.outputVolume != nil {
+		args = append(args, fmt.Sprintf("--output_user_root=%s", j.outputVolume.Mount.MountPath))
+	}
+	args = append(args, j.task.Command)
 	if j.remoteCache != "" {
 		args = append(args, fmt.Sprintf("--remote_cache=%s", j.remoteCache))
 		if j.remoteAssetAPI {
@@ -317,16 +370,20 @@ func (j *JobBuilder) Build() ([]runtime.Object, error) {
 		}
 	}
 	if j.commDirVolume != nil {
-		args = append(args,
-			fmt.Sprintf("--build_event_binary_file=%s/bep", j.commDirVolume.Mount.MountPath),
-			"--cache_test_results=no",
-		)
+		args = append(args, fmt.Sprintf("--build_event_binary_file=%s/bep", j.commDirVolume.Mount.MountPath))
 	}
-	if j.job.Command == "test" && !j.task.IsTrunk {
+	if j.reportContainer != nil {
+		args = append(args, "--cache_test_results=no")
+	}
+	if (j.job.Command == "test" || j.job.Command == "coverage") && !j.task.IsTrunk {
 		args = append(args, "--remote_upload_local_results=false")
 	}
+	if j.job.Command == "coverage" && !hasFlag(j.job.Flags, "combined_report") {
+		args = append(args, "--combined_report=lcov")
+	}
+	args = append(args, j.job.Flags...)
 	switch j.job.Command {
-	case "test":
+	case "test", "build", "coverage":
 		args = append(args, "--")
 		targets := strings.Split(j.task.Targets, "\n")
 		args = append(args, targets...)
@@ -346,7 +403,7 @@ func (j *JobBuilder) Build() ([]runtime.Object, error) {
 		k8sfactory.Labels(map[string]string{
 			labelKeyRepoId:    fmt.Sprintf("%d", j.repo.Id),
 			labelKeyJobId:     j.job.Identification(),
-			labelKeyTaskId:    strconv.Itoa(int(j.task.Id)),
+			LabelKeyTaskId:    strconv.Itoa(int(j.task.Id)),
 			labelKeyCtrlBy:    "bazel-build",
 			watcher.TypeLabel: jobType,
 		}),
@@ -365,6 +422,7 @@ func (j *JobBuilder) Build() ([]runtime.Object, error) {
 				k8sfactory.InitContainer(j.credentialSetupContainer),
 				k8sfactory.Container(mainContainer),
 				k8sfactory.Container(j.reportContainer),
+				k8sfactory.Container(j.artifactContainer),
 				k8sfactory.SortVolume(),
 			),
 		),
@@ -372,26 +430,133 @@ func (j *JobBuilder) Build() ([]runtime.Object, error) {
 	return builtObjects, nil
 }
 
+// needReport returns true if the job needs the report container.
+// The report of the test is collected at the trunk or if the failed tests are retried.
+// The coverage report is collected at any revision.
+func needReport(job *config.Job, task *database.Task) bool {
+	switch job.Command {
+	case "test":
+		return task.IsTrunk || job.RetryFlaky > 0
+	case "coverage":
+		return true
+	}
+	return false
+}
+
+// forgeTokenSecret returns the name of Secret for cloning the private repository.
+// If the repository is public or hosted on github.com, forgeTokenSecret returns an empty string.
+func (j *JobBuilder) forgeTokenSecret() string {
+	if !j.repo.Private {
+		return ""
+	}
+	u, err := url.Parse(j.repo.CloneUrl)
+	if err != nil || u.Hostname() == "github.com" {
+		return ""
+	}
+	return j.forgeTokenSecrets[u.Hostname()]
+}
+
 func (j *JobBuilder) makeReportContainer() {
-	if j.job == nil || j.job.Command != "test" {
+	if j.job == nil || j.task == nil || j.reportContainer != nil {
 		return
 	}
-	if j.task == nil || !j.task.IsTrunk {
+	if !needReport(j.job, j.task) {
 		return
 	}
 
-	j.commDirVolume = k8sfactory.NewEmptyDirVolumeSource("comm", "/comm")
+	j.useCommDir()
+	reportArgs := []string{"report", fmt.Sprintf("--event-binary-file=%s/bep", j.commDirVolume.Mount.MountPath), "--startup-timeout=10m"}
+	if j.job.Command == "coverage" {
+		reportArgs = append(reportArgs, fmt.Sprintf("--coverage-report-file=%s/bazel-out/_coverage/_coverage_report.dat", j.workDirVolume.Mount.MountPath))
+	}
 	j.reportContainer = k8sfactory.ContainerFactory(nil,
 		k8sfactory.Name(j.ReportContainerName),
 		k8sfactory.Image(j.sidecarImage, nil),
 		k8sfactory.PullPolicy(corev1.PullIfNotPresent),
 		k8sfactory.Volume(j.commDirVolume),
-		k8sfactory.Args("report", fmt.Sprintf("--event-binary-file=%s/bep", j.commDirVolume.Mount.MountPath), "--startup-timeout=10m"),
+		k8sfactory.Args(reportArgs...),
 	)
+
+	if j.job.Command == "coverage" {
+		// bazel-out in the workspace is the symlink to the output directory.
+		// The report container has to mount both volumes at the same path as the main container for reading the coverage report.
+		j.useOutputVolume()
+		j.reportContainer = k8sfactory.ContainerFactory(j.reportContainer, k8sfactory.Volume(j.workDirVolume), k8sfactory.Volume(j.outputVolume))
+	}
+}
+
+// makeArtifactContainer makes the container for uploading the artifacts.
+// The artifact container uploads the outputs to the object storage after the build has finished.
+func (j *JobBuilder) makeArtifactContainer() {
+	if j.job == nil || j.task == nil || j.artifactContainer != nil {
+		return
+	}
+	if len(j.job.Artifacts) == 0 || j.artifactEndpoint == "" {
+		return
+	}
+
+	j.useCommDir()
+	j.useOutputVolume()
+	args := []string{
+		"artifact",
+		fmt.Sprintf("--event-binary-file=%s/bep", j.commDirVolume.Mount.MountPath),
+		"--startup-timeout=10m",
+		fmt.Sprintf("--work-dir=%s", j.workDirVolume.Mount.MountPath),
+		fmt.Sprintf("--prefix=%s", ArtifactPrefix(j.task)),
+		fmt.Sprintf("--endpoint=%s", j.artifactEndpoint),
+		fmt.Sprintf("--bucket=%s", j.artifactBucket),
+	}
+	for _, v := range j.job.Artifacts {
+		args = append(args, fmt.Sprintf("--pattern=%s", v))
+	}
+	j.artifactContainer = k8sfactory.ContainerFactory(nil,
+		k8sfactory.Name(j.ArtifactContainerName),
+		k8sfactory.Image(j.sidecarImage, nil),
+		k8sfactory.PullPolicy(corev1.PullIfNotPresent),
+		k8sfactory.Volume(j.commDirVolume),
+		k8sfactory.Volume(j.workDirVolume),
+		k8sfactory.Volume(j.outputVolume),
+		// The credential is passed by the environment variables for not appearing in the spec of the pod.
+		k8sfactory.EnvFromSecret(sidecar.ArtifactAccessKeyEnv, j.artifactSecretName, "accesskey"),
+		k8sfactory.EnvFromSecret(sidecar.ArtifactSecretAccessKeyEnv, j.artifactSecretName, "secretkey"),
+		k8sfactory.Args(args...),
+	)
+}
+
+// useCommDir shares the Build Event Protocol file between the main container and the sidecars.
+func (j *JobBuilder) useCommDir() {
+	if j.commDirVolume != nil {
+		return
+	}
+	j.commDirVolume = k8sfactory.NewEmptyDirVolumeSource("comm", "/comm")
 	j.mainContainer = k8sfactory.ContainerFactory(j.mainContainer, k8sfactory.Volume(j.commDirVolume))
 	j.buildPod = k8sfactory.PodFactory(j.buildPod, k8sfactory.Volume(j.commDirVolume))
 }
 
+// useOutputVolume shares the output directory of bazel between the main container and the sidecars.
+func (j *JobBuilder) useOutputVolume() {
+	if j.outputVolume != nil {
+		return
+	}
+	j.outputVolume = k8sfactory.NewEmptyDirVolumeSource("output", "/output")
+	j.mainContainer = k8sfactory.ContainerFactory(j.mainContainer, k8sfactory.Volume(j.outputVolume))
+	j.buildPod = k8sfactory.PodFactory(j.buildPod, k8sfactory.Volume(j.outputVolume))
+}
+
+// ArtifactPrefix returns the prefix of the object name of the artifacts.
+func ArtifactPrefix(task *database.Task) string {
+	return fmt.Sprintf("artifacts/%d", task.Id)
+}
+
+func hasFlag(flags []string, name string) bool {
+	for _, v := range flags {
+		if v == "--"+name || strings.HasPrefix(v, "--"+name+"=") {
+			return true
+		}
+	}
+	return false
+}
+
 func (j *JobBuilder) injectSecret() {
 	if j.job == nil || j.task == nil || j.platform == "" {
 		return
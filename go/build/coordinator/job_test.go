@@ -41,6 +41,28 @@ func TestJobBuilder_Clone(t *testing.T) {
 	assert.False(t, n.useBazelisk)
 }
 
+func TestNeedReport(t *testing.T) {
+	cases := []struct {
+		Command    string
+		IsTrunk    bool
+		RetryFlaky int
+		Expect     bool
+	}{
+		{Command: "test", IsTrunk: true, Expect: true},
+		{Command: "test", Expect: false},
+		// The report is needed to retry the failed tests even if the task is not for the trunk.
+		{Command: "test", RetryFlaky: 2, Expect: true},
+		{Command: "coverage", Expect: true},
+		{Command: "build", IsTrunk: true, Expect: false},
+	}
+
+	for _, tc := range cases {
+		job := &config.Job{Command: tc.Command, RetryFlaky: tc.RetryFlaky}
+		task := &database.Task{IsTrunk: tc.IsTrunk}
+		assert.Equal(t, tc.Expect, needReport(job, task), "%+v", tc)
+	}
+}
+
 func TestJobBuilder(t *testing.T) {
 	job, repo, task, saObject, jobObject := testJobBuilderFixtures()
 
@@ -109,6 +131,91 @@ func TestJobBuilder(t *testing.T) {
 				},
 			},
 		},
+		{
+			Mutation: func(j *config.Job, r *database.SourceRepository, ta *database.Task) (*config.Job, *database.SourceRepository, *database.Task) {
+				j.Command = "build"
+				ta.Command = "build"
+				return j, r, ta
+			},
+			Platform:      "@io_bazel_rules_go//go/toolchain:linux_amd64",
+			ExpectObjects: []runtime.Object{saObject, jobObject},
+			ObjectMutation: map[runtime.Object][]k8sfactory.Trait{
+				jobObject: {
+					RemoveContainer("report"),
+					RemoveVolume("comm"),
+					k8sfactory.OnContainer("main", k8sfactory.Args("build", "--remote_cache=127.0.0.1:4567", "--experimental_remote_downloader=127.0.0.1:4567", "--platforms=@io_bazel_rules_go//go/toolchain:linux_amd64", "--", "//...")),
+				},
+			},
+		},
+		{
+			Mutation: func(j *config.Job, r *database.SourceRepository, ta *database.Task) (*config.Job, *database.SourceRepository, *database.Task) {
+				j.Flags = []string{"--test_output=errors", "--nocache_test_results"}
+				return j, r, ta
+			},
+			Platform:      "@io_bazel_rules_go//go/toolchain:linux_amd64",
+			ExpectObjects: []runtime.Object{saObject, jobObject},
+			ObjectMutation: map[runtime.Object][]k8sfactory.Trait{
+				jobObject: {
+					k8sfactory.OnContainer("main", AddArgsBefore("--", "--test_output=errors", "--nocache_test_results")),
+				},
+			},
+		},
+		{
+			Mutation: func(j *config.Job, r *database.SourceRepository, ta *database.Task) (*config.Job, *database.SourceRepository, *database.Task) {
+				j.Command = "coverage"
+				ta.Command = "coverage"
+				return j, r, ta
+			},
+			Platform:      "@io_bazel_rules_go//go/toolchain:linux_amd64",
+			ExpectObjects: []runtime.Object{saObject, jobObject},
+			ObjectMutation: map[runtime.Object][]k8sfactory.Trait{
+				jobObject: {
+					k8sfactory.OnContainer("main",
+						k8sfactory.Args("--output_user_root=/output", "coverage", "--remote_cache=127.0.0.1:4567", "--experimental_remote_downloader=127.0.0.1:4567", "--platforms=@io_bazel_rules_go//go/toolchain:linux_amd64", "--build_event_binary_file=/comm/bep", "--cache_test_results=no", "--combined_report=lcov", "--", "//..."),
+						k8sfactory.Volume(&k8sfactory.VolumeSource{Mount: corev1.VolumeMount{Name: "output", MountPath: "/output"}}),
+					),
+					k8sfactory.OnContainer("report",
+						AddArgs("--coverage-report-file=/work/bazel-out/_coverage/_coverage_report.dat"),
+						k8sfactory.Volume(&k8sfactory.VolumeSource{Mount: corev1.VolumeMount{Name: "workdir", MountPath: "/work"}}),
+						k8sfactory.Volume(&k8sfactory.VolumeSource{Mount: corev1.VolumeMount{Name: "output", MountPath: "/output"}}),
+					),
+					AddEmptyVolume("output"),
+					k8sfactory.SortVolume(),
+				},
+			},
+		},
+		{
+			Mutation: func(j *config.Job, r *database.SourceRepository, ta *database.Task) (*config.Job, *database.SourceRepository, *database.Task) {
+				j.Command = "build"
+				ta.Command = "build"
+				j.Artifacts = []string{"cmd/*/*_/*"}
+				return j, r, ta
+			},
+			Platform:      "@io_bazel_rules_go//go/toolchain:linux_amd64",
+			ExpectObjects: []runtime.Object{saObject, jobObject},
+			ObjectMutation: map[runtime.Object][]k8sfactory.Trait{
+				jobObject: {
+					RemoveContainer("report"),
+					k8sfactory.OnContainer("main",
+						k8sfactory.Args("--output_user_root=/output", "build", "--remote_cache=127.0.0.1:4567", "--experimental_remote_downloader=127.0.0.1:4567", "--platforms=@io_bazel_rules_go//go/toolchain:linux_amd64", "--build_event_binary_file=/comm/bep", "--", "//..."),
+						k8sfactory.Volume(&k8sfactory.VolumeSource{Mount: corev1.VolumeMount{Name: "output", MountPath: "/output"}}),
+					),
+					AddContainer(k8sfactory.ContainerFactory(nil,
+						k8sfactory.Name("artifact"),
+						k8sfactory.Image("registry/sidecar", nil),
+						k8sfactory.PullPolicy(corev1.PullIfNotPresent),
+						k8sfactory.Volume(&k8sfactory.VolumeSource{Mount: corev1.VolumeMount{Name: "comm", MountPath: "/comm"}}),
+						k8sfactory.Volume(&k8sfactory.VolumeSource{Mount: corev1.VolumeMount{Name: "workdir", MountPath: "/work"}}),
+						k8sfactory.Volume(&k8sfactory.VolumeSource{Mount: corev1.VolumeMount{Name: "output", MountPath: "/output"}}),
+						k8sfactory.EnvFromSecret("ARTIFACT_ACCESS_KEY", "artifact-secret", "accesskey"),
+						k8sfactory.EnvFromSecret("ARTIFACT_SECRET_ACCESS_KEY", "artifact-secret", "secretkey"),
+						k8sfactory.Args("artifact", "--event-binary-file=/comm/bep", "--startup-timeout=10m", "--work-dir=/work", "--prefix=artifacts/100", "--endpoint=http://minio.default.svc:9000", "--bucket=logs", "--pattern=cmd/*/*_/*"),
+					)),
+					AddEmptyVolume("output"),
+					k8sfactory.SortVolume(),
+				},
+			},
+		},
 		{
 			Mutation: func(j *config.Job, r *database.SourceRepository, ta *database.Task) (*config.Job, *database.SourceRepository, *database.Task) {
 				ta.IsTrunk = false
@@ -162,6 +269,24 @@ func TestJobBuilder(t *testing.T) {
 				},
 			},
 		},
+		{
+			Mutation: func(j *config.Job, r *database.SourceRepository, ta *database.Task) (*config.Job, *database.SourceRepository, *database.Task) {
+				r.Private = true
+				r.CloneUrl = "https://gitea.example.com/f110/example.git"
+				return j, r, ta
+			},
+			Platform:      "@io_bazel_rules_go//go/toolchain:linux_amd64",
+			ExpectObjects: []runtime.Object{saObject, jobObject},
+			ObjectMutation: map[runtime.Object][]k8sfactory.Trait{
+				jobObject: {
+					AddSecretVolume("pre-process", "forge-secret", "gitea-token", "/etc/forge"),
+					k8sfactory.SortVolume(),
+					k8sfactory.OnContainer("pre-process",
+						k8sfactory.Args("clone", "--work-dir=/work", "--url=https://gitea.example.com/f110/example.git", "--commit=e192aef54cb0d31afd7cae64b079be2a12a56a74", "--token-file=/etc/forge/token"),
+					),
+				},
+			},
+		},
 		{
 			Mutation: func(j *config.Job, r *database.SourceRepository, ta *database.Task) (*config.Job, *database.SourceRepository, *database.Task) {
 				ta.ConfigName = "e2e"
@@ -247,6 +372,17 @@ func TestJobBuilder(t *testing.T) {
 				jobObject: {k8sfactory.OnContainer("main", k8sfactory.Image("example.com/bazel:bazelisk", nil))},
 			},
 		},
+		{
+			Mutation: func(j *config.Job, r *database.SourceRepository, ta *database.Task) (*config.Job, *database.SourceRepository, *database.Task) {
+				ta.BazelVersion = "7.0.0"
+				return j, r, ta
+			},
+			Platform:      "@io_bazel_rules_go//go/toolchain:linux_amd64",
+			ExpectObjects: []runtime.Object{saObject, jobObject},
+			ObjectMutation: map[runtime.Object][]k8sfactory.Trait{
+				jobObject: {k8sfactory.OnContainer("main", k8sfactory.EnvVar("USE_BAZEL_VERSION", "7.0.0"))},
+			},
+		},
 	}
 
 	require.NoError(t, logger.Init())
@@ -258,10 +394,12 @@ func TestJobBuilder(t *testing.T) {
 			b.UseBazelisk()
 			b.PullAlways()
 			b.GitHubApp(2, 20, "githubapp-secret")
+			b.ForgeToken("gitea.example.com", "gitea-token")
 			b.DefaultLimit(resource.MustParse("1000m"), resource.MustParse("1024Mi"))
 			b.EnableRemoteCache("127.0.0.1:4567")
 			b.EnableRemoteAssetAPI()
 			b.Vault("https://127.0.0.1:7000")
+			b.ArtifactStorage("http://minio.default.svc:9000", "logs", "artifact-secret")
 
 			var j *config.Job
 			var r *database.SourceRepository
@@ -402,6 +540,19 @@ func AddCSIVolume(name string, csi *corev1.CSIVolumeSource) k8sfactory.Trait {
 	return fn
 }
 
+func AddContainer(c *corev1.Container) k8sfactory.Trait {
+	var fn k8sfactory.Trait
+	fn = func(obj any) {
+		switch v := obj.(type) {
+		case *corev1.PodSpec:
+			v.Containers = append(v.Containers, *c)
+		case *batchv1.Job:
+			fn(&v.Spec.Template.Spec)
+		}
+	}
+	return fn
+}
+
 func AddEmptyVolume(name string) k8sfactory.Trait {
 	var fn k8sfactory.Trait
 	fn = func(obj any) {
@@ -521,7 +672,7 @@ func testJobBuilderFixtures() (*config.Job, *database.SourceRepository, *databas
 			Labels: map[string]string{
 				labelKeyRepoId:    fmt.Sprintf("%d", repo.Id),
 				labelKeyJobId:     job.Identification(),
-				labelKeyTaskId:    fmt.Sprintf("%d", task.Id),
+				LabelKeyTaskId:    fmt.Sprintf("%d", task.Id),
 				labelKeyCtrlBy:    "bazel-build",
 				watcher.TypeLabel: "bazelBuilder",
 			},
@@ -529,7 +680,7 @@ func testJobBuilderFixtures() (*config.Job, *database.SourceRepository, *databas
 		Spec: batchv1.JobSpec{
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{labelKeyCtrlBy: "bazel-build", labelKeyTaskId: fmt.Sprintf("%d", task.Id)},
+					Labels: map[string]string{labelKeyCtrlBy: "bazel-build", LabelKeyTaskId: fmt.Sprintf("%d", task.Id)},
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: "build-" + job.RepositoryName,
@@ -150,6 +150,24 @@ func (d *Task) RegisterListByRevision(repositoryId int32, revision string, value
 	d.Register("ListByRevision", map[string]interface{}{"repositoryId": repositoryId, "revision": revision}, value, err)
 }
 
+func (d *Task) ListByPullRequest(ctx context.Context, repositoryId int32, pullRequest int32, opt ...dao.ListOption) ([]*database.Task, error) {
+	v, err := d.Call("ListByPullRequest", map[string]interface{}{"repositoryId": repositoryId, "pullRequest": pullRequest})
+	return v.([]*database.Task), err
+}
+
+func (d *Task) RegisterListByPullRequest(repositoryId int32, pullRequest int32, value []*database.Task, err error) {
+	d.Register("ListByPullRequest", map[string]interface{}{"repositoryId": repositoryId, "pullRequest": pullRequest}, value, err)
+}
+
+func (d *Task) ListByGroupId(ctx context.Context, groupId int32, opt ...dao.ListOption) ([]*database.Task, error) {
+	v, err := d.Call("ListByGroupId", map[string]interface{}{"groupId": groupId})
+	return v.([]*database.Task), err
+}
+
+func (d *Task) RegisterListByGroupId(groupId int32, value []*database.Task, err error) {
+	d.Register("ListByGroupId", map[string]interface{}{"groupId": groupId}, value, err)
+}
+
 func (d *Task) Create(ctx context.Context, task *database.Task, opt ...dao.ExecOption) (*database.Task, error) {
 	_, _ = d.Call("Create", map[string]interface{}{"task": task})
 	return task, nil
@@ -204,13 +222,13 @@ func (d *TrustedUser) RegisterListAll(value []*database.TrustedUser, err error)
 	d.Register("ListAll", map[string]interface{}{}, value, err)
 }
 
-func (d *TrustedUser) ListByGithubId(ctx context.Context, githubId int64, opt ...dao.ListOption) ([]*database.TrustedUser, error) {
-	v, err := d.Call("ListByGithubId", map[string]interface{}{"githubId": githubId})
+func (d *TrustedUser) ListByHostAndGithubId(ctx context.Context, host string, githubId int64, opt ...dao.ListOption) ([]*database.TrustedUser, error) {
+	v, err := d.Call("ListByHostAndGithubId", map[string]interface{}{"host": host, "githubId": githubId})
 	return v.([]*database.TrustedUser), err
 }
 
-func (d *TrustedUser) RegisterListByGithubId(githubId int64, value []*database.TrustedUser, err error) {
-	d.Register("ListByGithubId", map[string]interface{}{"githubId": githubId}, value, err)
+func (d *TrustedUser) RegisterListByHostAndGithubId(host string, githubId int64, value []*database.TrustedUser, err error) {
+	d.Register("ListByHostAndGithubId", map[string]interface{}{"host": host, "githubId": githubId}, value, err)
 }
 
 func (d *TrustedUser) Create(ctx context.Context, trustedUser *database.TrustedUser, opt ...dao.ExecOption) (*database.TrustedUser, error) {
@@ -258,13 +276,13 @@ func (d *PermitPullRequest) RegisterSelectMulti(id []int32, value []*database.Pe
 	d.Register("SelectMulti", map[string]interface{}{"id": id}, value, nil)
 }
 
-func (d *PermitPullRequest) ListByRepositoryAndNumber(ctx context.Context, repository string, number int32, opt ...dao.ListOption) ([]*database.PermitPullRequest, error) {
-	v, err := d.Call("ListByRepositoryAndNumber", map[string]interface{}{"repository": repository, "number": number})
+func (d *PermitPullRequest) ListByHostAndRepositoryAndNumber(ctx context.Context, host string, repository string, number int32, opt ...dao.ListOption) ([]*database.PermitPullRequest, error) {
+	v, err := d.Call("ListByHostAndRepositoryAndNumber", map[string]interface{}{"host": host, "repository": repository, "number": number})
 	return v.([]*database.PermitPullRequest), err
 }
 
-func (d *PermitPullRequest) RegisterListByRepositoryAndNumber(repository string, number int32, value []*database.PermitPullRequest, err error) {
-	d.Register("ListByRepositoryAndNumber", map[string]interface{}{"repository": repository, "number": number}, value, err)
+func (d *PermitPullRequest) RegisterListByHostAndRepositoryAndNumber(host string, repository string, number int32, value []*database.PermitPullRequest, err error) {
+	d.Register("ListByHostAndRepositoryAndNumber", map[string]interface{}{"host": host, "repository": repository, "number": number}, value, err)
 }
 
 func (d *PermitPullRequest) Create(ctx context.Context, permitPullRequest *database.PermitPullRequest, opt ...dao.ExecOption) (*database.PermitPullRequest, error) {
@@ -321,6 +339,15 @@ func (d *TestReport) RegisterListByTaskId(taskId int32, value []*database.TestRe
 	d.Register("ListByTaskId", map[string]interface{}{"taskId": taskId}, value, err)
 }
 
+func (d *TestReport) ListByLabel(ctx context.Context, repositoryId int32, label string, opt ...dao.ListOption) ([]*database.TestReport, error) {
+	v, err := d.Call("ListByLabel", map[string]interface{}{"repositoryId": repositoryId, "label": label})
+	return v.([]*database.TestReport), err
+}
+
+func (d *TestReport) RegisterListByLabel(repositoryId int32, label string, value []*database.TestReport, err error) {
+	d.Register("ListByLabel", map[string]interface{}{"repositoryId": repositoryId, "label": label}, value, err)
+}
+
 func (d *TestReport) Create(ctx context.Context, testReport *database.TestReport, opt ...dao.ExecOption) (*database.TestReport, error) {
 	_, _ = d.Call("Create", map[string]interface{}{"testReport": testReport})
 	return testReport, nil
@@ -335,3 +362,237 @@ func (d *TestReport) Update(ctx context.Context, testReport *database.TestReport
 	_, _ = d.Call("Update", map[string]interface{}{"testReport": testReport})
 	return nil
 }
+
+type FlakyTest struct {
+	*mock.Mock
+}
+
+func NewFlakyTest() *FlakyTest {
+	return &FlakyTest{Mock: mock.New()}
+}
+
+func (d *FlakyTest) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return nil
+}
+
+func (d *FlakyTest) Select(ctx context.Context, id int32) (*database.FlakyTest, error) {
+	v, err := d.Call("Select", map[string]interface{}{"id": id})
+	return v.(*database.FlakyTest), err
+}
+
+func (d *FlakyTest) RegisterSelect(id int32, value *database.FlakyTest) {
+	d.Register("Select", map[string]interface{}{"id": id}, value, nil)
+}
+
+func (d *FlakyTest) SelectMulti(ctx context.Context, id ...int32) ([]*database.FlakyTest, error) {
+	v, err := d.Call("SelectMulti", map[string]interface{}{"id": id})
+	return v.([]*database.FlakyTest), err
+}
+
+func (d *FlakyTest) RegisterSelectMulti(id []int32, value []*database.FlakyTest) {
+	d.Register("SelectMulti", map[string]interface{}{"id": id}, value, nil)
+}
+
+func (d *FlakyTest) ListByRepositoryId(ctx context.Context, repositoryId int32, opt ...dao.ListOption) ([]*database.FlakyTest, error) {
+	v, err := d.Call("ListByRepositoryId", map[string]interface{}{"repositoryId": repositoryId})
+	return v.([]*database.FlakyTest), err
+}
+
+func (d *FlakyTest) RegisterListByRepositoryId(repositoryId int32, value []*database.FlakyTest, err error) {
+	d.Register("ListByRepositoryId", map[string]interface{}{"repositoryId": repositoryId}, value, err)
+}
+
+func (d *FlakyTest) ListByLabel(ctx context.Context, repositoryId int32, label string, opt ...dao.ListOption) ([]*database.FlakyTest, error) {
+	v, err := d.Call("ListByLabel", map[string]interface{}{"repositoryId": repositoryId, "label": label})
+	return v.([]*database.FlakyTest), err
+}
+
+func (d *FlakyTest) RegisterListByLabel(repositoryId int32, label string, value []*database.FlakyTest, err error) {
+	d.Register("ListByLabel", map[string]interface{}{"repositoryId": repositoryId, "label": label}, value, err)
+}
+
+func (d *FlakyTest) Create(ctx context.Context, flakyTest *database.FlakyTest, opt ...dao.ExecOption) (*database.FlakyTest, error) {
+	_, _ = d.Call("Create", map[string]interface{}{"flakyTest": flakyTest})
+	return flakyTest, nil
+}
+
+func (d *FlakyTest) Delete(ctx context.Context, id int32, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Delete", map[string]interface{}{"id": id})
+	return nil
+}
+
+func (d *FlakyTest) Update(ctx context.Context, flakyTest *database.FlakyTest, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Update", map[string]interface{}{"flakyTest": flakyTest})
+	return nil
+}
+
+type CoverageReport struct {
+	*mock.Mock
+}
+
+func NewCoverageReport() *CoverageReport {
+	return &CoverageReport{Mock: mock.New()}
+}
+
+func (d *CoverageReport) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return nil
+}
+
+func (d *CoverageReport) Select(ctx context.Context, id int32) (*database.CoverageReport, error) {
+	v, err := d.Call("Select", map[string]interface{}{"id": id})
+	return v.(*database.CoverageReport), err
+}
+
+func (d *CoverageReport) RegisterSelect(id int32, value *database.CoverageReport) {
+	d.Register("Select", map[string]interface{}{"id": id}, value, nil)
+}
+
+func (d *CoverageReport) SelectMulti(ctx context.Context, id ...int32) ([]*database.CoverageReport, error) {
+	v, err := d.Call("SelectMulti", map[string]interface{}{"id": id})
+	return v.([]*database.CoverageReport), err
+}
+
+func (d *CoverageReport) RegisterSelectMulti(id []int32, value []*database.CoverageReport) {
+	d.Register("SelectMulti", map[string]interface{}{"id": id}, value, nil)
+}
+
+func (d *CoverageReport) ListByTaskId(ctx context.Context, taskId int32, opt ...dao.ListOption) ([]*database.CoverageReport, error) {
+	v, err := d.Call("ListByTaskId", map[string]interface{}{"taskId": taskId})
+	return v.([]*database.CoverageReport), err
+}
+
+func (d *CoverageReport) RegisterListByTaskId(taskId int32, value []*database.CoverageReport, err error) {
+	d.Register("ListByTaskId", map[string]interface{}{"taskId": taskId}, value, err)
+}
+
+func (d *CoverageReport) Create(ctx context.Context, coverageReport *database.CoverageReport, opt ...dao.ExecOption) (*database.CoverageReport, error) {
+	_, _ = d.Call("Create", map[string]interface{}{"coverageReport": coverageReport})
+	return coverageReport, nil
+}
+
+func (d *CoverageReport) Delete(ctx context.Context, id int32, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Delete", map[string]interface{}{"id": id})
+	return nil
+}
+
+func (d *CoverageReport) Update(ctx context.Context, coverageReport *database.CoverageReport, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Update", map[string]interface{}{"coverageReport": coverageReport})
+	return nil
+}
+
+type Artifact struct {
+	*mock.Mock
+}
+
+func NewArtifact() *Artifact {
+	return &Artifact{Mock: mock.New()}
+}
+
+func (d *Artifact) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return nil
+}
+
+func (d *Artifact) Select(ctx context.Context, id int32) (*database.Artifact, error) {
+	v, err := d.Call("Select", map[string]interface{}{"id": id})
+	return v.(*database.Artifact), err
+}
+
+func (d *Artifact) RegisterSelect(id int32, value *database.Artifact) {
+	d.Register("Select", map[string]interface{}{"id": id}, value, nil)
+}
+
+func (d *Artifact) SelectMulti(ctx context.Context, id ...int32) ([]*database.Artifact, error) {
+	v, err := d.Call("SelectMulti", map[string]interface{}{"id": id})
+	return v.([]*database.Artifact), err
+}
+
+func (d *Artifact) RegisterSelectMulti(id []int32, value []*database.Artifact) {
+	d.Register("SelectMulti", map[string]interface{}{"id": id}, value, nil)
+}
+
+func (d *Artifact) ListByTaskId(ctx context.Context, taskId int32, opt ...dao.ListOption) ([]*database.Artifact, error) {
+	v, err := d.Call("ListByTaskId", map[string]interface{}{"taskId": taskId})
+	return v.([]*database.Artifact), err
+}
+
+func (d *Artifact) RegisterListByTaskId(taskId int32, value []*database.Artifact, err error) {
+	d.Register("ListByTaskId", map[string]interface{}{"taskId": taskId}, value, err)
+}
+
+func (d *Artifact) Create(ctx context.Context, artifact *database.Artifact, opt ...dao.ExecOption) (*database.Artifact, error) {
+	_, _ = d.Call("Create", map[string]interface{}{"artifact": artifact})
+	return artifact, nil
+}
+
+func (d *Artifact) Delete(ctx context.Context, id int32, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Delete", map[string]interface{}{"id": id})
+	return nil
+}
+
+func (d *Artifact) Update(ctx context.Context, artifact *database.Artifact, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Update", map[string]interface{}{"artifact": artifact})
+	return nil
+}
+
+type JobSchedule struct {
+	*mock.Mock
+}
+
+func NewJobSchedule() *JobSchedule {
+	return &JobSchedule{Mock: mock.New()}
+}
+
+func (d *JobSchedule) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return nil
+}
+
+func (d *JobSchedule) Select(ctx context.Context, id int32) (*database.JobSchedule, error) {
+	v, err := d.Call("Select", map[string]interface{}{"id": id})
+	return v.(*database.JobSchedule), err
+}
+
+func (d *JobSchedule) RegisterSelect(id int32, value *database.JobSchedule) {
+	d.Register("Select", map[string]interface{}{"id": id}, value, nil)
+}
+
+func (d *JobSchedule) SelectMulti(ctx context.Context, id ...int32) ([]*database.JobSchedule, error) {
+	v, err := d.Call("SelectMulti", map[string]interface{}{"id": id})
+	return v.([]*database.JobSchedule), err
+}
+
+func (d *JobSchedule) RegisterSelectMulti(id []int32, value []*database.JobSchedule) {
+	d.Register("SelectMulti", map[string]interface{}{"id": id}, value, nil)
+}
+
+func (d *JobSchedule) ListAll(ctx context.Context, opt ...dao.ListOption) ([]*database.JobSchedule, error) {
+	v, err := d.Call("ListAll", map[string]interface{}{})
+	return v.([]*database.JobSchedule), err
+}
+
+func (d *JobSchedule) RegisterListAll(value []*database.JobSchedule, err error) {
+	d.Register("ListAll", map[string]interface{}{}, value, err)
+}
+
+func (d *JobSchedule) ListByRepositoryId(ctx context.Context, repositoryId int32, opt ...dao.ListOption) ([]*database.JobSchedule, error) {
+	v, err := d.Call("ListByRepositoryId", map[string]interface{}{"repositoryId": repositoryId})
+	return v.([]*database.JobSchedule), err
+}
+
+func (d *JobSchedule) RegisterListByRepositoryId(repositoryId int32, value []*database.JobSchedule, err error) {
+	d.Register("ListByRepositoryId", map[string]interface{}{"repositoryId": repositoryId}, value, err)
+}
+
+func (d *JobSchedule) Create(ctx context.Context, jobSchedule *database.JobSchedule, opt ...dao.ExecOption) (*database.JobSchedule, error) {
+	_, _ = d.Call("Create", map[string]interface{}{"jobSchedule": jobSchedule})
+	return jobSchedule, nil
+}
+
+func (d *JobSchedule) Delete(ctx context.Context, id int32, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Delete", map[string]interface{}{"id": id})
+	return nil
+}
+
+func (d *JobSchedule) Update(ctx context.Context, jobSchedule *database.JobSchedule, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Update", map[string]interface{}{"jobSchedule": jobSchedule})
+	return nil
+}
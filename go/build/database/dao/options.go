@@ -10,6 +10,10 @@ type Options struct {
 	TrustedUser       TrustedUserInterface
 	PermitPullRequest PermitPullRequestInterface
 	TestReport        TestReportInterface
+	CoverageReport    CoverageReportInterface
+	JobSchedule       JobScheduleInterface
+	Artifact          ArtifactInterface
+	FlakyTest         FlakyTestInterface
 
 	RawConnection *sql.DB
 }
@@ -21,6 +25,10 @@ func NewOptions(conn *sql.DB) Options {
 		TrustedUser:       NewTrustedUser(conn),
 		PermitPullRequest: NewPermitPullRequest(conn),
 		TestReport:        NewTestReport(conn),
+		CoverageReport:    NewCoverageReport(conn),
+		JobSchedule:       NewJobSchedule(conn),
+		Artifact:          NewArtifact(conn),
+		FlakyTest:         NewFlakyTest(conn),
 		RawConnection:     conn,
 	}
 }
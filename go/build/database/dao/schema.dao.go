@@ -115,7 +115,7 @@ func (d *SourceRepository) Select(ctx context.Context, id int32) (*database.Sour
 	row := d.conn.QueryRowContext(ctx, "SELECT * FROM `source_repository` WHERE `id` = ?", id)
 
 	v := &database.SourceRepository{}
-	if err := row.Scan(&v.Id, &v.Url, &v.CloneUrl, &v.Name, &v.Private, &v.CreatedAt, &v.UpdatedAt); err != nil {
+	if err := row.Scan(&v.Id, &v.Url, &v.CloneUrl, &v.Name, &v.Private, &v.ArtifactRetentionDays, &v.CreatedAt, &v.UpdatedAt); err != nil {
 		return nil, err
 	}
 
@@ -137,7 +137,7 @@ func (d *SourceRepository) SelectMulti(ctx context.Context, id ...int32) ([]*dat
 	res := make([]*database.SourceRepository, 0, len(id))
 	for rows.Next() {
 		r := &database.SourceRepository{}
-		if err := rows.Scan(&r.Id, &r.Url, &r.CloneUrl, &r.Name, &r.Private, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.Url, &r.CloneUrl, &r.Name, &r.Private, &r.ArtifactRetentionDays, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		res = append(res, r)
@@ -148,7 +148,7 @@ func (d *SourceRepository) SelectMulti(ctx context.Context, id ...int32) ([]*dat
 
 func (d *SourceRepository) ListAll(ctx context.Context, opt ...ListOption) ([]*database.SourceRepository, error) {
 	listOpts := newListOpt(opt...)
-	query := "SELECT `id`, `url`, `clone_url`, `name`, `private`, `created_at`, `updated_at` FROM `source_repository`"
+	query := "SELECT `id`, `url`, `clone_url`, `name`, `private`, `artifact_retention_days`, `created_at`, `updated_at` FROM `source_repository`"
 	orderCol := "`" + listOpts.sort + "`"
 	if listOpts.sort == "" {
 		orderCol = "`id`"
@@ -172,7 +172,7 @@ func (d *SourceRepository) ListAll(ctx context.Context, opt ...ListOption) ([]*d
 	res := make([]*database.SourceRepository, 0)
 	for rows.Next() {
 		r := &database.SourceRepository{}
-		if err := rows.Scan(&r.Id, &r.Url, &r.CloneUrl, &r.Name, &r.Private, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.Url, &r.CloneUrl, &r.Name, &r.Private, &r.ArtifactRetentionDays, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		r.ResetMark()
@@ -184,7 +184,7 @@ func (d *SourceRepository) ListAll(ctx context.Context, opt ...ListOption) ([]*d
 
 func (d *SourceRepository) ListByUrl(ctx context.Context, url string, opt ...ListOption) ([]*database.SourceRepository, error) {
 	listOpts := newListOpt(opt...)
-	query := "SELECT `id`, `url`, `clone_url`, `name`, `private`, `created_at`, `updated_at` FROM `source_repository` WHERE `url` = ?"
+	query := "SELECT `id`, `url`, `clone_url`, `name`, `private`, `artifact_retention_days`, `created_at`, `updated_at` FROM `source_repository` WHERE `url` = ?"
 	orderCol := "`" + listOpts.sort + "`"
 	if listOpts.sort == "" {
 		orderCol = "`id`"
@@ -209,7 +209,7 @@ func (d *SourceRepository) ListByUrl(ctx context.Context, url string, opt ...Lis
 	res := make([]*database.SourceRepository, 0)
 	for rows.Next() {
 		r := &database.SourceRepository{}
-		if err := rows.Scan(&r.Id, &r.Url, &r.CloneUrl, &r.Name, &r.Private, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.Url, &r.CloneUrl, &r.Name, &r.Private, &r.ArtifactRetentionDays, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		r.ResetMark()
@@ -230,8 +230,8 @@ func (d *SourceRepository) Create(ctx context.Context, sourceRepository *databas
 
 	res, err := conn.ExecContext(
 		ctx,
-		"INSERT INTO `source_repository` (`url`, `clone_url`, `name`, `private`, `created_at`) VALUES (?, ?, ?, ?, ?)",
-		sourceRepository.Url, sourceRepository.CloneUrl, sourceRepository.Name, sourceRepository.Private, time.Now(),
+		"INSERT INTO `source_repository` (`url`, `clone_url`, `name`, `private`, `artifact_retention_days`, `created_at`) VALUES (?, ?, ?, ?, ?, ?)",
+		sourceRepository.Url, sourceRepository.CloneUrl, sourceRepository.Name, sourceRepository.Private, sourceRepository.ArtifactRetentionDays, time.Now(),
 	)
 	if err != nil {
 		return nil, err
@@ -334,6 +334,8 @@ type TaskInterface interface {
 	ListPending(ctx context.Context, opt ...ListOption) ([]*database.Task, error)
 	ListUniqJobName(ctx context.Context, repositoryId int32, opt ...ListOption) ([]*database.Task, error)
 	ListByRevision(ctx context.Context, repositoryId int32, revision string, opt ...ListOption) ([]*database.Task, error)
+	ListByPullRequest(ctx context.Context, repositoryId int32, pullRequest int32, opt ...ListOption) ([]*database.Task, error)
+	ListByGroupId(ctx context.Context, groupId int32, opt ...ListOption) ([]*database.Task, error)
 	Create(ctx context.Context, task *database.Task, opt ...ExecOption) (*database.Task, error)
 	Update(ctx context.Context, task *database.Task, opt ...ExecOption) error
 	Delete(ctx context.Context, id int32, opt ...ExecOption) error
@@ -371,7 +373,7 @@ func (d *Task) Select(ctx context.Context, id int32) (*database.Task, error) {
 	row := d.conn.QueryRowContext(ctx, "SELECT * FROM `task` WHERE `id` = ?", id)
 
 	v := &database.Task{}
-	if err := row.Scan(&v.Id, &v.RepositoryId, &v.JobName, &v.JobConfiguration, &v.ParsedJobConfiguration, &v.Revision, &v.IsTrunk, &v.BazelVersion, &v.Success, &v.LogFile, &v.Command, &v.Target, &v.Targets, &v.Platform, &v.Via, &v.ConfigName, &v.Node, &v.Manifest, &v.Container, &v.ExecutedTestsCount, &v.SucceededTestsCount, &v.StartAt, &v.FinishedAt, &v.CreatedAt, &v.UpdatedAt); err != nil {
+	if err := row.Scan(&v.Id, &v.RepositoryId, &v.JobName, &v.JobConfiguration, &v.ParsedJobConfiguration, &v.Revision, &v.IsTrunk, &v.BazelVersion, &v.Success, &v.LogFile, &v.Command, &v.Target, &v.Targets, &v.Platform, &v.Via, &v.ConfigName, &v.Node, &v.Manifest, &v.Container, &v.ExecutedTestsCount, &v.SucceededTestsCount, &v.StartAt, &v.FinishedAt, &v.Canceled, &v.LinesFound, &v.LinesHit, &v.PullRequest, &v.SupersededBy, &v.Attempt, &v.RetryOf, &v.Matrix, &v.GroupId, &v.CreatedAt, &v.UpdatedAt); err != nil {
 		return nil, err
 	}
 
@@ -399,7 +401,7 @@ func (d *Task) SelectMulti(ctx context.Context, id ...int32) ([]*database.Task,
 	res := make([]*database.Task, 0, len(id))
 	for rows.Next() {
 		r := &database.Task{}
-		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.Canceled, &r.LinesFound, &r.LinesHit, &r.PullRequest, &r.SupersededBy, &r.Attempt, &r.RetryOf, &r.Matrix, &r.GroupId, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		res = append(res, r)
@@ -426,7 +428,7 @@ func (d *Task) SelectMulti(ctx context.Context, id ...int32) ([]*database.Task,
 
 func (d *Task) ListAll(ctx context.Context, opt ...ListOption) ([]*database.Task, error) {
 	listOpts := newListOpt(opt...)
-	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `created_at`, `updated_at` FROM `task`"
+	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `canceled`, `lines_found`, `lines_hit`, `pull_request`, `superseded_by`, `attempt`, `retry_of`, `matrix`, `group_id`, `created_at`, `updated_at` FROM `task`"
 	orderCol := "`" + listOpts.sort + "`"
 	if listOpts.sort == "" {
 		orderCol = "`id`"
@@ -450,7 +452,7 @@ func (d *Task) ListAll(ctx context.Context, opt ...ListOption) ([]*database.Task
 	res := make([]*database.Task, 0)
 	for rows.Next() {
 		r := &database.Task{}
-		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.Canceled, &r.LinesFound, &r.LinesHit, &r.PullRequest, &r.SupersededBy, &r.Attempt, &r.RetryOf, &r.Matrix, &r.GroupId, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		r.ResetMark()
@@ -478,7 +480,7 @@ func (d *Task) ListAll(ctx context.Context, opt ...ListOption) ([]*database.Task
 
 func (d *Task) ListByRepositoryId(ctx context.Context, repositoryId int32, opt ...ListOption) ([]*database.Task, error) {
 	listOpts := newListOpt(opt...)
-	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `created_at`, `updated_at` FROM `task` WHERE `repository_id` = ?"
+	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `canceled`, `lines_found`, `lines_hit`, `pull_request`, `superseded_by`, `attempt`, `retry_of`, `matrix`, `group_id`, `created_at`, `updated_at` FROM `task` WHERE `repository_id` = ?"
 	orderCol := "`" + listOpts.sort + "`"
 	if listOpts.sort == "" {
 		orderCol = "`id`"
@@ -503,7 +505,7 @@ func (d *Task) ListByRepositoryId(ctx context.Context, repositoryId int32, opt .
 	res := make([]*database.Task, 0)
 	for rows.Next() {
 		r := &database.Task{}
-		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.Canceled, &r.LinesFound, &r.LinesHit, &r.PullRequest, &r.SupersededBy, &r.Attempt, &r.RetryOf, &r.Matrix, &r.GroupId, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		r.ResetMark()
@@ -531,7 +533,7 @@ func (d *Task) ListByRepositoryId(ctx context.Context, repositoryId int32, opt .
 
 func (d *Task) ListPending(ctx context.Context, opt ...ListOption) ([]*database.Task, error) {
 	listOpts := newListOpt(opt...)
-	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `created_at`, `updated_at` FROM `task` WHERE `start_at` IS NULL"
+	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `canceled`, `lines_found`, `lines_hit`, `pull_request`, `superseded_by`, `attempt`, `retry_of`, `matrix`, `group_id`, `created_at`, `updated_at` FROM `task` WHERE `start_at` IS NULL"
 	orderCol := "`" + listOpts.sort + "`"
 	if listOpts.sort == "" {
 		orderCol = "`id`"
@@ -555,7 +557,7 @@ func (d *Task) ListPending(ctx context.Context, opt ...ListOption) ([]*database.
 	res := make([]*database.Task, 0)
 	for rows.Next() {
 		r := &database.Task{}
-		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.Canceled, &r.LinesFound, &r.LinesHit, &r.PullRequest, &r.SupersededBy, &r.Attempt, &r.RetryOf, &r.Matrix, &r.GroupId, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		r.ResetMark()
@@ -636,7 +638,7 @@ func (d *Task) ListUniqJobName(ctx context.Context, repositoryId int32, opt ...L
 
 func (d *Task) ListByRevision(ctx context.Context, repositoryId int32, revision string, opt ...ListOption) ([]*database.Task, error) {
 	listOpts := newListOpt(opt...)
-	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `created_at`, `updated_at` FROM `task` WHERE `repository_id` = ? AND `revision` = ?"
+	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `canceled`, `lines_found`, `lines_hit`, `pull_request`, `superseded_by`, `attempt`, `retry_of`, `matrix`, `group_id`, `created_at`, `updated_at` FROM `task` WHERE `repository_id` = ? AND `revision` = ?"
 	orderCol := "`" + listOpts.sort + "`"
 	if listOpts.sort == "" {
 		orderCol = "`id`"
@@ -662,7 +664,114 @@ func (d *Task) ListByRevision(ctx context.Context, repositoryId int32, revision
 	res := make([]*database.Task, 0)
 	for rows.Next() {
 		r := &database.Task{}
-		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.Canceled, &r.LinesFound, &r.LinesHit, &r.PullRequest, &r.SupersededBy, &r.Attempt, &r.RetryOf, &r.Matrix, &r.GroupId, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+	if len(res) > 0 {
+		repositoryPrimaryKeys := make([]int32, len(res))
+		for i, v := range res {
+			repositoryPrimaryKeys[i] = v.RepositoryId
+		}
+		repositoryData := make(map[int32]*database.SourceRepository)
+		{
+			rels, _ := d.sourceRepository.SelectMulti(ctx, repositoryPrimaryKeys...)
+			for _, v := range rels {
+				repositoryData[v.Id] = v
+			}
+		}
+		for _, v := range res {
+			v.Repository = repositoryData[v.RepositoryId]
+		}
+	}
+
+	return res, nil
+}
+
+func (d *Task) ListByPullRequest(ctx context.Context, repositoryId int32, pullRequest int32, opt ...ListOption) ([]*database.Task, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `canceled`, `lines_found`, `lines_hit`, `pull_request`, `superseded_by`, `attempt`, `retry_of`, `matrix`, `group_id`, `created_at`, `updated_at` FROM `task` WHERE `repository_id` = ? AND `pull_request` = ?"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		repositoryId,
+		pullRequest,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*database.Task, 0)
+	for rows.Next() {
+		r := &database.Task{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.Canceled, &r.LinesFound, &r.LinesHit, &r.PullRequest, &r.SupersededBy, &r.Attempt, &r.RetryOf, &r.Matrix, &r.GroupId, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+	if len(res) > 0 {
+		repositoryPrimaryKeys := make([]int32, len(res))
+		for i, v := range res {
+			repositoryPrimaryKeys[i] = v.RepositoryId
+		}
+		repositoryData := make(map[int32]*database.SourceRepository)
+		{
+			rels, _ := d.sourceRepository.SelectMulti(ctx, repositoryPrimaryKeys...)
+			for _, v := range rels {
+				repositoryData[v.Id] = v
+			}
+		}
+		for _, v := range res {
+			v.Repository = repositoryData[v.RepositoryId]
+		}
+	}
+
+	return res, nil
+}
+
+func (d *Task) ListByGroupId(ctx context.Context, groupId int32, opt ...ListOption) ([]*database.Task, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `canceled`, `lines_found`, `lines_hit`, `pull_request`, `superseded_by`, `attempt`, `retry_of`, `matrix`, `group_id`, `created_at`, `updated_at` FROM `task` WHERE `group_id` = ?"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		groupId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*database.Task, 0)
+	for rows.Next() {
+		r := &database.Task{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.Canceled, &r.LinesFound, &r.LinesHit, &r.PullRequest, &r.SupersededBy, &r.Attempt, &r.RetryOf, &r.Matrix, &r.GroupId, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		r.ResetMark()
@@ -699,8 +808,8 @@ func (d *Task) Create(ctx context.Context, task *database.Task, opt ...ExecOptio
 
 	res, err := conn.ExecContext(
 		ctx,
-		"INSERT INTO `task` (`repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `created_at`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
-		task.RepositoryId, task.JobName, task.JobConfiguration, task.ParsedJobConfiguration, task.Revision, task.IsTrunk, task.BazelVersion, task.Success, task.LogFile, task.Command, task.Target, task.Targets, task.Platform, task.Via, task.ConfigName, task.Node, task.Manifest, task.Container, task.ExecutedTestsCount, task.SucceededTestsCount, task.StartAt, task.FinishedAt, time.Now(),
+		"INSERT INTO `task` (`repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `canceled`, `lines_found`, `lines_hit`, `pull_request`, `superseded_by`, `attempt`, `retry_of`, `matrix`, `group_id`, `created_at`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		task.RepositoryId, task.JobName, task.JobConfiguration, task.ParsedJobConfiguration, task.Revision, task.IsTrunk, task.BazelVersion, task.Success, task.LogFile, task.Command, task.Target, task.Targets, task.Platform, task.Via, task.ConfigName, task.Node, task.Manifest, task.Container, task.ExecutedTestsCount, task.SucceededTestsCount, task.StartAt, task.FinishedAt, task.Canceled, task.LinesFound, task.LinesHit, task.PullRequest, task.SupersededBy, task.Attempt, task.RetryOf, task.Matrix, task.GroupId, time.Now(),
 	)
 	if err != nil {
 		return nil, err
@@ -797,7 +906,7 @@ type TrustedUserInterface interface {
 	Select(ctx context.Context, id int32) (*database.TrustedUser, error)
 	SelectMulti(ctx context.Context, id ...int32) ([]*database.TrustedUser, error)
 	ListAll(ctx context.Context, opt ...ListOption) ([]*database.TrustedUser, error)
-	ListByGithubId(ctx context.Context, githubId int64, opt ...ListOption) ([]*database.TrustedUser, error)
+	ListByHostAndGithubId(ctx context.Context, host string, githubId int64, opt ...ListOption) ([]*database.TrustedUser, error)
 	Create(ctx context.Context, trustedUser *database.TrustedUser, opt ...ExecOption) (*database.TrustedUser, error)
 	Update(ctx context.Context, trustedUser *database.TrustedUser, opt ...ExecOption) error
 	Delete(ctx context.Context, id int32, opt ...ExecOption) error
@@ -834,7 +943,7 @@ func (d *TrustedUser) Select(ctx context.Context, id int32) (*database.TrustedUs
 	row := d.conn.QueryRowContext(ctx, "SELECT * FROM `trusted_user` WHERE `id` = ?", id)
 
 	v := &database.TrustedUser{}
-	if err := row.Scan(&v.Id, &v.GithubId, &v.Username, &v.CreatedAt, &v.UpdatedAt); err != nil {
+	if err := row.Scan(&v.Id, &v.GithubId, &v.Username, &v.Host, &v.CreatedAt, &v.UpdatedAt); err != nil {
 		return nil, err
 	}
 
@@ -856,7 +965,7 @@ func (d *TrustedUser) SelectMulti(ctx context.Context, id ...int32) ([]*database
 	res := make([]*database.TrustedUser, 0, len(id))
 	for rows.Next() {
 		r := &database.TrustedUser{}
-		if err := rows.Scan(&r.Id, &r.GithubId, &r.Username, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.GithubId, &r.Username, &r.Host, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		res = append(res, r)
@@ -867,7 +976,7 @@ func (d *TrustedUser) SelectMulti(ctx context.Context, id ...int32) ([]*database
 
 func (d *TrustedUser) ListAll(ctx context.Context, opt ...ListOption) ([]*database.TrustedUser, error) {
 	listOpts := newListOpt(opt...)
-	query := "SELECT `id`, `github_id`, `username`, `created_at`, `updated_at` FROM `trusted_user`"
+	query := "SELECT `id`, `github_id`, `username`, `host`, `created_at`, `updated_at` FROM `trusted_user`"
 	orderCol := "`" + listOpts.sort + "`"
 	if listOpts.sort == "" {
 		orderCol = "`id`"
@@ -891,7 +1000,7 @@ func (d *TrustedUser) ListAll(ctx context.Context, opt ...ListOption) ([]*databa
 	res := make([]*database.TrustedUser, 0)
 	for rows.Next() {
 		r := &database.TrustedUser{}
-		if err := rows.Scan(&r.Id, &r.GithubId, &r.Username, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.GithubId, &r.Username, &r.Host, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		r.ResetMark()
@@ -901,9 +1010,9 @@ func (d *TrustedUser) ListAll(ctx context.Context, opt ...ListOption) ([]*databa
 	return res, nil
 }
 
-func (d *TrustedUser) ListByGithubId(ctx context.Context, githubId int64, opt ...ListOption) ([]*database.TrustedUser, error) {
+func (d *TrustedUser) ListByHostAndGithubId(ctx context.Context, host string, githubId int64, opt ...ListOption) ([]*database.TrustedUser, error) {
 	listOpts := newListOpt(opt...)
-	query := "SELECT `id`, `github_id`, `username`, `created_at`, `updated_at` FROM `trusted_user` WHERE `github_id` = ?"
+	query := "SELECT `id`, `github_id`, `username`, `host`, `created_at`, `updated_at` FROM `trusted_user` WHERE `host` = ? AND `github_id` = ?"
 	orderCol := "`" + listOpts.sort + "`"
 	if listOpts.sort == "" {
 		orderCol = "`id`"
@@ -919,6 +1028,7 @@ func (d *TrustedUser) ListByGithubId(ctx context.Context, githubId int64, opt ..
 	rows, err := d.conn.QueryContext(
 		ctx,
 		query,
+		host,
 		githubId,
 	)
 	if err != nil {
@@ -928,7 +1038,7 @@ func (d *TrustedUser) ListByGithubId(ctx context.Context, githubId int64, opt ..
 	res := make([]*database.TrustedUser, 0)
 	for rows.Next() {
 		r := &database.TrustedUser{}
-		if err := rows.Scan(&r.Id, &r.GithubId, &r.Username, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.GithubId, &r.Username, &r.Host, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		r.ResetMark()
@@ -949,8 +1059,8 @@ func (d *TrustedUser) Create(ctx context.Context, trustedUser *database.TrustedU
 
 	res, err := conn.ExecContext(
 		ctx,
-		"INSERT INTO `trusted_user` (`github_id`, `username`, `created_at`) VALUES (?, ?, ?)",
-		trustedUser.GithubId, trustedUser.Username, time.Now(),
+		"INSERT INTO `trusted_user` (`github_id`, `username`, `host`, `created_at`) VALUES (?, ?, ?, ?)",
+		trustedUser.GithubId, trustedUser.Username, trustedUser.Host, time.Now(),
 	)
 	if err != nil {
 		return nil, err
@@ -1046,7 +1156,7 @@ type PermitPullRequestInterface interface {
 	Tx(ctx context.Context, fn func(tx *sql.Tx) error) error
 	Select(ctx context.Context, id int32) (*database.PermitPullRequest, error)
 	SelectMulti(ctx context.Context, id ...int32) ([]*database.PermitPullRequest, error)
-	ListByRepositoryAndNumber(ctx context.Context, repository string, number int32, opt ...ListOption) ([]*database.PermitPullRequest, error)
+	ListByHostAndRepositoryAndNumber(ctx context.Context, host string, repository string, number int32, opt ...ListOption) ([]*database.PermitPullRequest, error)
 	Create(ctx context.Context, permitPullRequest *database.PermitPullRequest, opt ...ExecOption) (*database.PermitPullRequest, error)
 	Update(ctx context.Context, permitPullRequest *database.PermitPullRequest, opt ...ExecOption) error
 	Delete(ctx context.Context, id int32, opt ...ExecOption) error
@@ -1083,7 +1193,7 @@ func (d *PermitPullRequest) Select(ctx context.Context, id int32) (*database.Per
 	row := d.conn.QueryRowContext(ctx, "SELECT * FROM `permit_pull_request` WHERE `id` = ?", id)
 
 	v := &database.PermitPullRequest{}
-	if err := row.Scan(&v.Id, &v.Repository, &v.Number, &v.CreatedAt, &v.UpdatedAt); err != nil {
+	if err := row.Scan(&v.Id, &v.Repository, &v.Number, &v.Host, &v.CreatedAt, &v.UpdatedAt); err != nil {
 		return nil, err
 	}
 
@@ -1105,7 +1215,7 @@ func (d *PermitPullRequest) SelectMulti(ctx context.Context, id ...int32) ([]*da
 	res := make([]*database.PermitPullRequest, 0, len(id))
 	for rows.Next() {
 		r := &database.PermitPullRequest{}
-		if err := rows.Scan(&r.Id, &r.Repository, &r.Number, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.Repository, &r.Number, &r.Host, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		res = append(res, r)
@@ -1114,9 +1224,9 @@ func (d *PermitPullRequest) SelectMulti(ctx context.Context, id ...int32) ([]*da
 	return res, nil
 }
 
-func (d *PermitPullRequest) ListByRepositoryAndNumber(ctx context.Context, repository string, number int32, opt ...ListOption) ([]*database.PermitPullRequest, error) {
+func (d *PermitPullRequest) ListByHostAndRepositoryAndNumber(ctx context.Context, host string, repository string, number int32, opt ...ListOption) ([]*database.PermitPullRequest, error) {
 	listOpts := newListOpt(opt...)
-	query := "SELECT `id`, `repository`, `number`, `created_at`, `updated_at` FROM `permit_pull_request` WHERE `repository` = ? AND `number` = ?"
+	query := "SELECT `id`, `repository`, `number`, `host`, `created_at`, `updated_at` FROM `permit_pull_request` WHERE `host` = ? AND `repository` = ? AND `number` = ?"
 	orderCol := "`" + listOpts.sort + "`"
 	if listOpts.sort == "" {
 		orderCol = "`id`"
@@ -1132,6 +1242,7 @@ func (d *PermitPullRequest) ListByRepositoryAndNumber(ctx context.Context, repos
 	rows, err := d.conn.QueryContext(
 		ctx,
 		query,
+		host,
 		repository,
 		number,
 	)
@@ -1142,7 +1253,7 @@ func (d *PermitPullRequest) ListByRepositoryAndNumber(ctx context.Context, repos
 	res := make([]*database.PermitPullRequest, 0)
 	for rows.Next() {
 		r := &database.PermitPullRequest{}
-		if err := rows.Scan(&r.Id, &r.Repository, &r.Number, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.Repository, &r.Number, &r.Host, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		r.ResetMark()
@@ -1163,8 +1274,8 @@ func (d *PermitPullRequest) Create(ctx context.Context, permitPullRequest *datab
 
 	res, err := conn.ExecContext(
 		ctx,
-		"INSERT INTO `permit_pull_request` (`repository`, `number`, `created_at`) VALUES (?, ?, ?)",
-		permitPullRequest.Repository, permitPullRequest.Number, time.Now(),
+		"INSERT INTO `permit_pull_request` (`repository`, `number`, `host`, `created_at`) VALUES (?, ?, ?, ?)",
+		permitPullRequest.Repository, permitPullRequest.Number, permitPullRequest.Host, time.Now(),
 	)
 	if err != nil {
 		return nil, err
@@ -1264,6 +1375,7 @@ type TestReportInterface interface {
 	Select(ctx context.Context, id int32) (*database.TestReport, error)
 	SelectMulti(ctx context.Context, id ...int32) ([]*database.TestReport, error)
 	ListByTaskId(ctx context.Context, taskId int32, opt ...ListOption) ([]*database.TestReport, error)
+	ListByLabel(ctx context.Context, repositoryId int32, label string, opt ...ListOption) ([]*database.TestReport, error)
 	Create(ctx context.Context, testReport *database.TestReport, opt ...ExecOption) (*database.TestReport, error)
 	Update(ctx context.Context, testReport *database.TestReport, opt ...ExecOption) error
 	Delete(ctx context.Context, id int32, opt ...ExecOption) error
@@ -1342,11 +1454,18 @@ func (d *TestReport) SelectMulti(ctx context.Context, id ...int32) ([]*database.
 	}
 
 	if len(res) > 0 {
-		taskPrimaryKeys := make([]int32, len(res))
 		repositoryPrimaryKeys := make([]int32, len(res))
+		taskPrimaryKeys := make([]int32, len(res))
 		for i, v := range res {
-			taskPrimaryKeys[i] = v.TaskId
 			repositoryPrimaryKeys[i] = v.RepositoryId
+			taskPrimaryKeys[i] = v.TaskId
+		}
+		repositoryData := make(map[int32]*database.SourceRepository)
+		{
+			rels, _ := d.sourceRepository.SelectMulti(ctx, repositoryPrimaryKeys...)
+			for _, v := range rels {
+				repositoryData[v.Id] = v
+			}
 		}
 		taskData := make(map[int32]*database.Task)
 		{
@@ -1355,6 +1474,54 @@ func (d *TestReport) SelectMulti(ctx context.Context, id ...int32) ([]*database.
 				taskData[v.Id] = v
 			}
 		}
+		for _, v := range res {
+			v.Repository = repositoryData[v.RepositoryId]
+			v.Task = taskData[v.TaskId]
+		}
+	}
+	return res, nil
+}
+
+func (d *TestReport) ListByTaskId(ctx context.Context, taskId int32, opt ...ListOption) ([]*database.TestReport, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `repository_id`, `task_id`, `label`, `status`, `duration`, `start_at` FROM `test_report` WHERE `task_id` = ?"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		taskId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*database.TestReport, 0)
+	for rows.Next() {
+		r := &database.TestReport{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.TaskId, &r.Label, &r.Status, &r.Duration, &r.StartAt); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+	if len(res) > 0 {
+		repositoryPrimaryKeys := make([]int32, len(res))
+		taskPrimaryKeys := make([]int32, len(res))
+		for i, v := range res {
+			repositoryPrimaryKeys[i] = v.RepositoryId
+			taskPrimaryKeys[i] = v.TaskId
+		}
 		repositoryData := make(map[int32]*database.SourceRepository)
 		{
 			rels, _ := d.sourceRepository.SelectMulti(ctx, repositoryPrimaryKeys...)
@@ -1362,17 +1529,25 @@ func (d *TestReport) SelectMulti(ctx context.Context, id ...int32) ([]*database.
 				repositoryData[v.Id] = v
 			}
 		}
+		taskData := make(map[int32]*database.Task)
+		{
+			rels, _ := d.task.SelectMulti(ctx, taskPrimaryKeys...)
+			for _, v := range rels {
+				taskData[v.Id] = v
+			}
+		}
 		for _, v := range res {
-			v.Task = taskData[v.TaskId]
 			v.Repository = repositoryData[v.RepositoryId]
+			v.Task = taskData[v.TaskId]
 		}
 	}
+
 	return res, nil
 }
 
-func (d *TestReport) ListByTaskId(ctx context.Context, taskId int32, opt ...ListOption) ([]*database.TestReport, error) {
+func (d *TestReport) ListByLabel(ctx context.Context, repositoryId int32, label string, opt ...ListOption) ([]*database.TestReport, error) {
 	listOpts := newListOpt(opt...)
-	query := "SELECT `id`, `repository_id`, `task_id`, `label`, `status`, `duration`, `start_at` FROM `test_report` WHERE `task_id` = ?"
+	query := "SELECT `id`, `repository_id`, `task_id`, `label`, `status`, `duration`, `start_at` FROM `test_report` WHERE `repository_id` = ? AND `label` = ?"
 	orderCol := "`" + listOpts.sort + "`"
 	if listOpts.sort == "" {
 		orderCol = "`id`"
@@ -1388,7 +1563,8 @@ func (d *TestReport) ListByTaskId(ctx context.Context, taskId int32, opt ...List
 	rows, err := d.conn.QueryContext(
 		ctx,
 		query,
-		taskId,
+		repositoryId,
+		label,
 	)
 	if err != nil {
 		return nil, err
@@ -1530,3 +1706,1177 @@ func (d *TestReport) Update(ctx context.Context, testReport *database.TestReport
 	testReport.ResetMark()
 	return nil
 }
+
+type FlakyTest struct {
+	conn *sql.DB
+
+	sourceRepository *SourceRepository
+}
+
+type FlakyTestInterface interface {
+	Tx(ctx context.Context, fn func(tx *sql.Tx) error) error
+	Select(ctx context.Context, id int32) (*database.FlakyTest, error)
+	SelectMulti(ctx context.Context, id ...int32) ([]*database.FlakyTest, error)
+	ListByRepositoryId(ctx context.Context, repositoryId int32, opt ...ListOption) ([]*database.FlakyTest, error)
+	ListByLabel(ctx context.Context, repositoryId int32, label string, opt ...ListOption) ([]*database.FlakyTest, error)
+	Create(ctx context.Context, flakyTest *database.FlakyTest, opt ...ExecOption) (*database.FlakyTest, error)
+	Update(ctx context.Context, flakyTest *database.FlakyTest, opt ...ExecOption) error
+	Delete(ctx context.Context, id int32, opt ...ExecOption) error
+}
+
+var _ FlakyTestInterface = &FlakyTest{}
+
+func NewFlakyTest(conn *sql.DB) *FlakyTest {
+	return &FlakyTest{
+		conn:             conn,
+		sourceRepository: NewSourceRepository(conn),
+	}
+}
+
+func (d *FlakyTest) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rErr := tx.Rollback(); rErr != nil {
+			return rErr
+		}
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *FlakyTest) Select(ctx context.Context, id int32) (*database.FlakyTest, error) {
+	row := d.conn.QueryRowContext(ctx, "SELECT * FROM `flaky_test` WHERE `id` = ?", id)
+
+	v := &database.FlakyTest{}
+	if err := row.Scan(&v.Id, &v.RepositoryId, &v.Label, &v.Revision, &v.CreatedAt, &v.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	{
+		if rel, _ := d.sourceRepository.Select(ctx, v.RepositoryId); rel != nil {
+			v.Repository = rel
+		}
+	}
+
+	v.ResetMark()
+	return v, nil
+}
+
+func (d *FlakyTest) SelectMulti(ctx context.Context, id ...int32) ([]*database.FlakyTest, error) {
+	inCause := strings.Repeat("?, ", len(id))
+	args := make([]any, len(id))
+	for i := 0; i < len(id); i++ {
+		args[i] = id[i]
+	}
+	rows, err := d.conn.QueryContext(ctx, fmt.Sprintf("SELECT * FROM `flaky_test` WHERE `id` IN (%s)", inCause[:len(inCause)-2]), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*database.FlakyTest, 0, len(id))
+	for rows.Next() {
+		r := &database.FlakyTest{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.Label, &r.Revision, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+
+	if len(res) > 0 {
+		repositoryPrimaryKeys := make([]int32, len(res))
+		for i, v := range res {
+			repositoryPrimaryKeys[i] = v.RepositoryId
+		}
+		repositoryData := make(map[int32]*database.SourceRepository)
+		{
+			rels, _ := d.sourceRepository.SelectMulti(ctx, repositoryPrimaryKeys...)
+			for _, v := range rels {
+				repositoryData[v.Id] = v
+			}
+		}
+		for _, v := range res {
+			v.Repository = repositoryData[v.RepositoryId]
+		}
+	}
+	return res, nil
+}
+
+func (d *FlakyTest) ListByRepositoryId(ctx context.Context, repositoryId int32, opt ...ListOption) ([]*database.FlakyTest, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `repository_id`, `label`, `revision`, `created_at`, `updated_at` FROM `flaky_test` WHERE `repository_id` = ?"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		repositoryId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*database.FlakyTest, 0)
+	for rows.Next() {
+		r := &database.FlakyTest{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.Label, &r.Revision, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+	if len(res) > 0 {
+		repositoryPrimaryKeys := make([]int32, len(res))
+		for i, v := range res {
+			repositoryPrimaryKeys[i] = v.RepositoryId
+		}
+		repositoryData := make(map[int32]*database.SourceRepository)
+		{
+			rels, _ := d.sourceRepository.SelectMulti(ctx, repositoryPrimaryKeys...)
+			for _, v := range rels {
+				repositoryData[v.Id] = v
+			}
+		}
+		for _, v := range res {
+			v.Repository = repositoryData[v.RepositoryId]
+		}
+	}
+
+	return res, nil
+}
+
+func (d *FlakyTest) ListByLabel(ctx context.Context, repositoryId int32, label string, opt ...ListOption) ([]*database.FlakyTest, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `repository_id`, `label`, `revision`, `created_at`, `updated_at` FROM `flaky_test` WHERE `repository_id` = ? AND `label` = ?"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		repositoryId,
+		label,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*database.FlakyTest, 0)
+	for rows.Next() {
+		r := &database.FlakyTest{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.Label, &r.Revision, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+	if len(res) > 0 {
+		repositoryPrimaryKeys := make([]int32, len(res))
+		for i, v := range res {
+			repositoryPrimaryKeys[i] = v.RepositoryId
+		}
+		repositoryData := make(map[int32]*database.SourceRepository)
+		{
+			rels, _ := d.sourceRepository.SelectMulti(ctx, repositoryPrimaryKeys...)
+			for _, v := range rels {
+				repositoryData[v.Id] = v
+			}
+		}
+		for _, v := range res {
+			v.Repository = repositoryData[v.RepositoryId]
+		}
+	}
+
+	return res, nil
+}
+
+func (d *FlakyTest) Create(ctx context.Context, flakyTest *database.FlakyTest, opt ...ExecOption) (*database.FlakyTest, error) {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(
+		ctx,
+		"INSERT INTO `flaky_test` (`repository_id`, `label`, `revision`, `created_at`) VALUES (?, ?, ?, ?)",
+		flakyTest.RepositoryId, flakyTest.Label, flakyTest.Revision, time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	flakyTest = flakyTest.Copy()
+	insertedId, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	flakyTest.Id = int32(insertedId)
+
+	flakyTest.ResetMark()
+	return flakyTest, nil
+}
+
+func (d *FlakyTest) Delete(ctx context.Context, id int32, opt ...ExecOption) error {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(ctx, "DELETE FROM `flaky_test` WHERE `id` = ?", id)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (d *FlakyTest) Update(ctx context.Context, flakyTest *database.FlakyTest, opt ...ExecOption) error {
+	if !flakyTest.IsChanged() {
+		return nil
+	}
+
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	changedColumn := flakyTest.ChangedColumn()
+	cols := make([]string, len(changedColumn)+1)
+	values := make([]interface{}, len(changedColumn)+1)
+	for i := range changedColumn {
+		cols[i] = "`" + changedColumn[i].Name + "` = ?"
+		values[i] = changedColumn[i].Value
+	}
+	cols[len(cols)-1] = "`updated_at` = ?"
+	values[len(values)-1] = time.Now()
+
+	query := fmt.Sprintf("UPDATE `flaky_test` SET %s WHERE `id` = ?", strings.Join(cols, ", "))
+	res, err := conn.ExecContext(
+		ctx,
+		query,
+		append(values, flakyTest.Id)...,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	flakyTest.ResetMark()
+	return nil
+}
+
+type CoverageReport struct {
+	conn *sql.DB
+
+	sourceRepository *SourceRepository
+	task             *Task
+}
+
+type CoverageReportInterface interface {
+	Tx(ctx context.Context, fn func(tx *sql.Tx) error) error
+	Select(ctx context.Context, id int32) (*database.CoverageReport, error)
+	SelectMulti(ctx context.Context, id ...int32) ([]*database.CoverageReport, error)
+	ListByTaskId(ctx context.Context, taskId int32, opt ...ListOption) ([]*database.CoverageReport, error)
+	Create(ctx context.Context, coverageReport *database.CoverageReport, opt ...ExecOption) (*database.CoverageReport, error)
+	Update(ctx context.Context, coverageReport *database.CoverageReport, opt ...ExecOption) error
+	Delete(ctx context.Context, id int32, opt ...ExecOption) error
+}
+
+var _ CoverageReportInterface = &CoverageReport{}
+
+func NewCoverageReport(conn *sql.DB) *CoverageReport {
+	return &CoverageReport{
+		conn:             conn,
+		sourceRepository: NewSourceRepository(conn),
+		task:             NewTask(conn),
+	}
+}
+
+func (d *CoverageReport) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rErr := tx.Rollback(); rErr != nil {
+			return rErr
+		}
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *CoverageReport) Select(ctx context.Context, id int32) (*database.CoverageReport, error) {
+	row := d.conn.QueryRowContext(ctx, "SELECT * FROM `coverage_report` WHERE `id` = ?", id)
+
+	v := &database.CoverageReport{}
+	if err := row.Scan(&v.Id, &v.RepositoryId, &v.TaskId, &v.Path, &v.LinesFound, &v.LinesHit); err != nil {
+		return nil, err
+	}
+
+	{
+		if rel, _ := d.sourceRepository.Select(ctx, v.RepositoryId); rel != nil {
+			v.Repository = rel
+		}
+	}
+	{
+		if rel, _ := d.task.Select(ctx, v.TaskId); rel != nil {
+			v.Task = rel
+		}
+	}
+
+	v.ResetMark()
+	return v, nil
+}
+
+func (d *CoverageReport) SelectMulti(ctx context.Context, id ...int32) ([]*database.CoverageReport, error) {
+	inCause := strings.Repeat("?, ", len(id))
+	args := make([]any, len(id))
+	for i := 0; i < len(id); i++ {
+		args[i] = id[i]
+	}
+	rows, err := d.conn.QueryContext(ctx, fmt.Sprintf("SELECT * FROM `coverage_report` WHERE `id` IN (%s)", inCause[:len(inCause)-2]), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*database.CoverageReport, 0, len(id))
+	for rows.Next() {
+		r := &database.CoverageReport{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.TaskId, &r.Path, &r.LinesFound, &r.LinesHit); err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+
+	if len(res) > 0 {
+		repositoryPrimaryKeys := make([]int32, len(res))
+		taskPrimaryKeys := make([]int32, len(res))
+		for i, v := range res {
+			repositoryPrimaryKeys[i] = v.RepositoryId
+			taskPrimaryKeys[i] = v.TaskId
+		}
+		repositoryData := make(map[int32]*database.SourceRepository)
+		{
+			rels, _ := d.sourceRepository.SelectMulti(ctx, repositoryPrimaryKeys...)
+			for _, v := range rels {
+				repositoryData[v.Id] = v
+			}
+		}
+		taskData := make(map[int32]*database.Task)
+		{
+			rels, _ := d.task.SelectMulti(ctx, taskPrimaryKeys...)
+			for _, v := range rels {
+				taskData[v.Id] = v
+			}
+		}
+		for _, v := range res {
+			v.Repository = repositoryData[v.RepositoryId]
+			v.Task = taskData[v.TaskId]
+		}
+	}
+	return res, nil
+}
+
+func (d *CoverageReport) ListByTaskId(ctx context.Context, taskId int32, opt ...ListOption) ([]*database.CoverageReport, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `repository_id`, `task_id`, `path`, `lines_found`, `lines_hit` FROM `coverage_report` WHERE `task_id` = ?"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		taskId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*database.CoverageReport, 0)
+	for rows.Next() {
+		r := &database.CoverageReport{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.TaskId, &r.Path, &r.LinesFound, &r.LinesHit); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+	if len(res) > 0 {
+		repositoryPrimaryKeys := make([]int32, len(res))
+		taskPrimaryKeys := make([]int32, len(res))
+		for i, v := range res {
+			repositoryPrimaryKeys[i] = v.RepositoryId
+			taskPrimaryKeys[i] = v.TaskId
+		}
+		repositoryData := make(map[int32]*database.SourceRepository)
+		{
+			rels, _ := d.sourceRepository.SelectMulti(ctx, repositoryPrimaryKeys...)
+			for _, v := range rels {
+				repositoryData[v.Id] = v
+			}
+		}
+		taskData := make(map[int32]*database.Task)
+		{
+			rels, _ := d.task.SelectMulti(ctx, taskPrimaryKeys...)
+			for _, v := range rels {
+				taskData[v.Id] = v
+			}
+		}
+		for _, v := range res {
+			v.Repository = repositoryData[v.RepositoryId]
+			v.Task = taskData[v.TaskId]
+		}
+	}
+
+	return res, nil
+}
+
+func (d *CoverageReport) Create(ctx context.Context, coverageReport *database.CoverageReport, opt ...ExecOption) (*database.CoverageReport, error) {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(
+		ctx,
+		"INSERT INTO `coverage_report` (`repository_id`, `task_id`, `path`, `lines_found`, `lines_hit`) VALUES (?, ?, ?, ?, ?)",
+		coverageReport.RepositoryId, coverageReport.TaskId, coverageReport.Path, coverageReport.LinesFound, coverageReport.LinesHit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	coverageReport = coverageReport.Copy()
+	insertedId, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	coverageReport.Id = int32(insertedId)
+
+	coverageReport.ResetMark()
+	return coverageReport, nil
+}
+
+func (d *CoverageReport) Delete(ctx context.Context, id int32, opt ...ExecOption) error {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(ctx, "DELETE FROM `coverage_report` WHERE `id` = ?", id)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (d *CoverageReport) Update(ctx context.Context, coverageReport *database.CoverageReport, opt ...ExecOption) error {
+	if !coverageReport.IsChanged() {
+		return nil
+	}
+
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	changedColumn := coverageReport.ChangedColumn()
+	cols := make([]string, len(changedColumn)+1)
+	values := make([]interface{}, len(changedColumn)+1)
+	for i := range changedColumn {
+		cols[i] = "`" + changedColumn[i].Name + "` = ?"
+		values[i] = changedColumn[i].Value
+	}
+
+	query := fmt.Sprintf("UPDATE `coverage_report` SET %s WHERE `id` = ?", strings.Join(cols, ", "))
+	res, err := conn.ExecContext(
+		ctx,
+		query,
+		append(values, coverageReport.Id)...,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	coverageReport.ResetMark()
+	return nil
+}
+
+type Artifact struct {
+	conn *sql.DB
+
+	sourceRepository *SourceRepository
+	task             *Task
+}
+
+type ArtifactInterface interface {
+	Tx(ctx context.Context, fn func(tx *sql.Tx) error) error
+	Select(ctx context.Context, id int32) (*database.Artifact, error)
+	SelectMulti(ctx context.Context, id ...int32) ([]*database.Artifact, error)
+	ListByTaskId(ctx context.Context, taskId int32, opt ...ListOption) ([]*database.Artifact, error)
+	Create(ctx context.Context, artifact *database.Artifact, opt ...ExecOption) (*database.Artifact, error)
+	Update(ctx context.Context, artifact *database.Artifact, opt ...ExecOption) error
+	Delete(ctx context.Context, id int32, opt ...ExecOption) error
+}
+
+var _ ArtifactInterface = &Artifact{}
+
+func NewArtifact(conn *sql.DB) *Artifact {
+	return &Artifact{
+		conn:             conn,
+		sourceRepository: NewSourceRepository(conn),
+		task:             NewTask(conn),
+	}
+}
+
+func (d *Artifact) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rErr := tx.Rollback(); rErr != nil {
+			return rErr
+		}
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *Artifact) Select(ctx context.Context, id int32) (*database.Artifact, error) {
+	row := d.conn.QueryRowContext(ctx, "SELECT * FROM `artifact` WHERE `id` = ?", id)
+
+	v := &database.Artifact{}
+	if err := row.Scan(&v.Id, &v.RepositoryId, &v.TaskId, &v.Name, &v.Path, &v.Size); err != nil {
+		return nil, err
+	}
+
+	{
+		if rel, _ := d.sourceRepository.Select(ctx, v.RepositoryId); rel != nil {
+			v.Repository = rel
+		}
+	}
+	{
+		if rel, _ := d.task.Select(ctx, v.TaskId); rel != nil {
+			v.Task = rel
+		}
+	}
+
+	v.ResetMark()
+	return v, nil
+}
+
+func (d *Artifact) SelectMulti(ctx context.Context, id ...int32) ([]*database.Artifact, error) {
+	inCause := strings.Repeat("?, ", len(id))
+	args := make([]any, len(id))
+	for i := 0; i < len(id); i++ {
+		args[i] = id[i]
+	}
+	rows, err := d.conn.QueryContext(ctx, fmt.Sprintf("SELECT * FROM `artifact` WHERE `id` IN (%s)", inCause[:len(inCause)-2]), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*database.Artifact, 0, len(id))
+	for rows.Next() {
+		r := &database.Artifact{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.TaskId, &r.Name, &r.Path, &r.Size); err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+
+	if len(res) > 0 {
+		repositoryPrimaryKeys := make([]int32, len(res))
+		taskPrimaryKeys := make([]int32, len(res))
+		for i, v := range res {
+			repositoryPrimaryKeys[i] = v.RepositoryId
+			taskPrimaryKeys[i] = v.TaskId
+		}
+		repositoryData := make(map[int32]*database.SourceRepository)
+		{
+			rels, _ := d.sourceRepository.SelectMulti(ctx, repositoryPrimaryKeys...)
+			for _, v := range rels {
+				repositoryData[v.Id] = v
+			}
+		}
+		taskData := make(map[int32]*database.Task)
+		{
+			rels, _ := d.task.SelectMulti(ctx, taskPrimaryKeys...)
+			for _, v := range rels {
+				taskData[v.Id] = v
+			}
+		}
+		for _, v := range res {
+			v.Repository = repositoryData[v.RepositoryId]
+			v.Task = taskData[v.TaskId]
+		}
+	}
+	return res, nil
+}
+
+func (d *Artifact) ListByTaskId(ctx context.Context, taskId int32, opt ...ListOption) ([]*database.Artifact, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `repository_id`, `task_id`, `name`, `path`, `size` FROM `artifact` WHERE `task_id` = ?"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		taskId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*database.Artifact, 0)
+	for rows.Next() {
+		r := &database.Artifact{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.TaskId, &r.Name, &r.Path, &r.Size); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+	if len(res) > 0 {
+		repositoryPrimaryKeys := make([]int32, len(res))
+		taskPrimaryKeys := make([]int32, len(res))
+		for i, v := range res {
+			repositoryPrimaryKeys[i] = v.RepositoryId
+			taskPrimaryKeys[i] = v.TaskId
+		}
+		repositoryData := make(map[int32]*database.SourceRepository)
+		{
+			rels, _ := d.sourceRepository.SelectMulti(ctx, repositoryPrimaryKeys...)
+			for _, v := range rels {
+				repositoryData[v.Id] = v
+			}
+		}
+		taskData := make(map[int32]*database.Task)
+		{
+			rels, _ := d.task.SelectMulti(ctx, taskPrimaryKeys...)
+			for _, v := range rels {
+				taskData[v.Id] = v
+			}
+		}
+		for _, v := range res {
+			v.Repository = repositoryData[v.RepositoryId]
+			v.Task = taskData[v.TaskId]
+		}
+	}
+
+	return res, nil
+}
+
+func (d *Artifact) Create(ctx context.Context, artifact *database.Artifact, opt ...ExecOption) (*database.Artifact, error) {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(
+		ctx,
+		"INSERT INTO `artifact` (`repository_id`, `task_id`, `name`, `path`, `size`) VALUES (?, ?, ?, ?, ?)",
+		artifact.RepositoryId, artifact.TaskId, artifact.Name, artifact.Path, artifact.Size,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	artifact = artifact.Copy()
+	insertedId, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	artifact.Id = int32(insertedId)
+
+	artifact.ResetMark()
+	return artifact, nil
+}
+
+func (d *Artifact) Delete(ctx context.Context, id int32, opt ...ExecOption) error {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(ctx, "DELETE FROM `artifact` WHERE `id` = ?", id)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (d *Artifact) Update(ctx context.Context, artifact *database.Artifact, opt ...ExecOption) error {
+	if !artifact.IsChanged() {
+		return nil
+	}
+
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	changedColumn := artifact.ChangedColumn()
+	cols := make([]string, len(changedColumn)+1)
+	values := make([]interface{}, len(changedColumn)+1)
+	for i := range changedColumn {
+		cols[i] = "`" + changedColumn[i].Name + "` = ?"
+		values[i] = changedColumn[i].Value
+	}
+
+	query := fmt.Sprintf("UPDATE `artifact` SET %s WHERE `id` = ?", strings.Join(cols, ", "))
+	res, err := conn.ExecContext(
+		ctx,
+		query,
+		append(values, artifact.Id)...,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	artifact.ResetMark()
+	return nil
+}
+
+type JobSchedule struct {
+	conn *sql.DB
+
+	sourceRepository *SourceRepository
+}
+
+type JobScheduleInterface interface {
+	Tx(ctx context.Context, fn func(tx *sql.Tx) error) error
+	Select(ctx context.Context, id int32) (*database.JobSchedule, error)
+	SelectMulti(ctx context.Context, id ...int32) ([]*database.JobSchedule, error)
+	ListAll(ctx context.Context, opt ...ListOption) ([]*database.JobSchedule, error)
+	ListByRepositoryId(ctx context.Context, repositoryId int32, opt ...ListOption) ([]*database.JobSchedule, error)
+	Create(ctx context.Context, jobSchedule *database.JobSchedule, opt ...ExecOption) (*database.JobSchedule, error)
+	Update(ctx context.Context, jobSchedule *database.JobSchedule, opt ...ExecOption) error
+	Delete(ctx context.Context, id int32, opt ...ExecOption) error
+}
+
+var _ JobScheduleInterface = &JobSchedule{}
+
+func NewJobSchedule(conn *sql.DB) *JobSchedule {
+	return &JobSchedule{
+		conn:             conn,
+		sourceRepository: NewSourceRepository(conn),
+	}
+}
+
+func (d *JobSchedule) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rErr := tx.Rollback(); rErr != nil {
+			return rErr
+		}
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *JobSchedule) Select(ctx context.Context, id int32) (*database.JobSchedule, error) {
+	row := d.conn.QueryRowContext(ctx, "SELECT * FROM `job_schedule` WHERE `id` = ?", id)
+
+	v := &database.JobSchedule{}
+	if err := row.Scan(&v.Id, &v.RepositoryId, &v.JobName, &v.Schedule, &v.LastTaskId, &v.LastRunAt, &v.NextRunAt, &v.CreatedAt, &v.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	{
+		if rel, _ := d.sourceRepository.Select(ctx, v.RepositoryId); rel != nil {
+			v.Repository = rel
+		}
+	}
+
+	v.ResetMark()
+	return v, nil
+}
+
+func (d *JobSchedule) SelectMulti(ctx context.Context, id ...int32) ([]*database.JobSchedule, error) {
+	inCause := strings.Repeat("?, ", len(id))
+	args := make([]any, len(id))
+	for i := 0; i < len(id); i++ {
+		args[i] = id[i]
+	}
+	rows, err := d.conn.QueryContext(ctx, fmt.Sprintf("SELECT * FROM `job_schedule` WHERE `id` IN (%s)", inCause[:len(inCause)-2]), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*database.JobSchedule, 0, len(id))
+	for rows.Next() {
+		r := &database.JobSchedule{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.Schedule, &r.LastTaskId, &r.LastRunAt, &r.NextRunAt, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+
+	if len(res) > 0 {
+		repositoryPrimaryKeys := make([]int32, len(res))
+		for i, v := range res {
+			repositoryPrimaryKeys[i] = v.RepositoryId
+		}
+		repositoryData := make(map[int32]*database.SourceRepository)
+		{
+			rels, _ := d.sourceRepository.SelectMulti(ctx, repositoryPrimaryKeys...)
+			for _, v := range rels {
+				repositoryData[v.Id] = v
+			}
+		}
+		for _, v := range res {
+			v.Repository = repositoryData[v.RepositoryId]
+		}
+	}
+	return res, nil
+}
+
+func (d *JobSchedule) ListAll(ctx context.Context, opt ...ListOption) ([]*database.JobSchedule, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `repository_id`, `job_name`, `schedule`, `last_task_id`, `last_run_at`, `next_run_at`, `created_at`, `updated_at` FROM `job_schedule`"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*database.JobSchedule, 0)
+	for rows.Next() {
+		r := &database.JobSchedule{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.Schedule, &r.LastTaskId, &r.LastRunAt, &r.NextRunAt, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+	if len(res) > 0 {
+		repositoryPrimaryKeys := make([]int32, len(res))
+		for i, v := range res {
+			repositoryPrimaryKeys[i] = v.RepositoryId
+		}
+		repositoryData := make(map[int32]*database.SourceRepository)
+		{
+			rels, _ := d.sourceRepository.SelectMulti(ctx, repositoryPrimaryKeys...)
+			for _, v := range rels {
+				repositoryData[v.Id] = v
+			}
+		}
+		for _, v := range res {
+			v.Repository = repositoryData[v.RepositoryId]
+		}
+	}
+
+	return res, nil
+}
+
+func (d *JobSchedule) ListByRepositoryId(ctx context.Context, repositoryId int32, opt ...ListOption) ([]*database.JobSchedule, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `repository_id`, `job_name`, `schedule`, `last_task_id`, `last_run_at`, `next_run_at`, `created_at`, `updated_at` FROM `job_schedule` WHERE `repository_id` = ?"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		repositoryId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*database.JobSchedule, 0)
+	for rows.Next() {
+		r := &database.JobSchedule{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.Schedule, &r.LastTaskId, &r.LastRunAt, &r.NextRunAt, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+	if len(res) > 0 {
+		repositoryPrimaryKeys := make([]int32, len(res))
+		for i, v := range res {
+			repositoryPrimaryKeys[i] = v.RepositoryId
+		}
+		repositoryData := make(map[int32]*database.SourceRepository)
+		{
+			rels, _ := d.sourceRepository.SelectMulti(ctx, repositoryPrimaryKeys...)
+			for _, v := range rels {
+				repositoryData[v.Id] = v
+			}
+		}
+		for _, v := range res {
+			v.Repository = repositoryData[v.RepositoryId]
+		}
+	}
+
+	return res, nil
+}
+
+func (d *JobSchedule) Create(ctx context.Context, jobSchedule *database.JobSchedule, opt ...ExecOption) (*database.JobSchedule, error) {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(
+		ctx,
+		"INSERT INTO `job_schedule` (`repository_id`, `job_name`, `schedule`, `last_task_id`, `last_run_at`, `next_run_at`, `created_at`) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		jobSchedule.RepositoryId, jobSchedule.JobName, jobSchedule.Schedule, jobSchedule.LastTaskId, jobSchedule.LastRunAt, jobSchedule.NextRunAt, time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	jobSchedule = jobSchedule.Copy()
+	insertedId, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	jobSchedule.Id = int32(insertedId)
+
+	jobSchedule.ResetMark()
+	return jobSchedule, nil
+}
+
+func (d *JobSchedule) Delete(ctx context.Context, id int32, opt ...ExecOption) error {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(ctx, "DELETE FROM `job_schedule` WHERE `id` = ?", id)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (d *JobSchedule) Update(ctx context.Context, jobSchedule *database.JobSchedule, opt ...ExecOption) error {
+	if !jobSchedule.IsChanged() {
+		return nil
+	}
+
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	changedColumn := jobSchedule.ChangedColumn()
+	cols := make([]string, len(changedColumn)+1)
+	values := make([]interface{}, len(changedColumn)+1)
+	for i := range changedColumn {
+		cols[i] = "`" + changedColumn[i].Name + "` = ?"
+		values[i] = changedColumn[i].Value
+	}
+	cols[len(cols)-1] = "`updated_at` = ?"
+	values[len(values)-1] = time.Now()
+
+	query := fmt.Sprintf("UPDATE `job_schedule` SET %s WHERE `id` = ?", strings.Join(cols, ", "))
+	res, err := conn.ExecContext(
+		ctx,
+		query,
+		append(values, jobSchedule.Id)...,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	jobSchedule.ResetMark()
+	return nil
+}
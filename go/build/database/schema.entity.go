@@ -22,13 +22,14 @@ const (
 )
 
 type SourceRepository struct {
-	Id        int32
-	Url       string
-	CloneUrl  string
-	Name      string
-	Private   bool
-	CreatedAt time.Time
-	UpdatedAt *time.Time
+	Id                    int32
+	Url                   string
+	CloneUrl              string
+	Name                  string
+	Private               bool
+	ArtifactRetentionDays int32
+	CreatedAt             time.Time
+	UpdatedAt             *time.Time
 
 	mu   sync.Mutex
 	mark *SourceRepository
@@ -49,6 +50,7 @@ func (e *SourceRepository) IsChanged() bool {
 		e.CloneUrl != e.mark.CloneUrl ||
 		e.Name != e.mark.Name ||
 		e.Private != e.mark.Private ||
+		e.ArtifactRetentionDays != e.mark.ArtifactRetentionDays ||
 		!e.CreatedAt.Equal(e.mark.CreatedAt) ||
 		((e.UpdatedAt != nil && (e.mark.UpdatedAt == nil || !e.UpdatedAt.Equal(*e.mark.UpdatedAt))) || (e.UpdatedAt == nil && e.mark.UpdatedAt != nil))
 }
@@ -70,6 +72,9 @@ func (e *SourceRepository) ChangedColumn() []ddl.Column {
 	if e.Private != e.mark.Private {
 		res = append(res, ddl.Column{Name: "private", Value: e.Private})
 	}
+	if e.ArtifactRetentionDays != e.mark.ArtifactRetentionDays {
+		res = append(res, ddl.Column{Name: "artifact_retention_days", Value: e.ArtifactRetentionDays})
+	}
 	if !e.CreatedAt.Equal(e.mark.CreatedAt) {
 		res = append(res, ddl.Column{Name: "created_at", Value: e.CreatedAt})
 	}
@@ -86,12 +91,13 @@ func (e *SourceRepository) ChangedColumn() []ddl.Column {
 
 func (e *SourceRepository) Copy() *SourceRepository {
 	n := &SourceRepository{
-		Id:        e.Id,
-		Url:       e.Url,
-		CloneUrl:  e.CloneUrl,
-		Name:      e.Name,
-		Private:   e.Private,
-		CreatedAt: e.CreatedAt,
+		Id:                    e.Id,
+		Url:                   e.Url,
+		CloneUrl:              e.CloneUrl,
+		Name:                  e.Name,
+		Private:               e.Private,
+		ArtifactRetentionDays: e.ArtifactRetentionDays,
+		CreatedAt:             e.CreatedAt,
 	}
 	if e.UpdatedAt != nil {
 		v := *e.UpdatedAt
@@ -127,6 +133,15 @@ type Task struct {
 	SucceededTestsCount int32
 	StartAt             *time.Time
 	FinishedAt          *time.Time
+	Canceled            bool
+	LinesFound          int32
+	LinesHit            int32
+	PullRequest         int32
+	SupersededBy        int32
+	Attempt             int32
+	RetryOf             int32
+	Matrix              string
+	GroupId             int32
 	CreatedAt           time.Time
 	UpdatedAt           *time.Time
 
@@ -169,6 +184,15 @@ func (e *Task) IsChanged() bool {
 		e.SucceededTestsCount != e.mark.SucceededTestsCount ||
 		((e.StartAt != nil && (e.mark.StartAt == nil || !e.StartAt.Equal(*e.mark.StartAt))) || (e.StartAt == nil && e.mark.StartAt != nil)) ||
 		((e.FinishedAt != nil && (e.mark.FinishedAt == nil || !e.FinishedAt.Equal(*e.mark.FinishedAt))) || (e.FinishedAt == nil && e.mark.FinishedAt != nil)) ||
+		e.Canceled != e.mark.Canceled ||
+		e.LinesFound != e.mark.LinesFound ||
+		e.LinesHit != e.mark.LinesHit ||
+		e.PullRequest != e.mark.PullRequest ||
+		e.SupersededBy != e.mark.SupersededBy ||
+		e.Attempt != e.mark.Attempt ||
+		e.RetryOf != e.mark.RetryOf ||
+		e.Matrix != e.mark.Matrix ||
+		e.GroupId != e.mark.GroupId ||
 		!e.CreatedAt.Equal(e.mark.CreatedAt) ||
 		((e.UpdatedAt != nil && (e.mark.UpdatedAt == nil || !e.UpdatedAt.Equal(*e.mark.UpdatedAt))) || (e.UpdatedAt == nil && e.mark.UpdatedAt != nil))
 }
@@ -256,6 +280,33 @@ func (e *Task) ChangedColumn() []ddl.Column {
 			res = append(res, ddl.Column{Name: "finished_at", Value: nil})
 		}
 	}
+	if e.Canceled != e.mark.Canceled {
+		res = append(res, ddl.Column{Name: "canceled", Value: e.Canceled})
+	}
+	if e.LinesFound != e.mark.LinesFound {
+		res = append(res, ddl.Column{Name: "lines_found", Value: e.LinesFound})
+	}
+	if e.LinesHit != e.mark.LinesHit {
+		res = append(res, ddl.Column{Name: "lines_hit", Value: e.LinesHit})
+	}
+	if e.PullRequest != e.mark.PullRequest {
+		res = append(res, ddl.Column{Name: "pull_request", Value: e.PullRequest})
+	}
+	if e.SupersededBy != e.mark.SupersededBy {
+		res = append(res, ddl.Column{Name: "superseded_by", Value: e.SupersededBy})
+	}
+	if e.Attempt != e.mark.Attempt {
+		res = append(res, ddl.Column{Name: "attempt", Value: e.Attempt})
+	}
+	if e.RetryOf != e.mark.RetryOf {
+		res = append(res, ddl.Column{Name: "retry_of", Value: e.RetryOf})
+	}
+	if e.Matrix != e.mark.Matrix {
+		res = append(res, ddl.Column{Name: "matrix", Value: e.Matrix})
+	}
+	if e.GroupId != e.mark.GroupId {
+		res = append(res, ddl.Column{Name: "group_id", Value: e.GroupId})
+	}
 	if !e.CreatedAt.Equal(e.mark.CreatedAt) {
 		res = append(res, ddl.Column{Name: "created_at", Value: e.CreatedAt})
 	}
@@ -292,6 +343,15 @@ func (e *Task) Copy() *Task {
 		Container:              e.Container,
 		ExecutedTestsCount:     e.ExecutedTestsCount,
 		SucceededTestsCount:    e.SucceededTestsCount,
+		Canceled:               e.Canceled,
+		LinesFound:             e.LinesFound,
+		LinesHit:               e.LinesHit,
+		PullRequest:            e.PullRequest,
+		SupersededBy:           e.SupersededBy,
+		Attempt:                e.Attempt,
+		RetryOf:                e.RetryOf,
+		Matrix:                 e.Matrix,
+		GroupId:                e.GroupId,
 		CreatedAt:              e.CreatedAt,
 	}
 	if e.JobConfiguration != nil {
@@ -322,6 +382,7 @@ type TrustedUser struct {
 	Id        int32
 	GithubId  int64
 	Username  string
+	Host      string
 	CreatedAt time.Time
 	UpdatedAt *time.Time
 
@@ -342,6 +403,7 @@ func (e *TrustedUser) IsChanged() bool {
 
 	return e.GithubId != e.mark.GithubId ||
 		e.Username != e.mark.Username ||
+		e.Host != e.mark.Host ||
 		!e.CreatedAt.Equal(e.mark.CreatedAt) ||
 		((e.UpdatedAt != nil && (e.mark.UpdatedAt == nil || !e.UpdatedAt.Equal(*e.mark.UpdatedAt))) || (e.UpdatedAt == nil && e.mark.UpdatedAt != nil))
 }
@@ -357,6 +419,9 @@ func (e *TrustedUser) ChangedColumn() []ddl.Column {
 	if e.Username != e.mark.Username {
 		res = append(res, ddl.Column{Name: "username", Value: e.Username})
 	}
+	if e.Host != e.mark.Host {
+		res = append(res, ddl.Column{Name: "host", Value: e.Host})
+	}
 	if !e.CreatedAt.Equal(e.mark.CreatedAt) {
 		res = append(res, ddl.Column{Name: "created_at", Value: e.CreatedAt})
 	}
@@ -376,6 +441,7 @@ func (e *TrustedUser) Copy() *TrustedUser {
 		Id:        e.Id,
 		GithubId:  e.GithubId,
 		Username:  e.Username,
+		Host:      e.Host,
 		CreatedAt: e.CreatedAt,
 	}
 	if e.UpdatedAt != nil {
@@ -390,6 +456,7 @@ type PermitPullRequest struct {
 	Id         int32
 	Repository string
 	Number     int32
+	Host       string
 	CreatedAt  time.Time
 	UpdatedAt  *time.Time
 
@@ -410,6 +477,7 @@ func (e *PermitPullRequest) IsChanged() bool {
 
 	return e.Repository != e.mark.Repository ||
 		e.Number != e.mark.Number ||
+		e.Host != e.mark.Host ||
 		!e.CreatedAt.Equal(e.mark.CreatedAt) ||
 		((e.UpdatedAt != nil && (e.mark.UpdatedAt == nil || !e.UpdatedAt.Equal(*e.mark.UpdatedAt))) || (e.UpdatedAt == nil && e.mark.UpdatedAt != nil))
 }
@@ -425,6 +493,9 @@ func (e *PermitPullRequest) ChangedColumn() []ddl.Column {
 	if e.Number != e.mark.Number {
 		res = append(res, ddl.Column{Name: "number", Value: e.Number})
 	}
+	if e.Host != e.mark.Host {
+		res = append(res, ddl.Column{Name: "host", Value: e.Host})
+	}
 	if !e.CreatedAt.Equal(e.mark.CreatedAt) {
 		res = append(res, ddl.Column{Name: "created_at", Value: e.CreatedAt})
 	}
@@ -444,6 +515,7 @@ func (e *PermitPullRequest) Copy() *PermitPullRequest {
 		Id:         e.Id,
 		Repository: e.Repository,
 		Number:     e.Number,
+		Host:       e.Host,
 		CreatedAt:  e.CreatedAt,
 	}
 	if e.UpdatedAt != nil {
@@ -536,3 +608,349 @@ func (e *TestReport) Copy() *TestReport {
 
 	return n
 }
+
+type FlakyTest struct {
+	Id           int32
+	RepositoryId int32
+	Label        string
+	Revision     string
+	CreatedAt    time.Time
+	UpdatedAt    *time.Time
+
+	Repository *SourceRepository
+
+	mu   sync.Mutex
+	mark *FlakyTest
+}
+
+func (e *FlakyTest) ResetMark() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.mark = e.Copy()
+}
+
+func (e *FlakyTest) IsChanged() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.RepositoryId != e.mark.RepositoryId ||
+		e.Label != e.mark.Label ||
+		e.Revision != e.mark.Revision ||
+		!e.CreatedAt.Equal(e.mark.CreatedAt) ||
+		((e.UpdatedAt != nil && (e.mark.UpdatedAt == nil || !e.UpdatedAt.Equal(*e.mark.UpdatedAt))) || (e.UpdatedAt == nil && e.mark.UpdatedAt != nil))
+}
+
+func (e *FlakyTest) ChangedColumn() []ddl.Column {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	res := make([]ddl.Column, 0)
+	if e.RepositoryId != e.mark.RepositoryId {
+		res = append(res, ddl.Column{Name: "repository_id", Value: e.RepositoryId})
+	}
+	if e.Label != e.mark.Label {
+		res = append(res, ddl.Column{Name: "label", Value: e.Label})
+	}
+	if e.Revision != e.mark.Revision {
+		res = append(res, ddl.Column{Name: "revision", Value: e.Revision})
+	}
+	if !e.CreatedAt.Equal(e.mark.CreatedAt) {
+		res = append(res, ddl.Column{Name: "created_at", Value: e.CreatedAt})
+	}
+	if (e.UpdatedAt != nil && (e.mark.UpdatedAt == nil || !e.UpdatedAt.Equal(*e.mark.UpdatedAt))) || (e.UpdatedAt == nil && e.mark.UpdatedAt != nil) {
+		if e.UpdatedAt != nil {
+			res = append(res, ddl.Column{Name: "updated_at", Value: *e.UpdatedAt})
+		} else {
+			res = append(res, ddl.Column{Name: "updated_at", Value: nil})
+		}
+	}
+
+	return res
+}
+
+func (e *FlakyTest) Copy() *FlakyTest {
+	n := &FlakyTest{
+		Id:           e.Id,
+		RepositoryId: e.RepositoryId,
+		Label:        e.Label,
+		Revision:     e.Revision,
+		CreatedAt:    e.CreatedAt,
+	}
+	if e.UpdatedAt != nil {
+		v := *e.UpdatedAt
+		n.UpdatedAt = &v
+	}
+
+	if e.Repository != nil {
+		n.Repository = e.Repository.Copy()
+	}
+
+	return n
+}
+
+type CoverageReport struct {
+	Id           int32
+	RepositoryId int32
+	TaskId       int32
+	Path         string
+	LinesFound   int32
+	LinesHit     int32
+
+	Repository *SourceRepository
+	Task       *Task
+
+	mu   sync.Mutex
+	mark *CoverageReport
+}
+
+func (e *CoverageReport) ResetMark() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.mark = e.Copy()
+}
+
+func (e *CoverageReport) IsChanged() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.RepositoryId != e.mark.RepositoryId ||
+		e.TaskId != e.mark.TaskId ||
+		e.Path != e.mark.Path ||
+		e.LinesFound != e.mark.LinesFound ||
+		e.LinesHit != e.mark.LinesHit
+}
+
+func (e *CoverageReport) ChangedColumn() []ddl.Column {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	res := make([]ddl.Column, 0)
+	if e.RepositoryId != e.mark.RepositoryId {
+		res = append(res, ddl.Column{Name: "repository_id", Value: e.RepositoryId})
+	}
+	if e.TaskId != e.mark.TaskId {
+		res = append(res, ddl.Column{Name: "task_id", Value: e.TaskId})
+	}
+	if e.Path != e.mark.Path {
+		res = append(res, ddl.Column{Name: "path", Value: e.Path})
+	}
+	if e.LinesFound != e.mark.LinesFound {
+		res = append(res, ddl.Column{Name: "lines_found", Value: e.LinesFound})
+	}
+	if e.LinesHit != e.mark.LinesHit {
+		res = append(res, ddl.Column{Name: "lines_hit", Value: e.LinesHit})
+	}
+
+	return res
+}
+
+func (e *CoverageReport) Copy() *CoverageReport {
+	n := &CoverageReport{
+		Id:           e.Id,
+		RepositoryId: e.RepositoryId,
+		TaskId:       e.TaskId,
+		Path:         e.Path,
+		LinesFound:   e.LinesFound,
+		LinesHit:     e.LinesHit,
+	}
+
+	if e.Repository != nil {
+		n.Repository = e.Repository.Copy()
+	}
+	if e.Task != nil {
+		n.Task = e.Task.Copy()
+	}
+
+	return n
+}
+
+type Artifact struct {
+	Id           int32
+	RepositoryId int32
+	TaskId       int32
+	Name         string
+	Path         string
+	Size         int64
+
+	Repository *SourceRepository
+	Task       *Task
+
+	mu   sync.Mutex
+	mark *Artifact
+}
+
+func (e *Artifact) ResetMark() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.mark = e.Copy()
+}
+
+func (e *Artifact) IsChanged() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.RepositoryId != e.mark.RepositoryId ||
+		e.TaskId != e.mark.TaskId ||
+		e.Name != e.mark.Name ||
+		e.Path != e.mark.Path ||
+		e.Size != e.mark.Size
+}
+
+func (e *Artifact) ChangedColumn() []ddl.Column {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	res := make([]ddl.Column, 0)
+	if e.RepositoryId != e.mark.RepositoryId {
+		res = append(res, ddl.Column{Name: "repository_id", Value: e.RepositoryId})
+	}
+	if e.TaskId != e.mark.TaskId {
+		res = append(res, ddl.Column{Name: "task_id", Value: e.TaskId})
+	}
+	if e.Name != e.mark.Name {
+		res = append(res, ddl.Column{Name: "name", Value: e.Name})
+	}
+	if e.Path != e.mark.Path {
+		res = append(res, ddl.Column{Name: "path", Value: e.Path})
+	}
+	if e.Size != e.mark.Size {
+		res = append(res, ddl.Column{Name: "size", Value: e.Size})
+	}
+
+	return res
+}
+
+func (e *Artifact) Copy() *Artifact {
+	n := &Artifact{
+		Id:           e.Id,
+		RepositoryId: e.RepositoryId,
+		TaskId:       e.TaskId,
+		Name:         e.Name,
+		Path:         e.Path,
+		Size:         e.Size,
+	}
+
+	if e.Repository != nil {
+		n.Repository = e.Repository.Copy()
+	}
+	if e.Task != nil {
+		n.Task = e.Task.Copy()
+	}
+
+	return n
+}
+
+type JobSchedule struct {
+	Id           int32
+	RepositoryId int32
+	JobName      string
+	Schedule     string
+	LastTaskId   int32
+	LastRunAt    *time.Time
+	NextRunAt    *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    *time.Time
+
+	Repository *SourceRepository
+
+	mu   sync.Mutex
+	mark *JobSchedule
+}
+
+func (e *JobSchedule) ResetMark() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.mark = e.Copy()
+}
+
+func (e *JobSchedule) IsChanged() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.RepositoryId != e.mark.RepositoryId ||
+		e.JobName != e.mark.JobName ||
+		e.Schedule != e.mark.Schedule ||
+		e.LastTaskId != e.mark.LastTaskId ||
+		((e.LastRunAt != nil && (e.mark.LastRunAt == nil || !e.LastRunAt.Equal(*e.mark.LastRunAt))) || (e.LastRunAt == nil && e.mark.LastRunAt != nil)) ||
+		((e.NextRunAt != nil && (e.mark.NextRunAt == nil || !e.NextRunAt.Equal(*e.mark.NextRunAt))) || (e.NextRunAt == nil && e.mark.NextRunAt != nil)) ||
+		!e.CreatedAt.Equal(e.mark.CreatedAt) ||
+		((e.UpdatedAt != nil && (e.mark.UpdatedAt == nil || !e.UpdatedAt.Equal(*e.mark.UpdatedAt))) || (e.UpdatedAt == nil && e.mark.UpdatedAt != nil))
+}
+
+func (e *JobSchedule) ChangedColumn() []ddl.Column {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	res := make([]ddl.Column, 0)
+	if e.RepositoryId != e.mark.RepositoryId {
+		res = append(res, ddl.Column{Name: "repository_id", Value: e.RepositoryId})
+	}
+	if e.JobName != e.mark.JobName {
+		res = append(res, ddl.Column{Name: "job_name", Value: e.JobName})
+	}
+	if e.Schedule != e.mark.Schedule {
+		res = append(res, ddl.Column{Name: "schedule", Value: e.Schedule})
+	}
+	if e.LastTaskId != e.mark.LastTaskId {
+		res = append(res, ddl.Column{Name: "last_task_id", Value: e.LastTaskId})
+	}
+	if (e.LastRunAt != nil && (e.mark.LastRunAt == nil || !e.LastRunAt.Equal(*e.mark.LastRunAt))) || (e.LastRunAt == nil && e.mark.LastRunAt != nil) {
+		if e.LastRunAt != nil {
+			res = append(res, ddl.Column{Name: "last_run_at", Value: *e.LastRunAt})
+		} else {
+			res = append(res, ddl.Column{Name: "last_run_at", Value: nil})
+		}
+	}
+	if (e.NextRunAt != nil && (e.mark.NextRunAt == nil || !e.NextRunAt.Equal(*e.mark.NextRunAt))) || (e.NextRunAt == nil && e.mark.NextRunAt != nil) {
+		if e.NextRunAt != nil {
+			res = append(res, ddl.Column{Name: "next_run_at", Value: *e.NextRunAt})
+		} else {
+			res = append(res, ddl.Column{Name: "next_run_at", Value: nil})
+		}
+	}
+	if !e.CreatedAt.Equal(e.mark.CreatedAt) {
+		res = append(res, ddl.Column{Name: "created_at", Value: e.CreatedAt})
+	}
+	if (e.UpdatedAt != nil && (e.mark.UpdatedAt == nil || !e.UpdatedAt.Equal(*e.mark.UpdatedAt))) || (e.UpdatedAt == nil && e.mark.UpdatedAt != nil) {
+		if e.UpdatedAt != nil {
+			res = append(res, ddl.Column{Name: "updated_at", Value: *e.UpdatedAt})
+		} else {
+			res = append(res, ddl.Column{Name: "updated_at", Value: nil})
+		}
+	}
+
+	return res
+}
+
+func (e *JobSchedule) Copy() *JobSchedule {
+	n := &JobSchedule{
+		Id:           e.Id,
+		RepositoryId: e.RepositoryId,
+		JobName:      e.JobName,
+		Schedule:     e.Schedule,
+		LastTaskId:   e.LastTaskId,
+		CreatedAt:    e.CreatedAt,
+	}
+	if e.LastRunAt != nil {
+		v := *e.LastRunAt
+		n.LastRunAt = &v
+	}
+	if e.NextRunAt != nil {
+		v := *e.NextRunAt
+		n.NextRunAt = &v
+	}
+	if e.UpdatedAt != nil {
+		v := *e.UpdatedAt
+		n.UpdatedAt = &v
+	}
+
+	if e.Repository != nil {
+		n.Repository = e.Repository.Copy()
+	}
+
+	return n
+}
@@ -0,0 +1,173 @@
+package forge
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.f110.dev/xerrors"
+)
+
+type Kind string
+
+const (
+	KindGitHub Kind = "github"
+	KindGitea  Kind = "gitea"
+	KindGitLab Kind = "gitlab"
+)
+
+type EventType string
+
+const (
+	EventPush        EventType = "push"
+	EventPullRequest EventType = "pull_request"
+	EventComment     EventType = "comment"
+	EventRelease     EventType = "release"
+)
+
+// The actions of the event are normalized to the same values as GitHub.
+const (
+	ActionOpened      = "opened"
+	ActionSynchronize = "synchronize"
+	ActionClosed      = "closed"
+	ActionCreated     = "created"
+	ActionPublished   = "published"
+)
+
+type StatusState string
+
+const (
+	StatusPending StatusState = "pending"
+	StatusSuccess StatusState = "success"
+	StatusFailure StatusState = "failure"
+	StatusError   StatusState = "error"
+)
+
+// Repository is the repository on the forge.
+// Owner is the namespace of the repository. In GitLab, Owner may contain the sub groups (e.g. group/subgroup).
+type Repository struct {
+	Owner string
+	Name  string
+}
+
+func (r *Repository) FullName() string {
+	return r.Owner + "/" + r.Name
+}
+
+type User struct {
+	ID    int64
+	Login string
+}
+
+// Event is the normalized webhook event.
+type Event struct {
+	Kind          Kind
+	Type          EventType
+	Action        string
+	Repository    *Repository
+	RepositoryURL string
+	DefaultBranch string
+	// Ref is the full name of the ref (e.g. refs/heads/master). Ref is set only if Type is EventPush.
+	Ref string
+	// Revision is the head commit of the push event or the pull request.
+	Revision string
+	// Message is the message of the head commit of the push event or the title of the pull request.
+	Message string
+	// Number is the number of the pull request.
+	Number  int
+	Sender  *User
+	Comment string
+	Tag     string
+}
+
+type CommitStatus struct {
+	State       StatusState
+	Context     string
+	Description string
+	TargetURL   string
+}
+
+// Client is the interface for the hosting service of the repository.
+type Client interface {
+	Kind() Kind
+	// Host returns the hostname of the forge. The client is chosen by the hostname of the URL of the repository.
+	Host() string
+	CreateComment(ctx context.Context, repo *Repository, number int, body string) error
+	CreateStatus(ctx context.Context, repo *Repository, revision string, status *CommitStatus) error
+	GetPullRequestHead(ctx context.Context, repo *Repository, number int) (string, error)
+	GetDefaultBranch(ctx context.Context, repo *Repository) (string, error)
+	// GetCommit returns SHA of the commit which is pointed by ref.
+	GetCommit(ctx context.Context, repo *Repository, ref string) (string, error)
+	GetTagCommit(ctx context.Context, repo *Repository, tag string) (string, error)
+	// GetFiles returns the content of the files at the revision.
+	// The file which doesn't exist is not contained in the returned value.
+	GetFiles(ctx context.Context, repo *Repository, revision string, paths ...string) (map[string][]byte, error)
+	// HasWritePermission returns true if the user is allowed to push to the repository.
+	HasWritePermission(ctx context.Context, repo *Repository, user *User) (bool, error)
+}
+
+// Set is the collection of the clients.
+type Set struct {
+	clients []Client
+}
+
+func NewSet(clients ...Client) *Set {
+	s := &Set{}
+	for _, v := range clients {
+		if v == nil {
+			continue
+		}
+		s.clients = append(s.clients, v)
+	}
+	return s
+}
+
+// Client returns the client for the repository URL and the repository.
+func (s *Set) Client(repoURL string) (Client, *Repository, error) {
+	host, repo, err := ParseRepositoryURL(repoURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s == nil {
+		return nil, nil, xerrors.Definef("the forge for %s is not configured", host).WithStack()
+	}
+	for _, v := range s.clients {
+		if v.Host() == host {
+			return v, repo, nil
+		}
+	}
+	return nil, nil, xerrors.Definef("the forge for %s is not configured", host).WithStack()
+}
+
+// ParseRepositoryURL returns the hostname and the repository from the URL of the repository.
+func ParseRepositoryURL(repoURL string) (string, *Repository, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", nil, xerrors.WithStack(err)
+	}
+	p := strings.Trim(strings.TrimSuffix(u.Path, ".git"), "/")
+	i := strings.LastIndex(p, "/")
+	if i <= 0 || i == len(p)-1 {
+		return "", nil, xerrors.Definef("invalid repository url: %s", repoURL).WithStack()
+	}
+	return u.Hostname(), &Repository{Owner: p[:i], Name: p[i+1:]}, nil
+}
+
+// ParseWebHook parses the payload of the webhook.
+// The kind of the forge is detected by the header of the request.
+// If the event is not handled by the builder, ParseWebHook returns nil.
+func ParseWebHook(req *http.Request, payload []byte) (*Event, error) {
+	// Gitea sends X-GitHub-Event header for compatibility. Thus, X-Gitea-Event has to be checked before X-GitHub-Event.
+	if v := req.Header.Get("X-Gitea-Event"); v != "" {
+		return parseGiteaWebHook(v, payload)
+	}
+	if v := req.Header.Get("X-Gitlab-Event"); v != "" {
+		return parseGitLabWebHook(v, payload)
+	}
+	if v := req.Header.Get("X-GitHub-Event"); v != "" {
+		return parseGitHubWebHook(v, payload)
+	}
+
+	return nil, xerrors.Define("unknown webhook").WithStack()
+}
@@ -0,0 +1,213 @@
+package forge
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRepositoryURL(t *testing.T) {
+	cases := []struct {
+		URL   string
+		Host  string
+		Owner string
+		Name  string
+		Err   bool
+	}{
+		{URL: "https://github.com/f110/sandbox", Host: "github.com", Owner: "f110", Name: "sandbox"},
+		{URL: "https://gitea.example.com/f110/sandbox.git", Host: "gitea.example.com", Owner: "f110", Name: "sandbox"},
+		{URL: "https://gitlab.com/f110/group/sandbox", Host: "gitlab.com", Owner: "f110/group", Name: "sandbox"},
+		{URL: "https://github.com/f110", Err: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.URL, func(t *testing.T) {
+			host, repo, err := ParseRepositoryURL(tc.URL)
+			if tc.Err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.Host, host)
+			assert.Equal(t, tc.Owner, repo.Owner)
+			assert.Equal(t, tc.Name, repo.Name)
+		})
+	}
+}
+
+func TestSet_Client(t *testing.T) {
+	gitea, err := NewGitea("https://gitea.example.com", "", nil)
+	require.NoError(t, err)
+	s := NewSet(NewGitHub(nil), gitea)
+
+	c, repo, err := s.Client("https://gitea.example.com/f110/sandbox")
+	require.NoError(t, err)
+	assert.Equal(t, KindGitea, c.Kind())
+	assert.Equal(t, "f110/sandbox", repo.FullName())
+
+	c, _, err = s.Client("https://github.com/f110/sandbox")
+	require.NoError(t, err)
+	assert.Equal(t, KindGitHub, c.Kind())
+
+	_, _, err = s.Client("https://gitlab.com/f110/sandbox")
+	assert.Error(t, err)
+}
+
+func TestParseWebHook(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Header  map[string]string
+		Payload string
+		Expect  *Event
+	}{
+		{
+			Name:   "GitHubPullRequest",
+			Header: map[string]string{"X-GitHub-Event": "pull_request"},
+			Payload: `{"action":"synchronize","pull_request":{"number":2,"title":"Fix","head":{"sha":"abc"}},
+"repository":{"name":"sandbox","owner":{"login":"f110"},"html_url":"https://github.com/f110/sandbox","default_branch":"master"},
+"sender":{"id":10,"login":"octocat"}}`,
+			Expect: &Event{
+				Kind:          KindGitHub,
+				Type:          EventPullRequest,
+				Action:        ActionSynchronize,
+				Repository:    &Repository{Owner: "f110", Name: "sandbox"},
+				RepositoryURL: "https://github.com/f110/sandbox",
+				DefaultBranch: "master",
+				Revision:      "abc",
+				Message:       "Fix",
+				Number:        2,
+				Sender:        &User{ID: 10, Login: "octocat"},
+			},
+		},
+		{
+			Name: "GiteaPullRequest",
+			// Gitea sends X-GitHub-Event too.
+			Header: map[string]string{"X-Gitea-Event": "pull_request", "X-GitHub-Event": "pull_request"},
+			Payload: `{"action":"synchronized","number":2,"pull_request":{"number":2,"title":"Fix","head":{"sha":"abc"}},
+"repository":{"name":"sandbox","owner":{"login":"f110"},"html_url":"https://gitea.example.com/f110/sandbox","default_branch":"master"},
+"sender":{"id":10,"login":"octocat"}}`,
+			Expect: &Event{
+				Kind:          KindGitea,
+				Type:          EventPullRequest,
+				Action:        ActionSynchronize,
+				Repository:    &Repository{Owner: "f110", Name: "sandbox"},
+				RepositoryURL: "https://gitea.example.com/f110/sandbox",
+				DefaultBranch: "master",
+				Revision:      "abc",
+				Message:       "Fix",
+				Number:        2,
+				Sender:        &User{ID: 10, Login: "octocat"},
+			},
+		},
+		{
+			Name:   "GiteaPush",
+			Header: map[string]string{"X-Gitea-Event": "push"},
+			Payload: `{"ref":"refs/heads/master","after":"abc","head_commit":{"id":"abc","message":"[skip ci] Update"},
+"repository":{"name":"sandbox","owner":{"login":"f110"},"html_url":"https://gitea.example.com/f110/sandbox","default_branch":"master"},
+"sender":{"id":10,"login":"octocat"}}`,
+			Expect: &Event{
+				Kind:          KindGitea,
+				Type:          EventPush,
+				Repository:    &Repository{Owner: "f110", Name: "sandbox"},
+				RepositoryURL: "https://gitea.example.com/f110/sandbox",
+				DefaultBranch: "master",
+				Ref:           "refs/heads/master",
+				Revision:      "abc",
+				Message:       "[skip ci] Update",
+				Sender:        &User{ID: 10, Login: "octocat"},
+			},
+		},
+		{
+			Name:   "GitLabMergeRequest",
+			Header: map[string]string{"X-Gitlab-Event": "Merge Request Hook"},
+			Payload: `{"object_kind":"merge_request","user":{"id":10,"username":"octocat"},
+"project":{"web_url":"https://gitlab.com/f110/group/sandbox","default_branch":"main"},
+"object_attributes":{"iid":3,"title":"Fix","action":"open","last_commit":{"id":"abc"}}}`,
+			Expect: &Event{
+				Kind:          KindGitLab,
+				Type:          EventPullRequest,
+				Action:        ActionOpened,
+				Repository:    &Repository{Owner: "f110/group", Name: "sandbox"},
+				RepositoryURL: "https://gitlab.com/f110/group/sandbox",
+				DefaultBranch: "main",
+				Revision:      "abc",
+				Message:       "Fix",
+				Number:        3,
+				Sender:        &User{ID: 10, Login: "octocat"},
+			},
+		},
+		{
+			Name:   "GitHubIssueComment",
+			Header: map[string]string{"X-GitHub-Event": "issue_comment"},
+			Payload: `{"action":"created","issue":{"number":2,"pull_request":{"url":"https://api.github.com/repos/f110/sandbox/pulls/2"}},"comment":{"body":"/allow-build"},
+"repository":{"name":"sandbox","owner":{"login":"f110"},"html_url":"https://github.com/f110/sandbox","default_branch":"master"},
+"sender":{"id":10,"login":"octocat"}}`,
+			Expect: &Event{
+				Kind:          KindGitHub,
+				Type:          EventComment,
+				Action:        ActionCreated,
+				Repository:    &Repository{Owner: "f110", Name: "sandbox"},
+				RepositoryURL: "https://github.com/f110/sandbox",
+				DefaultBranch: "master",
+				Number:        2,
+				Sender:        &User{ID: 10, Login: "octocat"},
+				Comment:       "/allow-build",
+			},
+		},
+		{
+			// The comment for the issue is ignored.
+			Name:   "GitHubIssueCommentForIssue",
+			Header: map[string]string{"X-GitHub-Event": "issue_comment"},
+			Payload: `{"action":"created","issue":{"number":2},"comment":{"body":"/allow-build"},
+"repository":{"name":"sandbox","owner":{"login":"f110"},"html_url":"https://github.com/f110/sandbox","default_branch":"master"},
+"sender":{"id":10,"login":"octocat"}}`,
+		},
+		{
+			Name:   "GitLabMergeRequestUpdatedWithoutCommit",
+			Header: map[string]string{"X-Gitlab-Event": "Merge Request Hook"},
+			Payload: `{"object_kind":"merge_request","user":{"id":10,"username":"octocat"},
+"project":{"web_url":"https://gitlab.com/f110/sandbox","default_branch":"main"},
+"object_attributes":{"iid":3,"title":"Fix","action":"update","last_commit":{"id":"abc"}}}`,
+		},
+		{
+			Name:   "GitLabNote",
+			Header: map[string]string{"X-Gitlab-Event": "Note Hook"},
+			Payload: `{"object_kind":"note","user":{"id":10,"username":"octocat"},
+"project":{"web_url":"https://gitlab.com/f110/sandbox","default_branch":"main"},
+"object_attributes":{"note":"/allow-build","noteable_type":"MergeRequest"},"merge_request":{"iid":3}}`,
+			Expect: &Event{
+				Kind:          KindGitLab,
+				Type:          EventComment,
+				Action:        ActionCreated,
+				Repository:    &Repository{Owner: "f110", Name: "sandbox"},
+				RepositoryURL: "https://gitlab.com/f110/sandbox",
+				DefaultBranch: "main",
+				Number:        3,
+				Sender:        &User{ID: 10, Login: "octocat"},
+				Comment:       "/allow-build",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(tc.Payload))
+			for k, v := range tc.Header {
+				req.Header.Set(k, v)
+			}
+			event, err := ParseWebHook(req, []byte(tc.Payload))
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expect, event)
+		})
+	}
+
+	t.Run("Unknown", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader("{}"))
+		_, err := ParseWebHook(req, []byte("{}"))
+		assert.Error(t, err)
+	})
+}
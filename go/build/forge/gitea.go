@@ -0,0 +1,248 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.f110.dev/xerrors"
+)
+
+type Gitea struct {
+	host   string
+	client *restClient
+}
+
+var _ Client = &Gitea{}
+
+// NewGitea returns the client of Gitea. baseURL is the URL of the top page of Gitea (e.g. https://gitea.example.com).
+func NewGitea(baseURL, token string, httpClient *http.Client) (*Gitea, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	if u.Host == "" {
+		return nil, xerrors.Definef("invalid url: %s", baseURL).WithStack()
+	}
+	c := &restClient{
+		endpoint:   strings.TrimSuffix(baseURL, "/") + "/api/v1",
+		authHeader: "Authorization",
+		httpClient: httpClient,
+	}
+	if token != "" {
+		c.authValue = "token " + token
+	}
+	return &Gitea{host: u.Hostname(), client: c}, nil
+}
+
+func (*Gitea) Kind() Kind {
+	return KindGitea
+}
+
+func (g *Gitea) Host() string {
+	return g.host
+}
+
+func (g *Gitea) CreateComment(ctx context.Context, repo *Repository, number int, body string) error {
+	return g.client.do(ctx, http.MethodPost, fmt.Sprintf("%s/issues/%d/comments", g.repoPath(repo), number), map[string]string{"body": body}, nil)
+}
+
+func (g *Gitea) CreateStatus(ctx context.Context, repo *Repository, revision string, status *CommitStatus) error {
+	in := map[string]string{
+		"state":       string(status.State),
+		"context":     status.Context,
+		"description": status.Description,
+		"target_url":  status.TargetURL,
+	}
+	return g.client.do(ctx, http.MethodPost, fmt.Sprintf("%s/statuses/%s", g.repoPath(repo), revision), in, nil)
+}
+
+func (g *Gitea) GetPullRequestHead(ctx context.Context, repo *Repository, number int) (string, error) {
+	var pr giteaPullRequest
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("%s/pulls/%d", g.repoPath(repo), number), nil, &pr); err != nil {
+		return "", err
+	}
+	return pr.Head.Sha, nil
+}
+
+func (g *Gitea) GetDefaultBranch(ctx context.Context, repo *Repository) (string, error) {
+	var r giteaRepository
+	if err := g.client.do(ctx, http.MethodGet, g.repoPath(repo), nil, &r); err != nil {
+		return "", err
+	}
+	return r.DefaultBranch, nil
+}
+
+func (g *Gitea) GetCommit(ctx context.Context, repo *Repository, ref string) (string, error) {
+	if ref == "HEAD" {
+		branch, err := g.GetDefaultBranch(ctx, repo)
+		if err != nil {
+			return "", err
+		}
+		ref = branch
+	}
+	var commit struct {
+		Sha string `json:"sha"`
+	}
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("%s/git/commits/%s", g.repoPath(repo), url.PathEscape(ref)), nil, &commit); err != nil {
+		return "", err
+	}
+	return commit.Sha, nil
+}
+
+func (g *Gitea) GetTagCommit(ctx context.Context, repo *Repository, tag string) (string, error) {
+	var t struct {
+		Commit struct {
+			Sha string `json:"sha"`
+		} `json:"commit"`
+	}
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("%s/tags/%s", g.repoPath(repo), url.PathEscape(tag)), nil, &t); err != nil {
+		return "", err
+	}
+	return t.Commit.Sha, nil
+}
+
+func (g *Gitea) GetFiles(ctx context.Context, repo *Repository, revision string, paths ...string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	for _, v := range paths {
+		buf, err := g.client.raw(ctx, http.MethodGet, fmt.Sprintf("%s/raw/%s?ref=%s", g.repoPath(repo), v, url.QueryEscape(revision)), nil)
+		if errors.Is(err, errNotFound) {
+			continue
+		} else if err != nil {
+			return nil, xerrors.WithMessagef(err, "failed to get the file: %s", v)
+		}
+		files[v] = buf
+	}
+	return files, nil
+}
+
+func (g *Gitea) HasWritePermission(ctx context.Context, repo *Repository, user *User) (bool, error) {
+	var perm struct {
+		Permission string `json:"permission"`
+	}
+	err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("%s/collaborators/%s/permission", g.repoPath(repo), url.PathEscape(user.Login)), nil, &perm)
+	if errors.Is(err, errNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	switch perm.Permission {
+	case "owner", "admin", "write":
+		return true, nil
+	}
+	return false, nil
+}
+
+func (*Gitea) repoPath(repo *Repository) string {
+	return fmt.Sprintf("/repos/%s/%s", url.PathEscape(repo.Owner), url.PathEscape(repo.Name))
+}
+
+type giteaUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+type giteaRepository struct {
+	Name          string     `json:"name"`
+	FullName      string     `json:"full_name"`
+	HTMLURL       string     `json:"html_url"`
+	DefaultBranch string     `json:"default_branch"`
+	Owner         *giteaUser `json:"owner"`
+}
+
+type giteaPullRequest struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Head   struct {
+		Sha string `json:"sha"`
+	} `json:"head"`
+}
+
+type giteaWebHookPayload struct {
+	Action     string           `json:"action"`
+	Ref        string           `json:"ref"`
+	After      string           `json:"after"`
+	Number     int              `json:"number"`
+	IsPull     bool             `json:"is_pull"`
+	Repository *giteaRepository `json:"repository"`
+	Sender     *giteaUser       `json:"sender"`
+	HeadCommit *struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	} `json:"head_commit"`
+	PullRequest *giteaPullRequest `json:"pull_request"`
+	Issue       *struct {
+		Number int `json:"number"`
+	} `json:"issue"`
+	Comment *struct {
+		Body string `json:"body"`
+	} `json:"comment"`
+	Release *struct {
+		TagName string `json:"tag_name"`
+	} `json:"release"`
+}
+
+func parseGiteaWebHook(eventType string, payload []byte) (*Event, error) {
+	p := &giteaWebHookPayload{}
+	if err := json.Unmarshal(payload, p); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	if p.Repository == nil || p.Repository.Owner == nil {
+		return nil, xerrors.Define("repository is not found in the payload").WithStack()
+	}
+
+	e := &Event{
+		Kind:          KindGitea,
+		Action:        p.Action,
+		Repository:    &Repository{Owner: p.Repository.Owner.Login, Name: p.Repository.Name},
+		RepositoryURL: p.Repository.HTMLURL,
+		DefaultBranch: p.Repository.DefaultBranch,
+		Sender:        &User{},
+	}
+	if p.Sender != nil {
+		e.Sender = &User{ID: p.Sender.ID, Login: p.Sender.Login}
+	}
+	switch eventType {
+	case "push":
+		e.Type = EventPush
+		e.Ref = p.Ref
+		e.Revision = p.After
+		if p.HeadCommit != nil {
+			e.Revision = p.HeadCommit.ID
+			e.Message = p.HeadCommit.Message
+		}
+	case "pull_request":
+		if p.PullRequest == nil {
+			return nil, xerrors.Define("pull_request is not found in the payload").WithStack()
+		}
+		e.Type = EventPullRequest
+		e.Number = p.PullRequest.Number
+		e.Revision = p.PullRequest.Head.Sha
+		e.Message = p.PullRequest.Title
+		if p.Action == "synchronized" {
+			e.Action = ActionSynchronize
+		}
+	case "issue_comment":
+		// The comment for the issue is not needed.
+		if !p.IsPull || p.Issue == nil || p.Comment == nil {
+			return nil, nil
+		}
+		e.Type = EventComment
+		e.Number = p.Issue.Number
+		e.Comment = p.Comment.Body
+	case "release":
+		if p.Release == nil {
+			return nil, xerrors.Define("release is not found in the payload").WithStack()
+		}
+		e.Type = EventRelease
+		e.Tag = p.Release.TagName
+	default:
+		return nil, nil
+	}
+
+	return e, nil
+}
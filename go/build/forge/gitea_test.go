@@ -0,0 +1,93 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitea(t *testing.T) {
+	var status map[string]string
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "token foobar" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch req.Method + " " + req.URL.Path {
+		case "GET /api/v1/repos/f110/sandbox":
+			io.WriteString(w, `{"default_branch":"main"}`)
+		case "GET /api/v1/repos/f110/sandbox/git/commits/main":
+			io.WriteString(w, `{"sha":"headsha"}`)
+		case "GET /api/v1/repos/f110/sandbox/pulls/2":
+			io.WriteString(w, `{"number":2,"head":{"sha":"prsha"}}`)
+		case "GET /api/v1/repos/f110/sandbox/tags/v1.0.0":
+			io.WriteString(w, `{"name":"v1.0.0","commit":{"sha":"tagsha"}}`)
+		case "GET /api/v1/repos/f110/sandbox/raw/build.star":
+			if req.URL.Query().Get("ref") != "headsha" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			io.WriteString(w, `job()`)
+		case "GET /api/v1/repos/f110/sandbox/collaborators/octocat/permission":
+			io.WriteString(w, `{"permission":"write"}`)
+		case "GET /api/v1/repos/f110/sandbox/collaborators/reader/permission":
+			io.WriteString(w, `{"permission":"read"}`)
+		case "POST /api/v1/repos/f110/sandbox/statuses/headsha":
+			if err := json.NewDecoder(req.Body).Decode(&status); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer stub.Close()
+
+	c, err := NewGitea(stub.URL, "foobar", nil)
+	require.NoError(t, err)
+	repo := &Repository{Owner: "f110", Name: "sandbox"}
+	ctx := context.Background()
+
+	sha, err := c.GetCommit(ctx, repo, "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, "headsha", sha)
+
+	sha, err = c.GetPullRequestHead(ctx, repo, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "prsha", sha)
+
+	sha, err = c.GetTagCommit(ctx, repo, "v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "tagsha", sha)
+
+	files, err := c.GetFiles(ctx, repo, "headsha", "build.star", ".bazelversion")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"build.star": []byte("job()")}, files)
+
+	err = c.CreateStatus(ctx, repo, "headsha", &CommitStatus{State: StatusFailure, Context: "test foo", TargetURL: "http://localhost/task/1"})
+	require.NoError(t, err)
+	assert.Equal(t, "failure", status["state"])
+	assert.Equal(t, "test foo", status["context"])
+	assert.Equal(t, "http://localhost/task/1", status["target_url"])
+
+	_, err = c.GetPullRequestHead(ctx, repo, 3)
+	assert.Error(t, err)
+
+	ok, err := c.HasWritePermission(ctx, repo, &User{ID: 10, Login: "octocat"})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = c.HasWritePermission(ctx, repo, &User{ID: 11, Login: "reader"})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = c.HasWritePermission(ctx, repo, &User{ID: 12, Login: "guest"})
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,188 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v49/github"
+	"go.f110.dev/xerrors"
+)
+
+type GitHub struct {
+	client *github.Client
+}
+
+var _ Client = &GitHub{}
+
+func NewGitHub(client *github.Client) *GitHub {
+	return &GitHub{client: client}
+}
+
+func (*GitHub) Kind() Kind {
+	return KindGitHub
+}
+
+func (*GitHub) Host() string {
+	return "github.com"
+}
+
+func (g *GitHub) CreateComment(ctx context.Context, repo *Repository, number int, body string) error {
+	_, _, err := g.client.Issues.CreateComment(ctx, repo.Owner, repo.Name, number, &github.IssueComment{Body: github.String(body)})
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	return nil
+}
+
+func (g *GitHub) CreateStatus(ctx context.Context, repo *Repository, revision string, status *CommitStatus) error {
+	s := &github.RepoStatus{
+		State:     github.String(string(status.State)),
+		Context:   github.String(status.Context),
+		TargetURL: github.String(status.TargetURL),
+	}
+	if status.Description != "" {
+		s.Description = github.String(status.Description)
+	}
+	_, _, err := g.client.Repositories.CreateStatus(ctx, repo.Owner, repo.Name, revision, s)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	return nil
+}
+
+func (g *GitHub) GetPullRequestHead(ctx context.Context, repo *Repository, number int) (string, error) {
+	pr, res, err := g.client.PullRequests.Get(ctx, repo.Owner, repo.Name, number)
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", xerrors.Define("could not get pr").WithStack()
+	}
+	return pr.GetHead().GetSHA(), nil
+}
+
+func (g *GitHub) GetDefaultBranch(ctx context.Context, repo *Repository) (string, error) {
+	r, _, err := g.client.Repositories.Get(ctx, repo.Owner, repo.Name)
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	return r.GetDefaultBranch(), nil
+}
+
+func (g *GitHub) GetCommit(ctx context.Context, repo *Repository, ref string) (string, error) {
+	commit, _, err := g.client.Repositories.GetCommit(ctx, repo.Owner, repo.Name, ref, nil)
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	return commit.GetSHA(), nil
+}
+
+func (g *GitHub) GetTagCommit(ctx context.Context, repo *Repository, tag string) (string, error) {
+	ref, _, err := g.client.Git.GetRef(ctx, repo.Owner, repo.Name, fmt.Sprintf("tags/%s", tag))
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	return ref.Object.GetSHA(), nil
+}
+
+func (g *GitHub) GetFiles(ctx context.Context, repo *Repository, revision string, paths ...string) (map[string][]byte, error) {
+	tree, _, err := g.client.Git.GetTree(ctx, repo.Owner, repo.Name, revision, false)
+	if err != nil {
+		return nil, xerrors.WithMessagef(err, "failed to get the tree: %s", revision)
+	}
+	blobs := make(map[string]string)
+	for _, e := range tree.Entries {
+		blobs[e.GetPath()] = e.GetSHA()
+	}
+
+	files := make(map[string][]byte)
+	for _, v := range paths {
+		sha, ok := blobs[v]
+		if !ok {
+			continue
+		}
+		buf, _, err := g.client.Git.GetBlobRaw(ctx, repo.Owner, repo.Name, sha)
+		if err != nil {
+			return nil, xerrors.WithMessagef(err, "failed to get the blob: %s", v)
+		}
+		files[v] = buf
+	}
+	return files, nil
+}
+
+func (g *GitHub) HasWritePermission(ctx context.Context, repo *Repository, user *User) (bool, error) {
+	perm, _, err := g.client.Repositories.GetPermissionLevel(ctx, repo.Owner, repo.Name, user.Login)
+	if err != nil {
+		return false, xerrors.WithStack(err)
+	}
+	switch perm.GetPermission() {
+	case "admin", "write":
+		return true, nil
+	}
+	return false, nil
+}
+
+func parseGitHubWebHook(messageType string, payload []byte) (*Event, error) {
+	event, err := github.ParseWebHook(messageType, payload)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	switch event := event.(type) {
+	case *github.PushEvent:
+		return &Event{
+			Kind:          KindGitHub,
+			Type:          EventPush,
+			Repository:    &Repository{Owner: event.Repo.GetOwner().GetLogin(), Name: event.Repo.GetName()},
+			RepositoryURL: event.Repo.GetHTMLURL(),
+			DefaultBranch: event.Repo.GetMasterBranch(),
+			Ref:           event.GetRef(),
+			Revision:      event.HeadCommit.GetID(),
+			Message:       event.HeadCommit.GetMessage(),
+			Sender:        &User{ID: event.Sender.GetID(), Login: event.Sender.GetLogin()},
+		}, nil
+	case *github.PullRequestEvent:
+		return &Event{
+			Kind:          KindGitHub,
+			Type:          EventPullRequest,
+			Action:        event.GetAction(),
+			Repository:    &Repository{Owner: event.Repo.GetOwner().GetLogin(), Name: event.Repo.GetName()},
+			RepositoryURL: event.Repo.GetHTMLURL(),
+			DefaultBranch: event.Repo.GetDefaultBranch(),
+			Revision:      event.PullRequest.GetHead().GetSHA(),
+			Message:       event.PullRequest.GetTitle(),
+			Number:        event.PullRequest.GetNumber(),
+			Sender:        &User{ID: event.Sender.GetID(), Login: event.Sender.GetLogin()},
+		}, nil
+	case *github.IssueCommentEvent:
+		// The comment for the issue is not needed.
+		if !event.Issue.IsPullRequest() {
+			return nil, nil
+		}
+		return &Event{
+			Kind:          KindGitHub,
+			Type:          EventComment,
+			Action:        event.GetAction(),
+			Repository:    &Repository{Owner: event.Repo.GetOwner().GetLogin(), Name: event.Repo.GetName()},
+			RepositoryURL: event.Repo.GetHTMLURL(),
+			DefaultBranch: event.Repo.GetDefaultBranch(),
+			Number:        event.Issue.GetNumber(),
+			Sender:        &User{ID: event.Sender.GetID(), Login: event.Sender.GetLogin()},
+			Comment:       event.Comment.GetBody(),
+		}, nil
+	case *github.ReleaseEvent:
+		return &Event{
+			Kind:          KindGitHub,
+			Type:          EventRelease,
+			Action:        event.GetAction(),
+			Repository:    &Repository{Owner: event.Repo.GetOwner().GetLogin(), Name: event.Repo.GetName()},
+			RepositoryURL: event.Repo.GetHTMLURL(),
+			DefaultBranch: event.Repo.GetDefaultBranch(),
+			Sender:        &User{ID: event.Sender.GetID(), Login: event.Sender.GetLogin()},
+			Tag:           event.Release.GetTagName(),
+		}, nil
+	}
+
+	return nil, nil
+}
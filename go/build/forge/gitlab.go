@@ -0,0 +1,285 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.f110.dev/xerrors"
+)
+
+// gitLabAccessLevelDeveloper is the lowest access level which is allowed to push to the project.
+const gitLabAccessLevelDeveloper = 30
+
+type GitLab struct {
+	host   string
+	client *restClient
+}
+
+var _ Client = &GitLab{}
+
+// NewGitLab returns the client of GitLab. baseURL is the URL of the top page of GitLab (e.g. https://gitlab.com).
+func NewGitLab(baseURL, token string, httpClient *http.Client) (*GitLab, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	if u.Host == "" {
+		return nil, xerrors.Definef("invalid url: %s", baseURL).WithStack()
+	}
+	return &GitLab{
+		host: u.Hostname(),
+		client: &restClient{
+			endpoint:   strings.TrimSuffix(baseURL, "/") + "/api/v4",
+			authHeader: "PRIVATE-TOKEN",
+			authValue:  token,
+			httpClient: httpClient,
+		},
+	}, nil
+}
+
+func (*GitLab) Kind() Kind {
+	return KindGitLab
+}
+
+func (g *GitLab) Host() string {
+	return g.host
+}
+
+func (g *GitLab) CreateComment(ctx context.Context, repo *Repository, number int, body string) error {
+	return g.client.do(ctx, http.MethodPost, fmt.Sprintf("%s/merge_requests/%d/notes", g.projectPath(repo), number), map[string]string{"body": body}, nil)
+}
+
+func (g *GitLab) CreateStatus(ctx context.Context, repo *Repository, revision string, status *CommitStatus) error {
+	in := map[string]string{
+		"state":       gitLabStatusState(status.State),
+		"name":        status.Context,
+		"description": status.Description,
+		"target_url":  status.TargetURL,
+	}
+	return g.client.do(ctx, http.MethodPost, fmt.Sprintf("%s/statuses/%s", g.projectPath(repo), revision), in, nil)
+}
+
+func (g *GitLab) GetPullRequestHead(ctx context.Context, repo *Repository, number int) (string, error) {
+	var mr struct {
+		Sha string `json:"sha"`
+	}
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("%s/merge_requests/%d", g.projectPath(repo), number), nil, &mr); err != nil {
+		return "", err
+	}
+	return mr.Sha, nil
+}
+
+func (g *GitLab) GetDefaultBranch(ctx context.Context, repo *Repository) (string, error) {
+	var p gitLabProject
+	if err := g.client.do(ctx, http.MethodGet, g.projectPath(repo), nil, &p); err != nil {
+		return "", err
+	}
+	return p.DefaultBranch, nil
+}
+
+func (g *GitLab) GetCommit(ctx context.Context, repo *Repository, ref string) (string, error) {
+	if ref == "HEAD" {
+		branch, err := g.GetDefaultBranch(ctx, repo)
+		if err != nil {
+			return "", err
+		}
+		ref = branch
+	}
+	var commit struct {
+		ID string `json:"id"`
+	}
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("%s/repository/commits/%s", g.projectPath(repo), url.PathEscape(ref)), nil, &commit); err != nil {
+		return "", err
+	}
+	return commit.ID, nil
+}
+
+func (g *GitLab) GetTagCommit(ctx context.Context, repo *Repository, tag string) (string, error) {
+	var t struct {
+		Commit struct {
+			ID string `json:"id"`
+		} `json:"commit"`
+	}
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("%s/repository/tags/%s", g.projectPath(repo), url.PathEscape(tag)), nil, &t); err != nil {
+		return "", err
+	}
+	return t.Commit.ID, nil
+}
+
+func (g *GitLab) GetFiles(ctx context.Context, repo *Repository, revision string, paths ...string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	for _, v := range paths {
+		buf, err := g.client.raw(ctx, http.MethodGet, fmt.Sprintf("%s/repository/files/%s/raw?ref=%s", g.projectPath(repo), url.PathEscape(v), url.QueryEscape(revision)), nil)
+		if errors.Is(err, errNotFound) {
+			continue
+		} else if err != nil {
+			return nil, xerrors.WithMessagef(err, "failed to get the file: %s", v)
+		}
+		files[v] = buf
+	}
+	return files, nil
+}
+
+// HasWritePermission returns true if the user is the member of the project whose access level is Developer or higher.
+func (g *GitLab) HasWritePermission(ctx context.Context, repo *Repository, user *User) (bool, error) {
+	var member struct {
+		AccessLevel int `json:"access_level"`
+	}
+	err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("%s/members/all/%d", g.projectPath(repo), user.ID), nil, &member)
+	if errors.Is(err, errNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return member.AccessLevel >= gitLabAccessLevelDeveloper, nil
+}
+
+// projectPath returns the path of the project. GitLab accepts the URL-encoded path of the project as the id of the project.
+func (*GitLab) projectPath(repo *Repository) string {
+	return "/projects/" + url.PathEscape(repo.FullName())
+}
+
+// gitLabStatusState converts the state to the state of the commit status of GitLab.
+// GitLab doesn't have the error state. Thus, the error state is reported as canceled.
+func gitLabStatusState(s StatusState) string {
+	switch s {
+	case StatusPending:
+		return "pending"
+	case StatusSuccess:
+		return "success"
+	case StatusFailure:
+		return "failed"
+	default:
+		return "canceled"
+	}
+}
+
+type gitLabProject struct {
+	WebURL            string `json:"web_url"`
+	Name              string `json:"name"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	DefaultBranch     string `json:"default_branch"`
+}
+
+type gitLabUser struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+type gitLabWebHookPayload struct {
+	ObjectKind   string         `json:"object_kind"`
+	Ref          string         `json:"ref"`
+	After        string         `json:"after"`
+	CheckoutSha  string         `json:"checkout_sha"`
+	UserID       int64          `json:"user_id"`
+	UserUsername string         `json:"user_username"`
+	User         *gitLabUser    `json:"user"`
+	Project      *gitLabProject `json:"project"`
+	Commits      []struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	} `json:"commits"`
+	ObjectAttributes *struct {
+		IID        int    `json:"iid"`
+		Title      string `json:"title"`
+		Action     string `json:"action"`
+		OldRev     string `json:"oldrev"`
+		Note       string `json:"note"`
+		NoteType   string `json:"noteable_type"`
+		LastCommit struct {
+			ID string `json:"id"`
+		} `json:"last_commit"`
+	} `json:"object_attributes"`
+	MergeRequest *struct {
+		IID int `json:"iid"`
+	} `json:"merge_request"`
+	Action string `json:"action"`
+	Tag    string `json:"tag"`
+}
+
+func parseGitLabWebHook(eventType string, payload []byte) (*Event, error) {
+	p := &gitLabWebHookPayload{}
+	if err := json.Unmarshal(payload, p); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	if p.Project == nil {
+		return nil, xerrors.Define("project is not found in the payload").WithStack()
+	}
+	_, repo, err := ParseRepositoryURL(p.Project.WebURL)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Event{
+		Kind:          KindGitLab,
+		Repository:    repo,
+		RepositoryURL: p.Project.WebURL,
+		DefaultBranch: p.Project.DefaultBranch,
+		Sender:        &User{},
+	}
+	if p.User != nil {
+		e.Sender = &User{ID: p.User.ID, Login: p.User.Username}
+	} else if p.UserID != 0 {
+		e.Sender = &User{ID: p.UserID, Login: p.UserUsername}
+	}
+	switch eventType {
+	case "Push Hook":
+		e.Type = EventPush
+		e.Ref = p.Ref
+		e.Revision = p.CheckoutSha
+		for _, v := range p.Commits {
+			if v.ID == p.CheckoutSha {
+				e.Message = v.Message
+			}
+		}
+	case "Merge Request Hook":
+		if p.ObjectAttributes == nil {
+			return nil, xerrors.Define("object_attributes is not found in the payload").WithStack()
+		}
+		e.Type = EventPullRequest
+		e.Number = p.ObjectAttributes.IID
+		e.Revision = p.ObjectAttributes.LastCommit.ID
+		e.Message = p.ObjectAttributes.Title
+		switch p.ObjectAttributes.Action {
+		case "open":
+			e.Action = ActionOpened
+		case "update":
+			// The update action is also sent when the title or the description has been changed.
+			// oldrev is set only when new commits have been pushed.
+			if p.ObjectAttributes.OldRev == "" {
+				return nil, nil
+			}
+			e.Action = ActionSynchronize
+		case "close", "merge":
+			e.Action = ActionClosed
+		default:
+			e.Action = p.ObjectAttributes.Action
+		}
+	case "Note Hook":
+		// The comment for other than the merge request is not needed.
+		if p.ObjectAttributes == nil || p.ObjectAttributes.NoteType != "MergeRequest" || p.MergeRequest == nil {
+			return nil, nil
+		}
+		e.Type = EventComment
+		e.Action = ActionCreated
+		e.Number = p.MergeRequest.IID
+		e.Comment = p.ObjectAttributes.Note
+	case "Release Hook":
+		e.Type = EventRelease
+		e.Tag = p.Tag
+		if p.Action == "create" {
+			e.Action = ActionPublished
+		} else {
+			e.Action = p.Action
+		}
+	default:
+		return nil, nil
+	}
+
+	return e, nil
+}
@@ -0,0 +1,97 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitLab(t *testing.T) {
+	var status, comment map[string]string
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("PRIVATE-TOKEN") != "foobar" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		// The path of the project is URL-encoded.
+		switch req.Method + " " + req.URL.EscapedPath() {
+		case "GET /api/v4/projects/f110%2Fgroup%2Fsandbox":
+			io.WriteString(w, `{"default_branch":"main"}`)
+		case "GET /api/v4/projects/f110%2Fgroup%2Fsandbox/repository/commits/main":
+			io.WriteString(w, `{"id":"headsha"}`)
+		case "GET /api/v4/projects/f110%2Fgroup%2Fsandbox/merge_requests/2":
+			io.WriteString(w, `{"iid":2,"sha":"mrsha"}`)
+		case "GET /api/v4/projects/f110%2Fgroup%2Fsandbox/repository/tags/v1.0.0":
+			io.WriteString(w, `{"name":"v1.0.0","commit":{"id":"tagsha"}}`)
+		case "GET /api/v4/projects/f110%2Fgroup%2Fsandbox/repository/files/.bazelversion/raw":
+			io.WriteString(w, "6.0.0\n")
+		case "POST /api/v4/projects/f110%2Fgroup%2Fsandbox/statuses/headsha":
+			if err := json.NewDecoder(req.Body).Decode(&status); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{}`)
+		case "GET /api/v4/projects/f110%2Fgroup%2Fsandbox/members/all/10":
+			io.WriteString(w, `{"id":10,"username":"octocat","access_level":30}`)
+		case "GET /api/v4/projects/f110%2Fgroup%2Fsandbox/members/all/11":
+			io.WriteString(w, `{"id":11,"username":"reporter","access_level":20}`)
+		case "POST /api/v4/projects/f110%2Fgroup%2Fsandbox/merge_requests/2/notes":
+			if err := json.NewDecoder(req.Body).Decode(&comment); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer stub.Close()
+
+	c, err := NewGitLab(stub.URL, "foobar", nil)
+	require.NoError(t, err)
+	repo := &Repository{Owner: "f110/group", Name: "sandbox"}
+	ctx := context.Background()
+
+	sha, err := c.GetCommit(ctx, repo, "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, "headsha", sha)
+
+	sha, err = c.GetPullRequestHead(ctx, repo, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "mrsha", sha)
+
+	sha, err = c.GetTagCommit(ctx, repo, "v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "tagsha", sha)
+
+	files, err := c.GetFiles(ctx, repo, "headsha", "build.star", ".bazelversion")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{".bazelversion": []byte("6.0.0\n")}, files)
+
+	err = c.CreateStatus(ctx, repo, "headsha", &CommitStatus{State: StatusFailure, Context: "test foo", TargetURL: "http://localhost/task/1"})
+	require.NoError(t, err)
+	assert.Equal(t, "failed", status["state"])
+	assert.Equal(t, "test foo", status["name"])
+
+	err = c.CreateComment(ctx, repo, 2, "/allow-build")
+	require.NoError(t, err)
+	assert.Equal(t, "/allow-build", comment["body"])
+
+	ok, err := c.HasWritePermission(ctx, repo, &User{ID: 10, Login: "octocat"})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = c.HasWritePermission(ctx, repo, &User{ID: 11, Login: "reporter"})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = c.HasWritePermission(ctx, repo, &User{ID: 12, Login: "guest"})
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,77 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"go.f110.dev/xerrors"
+)
+
+var errNotFound = xerrors.Define("not found")
+
+// restClient is the client of the REST API which is used by Gitea and GitLab.
+type restClient struct {
+	endpoint   string
+	authHeader string
+	authValue  string
+	httpClient *http.Client
+}
+
+func (c *restClient) do(ctx context.Context, method, path string, in, out any) error {
+	buf, err := c.raw(ctx, method, path, in)
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(buf, out); err != nil {
+		return xerrors.WithStack(err)
+	}
+	return nil
+}
+
+func (c *restClient) raw(ctx context.Context, method, path string, in any) ([]byte, error) {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, body)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	if c.authValue != "" {
+		req.Header.Set(c.authHeader, c.authValue)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	defer res.Body.Close()
+
+	buf, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return nil, errNotFound.WithStack()
+	case res.StatusCode < 200 || res.StatusCode >= 300:
+		return nil, xerrors.Definef("%s %s: unexpected status code: %d", method, path, res.StatusCode).WithStack()
+	}
+	return buf, nil
+}
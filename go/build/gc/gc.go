@@ -16,10 +16,14 @@ import (
 	"go.f110.dev/mono/go/storage"
 )
 
+type objectStorageInterface interface {
+	Delete(ctx context.Context, name string) error
+}
+
 type GC struct {
 	interval time.Duration
 	dao      dao.Options
-	minio    *storage.MinIO
+	minio    objectStorageInterface
 }
 
 func NewGC(interval time.Duration, daoOpt dao.Options, bucket string, minioOpt storage.MinIOOptions) *GC {
@@ -57,12 +61,49 @@ func (g *GC) sweep(ctx context.Context) {
 		return tasks[i].Id > tasks[j].Id
 	})
 
-	garbageTasks := tasks[web.NumberOfTaskPerJob:]
+	keptTasks := tasks
+	var garbageTasks []*database.Task
+	if len(tasks) > web.NumberOfTaskPerJob {
+		keptTasks, garbageTasks = tasks[:web.NumberOfTaskPerJob], tasks[web.NumberOfTaskPerJob:]
+	}
 	for _, t := range garbageTasks {
 		if err := g.cleanTask(ctx, t); err != nil {
 			logger.Log.Info("Failed to cleanup task", zap.Error(err), zap.Int32("task_id", t.Id))
 		}
 	}
+
+	if err := g.sweepArtifacts(ctx, keptTasks, time.Now()); err != nil {
+		logger.Log.Warn("Failed to sweep artifacts", zap.Error(err))
+	}
+}
+
+// sweepArtifacts deletes the artifacts which have exceeded the retention period of the repository.
+// If the retention period of the repository is zero, the artifacts are kept until the task is deleted.
+func (g *GC) sweepArtifacts(ctx context.Context, tasks []*database.Task, now time.Time) error {
+	repos, err := g.dao.Repository.ListAll(ctx)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	retention := make(map[int32]time.Duration)
+	for _, v := range repos {
+		if v.ArtifactRetentionDays > 0 {
+			retention[v.Id] = time.Duration(v.ArtifactRetentionDays) * 24 * time.Hour
+		}
+	}
+
+	for _, t := range tasks {
+		if t.FinishedAt == nil {
+			continue
+		}
+		d, ok := retention[t.RepositoryId]
+		if !ok || now.Sub(*t.FinishedAt) < d {
+			continue
+		}
+		if err := g.deleteArtifacts(ctx, t); err != nil {
+			logger.Log.Info("Failed to delete artifacts", zap.Error(err), zap.Int32("task_id", t.Id))
+		}
+	}
+	return nil
 }
 
 func (g *GC) cleanTask(ctx context.Context, t *database.Task) error {
@@ -77,9 +118,31 @@ func (g *GC) cleanTask(ctx context.Context, t *database.Task) error {
 		}
 	}
 
+	if err := g.deleteArtifacts(ctx, t); err != nil {
+		return err
+	}
+
 	logger.Log.Info("Delete task", zap.Int32("task_id", t.Id))
 	if err := g.dao.Task.Delete(ctx, t.Id); err != nil {
 		return xerrors.WithStack(err)
 	}
 	return nil
 }
+
+func (g *GC) deleteArtifacts(ctx context.Context, t *database.Task) error {
+	artifacts, err := g.dao.Artifact.ListByTaskId(ctx, t.Id)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	for _, v := range artifacts {
+		logger.Log.Info("Delete artifact from object storage", zap.String("name", v.Path), zap.Int32("task_id", t.Id))
+		if err := g.minio.Delete(ctx, v.Path); err != nil {
+			return xerrors.WithStack(err)
+		}
+		if err := g.dao.Artifact.Delete(ctx, v.Id); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package gc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.f110.dev/mono/go/build/database"
+	"go.f110.dev/mono/go/build/database/dao"
+	"go.f110.dev/mono/go/build/database/dao/daotest"
+	"go.f110.dev/mono/go/logger"
+	"go.f110.dev/mono/go/storage"
+	"go.f110.dev/mono/go/varptr"
+)
+
+func TestGC_SweepArtifacts(t *testing.T) {
+	require.NoError(t, logger.Init())
+
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	repos := []*database.SourceRepository{
+		{Id: 1, Name: "retention", ArtifactRetentionDays: 3},
+		{Id: 2, Name: "no-retention"},
+	}
+	tasks := []*database.Task{
+		// Exceeded the retention period
+		{Id: 1, RepositoryId: 1, FinishedAt: varptr.Ptr(now.Add(-4 * 24 * time.Hour))},
+		// Within the retention period
+		{Id: 2, RepositoryId: 1, FinishedAt: varptr.Ptr(now.Add(-2 * 24 * time.Hour))},
+		// Still running
+		{Id: 3, RepositoryId: 1},
+		// The repository doesn't have the retention period
+		{Id: 4, RepositoryId: 2, FinishedAt: varptr.Ptr(now.Add(-30 * 24 * time.Hour))},
+	}
+
+	mockRepository := daotest.NewSourceRepository()
+	mockRepository.RegisterListAll(repos, nil)
+	mockArtifact := daotest.NewArtifact()
+	mockArtifact.RegisterListByTaskId(1, []*database.Artifact{{Id: 10, TaskId: 1, Path: "artifacts/1/foo"}}, nil)
+	objStorage := storage.NewMock()
+	require.NoError(t, objStorage.Put(context.Background(), "artifacts/1/foo", []byte("foo")))
+
+	g := &GC{dao: dao.Options{Repository: mockRepository, Artifact: mockArtifact}, minio: objStorage}
+	err := g.sweepArtifacts(context.Background(), tasks, now)
+	require.NoError(t, err)
+
+	listed := mockArtifact.Called("ListByTaskId")
+	require.Len(t, listed, 1)
+	assert.Equal(t, int32(1), listed[0].Args["taskId"])
+	deleted := mockArtifact.Called("Delete")
+	require.Len(t, deleted, 1)
+	assert.Equal(t, int32(10), deleted[0].Args["id"])
+	_, err = objStorage.Get(context.Background(), "artifacts/1/foo")
+	assert.Error(t, err)
+}
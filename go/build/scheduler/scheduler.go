@@ -0,0 +1,254 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"go.f110.dev/xerrors"
+	"go.uber.org/zap"
+
+	"go.f110.dev/mono/go/build/api"
+	"go.f110.dev/mono/go/build/config"
+	"go.f110.dev/mono/go/build/database"
+	"go.f110.dev/mono/go/build/database/dao"
+	"go.f110.dev/mono/go/build/forge"
+	"go.f110.dev/mono/go/ctxutil"
+	"go.f110.dev/mono/go/logger"
+	"go.f110.dev/mono/go/varptr"
+)
+
+const (
+	// refreshInterval is the interval for reading build configurations from repositories.
+	refreshInterval = 10 * time.Minute
+	via             = "schedule"
+)
+
+// Scheduler triggers the job which has the schedule at the tip of the main branch.
+// The time of the last run and the next run are stored in the database.
+// Thus, the scheduler doesn't trigger the job twice or skip the job even if the process is restarted.
+type Scheduler struct {
+	interval time.Duration
+	dao      dao.Options
+	builder  api.Builder
+	forges   *forge.Set
+
+	lastRefresh time.Time
+}
+
+func NewScheduler(interval time.Duration, daoOpt dao.Options, builder api.Builder, forges *forge.Set) *Scheduler {
+	return &Scheduler{
+		interval: interval,
+		dao:      daoOpt,
+		builder:  builder,
+		forges:   forges,
+	}
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	t := time.NewTicker(s.interval)
+	defer t.Stop()
+
+	s.run(ctx)
+	for {
+		select {
+		case <-t.C:
+			s.run(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	cCtx, cancelFunc := ctxutil.WithTimeout(ctx, s.interval)
+	defer cancelFunc()
+
+	now := time.Now()
+	if now.Sub(s.lastRefresh) > refreshInterval {
+		if err := s.refresh(cCtx, now); err != nil {
+			logger.Log.Warn("Failed to refresh schedules", logger.Error(err))
+		} else {
+			s.lastRefresh = now
+		}
+	}
+
+	if err := s.trigger(cCtx, now); err != nil {
+		logger.Log.Warn("Failed to trigger scheduled jobs", logger.Error(err))
+	}
+}
+
+// refresh synchronizes JobSchedule with the build configuration in the main branch of each repository.
+func (s *Scheduler) refresh(ctx context.Context, now time.Time) error {
+	repos, err := s.dao.Repository.ListAll(ctx)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	for _, repo := range repos {
+		if err := s.refreshRepository(ctx, repo, now); err != nil {
+			logger.Log.Info("Failed to refresh schedules of the repository", logger.Error(err), zap.String("repo", repo.Name))
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) refreshRepository(ctx context.Context, repo *database.SourceRepository, now time.Time) error {
+	client, forgeRepo, err := s.forges.Client(repo.Url)
+	if err != nil {
+		return err
+	}
+	// FetchBuildConfig returns an error if it failed to read the build configuration.
+	// Thus, the nil configuration means that the configuration file or the job doesn't exist,
+	// and the schedules are never deleted due to a transient failure of the forge.
+	conf, _, err := api.FetchBuildConfig(ctx, client, forgeRepo, "HEAD", false)
+	if err != nil {
+		return err
+	}
+
+	schedules, err := s.dao.JobSchedule.ListByRepositoryId(ctx, repo.Id)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	current := make(map[string]*database.JobSchedule)
+	for _, v := range schedules {
+		current[v.JobName] = v
+	}
+
+	var jobs []*config.Job
+	if conf != nil {
+		jobs = conf.Jobs
+	}
+	for _, job := range jobs {
+		if job.Schedule == "" {
+			continue
+		}
+		sched, err := job.ParseSchedule()
+		if err != nil {
+			logger.Log.Info("Skip the job due to invalid schedule", logger.Error(err), zap.String("job", job.Name))
+			continue
+		}
+
+		if v, ok := current[job.Name]; ok {
+			delete(current, job.Name)
+			if v.Schedule == job.Schedule && v.NextRunAt != nil {
+				continue
+			}
+			v.Schedule = job.Schedule
+			v.NextRunAt = varptr.Ptr(sched.Next(now))
+			if err := s.dao.JobSchedule.Update(ctx, v); err != nil {
+				return xerrors.WithStack(err)
+			}
+			logger.Log.Info("Update the schedule", zap.String("repo", repo.Name), zap.String("job", job.Name), zap.String("schedule", job.Schedule))
+			continue
+		}
+
+		_, err = s.dao.JobSchedule.Create(ctx, &database.JobSchedule{
+			RepositoryId: repo.Id,
+			JobName:      job.Name,
+			Schedule:     job.Schedule,
+			NextRunAt:    varptr.Ptr(sched.Next(now)),
+		})
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		logger.Log.Info("Add the schedule", zap.String("repo", repo.Name), zap.String("job", job.Name), zap.String("schedule", job.Schedule))
+	}
+
+	// The job has been removed from the configuration file or the schedule of the job has been removed.
+	for _, v := range current {
+		if err := s.dao.JobSchedule.Delete(ctx, v.Id); err != nil {
+			return xerrors.WithStack(err)
+		}
+		logger.Log.Info("Delete the schedule", zap.String("repo", repo.Name), zap.String("job", v.JobName))
+	}
+
+	return nil
+}
+
+// trigger starts the jobs which have reached the next run time.
+// If the process was stopped over the several run times, the job will be started only once.
+func (s *Scheduler) trigger(ctx context.Context, now time.Time) error {
+	schedules, err := s.dao.JobSchedule.ListAll(ctx)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	for _, v := range schedules {
+		if v.NextRunAt == nil || v.NextRunAt.After(now) {
+			continue
+		}
+		sched, err := (&config.Job{Name: v.JobName, Schedule: v.Schedule}).ParseSchedule()
+		if err != nil {
+			logger.Log.Info("Skip the job due to invalid schedule", logger.Error(err), zap.String("job", v.JobName))
+			continue
+		}
+
+		// The time of the next run is persisted before starting the job.
+		// Thus the job is never started twice even if the process dies while starting the job.
+		// If the job couldn't be started, the run is skipped until the next run time.
+		v.LastRunAt = varptr.Ptr(now)
+		v.NextRunAt = varptr.Ptr(sched.Next(now))
+		if err := s.dao.JobSchedule.Update(ctx, v); err != nil {
+			logger.Log.Warn("Failed to update the schedule", logger.Error(err), zap.Int32("id", v.Id))
+			continue
+		}
+		tasks, err := s.build(ctx, v)
+		if err != nil {
+			logger.Log.Warn("Failed to start the scheduled job", logger.Error(err), zap.String("job", v.JobName))
+			continue
+		}
+		if len(tasks) > 0 {
+			v.LastTaskId = tasks[len(tasks)-1].Id
+			if err := s.dao.JobSchedule.Update(ctx, v); err != nil {
+				logger.Log.Warn("Failed to update the schedule", logger.Error(err), zap.Int32("id", v.Id))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Scheduler) build(ctx context.Context, schedule *database.JobSchedule) ([]*database.Task, error) {
+	repo := schedule.Repository
+	if repo == nil {
+		r, err := s.dao.Repository.Select(ctx, schedule.RepositoryId)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		repo = r
+	}
+	client, forgeRepo, err := s.forges.Client(repo.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultBranch, err := client.GetDefaultBranch(ctx, forgeRepo)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := client.GetCommit(ctx, forgeRepo, defaultBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, bazelVersion, err := api.FetchBuildConfig(ctx, client, forgeRepo, revision, true)
+	if err != nil {
+		return nil, err
+	}
+	if conf == nil {
+		return nil, xerrors.Definef("build configuration is not found: %s", repo.Name).WithStack()
+	}
+	var job *config.Job
+	for _, v := range conf.Jobs {
+		if v.Name == schedule.JobName {
+			job = v
+			break
+		}
+	}
+	if job == nil {
+		return nil, xerrors.Definef("the job is not found: %s", schedule.JobName).WithStack()
+	}
+
+	logger.Log.Info("Start the scheduled job", zap.String("repo", repo.Name), zap.String("job", job.Name), zap.String("revision", revision))
+	return s.builder.Build(ctx, repo, job, revision, bazelVersion, job.Command, job.Targets, job.Platforms, via, true, 0)
+}
@@ -0,0 +1,280 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v49/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.f110.dev/mono/go/build/config"
+	"go.f110.dev/mono/go/build/database"
+	"go.f110.dev/mono/go/build/database/dao"
+	"go.f110.dev/mono/go/build/database/dao/daotest"
+	"go.f110.dev/mono/go/build/forge"
+	"go.f110.dev/mono/go/logger"
+	"go.f110.dev/mono/go/varptr"
+)
+
+type MockBuilder struct {
+	jobs     []*config.Job
+	revision string
+	via      string
+	err      error
+}
+
+func (m *MockBuilder) Build(_ context.Context, _ *database.SourceRepository, job *config.Job, revision, _, _ string, _, _ []string, via string, _ bool, _ int32) ([]*database.Task, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.jobs = append(m.jobs, job)
+	m.revision = revision
+	m.via = via
+	return []*database.Task{{Id: 10}}, nil
+}
+
+func (m *MockBuilder) Cancel(_ context.Context, _ *database.Task) error {
+	return nil
+}
+
+// failingUpdateJobSchedule fails to update the schedule.
+type failingUpdateJobSchedule struct {
+	*daotest.JobSchedule
+}
+
+func (f *failingUpdateJobSchedule) Update(_ context.Context, _ *database.JobSchedule, _ ...dao.ExecOption) error {
+	return errors.New("failed to update")
+}
+
+type MockTransport struct {
+	req   []*http.Request
+	res   []*http.Response
+	index int
+}
+
+func (m *MockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(m.res) <= m.index {
+		return nil, nil
+	}
+	m.req = append(m.req, req)
+	res := m.res[m.index]
+	m.index++
+	return res, nil
+}
+
+func jsonResponse(body string) *http.Response {
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func TestScheduler_Trigger(t *testing.T) {
+	logger.SetLogLevel("debug")
+	logger.Init()
+
+	repo := &database.SourceRepository{
+		Id:       1,
+		Url:      "https://github.com/f110/sandbox",
+		CloneUrl: "https://github.com/f110/sandbox.git",
+		Name:     "sandbox",
+	}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Due", func(t *testing.T) {
+		schedule := &database.JobSchedule{
+			Id:           1,
+			RepositoryId: repo.Id,
+			JobName:      "nightly",
+			Schedule:     "0 3 * * *",
+			NextRunAt:    varptr.Ptr(now.Add(-time.Minute)),
+			Repository:   repo,
+		}
+		mockDAO := daotest.NewJobSchedule()
+		mockDAO.RegisterListAll([]*database.JobSchedule{schedule}, nil)
+		mockTransport := &MockTransport{res: []*http.Response{
+			jsonResponse(`{"default_branch":"master"}`),
+			jsonResponse(`{"sha":"headsha"}`),
+			jsonResponse(`{"sha":"headsha"}`),
+			jsonResponse(`{"tree":[{"path":"build.star","sha":"buildstarsha"}]}`),
+			jsonResponse(`job(
+	name = "nightly",
+	event = ["manual"],
+	command = "test",
+	targets = ["//..."],
+	platforms = ["linux_amd64"],
+	schedule = "0 3 * * *",
+)`),
+		}}
+		builder := &MockBuilder{}
+		s := NewScheduler(time.Minute, dao.Options{JobSchedule: mockDAO}, builder, forge.NewSet(forge.NewGitHub(github.NewClient(&http.Client{Transport: mockTransport}))))
+
+		err := s.trigger(context.Background(), now)
+		require.NoError(t, err)
+
+		require.Len(t, builder.jobs, 1)
+		assert.Equal(t, "nightly", builder.jobs[0].Name)
+		assert.Equal(t, "headsha", builder.revision)
+		assert.Equal(t, "schedule", builder.via)
+		// The schedule is updated before starting the job, and then the id of the task is recorded.
+		assert.Len(t, mockDAO.Called("Update"), 2)
+		assert.Equal(t, now, *schedule.LastRunAt)
+		assert.Equal(t, time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC), *schedule.NextRunAt)
+		assert.Equal(t, int32(10), schedule.LastTaskId)
+	})
+
+	t.Run("BuildFailed", func(t *testing.T) {
+		schedule := &database.JobSchedule{
+			Id:           1,
+			RepositoryId: repo.Id,
+			JobName:      "nightly",
+			Schedule:     "0 3 * * *",
+			NextRunAt:    varptr.Ptr(now.Add(-time.Minute)),
+			Repository:   repo,
+		}
+		mockDAO := daotest.NewJobSchedule()
+		mockDAO.RegisterListAll([]*database.JobSchedule{schedule}, nil)
+		mockTransport := &MockTransport{res: []*http.Response{
+			jsonResponse(`{"default_branch":"master"}`),
+			jsonResponse(`{"sha":"headsha"}`),
+			jsonResponse(`{"sha":"headsha"}`),
+			jsonResponse(`{"tree":[{"path":"build.star","sha":"buildstarsha"}]}`),
+			jsonResponse(`job(
+	name = "nightly",
+	event = ["manual"],
+	command = "test",
+	targets = ["//..."],
+	platforms = ["linux_amd64"],
+	schedule = "0 3 * * *",
+)`),
+		}}
+		builder := &MockBuilder{err: errors.New("failed to create the task")}
+		s := NewScheduler(time.Minute, dao.Options{JobSchedule: mockDAO}, builder, forge.NewSet(forge.NewGitHub(github.NewClient(&http.Client{Transport: mockTransport}))))
+
+		err := s.trigger(context.Background(), now)
+		require.NoError(t, err)
+
+		// The run is skipped until the next run time.
+		assert.Len(t, mockDAO.Called("Update"), 1)
+		assert.Equal(t, now, *schedule.LastRunAt)
+		assert.Equal(t, time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC), *schedule.NextRunAt)
+		assert.Equal(t, int32(0), schedule.LastTaskId)
+	})
+
+	t.Run("UpdateFailed", func(t *testing.T) {
+		schedule := &database.JobSchedule{
+			Id:           1,
+			RepositoryId: repo.Id,
+			JobName:      "nightly",
+			Schedule:     "0 3 * * *",
+			NextRunAt:    varptr.Ptr(now.Add(-time.Minute)),
+			Repository:   repo,
+		}
+		mockDAO := daotest.NewJobSchedule()
+		mockDAO.RegisterListAll([]*database.JobSchedule{schedule}, nil)
+		builder := &MockBuilder{}
+		s := NewScheduler(time.Minute, dao.Options{JobSchedule: &failingUpdateJobSchedule{JobSchedule: mockDAO}}, builder, nil)
+
+		err := s.trigger(context.Background(), now)
+		require.NoError(t, err)
+
+		// The job is not started because the next run time couldn't be persisted.
+		assert.Len(t, builder.jobs, 0)
+	})
+
+	t.Run("NotDue", func(t *testing.T) {
+		schedule := &database.JobSchedule{
+			Id:           1,
+			RepositoryId: repo.Id,
+			JobName:      "nightly",
+			Schedule:     "0 3 * * *",
+			NextRunAt:    varptr.Ptr(now.Add(time.Minute)),
+			Repository:   repo,
+		}
+		mockDAO := daotest.NewJobSchedule()
+		mockDAO.RegisterListAll([]*database.JobSchedule{schedule}, nil)
+		builder := &MockBuilder{}
+		s := NewScheduler(time.Minute, dao.Options{JobSchedule: mockDAO}, builder, nil)
+
+		err := s.trigger(context.Background(), now)
+		require.NoError(t, err)
+
+		assert.Len(t, builder.jobs, 0)
+		assert.Len(t, mockDAO.Called("Update"), 0)
+		assert.Nil(t, schedule.LastRunAt)
+	})
+}
+
+func TestScheduler_RefreshRepository(t *testing.T) {
+	logger.SetLogLevel("debug")
+	logger.Init()
+
+	repo := &database.SourceRepository{
+		Id:       1,
+		Url:      "https://github.com/f110/sandbox",
+		CloneUrl: "https://github.com/f110/sandbox.git",
+		Name:     "sandbox",
+	}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Sync", func(t *testing.T) {
+		mockDAO := daotest.NewJobSchedule()
+		mockDAO.RegisterListByRepositoryId(repo.Id, []*database.JobSchedule{
+			{Id: 2, RepositoryId: repo.Id, JobName: "removed", Schedule: "@daily", NextRunAt: varptr.Ptr(now)},
+		}, nil)
+		mockTransport := &MockTransport{res: []*http.Response{
+			jsonResponse(`{"sha":"headsha"}`),
+			jsonResponse(`{"tree":[{"path":"build.star","sha":"buildstarsha"}]}`),
+			jsonResponse(`job(
+	name = "nightly",
+	event = ["manual"],
+	command = "test",
+	targets = ["//..."],
+	platforms = ["linux_amd64"],
+	schedule = "0 3 * * *",
+)
+
+job(
+	name = "test_all",
+	event = ["push"],
+	command = "test",
+	targets = ["//..."],
+	platforms = ["linux_amd64"],
+)`),
+		}}
+		s := NewScheduler(time.Minute, dao.Options{JobSchedule: mockDAO}, &MockBuilder{}, forge.NewSet(forge.NewGitHub(github.NewClient(&http.Client{Transport: mockTransport}))))
+
+		err := s.refreshRepository(context.Background(), repo, now)
+		require.NoError(t, err)
+
+		created := mockDAO.Called("Create")
+		require.Len(t, created, 1)
+		newSchedule := created[0].Args["jobSchedule"].(*database.JobSchedule)
+		assert.Equal(t, "nightly", newSchedule.JobName)
+		assert.Equal(t, time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC), *newSchedule.NextRunAt)
+		deleted := mockDAO.Called("Delete")
+		require.Len(t, deleted, 1)
+		assert.Equal(t, int32(2), deleted[0].Args["id"])
+	})
+
+	t.Run("FailedToFetch", func(t *testing.T) {
+		mockDAO := daotest.NewJobSchedule()
+		mockDAO.RegisterListByRepositoryId(repo.Id, []*database.JobSchedule{
+			{Id: 2, RepositoryId: repo.Id, JobName: "nightly", Schedule: "@daily", NextRunAt: varptr.Ptr(now)},
+		}, nil)
+		mockTransport := &MockTransport{res: []*http.Response{
+			jsonResponse(`{"sha":"headsha"}`),
+			{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader(""))},
+		}}
+		s := NewScheduler(time.Minute, dao.Options{JobSchedule: mockDAO}, &MockBuilder{}, forge.NewSet(forge.NewGitHub(github.NewClient(&http.Client{Transport: mockTransport}))))
+
+		err := s.refreshRepository(context.Background(), repo, now)
+		require.Error(t, err)
+
+		// The schedule must not be deleted if the build configuration couldn't be read.
+		assert.Len(t, mockDAO.Called("Delete"), 0)
+	})
+}
@@ -1,11 +1,14 @@
 package web
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
 	"io"
 	"net/http"
+	"path"
 	"sort"
 	"strconv"
 	"strings"
@@ -13,6 +16,7 @@ import (
 	"github.com/google/go-github/v49/github"
 	"go.f110.dev/protoc-ddl/probe"
 	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
 
 	"go.f110.dev/mono/go/build/config"
 	"go.f110.dev/mono/go/build/database"
@@ -23,24 +27,31 @@ import (
 )
 
 const (
-	NumberOfTaskPerJob = 10
+	NumberOfTaskPerJob  = 10
+	NumberOfTestHistory = 30
 )
 
 type Dashboard struct {
 	*http.Server
 
-	dao         dao.Options
-	apiHost     string
-	internalAPI string
-	minio       *storage.MinIO
+	dao            dao.Options
+	apiHost        string
+	internalAPI    string
+	minio          *storage.MinIO
+	kubeClient     kubernetes.Interface
+	buildNamespace string
 }
 
-func NewDashboard(addr string, daoOpt dao.Options, apiHost, internalAPI string, bucket string, minioOpt storage.MinIOOptions) *Dashboard {
+// NewDashboard returns the dashboard server.
+// If kubeClient is nil, the log of the running task is not available.
+func NewDashboard(addr string, daoOpt dao.Options, apiHost, internalAPI string, bucket string, minioOpt storage.MinIOOptions, kubeClient kubernetes.Interface, buildNamespace string) *Dashboard {
 	d := &Dashboard{
-		dao:         daoOpt,
-		apiHost:     apiHost,
-		internalAPI: internalAPI,
-		minio:       storage.NewMinIOStorage(bucket, minioOpt),
+		dao:            daoOpt,
+		apiHost:        apiHost,
+		internalAPI:    internalAPI,
+		minio:          storage.NewMinIOStorage(bucket, minioOpt),
+		kubeClient:     kubeClient,
+		buildNamespace: buildNamespace,
 	}
 
 	mux := http.NewServeMux()
@@ -48,12 +59,15 @@ func NewDashboard(addr string, daoOpt dao.Options, apiHost, internalAPI string,
 	mux.HandleFunc("/liveness", d.handleLiveness)
 	mux.HandleFunc("/readiness", d.handleReadiness)
 	mux.HandleFunc("/logs/", d.handleLogs)
+	mux.HandleFunc("/log_stream/", d.handleLogStream)
 	mux.HandleFunc("/manifest/", d.handleManifest)
+	mux.HandleFunc("/artifacts/", d.handleArtifact)
 	mux.HandleFunc("/task/", d.handleTask)
 	mux.HandleFunc("/new_repo", d.handleNewRepository)
 	mux.HandleFunc("/delete_repo", d.handleDeleteRepository)
 	mux.HandleFunc("/add_trusted_user", d.handleAddTrustedUser)
 	mux.HandleFunc("/server_info", d.handleServerInfo)
+	mux.HandleFunc("/tests", d.handleTests)
 	mux.HandleFunc("/", d.handleIndex)
 	s := &http.Server{
 		Addr:    addr,
@@ -70,7 +84,8 @@ func (d *Dashboard) Start() error {
 
 type Task struct {
 	*database.Task
-	RevisionUrl string
+	RevisionUrl    string
+	PullRequestUrl string
 }
 
 func (d *Dashboard) handleIndex(w http.ResponseWriter, req *http.Request) {
@@ -142,11 +157,31 @@ func (d *Dashboard) handleIndex(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	var schedules []*database.JobSchedule
+	if repoId != 0 {
+		s, err := d.dao.JobSchedule.ListByRepositoryId(req.Context(), repoId)
+		if err != nil {
+			logger.Log.Warn("Failed to get the schedule", logger.Error(err))
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		schedules = s
+	} else {
+		s, err := d.dao.JobSchedule.ListAll(req.Context())
+		if err != nil {
+			logger.Log.Warn("Failed to get the schedule", logger.Error(err))
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		schedules = s
+	}
+
 	err = IndexTemplate.Execute(w, struct {
 		Repositories       []*database.SourceRepository
 		TrustedUsers       []*database.TrustedUser
 		Tasks              []*Task
 		Jobs               []string
+		Schedules          []*database.JobSchedule
 		FilterRepositoryId int32
 		APIHost            template.JSStr
 	}{
@@ -154,6 +189,7 @@ func (d *Dashboard) handleIndex(w http.ResponseWriter, req *http.Request) {
 		TrustedUsers:       trustedUsers,
 		Tasks:              taskList,
 		Jobs:               jobs,
+		Schedules:          schedules,
 		FilterRepositoryId: repoId,
 		APIHost:            template.JSStr(d.apiHost),
 	})
@@ -196,6 +232,71 @@ func (d *Dashboard) handleServerInfo(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// handleTests shows the flaky tests of the repository.
+// If the label is given, the results of the test at the recent trunk builds are also shown.
+func (d *Dashboard) handleTests(w http.ResponseWriter, req *http.Request) {
+	repoId, err := strconv.Atoi(req.URL.Query().Get("repo"))
+	if err != nil {
+		http.Error(w, "invalid format", http.StatusBadRequest)
+		return
+	}
+	repo, err := d.dao.Repository.Select(req.Context(), int32(repoId))
+	if err != nil {
+		logger.Log.Info("Repository is not found", logger.Error(err))
+		http.Error(w, "repository is not found", http.StatusNotFound)
+		return
+	}
+
+	label := req.URL.Query().Get("label")
+	var history []*database.TestReport
+	var flakyTests []*database.FlakyTest
+	if label != "" {
+		h, err := d.dao.TestReport.ListByLabel(req.Context(), repo.Id, label, dao.Limit(NumberOfTestHistory), dao.Desc)
+		if err != nil {
+			logger.Log.Warn("Failed to get the test report", logger.Error(err))
+			http.Error(w, "failed to get the test report", http.StatusInternalServerError)
+			return
+		}
+		history = h
+		f, err := d.dao.FlakyTest.ListByLabel(req.Context(), repo.Id, label, dao.Desc)
+		if err != nil {
+			logger.Log.Warn("Failed to get the flaky test", logger.Error(err))
+			http.Error(w, "failed to get the flaky tests", http.StatusInternalServerError)
+			return
+		}
+		flakyTests = f
+	} else {
+		f, err := d.dao.FlakyTest.ListByRepositoryId(req.Context(), repo.Id, dao.Limit(100), dao.Desc)
+		if err != nil {
+			logger.Log.Warn("Failed to get the flaky test", logger.Error(err))
+			http.Error(w, "failed to get the flaky tests", http.StatusInternalServerError)
+			return
+		}
+		flakyTests = f
+	}
+	flakyRevisions := make(map[string]bool)
+	for _, v := range flakyTests {
+		flakyRevisions[v.Revision] = true
+	}
+
+	err = TestsTemplate.Execute(w, struct {
+		Repository     *database.SourceRepository
+		Label          string
+		History        []*database.TestReport
+		FlakyTests     []*database.FlakyTest
+		FlakyRevisions map[string]bool
+	}{
+		Repository:     repo,
+		Label:          label,
+		History:        history,
+		FlakyTests:     flakyTests,
+		FlakyRevisions: flakyRevisions,
+	})
+	if err != nil {
+		logger.Log.Warn("Failed to render template", zap.Error(err))
+	}
+}
+
 func (d *Dashboard) handleTask(w http.ResponseWriter, req *http.Request) {
 	s := strings.Split(req.URL.Path, "/")
 	if len(s) < 2 {
@@ -209,55 +310,167 @@ func (d *Dashboard) handleTask(w http.ResponseWriter, req *http.Request) {
 	}
 
 	task, err := d.dao.Task.Select(req.Context(), int32(id))
-	if err != nil {
+	if errors.Is(err, sql.ErrNoRows) {
 		logger.Log.Info("not found task", zap.Int("id", id))
-		w.WriteHeader(http.StatusBadRequest)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	} else if err != nil {
+		logger.Log.Warn("failed to get task", zap.Int("id", id), zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 	reports, err := d.dao.TestReport.ListByTaskId(req.Context(), task.Id)
 	if err != nil {
-		logger.Log.Info("failed to get report", zap.Int32("task_id", task.Id))
-		w.WriteHeader(http.StatusBadRequest)
+		logger.Log.Warn("failed to get report", zap.Int32("task_id", task.Id), zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	jobConf := &config.Job{}
-	if task.JobConfiguration != nil && len(*task.JobConfiguration) > 0 {
-		if err := config.UnmarshalJob([]byte(*task.JobConfiguration), jobConf); err != nil {
-			logger.Log.Warn("Failed to parse job configuration", logger.Error(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-	} else if len(task.ParsedJobConfiguration) > 0 {
-		if err := config.UnmarshalJob(task.ParsedJobConfiguration, jobConf); err != nil {
-			logger.Log.Warn("Failed to parse job configuration by gob", logger.Error(err))
+	coverageReports, err := d.dao.CoverageReport.ListByTaskId(req.Context(), task.Id)
+	if err != nil {
+		logger.Log.Warn("failed to get coverage report", zap.Int32("task_id", task.Id), zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	artifacts, err := d.dao.Artifact.ListByTaskId(req.Context(), task.Id)
+	if err != nil {
+		logger.Log.Warn("failed to get artifacts", zap.Int32("task_id", task.Id), zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	jobConf, err := config.DecodeJobConfiguration(task)
+	if err != nil {
+		logger.Log.Warn("Failed to parse job configuration", logger.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	tasks, err := d.dao.Task.ListByRevision(req.Context(), task.RepositoryId, task.Revision)
+	if err != nil {
+		logger.Log.Info("failed to get tasks", zap.Int32("task_id", task.Id))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	var group []*database.Task
+	if task.GroupId > 0 {
+		group, err = d.dao.Task.ListByGroupId(req.Context(), task.GroupId)
+		if err != nil {
+			logger.Log.Info("failed to get tasks of the group", zap.Int32("task_id", task.Id))
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 	}
-	revUrl := ""
+	revUrl, pullRequestUrl := "", ""
 	if strings.Contains(task.Repository.Url, "https://github.com") {
 		revUrl = task.Repository.Url + "/commit/" + task.Revision
+		if task.PullRequest > 0 {
+			pullRequestUrl = fmt.Sprintf("%s/pull/%d", task.Repository.Url, task.PullRequest)
+		}
 	}
 	err = DetailTemplate.Execute(w, struct {
-		Task       *Task
-		Job        *config.Job
-		TestReport []*database.TestReport
-		APIHost    template.JSStr
+		Task           *Task
+		Job            *config.Job
+		TestReport     []*database.TestReport
+		CoverageReport []*database.CoverageReport
+		Artifacts      []*database.Artifact
+		Pipeline       [][]*database.Task
+		Group          []*database.Task
+		APIHost        template.JSStr
 	}{
 		Task: &Task{
-			Task:        task,
-			RevisionUrl: revUrl,
+			Task:           task,
+			RevisionUrl:    revUrl,
+			PullRequestUrl: pullRequestUrl,
 		},
-		Job:        jobConf,
-		TestReport: reports,
-		APIHost:    template.JSStr(d.apiHost),
+		Job:            jobConf,
+		TestReport:     reports,
+		CoverageReport: coverageReports,
+		Artifacts:      artifacts,
+		Pipeline:       buildPipeline(tasks),
+		Group:          group,
+		APIHost:        template.JSStr(d.apiHost),
 	})
 	if err != nil {
 		logger.Log.Warn("Failed to render template", zap.Error(err))
 	}
 }
 
+// buildPipeline returns the stages of the jobs at the revision.
+// Each stage contains the latest tasks of the jobs which have the same depth in the dependency graph.
+// If any job doesn't have the dependency, buildPipeline returns nil.
+func buildPipeline(tasks []*database.Task) [][]*database.Task {
+	latest := make(map[string]*database.Task)
+	needs := make(map[string][]string)
+	for _, v := range tasks {
+		key := v.JobName + "/" + v.Platform + "/" + v.Matrix
+		if t, ok := latest[key]; ok && t.Id > v.Id {
+			continue
+		}
+		latest[key] = v
+
+		jobConf, err := config.DecodeJobConfiguration(v)
+		if err != nil {
+			continue
+		}
+		needs[v.JobName] = jobConf.Needs
+	}
+	hasDependency := false
+	for _, v := range needs {
+		if len(v) > 0 {
+			hasDependency = true
+			break
+		}
+	}
+	if !hasDependency {
+		return nil
+	}
+
+	depth := make(map[string]int)
+	var visit func(name string, path map[string]struct{}) int
+	visit = func(name string, path map[string]struct{}) int {
+		if v, ok := depth[name]; ok {
+			return v
+		}
+		if _, ok := path[name]; ok {
+			return 0
+		}
+		path[name] = struct{}{}
+		d := 0
+		for _, v := range needs[name] {
+			if _, ok := needs[v]; !ok {
+				continue
+			}
+			if n := visit(v, path) + 1; n > d {
+				d = n
+			}
+		}
+		delete(path, name)
+		depth[name] = d
+		return d
+	}
+
+	var stages [][]*database.Task
+	for _, v := range latest {
+		d := visit(v.JobName, make(map[string]struct{}))
+		for len(stages) <= d {
+			stages = append(stages, nil)
+		}
+		stages[d] = append(stages[d], v)
+	}
+	for _, v := range stages {
+		sort.Slice(v, func(i, j int) bool {
+			if v[i].JobName == v[j].JobName {
+				if v[i].Platform == v[j].Platform {
+					return v[i].Matrix < v[j].Matrix
+				}
+				return v[i].Platform < v[j].Platform
+			}
+			return v[i].JobName < v[j].JobName
+		})
+	}
+	return stages
+}
+
 func (d *Dashboard) handleLogs(w http.ResponseWriter, req *http.Request) {
 	s := strings.Split(req.URL.Path, "/")
 	if len(s) < 2 {
@@ -301,6 +514,40 @@ func (d *Dashboard) handleManifest(w http.ResponseWriter, req *http.Request) {
 	io.WriteString(w, task.Manifest)
 }
 
+func (d *Dashboard) handleArtifact(w http.ResponseWriter, req *http.Request) {
+	s := strings.Split(req.URL.Path, "/")
+	if len(s) < 3 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	id, err := strconv.Atoi(s[2])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	artifact, err := d.dao.Artifact.Select(req.Context(), int32(id))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	r, err := d.minio.Get(req.Context(), artifact.Path)
+	if err != nil {
+		logger.Log.Warn("Failed to get an artifact", zap.Error(err), zap.String("path", artifact.Path))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer r.Body.Close()
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(artifact.Name)))
+	w.Header().Set("Content-Length", strconv.FormatInt(r.Size, 10))
+	// The status code has already been sent. Thus, the error can only be logged.
+	if _, err := io.Copy(w, r.Body); err != nil {
+		logger.Log.Warn("Failed to send the artifact", zap.Error(err), zap.Int32("id", artifact.Id))
+	}
+}
+
 func (d *Dashboard) handleNewRepository(w http.ResponseWriter, req *http.Request) {
 	if req.Method != http.MethodPost {
 		w.WriteHeader(http.StatusMethodNotAllowed)
@@ -327,11 +574,22 @@ func (d *Dashboard) handleNewRepository(w http.ResponseWriter, req *http.Request
 	if req.FormValue("private") != "" {
 		private = true
 	}
+	var retentionDays int
+	if v := req.FormValue("artifact_retention_days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			logger.Log.Info("Invalid retention days", zap.String("artifact_retention_days", v))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		retentionDays = n
+	}
 	if _, err := d.dao.Repository.Create(req.Context(), &database.SourceRepository{
-		Name:     req.FormValue("name"),
-		Url:      req.FormValue("url"),
-		CloneUrl: req.FormValue("clone_url"),
-		Private:  private,
+		Name:                  req.FormValue("name"),
+		Url:                   req.FormValue("url"),
+		CloneUrl:              req.FormValue("clone_url"),
+		Private:               private,
+		ArtifactRetentionDays: int32(retentionDays),
 	}); err != nil {
 		logger.Log.Warn("Failed create repository", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
@@ -413,7 +671,7 @@ func (d *Dashboard) handleAddTrustedUser(w http.ResponseWriter, req *http.Reques
 		return
 	}
 
-	_, err = d.dao.TrustedUser.Create(req.Context(), &database.TrustedUser{GithubId: u.GetID(), Username: u.GetLogin()})
+	_, err = d.dao.TrustedUser.Create(req.Context(), &database.TrustedUser{GithubId: u.GetID(), Username: u.GetLogin(), Host: "github.com"})
 	if err != nil {
 		logger.Log.Warn("Failed create trusted user", zap.Error(err), zap.String("username", username))
 		w.WriteHeader(http.StatusInternalServerError)
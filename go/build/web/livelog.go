@@ -0,0 +1,214 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.f110.dev/xerrors"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"go.f110.dev/mono/go/build/coordinator"
+	"go.f110.dev/mono/go/build/database"
+	"go.f110.dev/mono/go/logger"
+)
+
+const (
+	logStreamPollInterval = 2 * time.Second
+	logStreamWaitTimeout  = 10 * time.Minute
+)
+
+type eventWriter struct {
+	w http.ResponseWriter
+	f http.Flusher
+}
+
+// Send writes the event in the format of server-sent events.
+// If event is empty, the event is received as the message event by the client.
+func (e *eventWriter) Send(event, data string) error {
+	if event != "" {
+		if _, err := fmt.Fprintf(e.w, "event: %s\n", event); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	for _, line := range strings.Split(data, "\n") {
+		if _, err := fmt.Fprintf(e.w, "data: %s\n", line); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	if _, err := io.WriteString(e.w, "\n"); err != nil {
+		return xerrors.WithStack(err)
+	}
+	e.f.Flush()
+	return nil
+}
+
+// handleLogStream streams the log of the task as server-sent events.
+// While the task is running, the log is read from the build pod through kube-apiserver.
+// The finished event which has the URL of the stored log is sent after the log has been stored.
+func (d *Dashboard) handleLogStream(w http.ResponseWriter, req *http.Request) {
+	s := strings.Split(req.URL.Path, "/")
+	if len(s) < 3 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	id, err := strconv.Atoi(s[2])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	task, err := d.dao.Task.Select(req.Context(), int32(id))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	ew := &eventWriter{w: w, f: flusher}
+
+	if task.FinishedAt == nil {
+		if d.kubeClient == nil {
+			ew.Send("", "The live log is not available")
+		} else if err := d.streamPodLog(req.Context(), ew, task.Id); err != nil {
+			logger.Log.Info("Failed to stream the log", logger.Error(err), zap.Int32("task.id", task.Id))
+		}
+
+		t, err := d.waitForTaskFinished(req.Context(), task.Id)
+		if err != nil {
+			logger.Log.Info("The task has not finished", logger.Error(err), zap.Int32("task.id", task.Id))
+			return
+		}
+		task = t
+	}
+
+	logURL := ""
+	if task.LogFile != "" {
+		logURL = "/logs/" + task.LogFile
+	}
+	ew.Send("finished", logURL)
+}
+
+// streamPodLog sends the log of the containers of the build pod until the main container has finished.
+func (d *Dashboard) streamPodLog(ctx context.Context, ew *eventWriter, taskId int32) error {
+	ctx, cancel := context.WithTimeout(ctx, logStreamWaitTimeout)
+	defer cancel()
+
+	for i, name := range []string{coordinator.PreProcessContainerName, coordinator.BuildContainerName} {
+		pod, err := d.waitForContainerStarted(ctx, taskId, name)
+		if err != nil {
+			return err
+		}
+		if pod == nil {
+			// The pod has been deleted.
+			return nil
+		}
+
+		// The header is the same as the stored log.
+		header := fmt.Sprintf("----- %s -----", name)
+		if i > 0 {
+			header = "\n" + header
+		}
+		if err := ew.Send("", header); err != nil {
+			return err
+		}
+
+		stream, err := d.kubeClient.CoreV1().Pods(d.buildNamespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: name, Follow: true}).Stream(ctx)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		scanner := bufio.NewScanner(stream)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if err := ew.Send("", scanner.Text()); err != nil {
+				stream.Close()
+				return err
+			}
+		}
+		stream.Close()
+		if err := scanner.Err(); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+// waitForContainerStarted returns the build pod after the container has started.
+// If the pod has been deleted after the pod has been found, waitForContainerStarted returns nil.
+func (d *Dashboard) waitForContainerStarted(ctx context.Context, taskId int32, name string) (*corev1.Pod, error) {
+	selector := labels.SelectorFromSet(labels.Set{coordinator.LabelKeyTaskId: strconv.Itoa(int(taskId))})
+	found := false
+	ticker := time.NewTicker(logStreamPollInterval)
+	defer ticker.Stop()
+	for {
+		pods, err := d.kubeClient.CoreV1().Pods(d.buildNamespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		if len(pods.Items) > 0 {
+			found = true
+			if containerStarted(&pods.Items[0], name) {
+				return &pods.Items[0], nil
+			}
+		} else if found {
+			return nil, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, xerrors.WithStack(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForTaskFinished returns the task after the coordinator has finished the task.
+func (d *Dashboard) waitForTaskFinished(ctx context.Context, taskId int32) (*database.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, logStreamWaitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(logStreamPollInterval)
+	defer ticker.Stop()
+	for {
+		task, err := d.dao.Task.Select(ctx, taskId)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		if task.FinishedAt != nil {
+			return task, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, xerrors.WithStack(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func containerStarted(pod *corev1.Pod, name string) bool {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, v := range statuses {
+		if v.Name != name {
+			continue
+		}
+		return v.State.Running != nil || v.State.Terminated != nil
+	}
+	return false
+}
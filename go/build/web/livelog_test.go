@@ -0,0 +1,60 @@
+package web
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"go.f110.dev/mono/go/build/coordinator"
+	"go.f110.dev/mono/go/k8s/k8sfactory"
+)
+
+func TestEventWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ew := &eventWriter{w: rec, f: rec}
+
+	require.NoError(t, ew.Send("", "foo\nbar"))
+	require.NoError(t, ew.Send("finished", "/logs/test"))
+	assert.Equal(t, "data: foo\ndata: bar\n\nevent: finished\ndata: /logs/test\n\n", rec.Body.String())
+}
+
+func TestDashboard_StreamPodLog(t *testing.T) {
+	terminated := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+	pod := k8sfactory.PodFactory(nil,
+		k8sfactory.Name("test-1"),
+		k8sfactory.Namespace("build"),
+		k8sfactory.Labels(map[string]string{coordinator.LabelKeyTaskId: "1"}),
+	)
+	pod.Status.InitContainerStatuses = []corev1.ContainerStatus{{Name: coordinator.PreProcessContainerName, State: terminated}}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: coordinator.BuildContainerName, State: terminated}}
+	d := &Dashboard{kubeClient: fake.NewSimpleClientset(pod), buildNamespace: "build"}
+
+	rec := httptest.NewRecorder()
+	err := d.streamPodLog(context.Background(), &eventWriter{w: rec, f: rec}, 1)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"data: ----- pre-process -----\n\ndata: fake logs\n\ndata: \ndata: ----- main -----\n\ndata: fake logs\n\n",
+		rec.Body.String(),
+	)
+}
+
+func TestContainerStarted(t *testing.T) {
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{Name: coordinator.PreProcessContainerName, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: coordinator.BuildContainerName, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}}},
+			},
+		},
+	}
+	assert.True(t, containerStarted(pod, coordinator.PreProcessContainerName))
+	assert.False(t, containerStarted(pod, coordinator.BuildContainerName))
+	assert.False(t, containerStarted(pod, "report"))
+}
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"fmt"
 	"html/template"
 	"time"
 )
@@ -9,6 +10,7 @@ var (
 	IndexTemplate      *template.Template
 	DetailTemplate     *template.Template
 	ServerInfoTemplate *template.Template
+	TestsTemplate      *template.Template
 )
 
 func init() {
@@ -19,11 +21,31 @@ func init() {
 			}
 			return end.Sub(*start).String()
 		},
+		"ByteSize": func(size int64) string {
+			units := []string{"B", "KB", "MB", "GB"}
+			v := float64(size)
+			i := 0
+			for v >= 1024 && i < len(units)-1 {
+				v /= 1024
+				i++
+			}
+			if i == 0 {
+				return fmt.Sprintf("%d %s", size, units[i])
+			}
+			return fmt.Sprintf("%.1f %s", v, units[i])
+		},
+		"Coverage": func(found, hit int32) string {
+			if found == 0 {
+				return ""
+			}
+			return fmt.Sprintf("%.1f%%", float64(hit)/float64(found)*100)
+		},
 	}
 
 	IndexTemplate = template.Must(template.New("").Funcs(funcs).Parse(indexTemplate))
 	DetailTemplate = template.Must(template.New("").Funcs(funcs).Parse(detailTemplate))
 	ServerInfoTemplate = template.Must(template.New("").Funcs(funcs).Parse(infoTemplate))
+	TestsTemplate = template.Must(template.New("").Funcs(funcs).Parse(testsTemplate))
 }
 
 const indexTemplate = `<html>
@@ -82,6 +104,15 @@ const indexTemplate = `<html>
     </div>
   </div>
 
+  <div class="ui item dropdown simple">
+    Flaky tests<i class="dropdown icon"></i>
+    <div class="menu">
+      {{- range .Repositories }}
+      <a class="item" href="/tests?repo={{ .Id }}">{{ .Name }}</a>
+      {{- end }}
+    </div>
+  </div>
+
   <div class="item right">
     <a href="/server_info"><i class="info icon"></i>Info</a>
   </div>
@@ -107,6 +138,10 @@ const indexTemplate = `<html>
         <label>Clone URL</label>
         <input type="text" name="clone_url" placeholder="URL for cloning of the repository (e.g https://github.com/f110/sandbox.git)">
       </div>
+      <div class="field">
+        <label>Artifact retention (days)</label>
+        <input type="number" name="artifact_retention_days" min="0" placeholder="The number of days to keep the artifacts. 0 means the artifacts are kept until the task is deleted.">
+      </div>
       <div class="field">
         <div class="ui checkbox">
           <input type="checkbox" tabindex="0" class="hidden" name="private">
@@ -173,6 +208,34 @@ const indexTemplate = `<html>
 </div>
 <!-- end of modal -->
 
+{{- if .Schedules }}
+<div class="table-container">
+  <h3 class="ui header">Scheduled jobs</h3>
+  <table class="ui striped table">
+    <thead>
+      <tr>
+        <th>Repository</th>
+        <th>Job</th>
+        <th>Schedule</th>
+        <th>Last run</th>
+        <th>Next run</th>
+      </tr>
+    </thead>
+    <tbody>
+      {{- range .Schedules }}
+      <tr>
+        <td>{{ if .Repository }}<a href="/?repo={{ .Repository.Id }}">{{ .Repository.Name }}</a>{{ end }}</td>
+        <td>{{ .JobName }}</td>
+        <td>{{ .Schedule }}</td>
+        <td>{{ if .LastRunAt }}{{ if .LastTaskId }}<a href="/task/{{ .LastTaskId }}">{{ .LastRunAt.Format "2006/01/02 15:04:05" }}</a>{{ else }}{{ .LastRunAt.Format "2006/01/02 15:04:05" }}{{ end }}{{ end }}</td>
+        <td>{{ if .NextRunAt }}{{ .NextRunAt.Format "2006/01/02 15:04:05" }}{{ end }}</td>
+      </tr>
+      {{- end }}
+    </tbody>
+  </table>
+</div>
+{{- end }}
+
 <div class="table-container">
   <div class="ui form">
     <div class="inline fields">
@@ -220,9 +283,9 @@ const indexTemplate = `<html>
       <tr>
         <td><a href="/task/{{ .Id }}">{{ .Id }}</a></td>
         <td><a href="/?repo={{ .Repository.Id }}">{{ .Repository.Name }}</a></td>
-        <td class="buildinfo" data-content="Bazel version: {{.BazelVersion }}">{{ .JobName }}</td>
-        <td>{{ .Command }}</td>
-        <td>{{ if .FinishedAt }}{{ if .Success }}<i class="green check icon"></i>{{ else }}<i class="red attention icon"></i>{{ end }}{{ else }}<i class="sync amber alternate icon"></i>{{ end }}</td>
+        <td class="buildinfo" data-content="Bazel version: {{.BazelVersion }}">{{ .JobName }}{{ if .Matrix }} <span class="ui grey text">{{ .Matrix }}</span>{{ end }}</td>
+        <td>{{ .Command }}{{ if .LinesFound }} ({{ Coverage .LinesFound .LinesHit }}){{ end }}</td>
+        <td>{{ if .Canceled }}<i class="grey ban icon"></i>{{ else if .FinishedAt }}{{ if .Success }}<i class="green check icon"></i>{{ else }}<i class="red attention icon"></i>{{ end }}{{ else if .StartAt }}<i class="sync amber alternate icon"></i>{{ else }}<i class="grey clock outline icon"></i>{{ end }}</td>
         <td><a href="{{ .RevisionUrl }}">{{ if .Revision }}{{ slice .Revision 0 6 }}{{ end }}</a></td>
         <td>{{ if .LogFile }}<a href="/logs/{{ .LogFile }}">text</a>{{ end }}</td>
         <td><a href="/manifest/{{ .Id }}">yaml</a></td>
@@ -231,7 +294,7 @@ const indexTemplate = `<html>
         <td>{{ .StartAt }}</td>
         <td>{{ Duration .StartAt .FinishedAt }}</td>
         <td>{{ if gt .ExecutedTestsCount 0 }}{{ .SucceededTestsCount }}/{{ .ExecutedTestsCount }}{{ end }}</td>
-        <td>{{ if .FinishedAt }}<a href="#" onclick="redoTask({{ .Id }})"><i class="amber redo icon"></i></a>{{ end }}</td>
+        <td>{{ if .FinishedAt }}<a href="#" onclick="redoTask({{ .Id }})"><i class="amber redo icon"></i></a>{{ else }}<a href="#" onclick="cancelTask({{ .Id }})"><i class="red stop circle outline icon"></i></a>{{ end }}</td>
       </tr>
       {{- end }}
     </tbody>
@@ -280,6 +343,7 @@ function createRepository() {
 	params.append("url", f.url.value);
 	params.append("clone_url", f.clone_url.value);
     params.append("private", f.private.value);
+	params.append("artifact_retention_days", f.artifact_retention_days.value);
 	fetch('/new_repo', {
 		method: 'POST',
 		body: params,
@@ -330,6 +394,21 @@ function redoTask(id) {
   });
 }
 
+function cancelTask(id) {
+  var params = new URLSearchParams();
+  params.append("task_id", id);
+  fetch(apiHost+"/cancel",{
+    mode: 'cors',
+    method: 'POST',
+    credentials: 'include',
+    body: params,
+  }).then(response => {
+    if (response.ok) {
+      window.location.reload(false);
+    }
+  });
+}
+
 function startRunTask(id, name) {
   var elms = document.querySelectorAll('span.repoName');
   elms.forEach(function (e) {
@@ -391,7 +470,7 @@ const detailTemplate = `<html>
 </div>
 
 <div class="ui container">
-  <table class="ui {{ if .Task.FinishedAt }}{{ if .Task.Success }}green{{ else }}red{{ end }}{{ else }}amber{{ end }} table definition">
+  <table class="ui {{ if .Task.Canceled }}grey{{ else if .Task.FinishedAt }}{{ if .Task.Success }}green{{ else }}red{{ end }}{{ else }}amber{{ end }} table definition">
     <tbody>
     <tr>
       <td>id</td>
@@ -399,16 +478,28 @@ const detailTemplate = `<html>
     </tr>
     <tr>
       <td>Status</td>
-      <td>{{ if .Task.FinishedAt }}{{ if .Task.Success }}<i class="green check icon"></i>Success{{ else }}<i class="red attention icon"></i>Failure{{ end }}{{ else }}<i class="amber sync alternate icon"></i>Running{{ end }}</td>
+      <td>{{ if .Task.Canceled }}<i class="grey ban icon"></i>Canceled{{ if .Task.SupersededBy }} (superseded by <a href="/task/{{ .Task.SupersededBy }}">#{{ .Task.SupersededBy }}</a>){{ end }}{{ else if .Task.FinishedAt }}{{ if .Task.Success }}<i class="green check icon"></i>Success{{ else }}<i class="red attention icon"></i>Failure{{ end }}{{ else if .Task.StartAt }}<i class="amber sync alternate icon"></i>Running{{ else }}<i class="grey clock outline icon"></i>Pending{{ end }}</td>
     </tr>
     <tr>
       <td>Job</td>
       <td>{{ .Task.JobName }}</td>
     </tr>
+    {{- if .Job.Needs }}
+    <tr>
+      <td>Needs</td>
+      <td>{{ range $i, $v := .Job.Needs }}{{ if $i }}, {{ end }}{{ $v }}{{ end }}</td>
+    </tr>
+    {{- end }}
     <tr>
       <td>Revision</td>
       <td><a href="{{ .Task.RevisionUrl }}">{{ if .Task.Revision }}{{ slice .Task.Revision 0 6 }}{{ end }}</a></td>
     </tr>
+    {{- if .Task.PullRequest }}
+    <tr>
+      <td>Pull request</td>
+      <td>{{ if .Task.PullRequestUrl }}<a href="{{ .Task.PullRequestUrl }}">#{{ .Task.PullRequest }}</a>{{ else }}#{{ .Task.PullRequest }}{{ end }}</td>
+    </tr>
+    {{- end }}
     <tr>
       <td>Started at</td>
       <td>{{ if .Task.StartAt }}{{ .Task.StartAt.Format "2006/01/02 15:04:06" }}{{ end }}</td>
@@ -422,7 +513,7 @@ const detailTemplate = `<html>
           <div class="content">
             <div class="ui list">
               {{- range .TestReport }}
-              <div class="item test-name"><i class="{{ if eq .Status 0 }}check circle green{{ else }}{{ if eq .Status 1 }}exclamation circle yellow{{ else }}exclamation triangle red{{ end }}{{ end }} icon"></i>{{ .Label }}</div>
+              <div class="item test-name"><i class="{{ if eq .Status 0 }}check circle green{{ else }}{{ if eq .Status 1 }}exclamation circle yellow{{ else }}exclamation triangle red{{ end }}{{ end }} icon"></i><a href="/tests?repo={{ $.Task.RepositoryId }}&label={{ .Label }}">{{ .Label }}</a></div>
               {{- end }}
             </div>
         </div>
@@ -431,6 +522,23 @@ const detailTemplate = `<html>
         {{- end }}
       </td>
     </tr>
+    {{- if .Task.LinesFound }}
+    <tr>
+      <td>Coverage</td>
+      <td>
+        <div class="ui accordion">
+          <div class="title"><i class="dropdown icon"></i>{{ Coverage .Task.LinesFound .Task.LinesHit }} ({{ .Task.LinesHit }} / {{ .Task.LinesFound }} lines)</div>
+          <div class="content">
+            <div class="ui list">
+              {{- range .CoverageReport }}
+              <div class="item">{{ Coverage .LinesFound .LinesHit }} {{ .Path }}</div>
+              {{- end }}
+            </div>
+          </div>
+        </div>
+      </td>
+    </tr>
+    {{- end }}
     <tr>
       <td>Duration</td>
       <td>{{ Duration .Task.StartAt .Task.FinishedAt }}</td>
@@ -443,6 +551,18 @@ const detailTemplate = `<html>
       <td>Trigger</td>
       <td>{{ .Task.Via }}</td>
     </tr>
+    {{- if .Task.RetryOf }}
+    <tr>
+      <td>Retry of</td>
+      <td><a href="/task/{{ .Task.RetryOf }}">#{{ .Task.RetryOf }}</a> (attempt {{ .Task.Attempt }})</td>
+    </tr>
+    {{- end }}
+    {{- if .Task.Matrix }}
+    <tr>
+      <td>Matrix</td>
+      <td>{{ .Task.Matrix }}</td>
+    </tr>
+    {{- end }}
     <tr>
       <td>Bazel version</td>
       <td>{{ .Task.BazelVersion }}</td>
@@ -459,6 +579,18 @@ const detailTemplate = `<html>
       <td>Log</td>
       <td>{{ if .Task.LogFile }}<a href="/logs/{{ .Task.LogFile }}">text</a>{{ end }}</td>
     </tr>
+    {{- if .Artifacts }}
+    <tr>
+      <td>Artifacts</td>
+      <td>
+        <div class="ui list">
+          {{- range .Artifacts }}
+          <div class="item"><i class="file outline icon"></i><a href="/artifacts/{{ .Id }}">{{ .Name }}</a> ({{ ByteSize .Size }})</div>
+          {{- end }}
+        </div>
+      </td>
+    </tr>
+    {{- end }}
     <tr>
       <td>Job manifest</td>
       <td><a href="/manifest/{{ .Task.Id }}">yaml</a></td>
@@ -466,8 +598,48 @@ const detailTemplate = `<html>
     </tbody>
   </table>
 
-  {{ if .Task.FinishedAt }}<a class="ui button amber" href="#" onclick="redoTask({{ .Task.Id }})">Restart</a>{{ end }}
+  {{- if .Pipeline }}
+  <h3 class="ui header">Pipeline</h3>
+  <div class="ui horizontal segments">
+    {{- range $i, $stage := .Pipeline }}
+    <div class="ui segment">
+      <div class="ui small header">Stage {{ $i }}</div>
+      <div class="ui list">
+        {{- range $stage }}
+        <div class="item">
+          {{ if .Canceled }}<i class="grey ban icon"></i>{{ else if .FinishedAt }}{{ if .Success }}<i class="green check icon"></i>{{ else }}<i class="red attention icon"></i>{{ end }}{{ else if .StartAt }}<i class="sync amber alternate icon"></i>{{ else }}<i class="grey clock outline icon"></i>{{ end }}
+          <div class="content"><a href="/task/{{ .Id }}">{{ if eq .Id $.Task.Id }}<b>{{ .JobName }}</b>{{ else }}{{ .JobName }}{{ end }}</a> <span class="ui grey text">{{ .Platform }}{{ if .Matrix }} {{ .Matrix }}{{ end }}</span></div>
+        </div>
+        {{- end }}
+      </div>
+    </div>
+    {{- end }}
+  </div>
+  {{- end }}
+
+  {{- if .Group }}
+  <h3 class="ui header">Group</h3>
+  <div class="ui segment">
+    <div class="ui list">
+      {{- range .Group }}
+      <div class="item">
+        {{ if .Canceled }}<i class="grey ban icon"></i>{{ else if .FinishedAt }}{{ if .Success }}<i class="green check icon"></i>{{ else }}<i class="red attention icon"></i>{{ end }}{{ else if .StartAt }}<i class="sync amber alternate icon"></i>{{ else }}<i class="grey clock outline icon"></i>{{ end }}
+        <div class="content"><a href="/task/{{ .Id }}">{{ if eq .Id $.Task.Id }}<b>#{{ .Id }}</b>{{ else }}#{{ .Id }}{{ end }}</a> <span class="ui grey text">{{ .Platform }}{{ if .Matrix }} {{ .Matrix }}{{ end }}{{ if .RetryOf }} (attempt {{ .Attempt }}){{ end }}</span></div>
+      </div>
+      {{- end }}
+    </div>
+  </div>
+  {{- end }}
+
+  {{ if .Task.FinishedAt }}<a class="ui button amber" href="#" onclick="redoTask({{ .Task.Id }})">Restart</a>{{ else }}<a class="ui button red" href="#" onclick="cancelTask({{ .Task.Id }})">Cancel</a>{{ end }}
+</div>
+
+{{- if not .Task.FinishedAt }}
+<div class="ui container">
+  <h3 class="ui header">Log <div class="ui mini label live-log-status">Live</div></h3>
+  <pre class="ui segment live-log"></pre>
 </div>
+{{- end }}
 
 <script>
 $('.ui.accordion')
@@ -490,6 +662,61 @@ function redoTask(id) {
     }
   });
 }
+
+function cancelTask(id) {
+  var params = new URLSearchParams();
+  params.append("task_id", id);
+  fetch(apiHost+"/cancel",{
+    mode: 'cors',
+    method: 'POST',
+    credentials: 'include',
+    body: params,
+  }).then(response => {
+    if (response.ok) {
+      window.location.reload(false);
+    }
+  });
+}
+{{- if not .Task.FinishedAt }}
+
+function isFollowing() {
+  return window.innerHeight + window.scrollY >= document.body.scrollHeight - 10;
+}
+
+function followLog(id) {
+  const log = document.querySelector(".live-log");
+  const status = document.querySelector(".live-log-status");
+  const source = new EventSource("/log_stream/"+id);
+  // The server sends the log from the beginning at each connection.
+  source.onopen = () => {
+    log.textContent = "";
+  };
+  source.onmessage = e => {
+    const following = isFollowing();
+    log.append(e.data+"\n");
+    if (following) {
+      window.scrollTo(0, document.body.scrollHeight);
+    }
+  };
+  source.addEventListener("finished", e => {
+    source.close();
+    status.textContent = "Finished";
+    if (!e.data) {
+      return;
+    }
+    // Replace the live log with the stored log.
+    fetch(e.data).then(response => response.text()).then(text => {
+      const following = isFollowing();
+      log.textContent = text;
+      if (following) {
+        window.scrollTo(0, document.body.scrollHeight);
+      }
+    });
+  });
+}
+
+followLog({{ .Task.Id }});
+{{- end }}
 </script>
 </body>
 </html>
@@ -545,3 +772,91 @@ const infoTemplate = `<html>
 </body>
 </html>
 `
+
+const testsTemplate = `<html>
+<head>
+  <title>{{ if .Label }}{{ .Label }}{{ else }}Flaky tests{{ end }}</title>
+  <link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/semantic-ui@2.4.2/dist/semantic.min.css">
+  <script src="https://code.jquery.com/jquery-3.4.1.min.js" integrity="sha256-CSXorXvZcTkaix6Yvo6HppcZGetbYMGWSFlBw8HfCJo=" crossorigin="anonymous"></script>
+  <script src="https://cdn.jsdelivr.net/npm/semantic-ui@2.4.2/dist/semantic.min.js"></script>
+  <style>
+    i.amber.icon {color: #FFA000;}
+  </style>
+</head>
+<body>
+
+<div class="ui menu inverted huge">
+  <div class="header item">
+    <a href="/">Dashboard</a>
+  </div>
+
+  <div class="item right">
+    <a href="/server_info"><i class="info icon"></i>Info</a>
+  </div>
+</div>
+
+<div class="ui container">
+  <h2 class="ui header">
+    <a href="/?repo={{ .Repository.Id }}">{{ .Repository.Name }}</a>
+    <div class="sub header">{{ if .Label }}{{ .Label }}{{ else }}Flaky tests{{ end }}</div>
+  </h2>
+
+  {{- if .Label }}
+  <h3 class="ui header">History</h3>
+  <div>
+    {{- range .History }}
+    <a href="/task/{{ .TaskId }}" title="{{ .StartAt.Format "2006/01/02 15:04:05" }}"><i class="{{ if eq .Status 0 }}check circle green{{ else }}{{ if eq .Status 1 }}exclamation circle yellow{{ else }}exclamation triangle red{{ end }}{{ end }} icon"></i></a>
+    {{- end }}
+  </div>
+  <table class="ui table">
+    <thead>
+    <tr>
+      <th>Task</th>
+      <th>Status</th>
+      <th>Revision</th>
+      <th>Duration</th>
+      <th>Start at</th>
+    </tr>
+    </thead>
+    <tbody>
+    {{- range .History }}
+    <tr>
+      <td><a href="/task/{{ .TaskId }}">{{ .TaskId }}</a></td>
+      <td>{{ if eq .Status 0 }}<i class="green check icon"></i>Passed{{ else if eq .Status 1 }}<i class="yellow exclamation circle icon"></i>Flaky{{ else }}<i class="red attention icon"></i>Failed{{ end }}</td>
+      <td>{{ if .Task }}{{ if .Task.Revision }}{{ slice .Task.Revision 0 6 }}{{ end }}{{ if index $.FlakyRevisions .Task.Revision }} <span class="ui mini yellow label">flaky</span>{{ end }}{{ end }}</td>
+      <td>{{ .Duration }}ms</td>
+      <td>{{ .StartAt.Format "2006/01/02 15:04:05" }}</td>
+    </tr>
+    {{- end }}
+    </tbody>
+  </table>
+  {{- end }}
+
+  <h3 class="ui header">Flagged</h3>
+  {{- if .FlakyTests }}
+  <table class="ui table">
+    <thead>
+    <tr>
+      <th>Test</th>
+      <th>Revision</th>
+      <th>Detected at</th>
+    </tr>
+    </thead>
+    <tbody>
+    {{- range .FlakyTests }}
+    <tr>
+      <td><a href="/tests?repo={{ $.Repository.Id }}&label={{ .Label }}">{{ .Label }}</a></td>
+      <td>{{ if .Revision }}{{ slice .Revision 0 6 }}{{ end }}</td>
+      <td>{{ .CreatedAt.Format "2006/01/02 15:04:05" }}</td>
+    </tr>
+    {{- end }}
+    </tbody>
+  </table>
+  {{- else }}
+  There is no flaky test
+  {{- end }}
+</div>
+
+</body>
+</html>
+`
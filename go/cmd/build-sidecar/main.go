@@ -36,6 +36,16 @@ func buildSidecar(args []string) error {
 	report.SetFlags(reportCmd.Flags())
 	root.AddCommand(reportCmd)
 
+	artifact := sidecar.NewArtifactCommand()
+	artifactCmd := &cli.Command{
+		Use: artifact.Name(),
+		Run: func(ctx context.Context, _ *cli.Command, _ []string) error {
+			return artifact.Run(ctx)
+		},
+	}
+	artifact.SetFlags(artifactCmd.Flags())
+	root.AddCommand(artifactCmd)
+
 	credential := sidecar.NewCredentialCommand()
 	credentialCmd := &cli.Command{
 		Use: "credential",
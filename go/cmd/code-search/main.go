@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.f110.dev/xerrors"
+
+	"go.f110.dev/mono/go/cli"
+	"go.f110.dev/mono/go/codesearch"
+)
+
+func codeSearch(args []string) error {
+	search := codesearch.NewSearchCommand()
+
+	cmd := &cli.Command{
+		Use: "code-search",
+		Run: func(ctx context.Context, _ *cli.Command, _ []string) error {
+			if err := search.Run(ctx); err != nil {
+				return xerrors.WithStack(err)
+			}
+
+			return nil
+		},
+	}
+	search.Flags(cmd.Flags())
+
+	return cmd.Execute(args)
+}
+
+func main() {
+	if err := codeSearch(os.Args); err != nil {
+		fmt.Fprintf(os.Stderr, "%+v\n", err)
+		os.Exit(1)
+	}
+}
@@ -38,6 +38,8 @@ const (
 	ControllerMinIOBucket        = "minio-bucket"
 	ControllerMinIOUser          = "minio-user"
 	ControllerConsulBackup       = "consul-backup"
+	ControllerConsulRestore      = "consul-restore"
+	ControllerEtcdBackup         = "etcd-backup"
 )
 
 type ChildController struct {
@@ -152,6 +154,28 @@ var AllChildControllers = ChildControllers{
 		},
 		Enable: true,
 	},
+	{
+		Name: ControllerConsulRestore,
+		New: func(_ context.Context, p *Controllers, core kubeinformers.SharedInformerFactory, factory *client.InformerFactory) (controller, error) {
+			r, err := controllers.NewConsulRestoreController(core, factory, p.coreClient, p.client, p.config, p.dev)
+			if err != nil {
+				return nil, xerrors.WithStack(err)
+			}
+			return r, nil
+		},
+		Enable: true,
+	},
+	{
+		Name: ControllerEtcdBackup,
+		New: func(_ context.Context, p *Controllers, core kubeinformers.SharedInformerFactory, factory *client.InformerFactory) (controller, error) {
+			b, err := controllers.NewEtcdBackupController(core, factory, p.coreClient, p.client, p.config, p.dev)
+			if err != nil {
+				return nil, xerrors.WithStack(err)
+			}
+			return b, nil
+		},
+		Enable: true,
+	},
 }
 
 const (
@@ -31,6 +31,7 @@ type docSearchService struct {
 	Insecure               bool
 	Workers                int
 	MaxConns               int
+	UpdateInterval         time.Duration
 	Bucket                 string
 	StorageEndpoint        string
 	StorageRegion          string
@@ -86,11 +87,14 @@ func (c *docSearchService) init(ctx context.Context) (fsm.State, error) {
 	c.s = s
 
 	logger.Log.Debug("Initialize cache")
-	ctx, cancel := ctxutil.WithTimeout(ctx, 3*time.Minute)
+	initCtx, cancel := ctxutil.WithTimeout(ctx, 3*time.Minute)
 	defer cancel()
-	if err := service.Initialize(ctx, c.Workers, c.MaxConns); err != nil {
+	if err := service.Initialize(initCtx, c.Workers, c.MaxConns); err != nil {
 		return fsm.Error(err)
 	}
+	if c.UpdateInterval > 0 {
+		go service.StartUpdating(ctx, c.UpdateInterval, c.Workers)
+	}
 
 	return fsm.Next(stateStartServer)
 }
@@ -125,6 +129,7 @@ func (c *docSearchService) Flags(fs *cli.FlagSet) {
 	fs.String("listen", "Listen addr").Var(&c.Listen).Default(":8057")
 	fs.String("git-data-service", "The url of git-data-service").Var(&c.GitDataService).Default("127.0.0.1:9010")
 	fs.Int("workers", "The number of workers to fetching page title").Var(&c.Workers).Default(1)
+	fs.Duration("update-interval", "The interval of updating the changed pages. If zero, the pages are not updated").Var(&c.UpdateInterval).Default(5 * time.Minute)
 	fs.Int("max-conns", "The total number of connections to fetch the external page title").Var(&c.MaxConns).Default(1)
 	fs.Bool("insecure", "Insecure access to backend").Var(&c.Insecure)
 	fs.String("storage-endpoint", "The endpoint of the object storage").Var(&c.StorageEndpoint)
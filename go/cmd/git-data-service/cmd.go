@@ -2,12 +2,14 @@ package main
 
 import (
 	"context"
+	"errors"
 	"net"
+	"net/http"
 	"strings"
 	"time"
 
 	goGit "github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	gogitHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"go.f110.dev/go-memcached/client"
 	"go.f110.dev/xerrors"
 	"go.uber.org/zap"
@@ -27,10 +29,12 @@ import (
 type gitDataServiceCommand struct {
 	*fsm.FSM
 	s             *grpc.Server
+	httpServer    *http.Server
 	updater       *repositoryUpdater
 	storageClient *storage.S3
 
 	Listen                string
+	ListenHTTP            string
 	RepositoryInitTimeout time.Duration
 	GitHubClient          *githubutil.GitHubClientFactory
 
@@ -92,6 +96,7 @@ func newGitDataServiceCommand() *gitDataServiceCommand {
 
 func (c *gitDataServiceCommand) Flags(fs *cli.FlagSet) {
 	fs.String("listen", "Listen addr").Var(&c.Listen).Default(":8056")
+	fs.String("listen-http", "Listen addr of git smart HTTP server. If not set the value, the server is disabled").Var(&c.ListenHTTP)
 	fs.String("storage-endpoint", "The endpoint of the object storage").Var(&c.StorageEndpoint)
 	fs.String("storage-region", "The region name").Var(&c.StorageRegion)
 	fs.String("bucket", "The bucket name that will be used").Var(&c.Bucket)
@@ -163,9 +168,9 @@ func (c *gitDataServiceCommand) init(ctx context.Context) (fsm.State, error) {
 			ctx, cancel := ctxutil.WithTimeout(ctx, c.RepositoryInitTimeout)
 
 			logger.Log.Info("Init repository", zap.String("name", r.Name), zap.String("url", r.URL), zap.String("prefix", r.Prefix))
-			var auth *http.BasicAuth
+			var auth *gogitHttp.BasicAuth
 			if v, err := c.GitHubClient.TokenProvider.Token(ctx); err == nil {
-				auth = &http.BasicAuth{
+				auth = &gogitHttp.BasicAuth{
 					Username: "octocat",
 					Password: v,
 				}
@@ -204,6 +209,12 @@ func (c *gitDataServiceCommand) init(ctx context.Context) (fsm.State, error) {
 	healthSvc.SetServingStatus("git-data", healthpb.HealthCheckResponse_SERVING)
 	healthpb.RegisterHealthServer(s, healthSvc)
 	c.s = s
+	if c.ListenHTTP != "" {
+		c.httpServer = &http.Server{
+			Addr:    c.ListenHTTP,
+			Handler: git.NewSmartHTTPServer(repo),
+		}
+	}
 
 	return fsm.Next(stateStartUpdater)
 }
@@ -252,6 +263,14 @@ func (c *gitDataServiceCommand) startServer(_ context.Context) (fsm.State, error
 			logger.Log.Error("gRPC server returns error", logger.Error(err))
 		}
 	}()
+	if c.httpServer != nil {
+		logger.Log.Info("Start git smart HTTP server", zap.String("addr", c.ListenHTTP))
+		go func() {
+			if err := c.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Log.Error("git smart HTTP server returns error", logger.Error(err))
+			}
+		}()
+	}
 
 	return fsm.Wait()
 }
@@ -262,6 +281,12 @@ func (c *gitDataServiceCommand) shuttingDown(ctx context.Context) (fsm.State, er
 		c.s.GracefulStop()
 		logger.Log.Info("Stop gRPC server")
 	}
+	if c.httpServer != nil {
+		logger.Log.Debug("Shutting down git smart HTTP server")
+		if err := c.httpServer.Shutdown(ctx); err != nil {
+			logger.Log.Warn("Failed to shutdown git smart HTTP server", logger.Error(err))
+		}
+	}
 	if c.updater != nil {
 		logger.Log.Debug("Stopping updater")
 		c.updater.Stop(ctx)
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"go.f110.dev/go-memcached/client"
+	"go.f110.dev/xerrors"
+	"go.uber.org/zap"
 
 	"go.f110.dev/mono/go/cli"
 	"go.f110.dev/mono/go/ctxutil"
@@ -17,6 +19,7 @@ import (
 	"go.f110.dev/mono/go/githubutil"
 	"go.f110.dev/mono/go/gomodule"
 	"go.f110.dev/mono/go/logger"
+	"go.f110.dev/mono/go/storage"
 )
 
 type goModuleProxyCommand struct {
@@ -36,12 +39,30 @@ type goModuleProxyCommand struct {
 	StorageCACertFile      string
 
 	MemcachedServers []string
+	GCInterval       time.Duration
+	WebhookSecret    string
+
+	SumDBSignerKeyFile string
+	UpstreamSumDBKey   string
+
+	AuthTokenFile     string
+	OIDCIssuer        string
+	OIDCAudience      string
+	OIDCUsernameClaim string
+	OIDCGroupsClaim   string
+	AdminUsers        []string
+	AdminGroups       []string
 
 	upstream *url.URL
 	config   gomodule.Config
 	cache    *gomodule.ModuleCache
 	caBundle []byte
 	server   *gomodule.ProxyServer
+	proxy    *gomodule.ModuleProxy
+	sumDB    *gomodule.SumDB
+	gcCancel context.CancelFunc
+
+	accessControl *gomodule.AccessControl
 
 	githubClientFactory *githubutil.GitHubClientFactory
 }
@@ -56,6 +77,10 @@ func newGoModuleProxyCommand() *goModuleProxyCommand {
 	c := &goModuleProxyCommand{
 		Addr:                ":7589",
 		UpstreamURL:         "https://proxy.golang.org",
+		UpstreamSumDBKey:    gomodule.DefaultUpstreamSumDBKey,
+		GCInterval:          24 * time.Hour,
+		OIDCUsernameClaim:   "email",
+		OIDCGroupsClaim:     "groups",
 		githubClientFactory: githubutil.NewGitHubClientFactory("gomodule-proxy", false),
 	}
 	c.FSM = fsm.NewFSM(
@@ -86,6 +111,17 @@ func (c *goModuleProxyCommand) Flags(fs *cli.FlagSet) {
 	fs.String("storage-secret-access-key", "Secret access key").Var(&c.StorageSecretAccessKey).Default(c.StorageSecretAccessKey)
 	fs.String("storage-ca-file", "File path that contains the certificate of CA").Var(&c.StorageCACertFile).Default(c.StorageCACertFile)
 	fs.StringArray("memcached-servers", "Memcached server name and address for the metadata cache").Var(&c.MemcachedServers)
+	fs.String("webhook-secret", "The secret of GitHub webhook. If empty, the endpoint of the webhook is disabled").Var(&c.WebhookSecret).Default(c.WebhookSecret)
+	fs.Duration("gc-interval", "The interval of the garbage collection of the cache. If zero, the garbage collection is disabled").Var(&c.GCInterval).Default(c.GCInterval)
+	fs.String("sumdb-signer-key-file", "A file path that contains the private key of the checksum database. If not set, the checksum database is disabled").Var(&c.SumDBSignerKeyFile).Default(c.SumDBSignerKeyFile)
+	fs.String("auth-token-file", "A file path that contains the list of the user and the token for the basic authentication").Var(&c.AuthTokenFile).Default(c.AuthTokenFile)
+	fs.String("oidc-issuer", "The issuer URL of OpenID Connect. If set, ID token is accepted as the credential").Var(&c.OIDCIssuer).Default(c.OIDCIssuer)
+	fs.String("oidc-audience", "The audience of ID token").Var(&c.OIDCAudience).Default(c.OIDCAudience)
+	fs.String("oidc-username-claim", "The claim name of ID token that is used as the user name").Var(&c.OIDCUsernameClaim).Default(c.OIDCUsernameClaim)
+	fs.String("oidc-groups-claim", "The claim name of ID token that is used as the groups").Var(&c.OIDCGroupsClaim).Default(c.OIDCGroupsClaim)
+	fs.StringArray("admin-users", "The users who can call the endpoints for the administrator").Var(&c.AdminUsers)
+	fs.StringArray("admin-groups", "The groups which can call the endpoints for the administrator").Var(&c.AdminGroups)
+	fs.String("upstream-sumdb-key", "The verifier key of the upstream checksum database. If empty, the records of public modules are not imported").Var(&c.UpstreamSumDBKey).Default(c.UpstreamSumDBKey)
 
 	c.githubClientFactory.Flags(fs)
 }
@@ -94,7 +130,7 @@ func (c *goModuleProxyCommand) RequiredFlags() []string {
 	return []string{"config"}
 }
 
-func (c *goModuleProxyCommand) init(_ context.Context) (fsm.State, error) {
+func (c *goModuleProxyCommand) init(ctx context.Context) (fsm.State, error) {
 	if err := c.githubClientFactory.Init(); err != nil {
 		return fsm.Error(err)
 	}
@@ -139,8 +175,57 @@ func (c *goModuleProxyCommand) init(_ context.Context) (fsm.State, error) {
 		logger.Log.Debug("Disable cache")
 	}
 
+	if c.AuthTokenFile != "" || c.OIDCIssuer != "" {
+		ac := &gomodule.AccessControl{AdminUsers: c.AdminUsers, AdminGroups: c.AdminGroups}
+		if c.AuthTokenFile != "" {
+			tokenAuthenticator, err := gomodule.NewTokenAuthenticatorFromFile(c.AuthTokenFile)
+			if err != nil {
+				return fsm.Error(err)
+			}
+			ac.Authenticators = append(ac.Authenticators, tokenAuthenticator)
+		}
+		if c.OIDCIssuer != "" {
+			oidcAuthenticator, err := gomodule.NewOIDCAuthenticator(ctx, c.OIDCIssuer, c.OIDCAudience, c.OIDCUsernameClaim, c.OIDCGroupsClaim)
+			if err != nil {
+				return fsm.Error(err)
+			}
+			ac.Authenticators = append(ac.Authenticators, oidcAuthenticator)
+		}
+		c.accessControl = ac
+	} else {
+		logger.Log.Warn("Authentication is disabled")
+	}
+
 	proxy := gomodule.NewModuleProxy(c.config, c.ModuleDir, c.cache, c.githubClientFactory.REST, c.githubClientFactory.TokenProvider, c.caBundle)
-	c.server = gomodule.NewProxyServer(c.Addr, c.upstream, proxy)
+	if c.SumDBSignerKeyFile != "" {
+		if c.StorageEndpoint == "" || c.StorageRegion == "" || c.StorageBucket == "" || c.StorageAccessKey == "" || c.StorageSecretAccessKey == "" {
+			return fsm.Error(xerrors.New("the checksum database requires the object storage"))
+		}
+		key, err := os.ReadFile(c.SumDBSignerKeyFile)
+		if err != nil {
+			return fsm.Error(xerrors.WithStack(err))
+		}
+		opt := storage.NewS3OptionToExternal(c.StorageEndpoint, c.StorageRegion, c.StorageAccessKey, c.StorageSecretAccessKey)
+		opt.PathStyle = true
+		opt.CACertFile = c.StorageCACertFile
+		objStorage := storage.NewS3(c.StorageBucket, opt)
+
+		var upstreamSumDB *gomodule.UpstreamSumDB
+		if c.UpstreamSumDBKey != "" {
+			upstreamSumDB, err = gomodule.NewUpstreamSumDB(c.UpstreamSumDBKey, c.upstream, objStorage)
+			if err != nil {
+				return fsm.Error(err)
+			}
+		}
+		sumDB, err := gomodule.NewSumDB(ctx, string(key), objStorage, proxy, upstreamSumDB)
+		if err != nil {
+			return fsm.Error(err)
+		}
+		c.sumDB = sumDB
+		logger.Log.Info("Enable checksum database", zap.String("name", sumDB.Name()))
+	}
+	c.proxy = proxy
+	c.server = gomodule.NewProxyServer(c.Addr, c.upstream, proxy, c.sumDB, c.accessControl, c.WebhookSecret)
 
 	return fsm.Next(stateStartServer)
 }
@@ -152,10 +237,36 @@ func (c *goModuleProxyCommand) startServer(_ context.Context) (fsm.State, error)
 		}
 	}()
 
+	if c.cache != nil && c.GCInterval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		c.gcCancel = cancel
+		go c.garbageCollect(ctx)
+	}
+
 	return fsm.Wait()
 }
 
+func (c *goModuleProxyCommand) garbageCollect(ctx context.Context) {
+	ticker := time.NewTicker(c.GCInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			logger.Log.Info("Start garbage collection")
+			if err := c.proxy.GarbageCollect(ctx); err != nil {
+				logger.Log.Warn("Failed garbage collection", logger.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func (c *goModuleProxyCommand) shuttingDown(ctx context.Context) (fsm.State, error) {
+	if c.gcCancel != nil {
+		c.gcCancel()
+	}
 	if c.server != nil {
 		logger.Log.Info("Shutting down proxy")
 		if err := c.server.Stop(ctx); err != nil {
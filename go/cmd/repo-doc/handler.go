@@ -20,6 +20,10 @@ import (
 
 const (
 	pathSeparator = "/_/"
+	// searchResultLimit is the maximum number of results of the search.
+	searchResultLimit = 50
+	// historyPageSize is the number of commits in the page of the file history.
+	historyPageSize = 30
 )
 
 //go:embed style.css
@@ -78,6 +82,14 @@ func (h *httpHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		h.readiness(w, req)
 		return
 	}
+	if req.URL.Path == "/_/search" {
+		h.serveSearch(w, req)
+		return
+	}
+	if req.URL.Path == "/_/history" {
+		h.serveHistory(w, req)
+		return
+	}
 
 	if strings.Index(req.URL.Path, pathSeparator) == -1 {
 		if req.URL.Path == "/" {
@@ -158,6 +170,72 @@ func (h *httpHandler) serveRepositoryIndex(w http.ResponseWriter, req *http.Requ
 	h.renderer.RenderRepositories(w, repositories.Repositories)
 }
 
+func (h *httpHandler) serveSearch(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query().Get("q")
+	repo := req.URL.Query().Get("repo")
+	if strings.TrimSpace(query) == "" {
+		http.Redirect(w, req, "/", http.StatusFound)
+		return
+	}
+
+	res, err := h.docSearch.Search(req.Context(), &docutil.RequestSearch{Query: query, Repo: repo, Limit: searchResultLimit})
+	if err != nil {
+		logger.Log.Error("Failed to search", logger.Error(err))
+		http.Error(w, "Failed to search", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderer.RenderSearchResult(w, query, repo, res)
+}
+
+func (h *httpHandler) serveHistory(w http.ResponseWriter, req *http.Request) {
+	repoName := req.URL.Query().Get("repo")
+	filePath := strings.TrimPrefix(req.URL.Query().Get("path"), "/")
+	if repoName == "" {
+		http.Error(w, "repo is required", http.StatusBadRequest)
+		return
+	}
+	repoRes, err := h.docSearch.GetRepository(req.Context(), &docutil.RequestGetRepository{Repo: repoName})
+	if err != nil {
+		http.Error(w, "Failed to get repository", http.StatusBadRequest)
+		return
+	}
+
+	commits, err := h.gitData.ListCommits(req.Context(), &git.RequestListCommits{
+		Repo:      repoName,
+		Ref:       plumbing.NewBranchReferenceName(repoRes.Repository.DefaultBranch).String(),
+		Path:      filePath,
+		PageSize:  historyPageSize,
+		PageToken: req.URL.Query().Get("page_token"),
+	})
+	if err != nil {
+		logger.Log.Error("Failed to get commits", logger.Error(err))
+		http.Error(w, "Failed to get commits", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderer.RenderHistory(w, repoRes.Repository, filePath, commits)
+}
+
+// lastCommit returns the commit which changed the file most recently.
+// The page can be shown without the commit. Thus, lastCommit returns nil instead of the error.
+func (h *httpHandler) lastCommit(ctx context.Context, repo *docutil.Repository, filePath string) *git.Commit {
+	res, err := h.gitData.ListCommits(ctx, &git.RequestListCommits{
+		Repo:     repo.Name,
+		Ref:      plumbing.NewBranchReferenceName(repo.DefaultBranch).String(),
+		Path:     filePath,
+		PageSize: 1,
+	})
+	if err != nil {
+		logger.Log.Warn("Failed to get the last commit", logger.Error(err))
+		return nil
+	}
+	if len(res.Commits) == 0 {
+		return nil
+	}
+	return res.Commits[0]
+}
+
 func (h *httpHandler) serveDocumentFile(ctx context.Context, w http.ResponseWriter, file *git.ResponseGetFile, repo *docutil.Repository, repoName, rawRef string, commit *git.Commit, blobPath string) {
 	var doc *document
 	switch filepath.Ext(blobPath) {
@@ -209,7 +287,7 @@ func (h *httpHandler) serveDocumentPage(ctx context.Context, w http.ResponseWrit
 		doc = d
 	}
 
-	h.renderer.RenderPage(w, repo, page, doc, commit)
+	h.renderer.RenderPage(w, repo, page, doc, commit, h.lastCommit(ctx, repo, requestFilePath))
 }
 
 func (h *httpHandler) makeDocument(file *git.ResponseGetFile, blobPath string) (*document, error) {
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"go.f110.dev/mono/go/docutil"
 	"go.f110.dev/mono/go/git"
@@ -60,9 +61,38 @@ func TestServeHTTP(t *testing.T) {
 	h.ServeHTTP(recorder, req)
 
 	assert.Contains(t, recorder.Body.String(), "<title>repo-doc - Document title</title>")
+	assert.Contains(t, recorder.Body.String(), "Last changed by <b>Alice</b>")
+	assert.Contains(t, recorder.Body.String(), `href="/_/history?path=README.md&amp;repo=test"`)
 	assert.Equal(t, http.StatusOK, recorder.Code)
 }
 
+func TestServeHistory(t *testing.T) {
+	h, err := newHttpHandler(context.Background(), &stubGitDataClient{}, &stubDocSearchClient{}, "repo-doc", "", 0)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/_/history?repo=test&path=README.md", nil)
+	h.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "<code title=\"0123456789abcdef0123456789abcdef01234567\">0123456</code>")
+	assert.Contains(t, recorder.Body.String(), "<td>Update README</td>")
+	assert.Contains(t, recorder.Body.String(), `href="/_/history?path=README.md&amp;repo=test&amp;page_token=next"`)
+}
+
+func TestServeSearch(t *testing.T) {
+	h, err := newHttpHandler(context.Background(), &stubGitDataClient{}, &stubDocSearchClient{}, "repo-doc", "", 0)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/_/search?q=hello&repo=test", nil)
+	h.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `href="/test/_/README.md"`)
+	assert.Contains(t, recorder.Body.String(), "&lt;b&gt; <mark>Hello</mark> World!")
+}
+
 type stubGitDataClient struct{}
 
 var _ git.GitDataClient = &stubGitDataClient{}
@@ -165,3 +195,45 @@ func (s *stubDocSearchClient) PageLink(ctx context.Context, in *docutil.RequestP
 func (s *stubDocSearchClient) GetDirectory(ctx context.Context, in *docutil.RequestGetDirectory, opts ...grpc.CallOption) (*docutil.ResponseGetDirectory, error) {
 	return &docutil.ResponseGetDirectory{}, nil
 }
+
+func (s *stubDocSearchClient) Search(ctx context.Context, in *docutil.RequestSearch, opts ...grpc.CallOption) (*docutil.ResponseSearch, error) {
+	return &docutil.ResponseSearch{
+		Results: []*docutil.SearchResult{
+			{
+				Repository: "test",
+				Path:       "README.md",
+				Title:      "Document title",
+				Snippets: []*docutil.Snippet{
+					{Text: "<b> Hello World!", Highlights: []*docutil.Highlight{{Start: 4, End: 9}}},
+				},
+			},
+		},
+		Total: 1,
+	}, nil
+}
+
+func (s *stubGitDataClient) ListCommits(_ context.Context, in *git.RequestListCommits, opts ...grpc.CallOption) (*git.ResponseListCommits, error) {
+	res := &git.ResponseListCommits{
+		Commits: []*git.Commit{
+			{
+				Sha:     "0123456789abcdef0123456789abcdef01234567",
+				Message: "Update README\n\nDetail of the change",
+				Author:  &git.Signature{Name: "Alice", Email: "alice@example.com", When: timestamppb.Now()},
+			},
+		},
+	}
+	if in.PageSize > 1 {
+		res.NextPageToken = "next"
+	}
+	return res, nil
+}
+
+func (s *stubGitDataClient) GetDiff(ctx context.Context, in *git.RequestGetDiff, opts ...grpc.CallOption) (*git.ResponseGetDiff, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (s *stubGitDataClient) GetBlame(ctx context.Context, in *git.RequestGetBlame, opts ...grpc.CallOption) (*git.ResponseGetBlame, error) {
+	//TODO implement me
+	panic("implement me")
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"strings"
 
@@ -14,7 +15,7 @@ import (
 	"go.f110.dev/mono/go/logger"
 )
 
-//go:embed doc.tmpl directory.tmpl index.tmpl
+//go:embed doc.tmpl directory.tmpl index.tmpl search.tmpl history.tmpl
 var templateFiles embed.FS
 
 var (
@@ -101,7 +102,7 @@ func (r *Renderer) RenderFile(ctx context.Context, w http.ResponseWriter, repo *
 	}
 }
 
-func (r *Renderer) RenderPage(w http.ResponseWriter, repo *docutil.Repository, page *docutil.ResponseGetPage, doc *document, commit *git.Commit) {
+func (r *Renderer) RenderPage(w http.ResponseWriter, repo *docutil.Repository, page *docutil.ResponseGetPage, doc *document, commit, lastCommit *git.Commit) {
 	breadcrumb := makeBreadcrumb(repo.Name, doc.Path, false)
 	err := pageTemplate.ExecuteTemplate(w, "doc.tmpl", &pageTemplateVar{
 		Title:               r.Title,
@@ -117,6 +118,8 @@ func (r *Renderer) RenderPage(w http.ResponseWriter, repo *docutil.Repository, p
 		EditURL:             page.EditUrl,
 		References:          page.Out,
 		Cited:               page.In,
+		LastCommit:          lastCommit,
+		HistoryURL:          makeHistoryURL(repo.Name, doc.Path),
 	})
 	if err != nil {
 		logger.Log.Error("Failed to render page", logger.Error(err))
@@ -157,6 +160,128 @@ func (r *Renderer) RenderDirectoryIndex(w http.ResponseWriter, repo *docutil.Rep
 	}
 }
 
+type historyEntry struct {
+	Sha         string
+	ShortSha    string
+	Summary     string
+	AuthorName  string
+	AuthorEmail string
+	When        string
+}
+
+func (r *Renderer) RenderHistory(w http.ResponseWriter, repo *docutil.Repository, filePath string, res *git.ResponseListCommits) {
+	entries := make([]*historyEntry, len(res.Commits))
+	for i, v := range res.Commits {
+		summary, _, _ := strings.Cut(v.Message, "\n")
+		e := &historyEntry{Sha: v.Sha, ShortSha: v.Sha, Summary: summary}
+		if len(v.Sha) > 7 {
+			e.ShortSha = v.Sha[:7]
+		}
+		if v.Author != nil {
+			e.AuthorName = v.Author.Name
+			e.AuthorEmail = v.Author.Email
+			e.When = v.Author.When.AsTime().Format("2006-01-02 15:04")
+		}
+		entries[i] = e
+	}
+
+	var nextURL string
+	if res.NextPageToken != "" {
+		nextURL = makeHistoryURL(repo.Name, filePath) + "&page_token=" + url.QueryEscape(res.NextPageToken)
+	}
+	var fileURL string
+	if filePath != "" {
+		fileURL = fmt.Sprintf("/%s%s%s", repo.Name, pathSeparator, filePath)
+	} else {
+		fileURL = fmt.Sprintf("/%s%s", repo.Name, pathSeparator)
+	}
+	err := pageTemplate.ExecuteTemplate(w, "history.tmpl", struct {
+		Title         string
+		EnabledSearch bool
+		Repo          string
+		Path          string
+		FileURL       string
+		Commits       []*historyEntry
+		NextURL       string
+	}{
+		Title:         r.Title,
+		EnabledSearch: r.enabledSearch,
+		Repo:          repo.Name,
+		Path:          filePath,
+		FileURL:       fileURL,
+		Commits:       entries,
+		NextURL:       nextURL,
+	})
+	if err != nil {
+		logger.Log.Error("Failed to render page", logger.Error(err))
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+func makeHistoryURL(repo, filePath string) string {
+	v := url.Values{}
+	v.Set("repo", repo)
+	v.Set("path", strings.TrimPrefix(filePath, "/"))
+	return "/_/history?" + v.Encode()
+}
+
+type searchResult struct {
+	Repository string
+	Path       string
+	Title      string
+	Snippets   []template.HTML
+}
+
+func (r *Renderer) RenderSearchResult(w http.ResponseWriter, query, repo string, res *docutil.ResponseSearch) {
+	results := make([]*searchResult, len(res.Results))
+	for i, v := range res.Results {
+		snippets := make([]template.HTML, len(v.Snippets))
+		for j, s := range v.Snippets {
+			snippets[j] = highlightSnippet(s)
+		}
+		results[i] = &searchResult{Repository: v.Repository, Path: v.Path, Title: v.Title, Snippets: snippets}
+	}
+
+	err := pageTemplate.ExecuteTemplate(w, "search.tmpl", struct {
+		Title   string
+		Query   string
+		Repo    string
+		Total   int32
+		Results []*searchResult
+	}{
+		Title:   r.Title,
+		Query:   query,
+		Repo:    repo,
+		Total:   res.Total,
+		Results: results,
+	})
+	if err != nil {
+		logger.Log.Error("Failed to render page", logger.Error(err))
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// highlightSnippet returns the escaped text of the snippet which the highlights are surrounded by mark tag.
+func highlightSnippet(s *docutil.Snippet) template.HTML {
+	buf := new(strings.Builder)
+	pos := 0
+	for _, h := range s.Highlights {
+		start, end := int(h.Start), int(h.End)
+		if start < pos || end > len(s.Text) || start > end {
+			continue
+		}
+		buf.WriteString(template.HTMLEscapeString(s.Text[pos:start]))
+		buf.WriteString("<mark>")
+		buf.WriteString(template.HTMLEscapeString(s.Text[start:end]))
+		buf.WriteString("</mark>")
+		pos = end
+	}
+	buf.WriteString(template.HTMLEscapeString(s.Text[pos:]))
+	return template.HTML(buf.String())
+}
+
 type pageTemplateVar struct {
 	Title               string
 	PageTitle           string
@@ -171,6 +296,8 @@ type pageTemplateVar struct {
 	EditURL             string
 	References          []*docutil.PageLink
 	Cited               []*docutil.PageLink
+	LastCommit          *git.Commit
+	HistoryURL          string
 }
 
 type breadcrumbNode struct {
@@ -41,6 +41,9 @@ type Indexer struct {
 	initRun       bool
 	parallelism   int
 	tokenProvider *githubutil.TokenProvider
+	// previous is the manifest of the last indexing.
+	// The index of the repository which is not changed since the last indexing will be reused.
+	previous *Manifest
 
 	lister *RepositoryLister
 }
@@ -81,108 +84,160 @@ func (x *Indexer) Sync(ctx context.Context) error {
 	return nil
 }
 
-func (x *Indexer) BuildIndex(ctx context.Context) error {
-	indexDir := filepath.Join(x.workDir, ".index")
+// SetPreviousManifest sets the manifest of the last indexing for the incremental indexing.
+func (x *Indexer) SetPreviousManifest(m *Manifest) {
+	x.previous = m
+}
 
+func (x *Indexer) BuildIndex(ctx context.Context) error {
 	for _, v := range x.lister.List(ctx) {
-		t1 := time.Now()
-		m := newRepositoryMutator(v)
-		branchRefs, err := m.Mutate(ctx, x.workDir, v.Refs)
-		if err != nil {
-			logger.Log.Info("Failed to mutate repository", zap.String("name", v.Name), zap.Error(err))
-			continue
+		if err := x.buildRepositoryIndex(ctx, v); err != nil {
+			return err
 		}
-		t2 := time.Now()
+	}
 
-		files := make(map[file]map[plumbing.Hash]struct{})
-		fileBranches := make(map[file][]string)
-		for _, refName := range branchRefs {
-			f, err := v.files(refName)
-			if err != nil {
-				logger.Log.Info("Failed traverse the tree", zap.String("name", v.Name), zap.String("ref", refName.String()), zap.Error(err))
-				continue
-			}
-			for k, v := range f {
-				if _, ok := files[k]; !ok {
-					files[k] = make(map[plumbing.Hash]struct{})
-				}
-				files[k][v] = struct{}{}
-				fileBranches[k] = append(fileBranches[k], refName.Short())
-			}
-		}
+	return nil
+}
 
-		branches := make([]zoekt.RepositoryBranch, 0)
-		for _, v := range v.Refs {
-			branches = append(branches, zoekt.RepositoryBranch{Name: strings.TrimPrefix(v.Short(), "origin/")})
-		}
-		opt := build.Options{
-			IndexDir: indexDir,
-			RepositoryDescription: zoekt.Repository{
-				Name:     v.Name,
-				Branches: branches,
-			},
-			CTags:       x.ctags,
-			Parallelism: x.parallelism,
+// FindRepository returns the repository which is indexed by the name.
+// FindRepository returns nil if the repository is not a target of the indexing.
+func (x *Indexer) FindRepository(ctx context.Context, name string) *Repository {
+	for _, v := range x.lister.List(ctx) {
+		if v.Name == name {
+			return v
 		}
-		opt.SetDefaults()
-		builder, err := build.NewBuilder(opt)
-		if err != nil {
-			return xerrors.WithStack(err)
+	}
+
+	return nil
+}
+
+// BuildRepositoryIndex fetches the repository and builds the index of only the repository.
+func (x *Indexer) BuildRepositoryIndex(ctx context.Context, repo *Repository) error {
+	if err := repo.sync(ctx, x.workDir, x.tokenProvider, x.initRun); err != nil {
+		return err
+	}
+
+	return x.buildRepositoryIndex(ctx, repo)
+}
+
+func (x *Indexer) buildRepositoryIndex(ctx context.Context, v *Repository) error {
+	indexDir := filepath.Join(x.workDir, ".index")
+
+	t1 := time.Now()
+	commits, err := v.commits(x.workDir)
+	if err != nil {
+		logger.Log.Info("Failed to resolve references", zap.String("name", v.Name), zap.Error(err))
+		return nil
+	}
+	var previousCommits map[string]string
+	if x.previous != nil {
+		previousCommits = x.previous.Commits[v.Name]
+		if indexPath, ok := x.previous.Indexes[v.Name]; ok && sameCommits(previousCommits, commits) {
+			logger.Log.Info("Reuse the index because the repository is not changed", zap.String("name", v.Name), zap.String("index", indexPath))
+			x.Indexes = append(x.Indexes, &RepositoryIndex{Name: v.Name, Commits: commits, IndexPath: indexPath})
+			return nil
 		}
+	}
+
+	m := newRepositoryMutator(v)
+	branchRefs, err := m.Mutate(ctx, x.workDir, v.Refs, previousCommits)
+	if err != nil {
+		logger.Log.Info("Failed to mutate repository", zap.String("name", v.Name), zap.Error(err))
+		return nil
+	}
+	t2 := time.Now()
 
-		t3 := time.Now()
-		queue := make(chan file, x.parallelism)
-		var docCount int32
-		var wg sync.WaitGroup
-		for range x.parallelism {
-			wg.Add(1)
-			go func() {
-				x.worker(queue, builder, v, fileBranches, &docCount)
-				wg.Done()
-			}()
+	files := make(map[file]map[plumbing.Hash]struct{})
+	fileBranches := make(map[file][]string)
+	for _, refName := range branchRefs {
+		f, err := v.files(refName)
+		if err != nil {
+			logger.Log.Info("Failed traverse the tree", zap.String("name", v.Name), zap.String("ref", refName.String()), zap.Error(err))
+			continue
 		}
-		for f := range files {
-			queue <- f
+		for k, v := range f {
+			if _, ok := files[k]; !ok {
+				files[k] = make(map[plumbing.Hash]struct{})
+			}
+			files[k][v] = struct{}{}
+			fileBranches[k] = append(fileBranches[k], refName.Short())
 		}
-		close(queue)
-		doneCh := make(chan struct{})
+	}
+
+	branches := make([]zoekt.RepositoryBranch, 0)
+	for _, v := range v.Refs {
+		branches = append(branches, zoekt.RepositoryBranch{Name: strings.TrimPrefix(v.Short(), "origin/")})
+	}
+	opt := build.Options{
+		IndexDir: indexDir,
+		RepositoryDescription: zoekt.Repository{
+			Name:     v.Name,
+			Branches: branches,
+		},
+		CTags:       x.ctags,
+		Parallelism: x.parallelism,
+	}
+	opt.SetDefaults()
+	builder, err := build.NewBuilder(opt)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	t3 := time.Now()
+	queue := make(chan file, x.parallelism)
+	var docCount int32
+	var wg sync.WaitGroup
+	for range x.parallelism {
+		wg.Add(1)
 		go func() {
-			wg.Wait()
-			close(doneCh)
+			x.worker(queue, builder, v, fileBranches, &docCount)
+			wg.Done()
 		}()
-		select {
-		case <-doneCh:
-		case <-ctx.Done():
-			return ctx.Err()
-		}
-
-		logger.Log.Info("Total document",
-			zap.String("name", v.Name),
-			zap.Int32("count", docCount),
-			zap.Duration("elapsed", time.Since(t1)),
-			zap.Duration("mutating_elapsed", t2.Sub(t1)),
-			zap.Duration("indexing_elapsed", time.Since(t3)),
-		)
-		if err := builder.Finish(); err != nil {
-			return xerrors.WithStack(err)
-		}
+	}
+	for f := range files {
+		queue <- f
+	}
+	close(queue)
+	doneCh := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(doneCh)
+	}()
+	select {
+	case <-doneCh:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	logger.Log.Info("Total document",
+		zap.String("name", v.Name),
+		zap.Int32("count", docCount),
+		zap.Duration("elapsed", time.Since(t1)),
+		zap.Duration("mutating_elapsed", t2.Sub(t1)),
+		zap.Duration("indexing_elapsed", time.Since(t3)),
+	)
+	if err := builder.Finish(); err != nil {
+		return xerrors.WithStack(err)
+	}
 
-		if err := v.cleanup(x.workDir); err != nil {
-			return xerrors.WithStack(err)
-		}
+	if err := v.cleanup(x.workDir); err != nil {
+		return xerrors.WithStack(err)
+	}
 
-		index, err := NewRepositoryIndex(v.Name, indexDir)
-		if err != nil {
-			return xerrors.WithStack(err)
-		}
-		x.Indexes = append(x.Indexes, index)
+	index, err := NewRepositoryIndex(v.Name, indexDir)
+	if err != nil {
+		return xerrors.WithStack(err)
 	}
+	index.Commits = commits
+	x.Indexes = append(x.Indexes, index)
 
 	return nil
 }
 
 func (x *Indexer) Reset() {
 	x.lister.ClearCache()
+	x.Indexes = nil
+	x.previous = nil
 }
 
 func (x *Indexer) worker(queue chan file, builder *build.Builder, repo *Repository, fileBranches map[file][]string, docCount *int32) {
@@ -278,10 +333,21 @@ func newRepositoryMutator(repo *Repository) *repositoryMutator {
 	return &repositoryMutator{repo: repo}
 }
 
-func (m *repositoryMutator) Mutate(ctx context.Context, workDir string, refs []plumbing.ReferenceName) ([]plumbing.ReferenceName, error) {
+// Mutate checkouts each reference and makes the commit which contains the vendored files.
+// previousCommits is the commit hash of each reference at the last indexing.
+// The reference which is not moved since the last indexing will not be mutated again.
+func (m *repositoryMutator) Mutate(ctx context.Context, workDir string, refs []plumbing.ReferenceName, previousCommits map[string]string) ([]plumbing.ReferenceName, error) {
 	branchRefs := make([]plumbing.ReferenceName, 0)
 
 	for _, refName := range refs {
+		if h, ok := previousCommits[refName.String()]; ok {
+			if branchRef, ok := m.repo.mutatedBranch(refName, plumbing.NewHash(h)); ok {
+				logger.Log.Debug("Skip mutating because the reference is not moved", zap.String("name", m.repo.Name), zap.String("ref", refName.Short()))
+				branchRefs = append(branchRefs, branchRef)
+				continue
+			}
+		}
+
 		logger.Log.Debug("Prepare", zap.String("name", m.repo.Name), zap.String("ref", refName.Short()))
 		dir := filepath.Join(workDir, m.repo.Name)
 		if branchRef, err := m.repo.checkout(workDir, refName); err != nil {
@@ -469,6 +535,55 @@ func (x *Repository) checkout(workDir string, refName plumbing.ReferenceName) (p
 	return branchRef.Name(), nil
 }
 
+// commits returns the commit hash of each reference.
+// The key of the map is the full name of the reference.
+func (x *Repository) commits(workDir string) (map[string]string, error) {
+	repo, err := git.PlainOpen(filepath.Join(workDir, x.Name))
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	x.repo = repo
+
+	commits := make(map[string]string)
+	for _, refName := range x.Refs {
+		hash, err := x.resolveReference(refName)
+		if err != nil {
+			logger.Log.Debug("Failed to resolve the reference", zap.String("name", x.Name), zap.String("ref", refName.String()), zap.Error(err))
+			continue
+		}
+		commits[refName.String()] = hash.String()
+	}
+
+	return commits, nil
+}
+
+// mutatedBranch returns the branch which was mutated from the commit at the last indexing.
+func (x *Repository) mutatedBranch(refName plumbing.ReferenceName, hash plumbing.Hash) (plumbing.ReferenceName, bool) {
+	current, err := x.resolveReference(refName)
+	if err != nil || current != hash {
+		return "", false
+	}
+
+	branchName := plumbing.NewBranchReferenceName(strings.TrimPrefix(refName.Short(), "origin/"))
+	ref, err := x.repo.Reference(branchName, true)
+	if err != nil {
+		return "", false
+	}
+	if ref.Hash() == hash {
+		// The branch has no commit for vendoring.
+		return branchName, true
+	}
+	commit, err := x.repo.CommitObject(ref.Hash())
+	if err != nil {
+		return "", false
+	}
+	if len(commit.ParentHashes) == 1 && commit.ParentHashes[0] == hash {
+		return branchName, true
+	}
+
+	return "", false
+}
+
 func (x *Repository) resolveReference(refName plumbing.ReferenceName) (plumbing.Hash, error) {
 	hash := plumbing.ZeroHash
 	ref, err := x.repo.Reference(refName, false)
@@ -595,6 +710,11 @@ func (x *Repository) cleanup(workDir string) error {
 type RepositoryIndex struct {
 	Name  string
 	Files []string
+	// Commits has the commit hash of each reference which is indexed.
+	Commits map[string]string
+	// IndexPath is the path of the index in the object storage.
+	// IndexPath is not empty if the index of the last indexing is reused.
+	IndexPath string
 }
 
 func NewRepositoryIndex(name, indexDir string) (*RepositoryIndex, error) {
@@ -620,3 +740,16 @@ func NewRepositoryIndex(name, indexDir string) (*RepositoryIndex, error) {
 
 	return &RepositoryIndex{Name: name, Files: files}, nil
 }
+
+func sameCommits(a, b map[string]string) bool {
+	if len(a) == 0 || len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,116 @@
+package codesearch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.f110.dev/mono/go/logger"
+)
+
+func TestIndexer_IncrementalBuild(t *testing.T) {
+	logger.Init()
+	srcDir := t.TempDir()
+	src, err := git.PlainInit(srcDir, false)
+	require.NoError(t, err)
+	first := commitTestFile(t, src, srcDir, "README.md", "Hello")
+	err = src.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("develop"), first))
+	require.NoError(t, err)
+
+	workDir := t.TempDir()
+	repo := NewRepository(
+		"f110/test",
+		srcDir,
+		[]plumbing.ReferenceName{"refs/remotes/origin/master", "refs/remotes/origin/develop"},
+		true,
+		nil,
+	)
+	_, err = git.PlainClone(filepath.Join(workDir, repo.Name), false, &git.CloneOptions{URL: srcDir, NoCheckout: true})
+	require.NoError(t, err)
+	indexer := &Indexer{
+		workDir:     workDir,
+		parallelism: 1,
+		lister:      &RepositoryLister{repositories: []*Repository{repo}},
+	}
+
+	err = indexer.BuildIndex(context.Background())
+	require.NoError(t, err)
+	require.Len(t, indexer.Indexes, 1)
+	assert.NotEmpty(t, indexer.Indexes[0].Files)
+	assert.Empty(t, indexer.Indexes[0].IndexPath)
+	assert.Equal(t, map[string]string{
+		"refs/remotes/origin/master":  first.String(),
+		"refs/remotes/origin/develop": first.String(),
+	}, indexer.Indexes[0].Commits)
+
+	// The repository is not changed
+	manifest := NewManifest(1, map[string]string{repo.Name: "mock://test/f110/test/1"}, 0)
+	manifest.Commits = map[string]map[string]string{repo.Name: indexer.Indexes[0].Commits}
+	indexer.SetPreviousManifest(&manifest)
+	indexer.Indexes = nil
+	err = indexer.BuildIndex(context.Background())
+	require.NoError(t, err)
+	require.Len(t, indexer.Indexes, 1)
+	assert.Empty(t, indexer.Indexes[0].Files)
+	assert.Equal(t, "mock://test/f110/test/1", indexer.Indexes[0].IndexPath)
+
+	// master is moved but develop is not.
+	second := commitTestFile(t, src, srcDir, "README.md", "Hello world")
+	err = repo.sync(context.Background(), workDir, nil, false)
+	require.NoError(t, err)
+	branch, ok := repo.mutatedBranch("refs/remotes/origin/develop", first)
+	assert.True(t, ok)
+	assert.Equal(t, plumbing.NewBranchReferenceName("develop"), branch)
+	_, ok = repo.mutatedBranch("refs/remotes/origin/master", first)
+	assert.False(t, ok)
+
+	indexer.Indexes = nil
+	err = indexer.BuildIndex(context.Background())
+	require.NoError(t, err)
+	require.Len(t, indexer.Indexes, 1)
+	assert.NotEmpty(t, indexer.Indexes[0].Files)
+	assert.Empty(t, indexer.Indexes[0].IndexPath)
+	assert.Equal(t, second.String(), indexer.Indexes[0].Commits["refs/remotes/origin/master"])
+	assert.Equal(t, first.String(), indexer.Indexes[0].Commits["refs/remotes/origin/develop"])
+
+	// The previous manifest is not reused by the next run.
+	indexer.Reset()
+	assert.Nil(t, indexer.Indexes)
+	assert.Nil(t, indexer.previous)
+}
+
+func TestExcludeSharedIndexes(t *testing.T) {
+	expired := []Manifest{
+		{ExecutionKey: 1, Indexes: map[string]string{"f110/mono": "mock://test/f110/mono/1", "f110/tools": "mock://test/f110/tools/1"}},
+	}
+	all := append([]Manifest{
+		{ExecutionKey: 2, Indexes: map[string]string{"f110/mono": "mock://test/f110/mono/2", "f110/tools": "mock://test/f110/tools/1"}},
+	}, expired...)
+
+	result := excludeSharedIndexes(expired, all)
+	require.Len(t, result, 1)
+	assert.Equal(t, map[string]string{"f110/mono": "mock://test/f110/mono/1"}, result[0].Indexes)
+}
+
+func commitTestFile(t *testing.T, repo *git.Repository, dir, name, content string) plumbing.Hash {
+	err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = wt.Add(name)
+	require.NoError(t, err)
+	hash, err := wt.Commit("Update "+name, &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	return hash
+}
@@ -20,7 +20,10 @@ type Manifest struct {
 	CreatedAt time.Time
 	// Indexes has Repository.Name and the index path prefix.
 	// The key is Repository.Name. The value is the index path prefix.
-	Indexes        map[string]string
+	Indexes map[string]string
+	// Commits has the commit hash of each reference which is indexed.
+	// The key is Repository.Name. The value is the map of the full name of the reference and the commit hash.
+	Commits        map[string]map[string]string
 	ExecutionKey   uint64
 	TotalIndexSize uint64
 
@@ -164,6 +164,20 @@ func (s *ObjectStorageIndexManager) Delete(ctx context.Context, manifests []Mani
 	return nil
 }
 
+// Size returns the total size of the index files.
+func (s *ObjectStorageIndexManager) Size(ctx context.Context, indexDirURL string) (uint64, error) {
+	files, err := s.listFiles(ctx, indexDirURL, s.stripPrefixSlash)
+	if err != nil {
+		return 0, xerrors.WithStack(err)
+	}
+
+	var size uint64
+	for _, v := range files {
+		size += uint64(v.Size)
+	}
+	return size, nil
+}
+
 func (s *ObjectStorageIndexManager) CleanUploadedFiles(ctx context.Context) error {
 	for _, v := range s.uploadedFiles {
 		if err := s.backend.Delete(ctx, v); err != nil {
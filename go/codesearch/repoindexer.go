@@ -7,6 +7,7 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/robfig/cron/v3"
 	"go.f110.dev/xerrors"
@@ -28,10 +29,13 @@ type IndexerCommand struct {
 	InitRun          bool
 	WithoutFetch     bool
 	DisableCleanup   bool
+	FullIndex        bool
 	Parallelism      int
 	HTTPAddr         string
 	URLReplaceRegexp []string
 	CABundleFile     string
+	WebhookSecret    string
+	WebhookDelay     time.Duration
 
 	Bucket                      string
 	MinIOEndpoint               string
@@ -63,6 +67,7 @@ type IndexerCommand struct {
 	cancel              context.CancelFunc
 	caBundle            []byte
 	natsNotify          *Notify
+	debouncer           *debouncer
 
 	mu sync.Mutex
 
@@ -83,6 +88,7 @@ func NewIndexerCommand() *IndexerCommand {
 		NATSStreamName:      "repoindexer",
 		NATSSubject:         "notify",
 		S3PartSize:          1 * 1024 * 1024 * 1024, // 1GiB
+		WebhookDelay:        30 * time.Second,
 		githubClientFactory: githubutil.NewGitHubClientFactory("repo-indexer", false),
 	}
 }
@@ -95,6 +101,7 @@ func (r *IndexerCommand) Flags(fs *cli.FlagSet) {
 	fs.Bool("init-run", "").Var(&r.InitRun)
 	fs.Bool("without-fetch", "Disable fetch").Var(&r.WithoutFetch)
 	fs.Bool("disable-cleanup", "Disable cleanup in the working directory not the object storage").Var(&r.DisableCleanup)
+	fs.Bool("full-index", "Rebuild the index of all repositories even if the repository is not changed").Var(&r.FullIndex)
 	fs.Int("parallelism", "The number of workers").Var(&r.Parallelism).Default(r.Parallelism)
 	fs.String("minio-endpoint", "The endpoint of MinIO").Var(&r.MinIOEndpoint)
 	fs.String("minio-region", "The region name").Var(&r.MinIORegion)
@@ -118,6 +125,8 @@ func (r *IndexerCommand) Flags(fs *cli.FlagSet) {
 	fs.String("ca-bundle-file", "A file path that contains ca certificates for clone a repository").Var(&r.CABundleFile)
 	fs.Bool("dev", "Development mode").Var(&r.Dev)
 	fs.String("http-addr", "HTTP listen addr").Var(&r.HTTPAddr)
+	fs.String("webhook-secret", "The secret of the webhook of GitHub. If empty, the endpoint of the webhook is disabled").Var(&r.WebhookSecret)
+	fs.Duration("webhook-delay", "The duration to wait for the next push before reindexing the pushed repository").Var(&r.WebhookDelay).Default(r.WebhookDelay)
 
 	r.githubClientFactory.Flags(fs)
 }
@@ -175,6 +184,11 @@ func (r *IndexerCommand) Run() error {
 		r.caBundle,
 	)
 	r.indexer = indexer
+	r.debouncer = newDebouncer(r.WebhookDelay, func(name string) {
+		if err := r.reindexRepository(name); err != nil {
+			logger.Log.Info("Failed reindexing", zap.String("repo", name), zap.Error(err))
+		}
+	})
 	if r.HTTPAddr != "" {
 		if err := r.webEndpoint(r.HTTPAddr); err != nil {
 			return xerrors.WithStack(err)
@@ -220,11 +234,19 @@ func (r *IndexerCommand) run() error {
 			return err
 		}
 	}
+	// The manifest which was set by the other run must not be reused.
+	// The previous manifest is used only if it is fetched successfully in this run.
+	r.indexer.SetPreviousManifest(nil)
+	if r.enableUpload() && !r.FullIndex {
+		if err := r.setPreviousManifest(ctx); err != nil {
+			logger.Log.Info("Could not get the previous manifest. All repositories will be indexed", zap.Error(err))
+		}
+	}
 	if err := r.indexer.BuildIndex(ctx); err != nil {
 		return err
 	}
 	if r.enableUpload() {
-		manifest, err := r.uploadIndex(ctx, r.indexer, r.Bucket, r.DisableObjectStorageCleanup)
+		manifest, err := r.uploadIndex(ctx, r.indexer.Indexes, r.Bucket, r.DisableObjectStorageCleanup)
 		if err != nil {
 			return err
 		}
@@ -246,6 +268,21 @@ func (r *IndexerCommand) run() error {
 	return nil
 }
 
+func (r *IndexerCommand) setPreviousManifest(ctx context.Context) error {
+	s, err := r.newStorageClient()
+	if err != nil {
+		return err
+	}
+	manifest, err := NewManifestManager(s).GetLatest(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Log.Debug("Found the previous manifest", zap.Uint64("key", manifest.ExecutionKey))
+	r.indexer.SetPreviousManifest(&manifest)
+
+	return nil
+}
+
 func (r *IndexerCommand) scheduler(schedule string) error {
 	if volume.CanWatchVolume(r.ConfigFile) {
 		mountPath, err := volume.FindMountPath(r.ConfigFile)
@@ -290,6 +327,7 @@ func (r *IndexerCommand) scheduler(schedule string) error {
 
 	<-ctx.Done()
 	logger.Log.Debug("Got signal")
+	r.debouncer.Stop()
 
 	ctx = r.cron.Stop()
 
@@ -315,6 +353,10 @@ func (r *IndexerCommand) webEndpoint(addr string) error {
 			}
 		}()
 	})
+	if r.WebhookSecret != "" {
+		// The webhook is enabled only when the payload can be verified.
+		mux.HandleFunc("/webhook/github", r.githubWebhook)
+	}
 	mux.HandleFunc("/readiness", func(w http.ResponseWriter, req *http.Request) {
 		r.readyMu.Lock()
 		ready := r.ready
@@ -450,7 +492,7 @@ func (r *IndexerCommand) reloadConfig() {
 
 func (r *IndexerCommand) uploadIndex(
 	ctx context.Context,
-	indexer *Indexer,
+	indexes []*RepositoryIndex,
 	bucket string,
 	disableCleanup bool,
 ) (*Manifest, error) {
@@ -460,8 +502,20 @@ func (r *IndexerCommand) uploadIndex(
 	}
 	manager := NewObjectStorageIndexManager(s, bucket)
 	uploadedPath := make(map[string]string, 0)
+	commits := make(map[string]map[string]string)
 	totalSize := uint64(0)
-	for _, v := range indexer.Indexes {
+	for _, v := range indexes {
+		commits[v.Name] = v.Commits
+		if v.IndexPath != "" {
+			size, err := manager.Size(ctx, v.IndexPath)
+			if err != nil {
+				return nil, xerrors.WithStack(err)
+			}
+			uploadedPath[v.Name] = v.IndexPath
+			totalSize += size
+			continue
+		}
+
 		uploadDir, size, err := manager.Add(ctx, v.Name, v.Files)
 		if err != nil {
 			if err := manager.CleanUploadedFiles(ctx); err != nil {
@@ -474,6 +528,7 @@ func (r *IndexerCommand) uploadIndex(
 	}
 
 	manifest := NewManifest(manager.ExecutionKey(), uploadedPath, totalSize)
+	manifest.Commits = commits
 	mm := NewManifestManager(s)
 	if err := mm.Update(ctx, manifest); err != nil {
 		if err := manager.CleanUploadedFiles(ctx); err != nil {
@@ -487,7 +542,12 @@ func (r *IndexerCommand) uploadIndex(
 		if err != nil {
 			return nil, xerrors.WithStack(err)
 		}
-		if err := manager.Delete(ctx, expired); err != nil {
+		all, err := mm.GetAll(ctx)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		// The index of the expired manifest may be reused by the newer manifest.
+		if err := manager.Delete(ctx, excludeSharedIndexes(expired, all)); err != nil {
 			return nil, xerrors.WithStack(err)
 		}
 		for _, m := range expired {
@@ -499,3 +559,35 @@ func (r *IndexerCommand) uploadIndex(
 
 	return &manifest, nil
 }
+
+// excludeSharedIndexes returns expired manifests which don't have the index that is referenced by the alive manifest.
+func excludeSharedIndexes(expired, all []Manifest) []Manifest {
+	expiredKeys := make(map[uint64]struct{})
+	for _, v := range expired {
+		expiredKeys[v.ExecutionKey] = struct{}{}
+	}
+	used := make(map[string]struct{})
+	for _, m := range all {
+		if _, ok := expiredKeys[m.ExecutionKey]; ok {
+			continue
+		}
+		for _, v := range m.Indexes {
+			used[v] = struct{}{}
+		}
+	}
+
+	result := make([]Manifest, 0, len(expired))
+	for _, m := range expired {
+		indexes := make(map[string]string)
+		for name, v := range m.Indexes {
+			if _, ok := used[v]; ok {
+				continue
+			}
+			indexes[name] = v
+		}
+		m.Indexes = indexes
+		result = append(result, m)
+	}
+
+	return result
+}
@@ -0,0 +1,393 @@
+package codesearch
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp/syntax"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/zoekt"
+	"github.com/google/zoekt/query"
+	"github.com/google/zoekt/shards"
+	"github.com/grafana/regexp"
+	"go.f110.dev/xerrors"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"go.f110.dev/mono/go/logger"
+)
+
+const (
+	defaultSearchLimit = 50
+	maxSearchLimit     = 1000
+	maxContextLines    = 10
+)
+
+type indexDownloader interface {
+	Download(ctx context.Context, indexDir string, manifest Manifest) error
+}
+
+// SearchService is the search service for the index which is built by Indexer.
+// SearchService downloads the index files of the manifest and swaps the searcher when the new manifest is loaded.
+type SearchService struct {
+	UnimplementedCodeSearchServer
+
+	indexDir     string
+	indexManager indexDownloader
+
+	// loadMu serializes Load. The manifest is checked and swapped under loadMu.
+	loadMu   sync.Mutex
+	mu       sync.RWMutex
+	searcher zoekt.Searcher
+	manifest Manifest
+}
+
+var _ CodeSearchServer = &SearchService{}
+
+func NewSearchService(indexManager *ObjectStorageIndexManager, indexDir string) *SearchService {
+	return &SearchService{indexDir: indexDir, indexManager: indexManager}
+}
+
+// Load downloads the index files of the manifest and replaces the searcher.
+// The manifest which is older than the current manifest will be ignored.
+func (s *SearchService) Load(ctx context.Context, manifest Manifest) error {
+	s.loadMu.Lock()
+	defer s.loadMu.Unlock()
+
+	// s.manifest is written only under loadMu. Thus, the read lock is not needed.
+	current := s.manifest.ExecutionKey
+	if manifest.ExecutionKey <= current {
+		logger.Log.Debug("Manifest is not newer than current", zap.Uint64("current", current), zap.Uint64("key", manifest.ExecutionKey))
+		return nil
+	}
+
+	dir := filepath.Join(s.indexDir, strconv.FormatUint(manifest.ExecutionKey, 10))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return xerrors.WithStack(err)
+	}
+	if err := s.indexManager.Download(ctx, dir, manifest); err != nil {
+		return xerrors.WithStack(err)
+	}
+	searcher, err := shards.NewDirectorySearcher(dir)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	// Searching holds the read lock until the end.
+	// Thus, no one uses the old searcher after acquiring the lock.
+	s.mu.Lock()
+	old := s.searcher
+	s.searcher = searcher
+	s.manifest = manifest
+	s.mu.Unlock()
+	logger.Log.Info("Loaded the index", zap.Uint64("key", manifest.ExecutionKey), zap.Int("repositories", len(manifest.Indexes)))
+
+	if old != nil {
+		old.Close()
+	}
+	if err := s.removeOldIndexes(filepath.Base(dir)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Watch loads the manifest which is notified by the subscription until ctx is cancelled.
+func (s *SearchService) Watch(ctx context.Context, sub *Subscription) {
+	for {
+		select {
+		case m := <-sub.ch:
+			logger.Log.Info("Got notify", zap.Uint64("key", m.ExecutionKey))
+			if err := s.Load(ctx, m); err != nil {
+				logger.Log.Warn("Failed to load the index", zap.Error(err), zap.Uint64("key", m.ExecutionKey))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Ready returns true if the index is loaded.
+func (s *SearchService) Ready() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.searcher != nil
+}
+
+func (s *SearchService) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.searcher != nil {
+		s.searcher.Close()
+		s.searcher = nil
+	}
+}
+
+func (s *SearchService) removeOldIndexes(current string) error {
+	entry, err := os.ReadDir(s.indexDir)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	for _, v := range entry {
+		if !v.IsDir() || v.Name() == current {
+			continue
+		}
+		if _, err := strconv.ParseUint(v.Name(), 10, 64); err != nil {
+			continue
+		}
+		logger.Log.Debug("Remove old index", zap.String("dir", v.Name()))
+		if err := os.RemoveAll(filepath.Join(s.indexDir, v.Name())); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SearchService) Search(ctx context.Context, req *RequestSearch) (*ResponseSearch, error) {
+	q, err := buildQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	opts := &zoekt.SearchOptions{
+		MaxDocDisplayCount: defaultSearchLimit,
+		NumContextLines:    int(req.ContextLines),
+	}
+	if req.Limit > 0 {
+		opts.MaxDocDisplayCount = int(req.Limit)
+	}
+	if opts.MaxDocDisplayCount > maxSearchLimit {
+		opts.MaxDocDisplayCount = maxSearchLimit
+	}
+	if opts.NumContextLines > maxContextLines {
+		opts.NumContextLines = maxContextLines
+	}
+	opts.SetDefaults()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.searcher == nil {
+		return nil, status.Error(codes.Unavailable, "index is not loaded yet")
+	}
+
+	result, err := s.searcher.Search(ctx, q, opts)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	res := &ResponseSearch{
+		MatchCount:   int32(result.MatchCount),
+		FileCount:    int32(result.FileCount),
+		ExecutionKey: s.manifest.ExecutionKey,
+	}
+	for _, f := range result.Files {
+		fileMatch := &FileMatch{
+			Repository: f.Repository,
+			Path:       f.FileName,
+			Branches:   f.Branches,
+			Language:   f.Language,
+			Score:      f.Score,
+		}
+		for _, l := range f.LineMatches {
+			lineMatch := &LineMatch{
+				LineNumber: int32(l.LineNumber),
+				Line:       string(l.Line),
+				Before:     string(l.Before),
+				After:      string(l.After),
+				FileName:   l.FileName,
+			}
+			for _, frag := range l.LineFragments {
+				lineMatch.Fragments = append(lineMatch.Fragments, &Fragment{Offset: int32(frag.LineOffset), Length: int32(frag.MatchLength)})
+			}
+			fileMatch.Lines = append(fileMatch.Lines, lineMatch)
+		}
+		res.Files = append(res.Files, fileMatch)
+	}
+
+	return res, nil
+}
+
+func (s *SearchService) ListRepository(ctx context.Context, _ *RequestListRepository) (*ResponseListRepository, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.searcher == nil {
+		return nil, status.Error(codes.Unavailable, "index is not loaded yet")
+	}
+
+	list, err := s.searcher.List(ctx, &query.Const{Value: true}, &zoekt.ListOptions{})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	res := &ResponseListRepository{ExecutionKey: s.manifest.ExecutionKey}
+	for _, v := range list.Repos {
+		repo := &IndexedRepository{Name: v.Repository.Name, Files: int64(v.Stats.Documents)}
+		for _, b := range v.Repository.Branches {
+			repo.Branches = append(repo.Branches, b.Name)
+		}
+		res.Repositories = append(res.Repositories, repo)
+	}
+	sort.Slice(res.Repositories, func(i, j int) bool {
+		return res.Repositories[i].Name < res.Repositories[j].Name
+	})
+
+	return res, nil
+}
+
+// ServeHTTP serves the JSON API.
+// GET /search?q=<query>&regexp=<bool>&repo=<repo>&branch=<branch>&file=<file>&case=<bool>&limit=<n>&context=<n>
+// GET /repositories
+func (s *SearchService) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var res proto.Message
+	var err error
+	switch strings.TrimSuffix(req.URL.Path, "/") {
+	case "/search":
+		searchReq, parseErr := parseSearchRequest(req)
+		if parseErr != nil {
+			http.Error(w, parseErr.Error(), http.StatusBadRequest)
+			return
+		}
+		res, err = s.Search(req.Context(), searchReq)
+	case "/repositories":
+		res, err = s.ListRepository(req.Context(), &RequestListRepository{})
+	default:
+		http.NotFound(w, req)
+		return
+	}
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+		case codes.Unavailable:
+			http.Error(w, status.Convert(err).Message(), http.StatusServiceUnavailable)
+		default:
+			logger.Log.Warn("Failed to search", zap.Error(err))
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	buf, err := protojson.Marshal(res)
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(buf)
+}
+
+func parseSearchRequest(req *http.Request) (*RequestSearch, error) {
+	v := req.URL.Query()
+	searchReq := &RequestSearch{
+		Query:  v.Get("q"),
+		Repo:   v.Get("repo"),
+		Branch: v.Get("branch"),
+		File:   v.Get("file"),
+	}
+	for _, b := range []struct {
+		Key string
+		Val *bool
+	}{
+		{Key: "regexp", Val: &searchReq.Regexp},
+		{Key: "case", Val: &searchReq.CaseSensitive},
+	} {
+		if s := v.Get(b.Key); s != "" {
+			p, err := strconv.ParseBool(s)
+			if err != nil {
+				return nil, xerrors.Definef("%s is not a boolean", b.Key).WithStack()
+			}
+			*b.Val = p
+		}
+	}
+	for _, i := range []struct {
+		Key string
+		Val *int32
+	}{
+		{Key: "limit", Val: &searchReq.Limit},
+		{Key: "context", Val: &searchReq.ContextLines},
+	} {
+		if s := v.Get(i.Key); s != "" {
+			p, err := strconv.ParseInt(s, 10, 32)
+			if err != nil || p < 0 {
+				return nil, xerrors.Definef("%s is not a positive number", i.Key).WithStack()
+			}
+			*i.Val = int32(p)
+		}
+	}
+
+	return searchReq, nil
+}
+
+func buildQuery(req *RequestSearch) (query.Q, error) {
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, invalidArgument("query", "query is empty")
+	}
+
+	var qs []query.Q
+	if req.Regexp {
+		re, err := syntax.Parse(req.Query, syntax.Perl)
+		if err != nil {
+			return nil, invalidArgument("query", err.Error())
+		}
+		qs = append(qs, &query.Regexp{Regexp: re, Content: true, CaseSensitive: req.CaseSensitive})
+	} else {
+		q := req.Query
+		if req.CaseSensitive {
+			q = "case:yes " + q
+		}
+		parsed, err := query.Parse(q)
+		if err != nil {
+			return nil, invalidArgument("query", err.Error())
+		}
+		qs = append(qs, parsed)
+	}
+
+	if req.Repo != "" {
+		re, err := regexp.Compile(req.Repo)
+		if err != nil {
+			return nil, invalidArgument("repo", err.Error())
+		}
+		qs = append(qs, &query.Repo{Regexp: re})
+	}
+	if req.Branch != "" {
+		qs = append(qs, &query.Branch{Pattern: req.Branch, Exact: true})
+	}
+	if req.File != "" {
+		re, err := syntax.Parse(req.File, syntax.Perl)
+		if err != nil {
+			return nil, invalidArgument("file", err.Error())
+		}
+		qs = append(qs, &query.Regexp{Regexp: re, FileName: true, CaseSensitive: req.CaseSensitive})
+	}
+
+	return query.Simplify(query.NewAnd(qs...)), nil
+}
+
+func invalidArgument(field, description string) error {
+	detail := &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: description},
+		},
+	}
+	st := status.New(codes.InvalidArgument, description)
+	if rpcErr, err := st.WithDetails(detail); err != nil {
+		return status.Error(codes.Internal, "")
+	} else {
+		return rpcErr.Err()
+	}
+}
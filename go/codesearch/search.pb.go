@@ -0,0 +1,957 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.21.7
+// source: proto/codesearch/search.proto
+
+package codesearch
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RequestSearch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// If regexp is true, query is interpreted as the regular expression.
+	// Otherwise query is interpreted as the zoekt query language.
+	Regexp bool   `protobuf:"varint,2,opt,name=regexp,proto3" json:"regexp,omitempty"`
+	Repo   string `protobuf:"bytes,3,opt,name=repo,proto3" json:"repo,omitempty"`
+	Branch string `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
+	// file is the regular expression for the file path.
+	File          string `protobuf:"bytes,5,opt,name=file,proto3" json:"file,omitempty"`
+	CaseSensitive bool   `protobuf:"varint,6,opt,name=case_sensitive,json=caseSensitive,proto3" json:"case_sensitive,omitempty"`
+	Limit         int32  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	ContextLines  int32  `protobuf:"varint,8,opt,name=context_lines,json=contextLines,proto3" json:"context_lines,omitempty"`
+}
+
+func (x *RequestSearch) Reset() {
+	*x = RequestSearch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_codesearch_search_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestSearch) ProtoMessage() {}
+
+func (x *RequestSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_codesearch_search_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestSearch.ProtoReflect.Descriptor instead.
+func (*RequestSearch) Descriptor() ([]byte, []int) {
+	return file_proto_codesearch_search_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RequestSearch) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *RequestSearch) GetRegexp() bool {
+	if x != nil {
+		return x.Regexp
+	}
+	return false
+}
+
+func (x *RequestSearch) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *RequestSearch) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *RequestSearch) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *RequestSearch) GetCaseSensitive() bool {
+	if x != nil {
+		return x.CaseSensitive
+	}
+	return false
+}
+
+func (x *RequestSearch) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RequestSearch) GetContextLines() int32 {
+	if x != nil {
+		return x.ContextLines
+	}
+	return 0
+}
+
+type ResponseSearch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Files        []*FileMatch `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	MatchCount   int32        `protobuf:"varint,2,opt,name=match_count,json=matchCount,proto3" json:"match_count,omitempty"`
+	FileCount    int32        `protobuf:"varint,3,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`
+	ExecutionKey uint64       `protobuf:"varint,4,opt,name=execution_key,json=executionKey,proto3" json:"execution_key,omitempty"`
+}
+
+func (x *ResponseSearch) Reset() {
+	*x = ResponseSearch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_codesearch_search_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseSearch) ProtoMessage() {}
+
+func (x *ResponseSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_codesearch_search_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseSearch.ProtoReflect.Descriptor instead.
+func (*ResponseSearch) Descriptor() ([]byte, []int) {
+	return file_proto_codesearch_search_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ResponseSearch) GetFiles() []*FileMatch {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ResponseSearch) GetMatchCount() int32 {
+	if x != nil {
+		return x.MatchCount
+	}
+	return 0
+}
+
+func (x *ResponseSearch) GetFileCount() int32 {
+	if x != nil {
+		return x.FileCount
+	}
+	return 0
+}
+
+func (x *ResponseSearch) GetExecutionKey() uint64 {
+	if x != nil {
+		return x.ExecutionKey
+	}
+	return 0
+}
+
+type FileMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Repository string       `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	Path       string       `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Branches   []string     `protobuf:"bytes,3,rep,name=branches,proto3" json:"branches,omitempty"`
+	Language   string       `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`
+	Score      float64      `protobuf:"fixed64,5,opt,name=score,proto3" json:"score,omitempty"`
+	Lines      []*LineMatch `protobuf:"bytes,6,rep,name=lines,proto3" json:"lines,omitempty"`
+}
+
+func (x *FileMatch) Reset() {
+	*x = FileMatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_codesearch_search_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileMatch) ProtoMessage() {}
+
+func (x *FileMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_codesearch_search_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileMatch.ProtoReflect.Descriptor instead.
+func (*FileMatch) Descriptor() ([]byte, []int) {
+	return file_proto_codesearch_search_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FileMatch) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *FileMatch) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileMatch) GetBranches() []string {
+	if x != nil {
+		return x.Branches
+	}
+	return nil
+}
+
+func (x *FileMatch) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *FileMatch) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *FileMatch) GetLines() []*LineMatch {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type LineMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LineNumber int32       `protobuf:"varint,1,opt,name=line_number,json=lineNumber,proto3" json:"line_number,omitempty"`
+	Line       string      `protobuf:"bytes,2,opt,name=line,proto3" json:"line,omitempty"`
+	Before     string      `protobuf:"bytes,3,opt,name=before,proto3" json:"before,omitempty"`
+	After      string      `protobuf:"bytes,4,opt,name=after,proto3" json:"after,omitempty"`
+	FileName   bool        `protobuf:"varint,5,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Fragments  []*Fragment `protobuf:"bytes,6,rep,name=fragments,proto3" json:"fragments,omitempty"`
+}
+
+func (x *LineMatch) Reset() {
+	*x = LineMatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_codesearch_search_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LineMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineMatch) ProtoMessage() {}
+
+func (x *LineMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_codesearch_search_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineMatch.ProtoReflect.Descriptor instead.
+func (*LineMatch) Descriptor() ([]byte, []int) {
+	return file_proto_codesearch_search_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LineMatch) GetLineNumber() int32 {
+	if x != nil {
+		return x.LineNumber
+	}
+	return 0
+}
+
+func (x *LineMatch) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+func (x *LineMatch) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *LineMatch) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+func (x *LineMatch) GetFileName() bool {
+	if x != nil {
+		return x.FileName
+	}
+	return false
+}
+
+func (x *LineMatch) GetFragments() []*Fragment {
+	if x != nil {
+		return x.Fragments
+	}
+	return nil
+}
+
+type Fragment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Offset int32 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length int32 `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
+}
+
+func (x *Fragment) Reset() {
+	*x = Fragment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_codesearch_search_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Fragment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fragment) ProtoMessage() {}
+
+func (x *Fragment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_codesearch_search_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fragment.ProtoReflect.Descriptor instead.
+func (*Fragment) Descriptor() ([]byte, []int) {
+	return file_proto_codesearch_search_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Fragment) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Fragment) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type RequestListRepository struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RequestListRepository) Reset() {
+	*x = RequestListRepository{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_codesearch_search_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestListRepository) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestListRepository) ProtoMessage() {}
+
+func (x *RequestListRepository) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_codesearch_search_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestListRepository.ProtoReflect.Descriptor instead.
+func (*RequestListRepository) Descriptor() ([]byte, []int) {
+	return file_proto_codesearch_search_proto_rawDescGZIP(), []int{5}
+}
+
+type ResponseListRepository struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Repositories []*IndexedRepository `protobuf:"bytes,1,rep,name=repositories,proto3" json:"repositories,omitempty"`
+	ExecutionKey uint64               `protobuf:"varint,2,opt,name=execution_key,json=executionKey,proto3" json:"execution_key,omitempty"`
+}
+
+func (x *ResponseListRepository) Reset() {
+	*x = ResponseListRepository{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_codesearch_search_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseListRepository) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseListRepository) ProtoMessage() {}
+
+func (x *ResponseListRepository) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_codesearch_search_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseListRepository.ProtoReflect.Descriptor instead.
+func (*ResponseListRepository) Descriptor() ([]byte, []int) {
+	return file_proto_codesearch_search_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ResponseListRepository) GetRepositories() []*IndexedRepository {
+	if x != nil {
+		return x.Repositories
+	}
+	return nil
+}
+
+func (x *ResponseListRepository) GetExecutionKey() uint64 {
+	if x != nil {
+		return x.ExecutionKey
+	}
+	return 0
+}
+
+type IndexedRepository struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Branches []string `protobuf:"bytes,2,rep,name=branches,proto3" json:"branches,omitempty"`
+	Files    int64    `protobuf:"varint,3,opt,name=files,proto3" json:"files,omitempty"`
+}
+
+func (x *IndexedRepository) Reset() {
+	*x = IndexedRepository{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_codesearch_search_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndexedRepository) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexedRepository) ProtoMessage() {}
+
+func (x *IndexedRepository) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_codesearch_search_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexedRepository.ProtoReflect.Descriptor instead.
+func (*IndexedRepository) Descriptor() ([]byte, []int) {
+	return file_proto_codesearch_search_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *IndexedRepository) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IndexedRepository) GetBranches() []string {
+	if x != nil {
+		return x.Branches
+	}
+	return nil
+}
+
+func (x *IndexedRepository) GetFiles() int64 {
+	if x != nil {
+		return x.Files
+	}
+	return 0
+}
+
+var File_proto_codesearch_search_proto protoreflect.FileDescriptor
+
+var file_proto_codesearch_search_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x2f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x0f, 0x6d, 0x6f, 0x6e, 0x6f, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x22, 0xdf, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x65,
+	0x78, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x67, 0x65, 0x78, 0x70,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x72, 0x65, 0x70, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65,
+	0x12, 0x25, 0x0a, 0x0e, 0x63, 0x61, 0x73, 0x65, 0x5f, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69,
+	0x76, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x63, 0x61, 0x73, 0x65, 0x53, 0x65,
+	0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x23, 0x0a,
+	0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x4c, 0x69, 0x6e,
+	0x65, 0x73, 0x22, 0xa7, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x30, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x6f, 0x6e, 0x6f, 0x2e, 0x63, 0x6f, 0x64, 0x65,
+	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x69,
+	0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
+	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x22, 0xbf, 0x01, 0x0a,
+	0x09, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1a,
+	0x0a, 0x08, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x30, 0x0a, 0x05,
+	0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x6f,
+	0x6e, 0x6f, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x4c, 0x69,
+	0x6e, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x22, 0xc4,
+	0x01, 0x0a, 0x09, 0x4c, 0x69, 0x6e, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x6c, 0x69, 0x6e, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x66, 0x74,
+	0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x12,
+	0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x37, 0x0a, 0x09,
+	0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x6d, 0x6f, 0x6e, 0x6f, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x2e, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x66, 0x72, 0x61, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x3a, 0x0a, 0x08, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x22, 0x17, 0x0a, 0x15, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x22, 0x85, 0x01, 0x0a, 0x16, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x46, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6d, 0x6f,
+	0x6e, 0x6f, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x0c, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x23, 0x0a,
+	0x0d, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4b,
+	0x65, 0x79, 0x22, 0x59, 0x0a, 0x11, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x52, 0x65, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x62,
+	0x72, 0x61, 0x6e, 0x63, 0x68, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x62,
+	0x72, 0x61, 0x6e, 0x63, 0x68, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x32, 0xba, 0x01,
+	0x0a, 0x0a, 0x43, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x49, 0x0a, 0x06,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x1e, 0x2e, 0x6d, 0x6f, 0x6e, 0x6f, 0x2e, 0x63, 0x6f,
+	0x64, 0x65, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x1a, 0x1f, 0x2e, 0x6d, 0x6f, 0x6e, 0x6f, 0x2e, 0x63, 0x6f,
+	0x64, 0x65, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x61, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x26, 0x2e, 0x6d, 0x6f, 0x6e, 0x6f,
+	0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
+	0x79, 0x1a, 0x27, 0x2e, 0x6d, 0x6f, 0x6e, 0x6f, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x42, 0x20, 0x5a, 0x1e, 0x67, 0x6f,
+	0x2e, 0x66, 0x31, 0x31, 0x30, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x6d, 0x6f, 0x6e, 0x6f, 0x2f, 0x67,
+	0x6f, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_codesearch_search_proto_rawDescOnce sync.Once
+	file_proto_codesearch_search_proto_rawDescData = file_proto_codesearch_search_proto_rawDesc
+)
+
+func file_proto_codesearch_search_proto_rawDescGZIP() []byte {
+	file_proto_codesearch_search_proto_rawDescOnce.Do(func() {
+		file_proto_codesearch_search_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_codesearch_search_proto_rawDescData)
+	})
+	return file_proto_codesearch_search_proto_rawDescData
+}
+
+var file_proto_codesearch_search_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_codesearch_search_proto_goTypes = []interface{}{
+	(*RequestSearch)(nil),          // 0: mono.codesearch.RequestSearch
+	(*ResponseSearch)(nil),         // 1: mono.codesearch.ResponseSearch
+	(*FileMatch)(nil),              // 2: mono.codesearch.FileMatch
+	(*LineMatch)(nil),              // 3: mono.codesearch.LineMatch
+	(*Fragment)(nil),               // 4: mono.codesearch.Fragment
+	(*RequestListRepository)(nil),  // 5: mono.codesearch.RequestListRepository
+	(*ResponseListRepository)(nil), // 6: mono.codesearch.ResponseListRepository
+	(*IndexedRepository)(nil),      // 7: mono.codesearch.IndexedRepository
+}
+var file_proto_codesearch_search_proto_depIdxs = []int32{
+	2, // 0: mono.codesearch.ResponseSearch.files:type_name -> mono.codesearch.FileMatch
+	3, // 1: mono.codesearch.FileMatch.lines:type_name -> mono.codesearch.LineMatch
+	4, // 2: mono.codesearch.LineMatch.fragments:type_name -> mono.codesearch.Fragment
+	7, // 3: mono.codesearch.ResponseListRepository.repositories:type_name -> mono.codesearch.IndexedRepository
+	0, // 4: mono.codesearch.CodeSearch.Search:input_type -> mono.codesearch.RequestSearch
+	5, // 5: mono.codesearch.CodeSearch.ListRepository:input_type -> mono.codesearch.RequestListRepository
+	1, // 6: mono.codesearch.CodeSearch.Search:output_type -> mono.codesearch.ResponseSearch
+	6, // 7: mono.codesearch.CodeSearch.ListRepository:output_type -> mono.codesearch.ResponseListRepository
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_proto_codesearch_search_proto_init() }
+func file_proto_codesearch_search_proto_init() {
+	if File_proto_codesearch_search_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_codesearch_search_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestSearch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_codesearch_search_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseSearch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_codesearch_search_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileMatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_codesearch_search_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LineMatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_codesearch_search_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Fragment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_codesearch_search_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestListRepository); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_codesearch_search_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseListRepository); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_codesearch_search_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndexedRepository); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_codesearch_search_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_codesearch_search_proto_goTypes,
+		DependencyIndexes: file_proto_codesearch_search_proto_depIdxs,
+		MessageInfos:      file_proto_codesearch_search_proto_msgTypes,
+	}.Build()
+	File_proto_codesearch_search_proto = out.File
+	file_proto_codesearch_search_proto_rawDesc = nil
+	file_proto_codesearch_search_proto_goTypes = nil
+	file_proto_codesearch_search_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// CodeSearchClient is the client API for CodeSearch service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CodeSearchClient interface {
+	Search(ctx context.Context, in *RequestSearch, opts ...grpc.CallOption) (*ResponseSearch, error)
+	ListRepository(ctx context.Context, in *RequestListRepository, opts ...grpc.CallOption) (*ResponseListRepository, error)
+}
+
+type codeSearchClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCodeSearchClient(cc grpc.ClientConnInterface) CodeSearchClient {
+	return &codeSearchClient{cc}
+}
+
+func (c *codeSearchClient) Search(ctx context.Context, in *RequestSearch, opts ...grpc.CallOption) (*ResponseSearch, error) {
+	out := new(ResponseSearch)
+	err := c.cc.Invoke(ctx, "/mono.codesearch.CodeSearch/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeSearchClient) ListRepository(ctx context.Context, in *RequestListRepository, opts ...grpc.CallOption) (*ResponseListRepository, error) {
+	out := new(ResponseListRepository)
+	err := c.cc.Invoke(ctx, "/mono.codesearch.CodeSearch/ListRepository", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CodeSearchServer is the server API for CodeSearch service.
+type CodeSearchServer interface {
+	Search(context.Context, *RequestSearch) (*ResponseSearch, error)
+	ListRepository(context.Context, *RequestListRepository) (*ResponseListRepository, error)
+}
+
+// UnimplementedCodeSearchServer can be embedded to have forward compatible implementations.
+type UnimplementedCodeSearchServer struct {
+}
+
+func (*UnimplementedCodeSearchServer) Search(context.Context, *RequestSearch) (*ResponseSearch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (*UnimplementedCodeSearchServer) ListRepository(context.Context, *RequestListRepository) (*ResponseListRepository, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRepository not implemented")
+}
+
+func RegisterCodeSearchServer(s *grpc.Server, srv CodeSearchServer) {
+	s.RegisterService(&_CodeSearch_serviceDesc, srv)
+}
+
+func _CodeSearch_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestSearch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeSearchServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mono.codesearch.CodeSearch/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeSearchServer).Search(ctx, req.(*RequestSearch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeSearch_ListRepository_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestListRepository)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeSearchServer).ListRepository(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mono.codesearch.CodeSearch/ListRepository",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeSearchServer).ListRepository(ctx, req.(*RequestListRepository))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CodeSearch_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "mono.codesearch.CodeSearch",
+	HandlerType: (*CodeSearchServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Search",
+			Handler:    _CodeSearch_Search_Handler,
+		},
+		{
+			MethodName: "ListRepository",
+			Handler:    _CodeSearch_ListRepository_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/codesearch/search.proto",
+}
@@ -0,0 +1,241 @@
+package codesearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/zoekt"
+	"github.com/google/zoekt/build"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.f110.dev/mono/go/logger"
+	"go.f110.dev/mono/go/storage"
+)
+
+func TestSearchService(t *testing.T) {
+	logger.Init()
+	mockStorage := storage.NewMock()
+	indexDir := t.TempDir()
+	svc := NewSearchService(NewObjectStorageIndexManager(mockStorage, "test"), indexDir)
+	t.Cleanup(svc.Close)
+
+	_, err := svc.Search(context.Background(), &RequestSearch{Query: "foo"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	manifest := uploadTestIndex(t, mockStorage, 1, map[string][]zoekt.Document{
+		"f110/mono": {
+			{Name: "main.go", Content: []byte("package main\n\nfunc HelloWorld() {}\n"), Branches: []string{"master"}},
+			{Name: "docs/README.md", Content: []byte("Hello world\n"), Branches: []string{"master", "develop"}},
+		},
+		"f110/tools": {
+			{Name: "tool.go", Content: []byte("package tools\n\nfunc HelloTool() {}\n"), Branches: []string{"master"}},
+		},
+	})
+	err = svc.Load(context.Background(), manifest)
+	require.NoError(t, err)
+	assert.True(t, svc.Ready())
+
+	t.Run("Search", func(t *testing.T) {
+		cases := []struct {
+			Name  string
+			Req   *RequestSearch
+			Files []string
+		}{
+			{Name: "Substring", Req: &RequestSearch{Query: "Hello"}, Files: []string{"docs/README.md", "main.go", "tool.go"}},
+			{Name: "Regexp", Req: &RequestSearch{Query: `func Hello\w+\(`, Regexp: true}, Files: []string{"main.go", "tool.go"}},
+			{Name: "Repo", Req: &RequestSearch{Query: "Hello", Repo: "^f110/tools$"}, Files: []string{"tool.go"}},
+			{Name: "Branch", Req: &RequestSearch{Query: "Hello", Branch: "develop"}, Files: []string{"docs/README.md"}},
+			{Name: "File", Req: &RequestSearch{Query: "Hello", File: `\.go$`}, Files: []string{"main.go", "tool.go"}},
+			{Name: "CaseSensitive", Req: &RequestSearch{Query: "hello", CaseSensitive: true}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.Name, func(t *testing.T) {
+				res, err := svc.Search(context.Background(), tc.Req)
+				require.NoError(t, err)
+				assert.Equal(t, uint64(1), res.ExecutionKey)
+				var files []string
+				for _, v := range res.Files {
+					files = append(files, v.Path)
+				}
+				assert.ElementsMatch(t, tc.Files, files)
+			})
+		}
+
+		res, err := svc.Search(context.Background(), &RequestSearch{Query: "HelloWorld"})
+		require.NoError(t, err)
+		require.Len(t, res.Files, 1)
+		assert.Equal(t, "f110/mono", res.Files[0].Repository)
+		require.Len(t, res.Files[0].Lines, 1)
+		assert.Equal(t, int32(3), res.Files[0].Lines[0].LineNumber)
+		require.Len(t, res.Files[0].Lines[0].Fragments, 1)
+		assert.Equal(t, &Fragment{Offset: 5, Length: 10}, res.Files[0].Lines[0].Fragments[0])
+
+		_, err = svc.Search(context.Background(), &RequestSearch{Query: ""})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = svc.Search(context.Background(), &RequestSearch{Query: "(", Regexp: true})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("ListRepository", func(t *testing.T) {
+		res, err := svc.ListRepository(context.Background(), &RequestListRepository{})
+		require.NoError(t, err)
+		require.Len(t, res.Repositories, 2)
+		assert.Equal(t, "f110/mono", res.Repositories[0].Name)
+		assert.Equal(t, []string{"master", "develop"}, res.Repositories[0].Branches)
+		assert.Equal(t, int64(2), res.Repositories[0].Files)
+		assert.Equal(t, "f110/tools", res.Repositories[1].Name)
+	})
+
+	t.Run("HTTP", func(t *testing.T) {
+		s := httptest.NewServer(svc)
+		t.Cleanup(s.Close)
+
+		res, err := http.Get(s.URL + "/search?q=Hello&file=%5C.go%24&repo=mono")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		var body struct {
+			Files []struct {
+				Repository string `json:"repository"`
+				Path       string `json:"path"`
+			} `json:"files"`
+		}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+		require.Len(t, body.Files, 1)
+		assert.Equal(t, "f110/mono", body.Files[0].Repository)
+		assert.Equal(t, "main.go", body.Files[0].Path)
+
+		res, err = http.Get(s.URL + "/search?q=Hello&limit=foo")
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		res, err = http.Get(s.URL + "/search?q=(&regexp=true")
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("Swap", func(t *testing.T) {
+		newManifest := uploadTestIndex(t, mockStorage, 2, map[string][]zoekt.Document{
+			"f110/mono": {
+				{Name: "main.go", Content: []byte("package main\n\nfunc GoodbyeWorld() {}\n"), Branches: []string{"master"}},
+			},
+		})
+		err := svc.Load(context.Background(), newManifest)
+		require.NoError(t, err)
+
+		res, err := svc.Search(context.Background(), &RequestSearch{Query: "Hello"})
+		require.NoError(t, err)
+		assert.Len(t, res.Files, 0)
+		res, err = svc.Search(context.Background(), &RequestSearch{Query: "Goodbye"})
+		require.NoError(t, err)
+		assert.Len(t, res.Files, 1)
+		assert.Equal(t, uint64(2), res.ExecutionKey)
+
+		_, err = os.Stat(filepath.Join(indexDir, "1"))
+		assert.True(t, os.IsNotExist(err))
+
+		// The older manifest should be ignored.
+		err = svc.Load(context.Background(), manifest)
+		require.NoError(t, err)
+		res, err = svc.Search(context.Background(), &RequestSearch{Query: "Goodbye"})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), res.ExecutionKey)
+	})
+}
+
+type blockingDownloader struct {
+	mu      sync.Mutex
+	keys    []uint64
+	started chan struct{}
+	release chan struct{}
+}
+
+func (d *blockingDownloader) Download(_ context.Context, _ string, manifest Manifest) error {
+	d.mu.Lock()
+	d.keys = append(d.keys, manifest.ExecutionKey)
+	d.mu.Unlock()
+	if manifest.ExecutionKey == 2 {
+		close(d.started)
+		<-d.release
+	}
+	return nil
+}
+
+func TestSearchService_LoadConcurrently(t *testing.T) {
+	logger.Init()
+	downloader := &blockingDownloader{started: make(chan struct{}), release: make(chan struct{})}
+	svc := &SearchService{indexDir: t.TempDir(), indexManager: downloader}
+	t.Cleanup(svc.Close)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, svc.Load(context.Background(), Manifest{ExecutionKey: 2}))
+	}()
+	<-downloader.started
+	// The older manifest is loaded while the newer manifest is being downloaded.
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, svc.Load(context.Background(), Manifest{ExecutionKey: 1}))
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(downloader.release)
+	wg.Wait()
+
+	assert.Equal(t, uint64(2), svc.manifest.ExecutionKey)
+	assert.Equal(t, []uint64{2}, downloader.keys)
+}
+
+func uploadTestIndex(t *testing.T, s *storage.Mock, key uint64, repos map[string][]zoekt.Document) Manifest {
+	indexes := make(map[string]string)
+	for name, docs := range repos {
+		dir := t.TempDir()
+		branches := make(map[string]struct{})
+		var repoBranches []zoekt.RepositoryBranch
+		for _, d := range docs {
+			for _, b := range d.Branches {
+				if _, ok := branches[b]; ok {
+					continue
+				}
+				branches[b] = struct{}{}
+				repoBranches = append(repoBranches, zoekt.RepositoryBranch{Name: b})
+			}
+		}
+		opt := build.Options{
+			IndexDir:              dir,
+			RepositoryDescription: zoekt.Repository{Name: name, Branches: repoBranches},
+		}
+		opt.SetDefaults()
+		builder, err := build.NewBuilder(opt)
+		require.NoError(t, err)
+		for _, d := range docs {
+			require.NoError(t, builder.Add(d))
+		}
+		require.NoError(t, builder.Finish())
+
+		files, err := filepath.Glob(filepath.Join(dir, "*.zoekt"))
+		require.NoError(t, err)
+		require.NotEmpty(t, files)
+		for _, f := range files {
+			buf, err := os.ReadFile(f)
+			require.NoError(t, err)
+			s.AddTree(filepath.Join(name, fmt.Sprintf("%d", key), filepath.Base(f)), buf)
+		}
+		indexes[name] = fmt.Sprintf("mock://test/%s/%d", name, key)
+	}
+
+	return NewManifest(key, indexes, 0)
+}
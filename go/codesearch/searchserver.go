@@ -0,0 +1,226 @@
+package codesearch
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.f110.dev/xerrors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"go.f110.dev/mono/go/cli"
+	"go.f110.dev/mono/go/ctxutil"
+	"go.f110.dev/mono/go/logger"
+	"go.f110.dev/mono/go/storage"
+)
+
+type SearchCommand struct {
+	IndexDir  string
+	GRPCAddr  string
+	HTTPAddr  string
+	Subscribe bool
+
+	Bucket               string
+	MinIOEndpoint        string
+	MinIORegion          string
+	MinIOName            string
+	MinIONamespace       string
+	MinIOPort            int
+	MinIOAccessKey       string
+	MinIOSecretAccessKey string
+	S3Endpoint           string
+	S3Region             string
+	S3AccessKey          string
+	S3SecretAccessKey    string
+	S3CACertFile         string
+
+	NATSURL        string
+	NATSStreamName string
+	NATSSubject    string
+
+	Dev bool
+
+	service *SearchService
+}
+
+func NewSearchCommand() *SearchCommand {
+	return &SearchCommand{
+		GRPCAddr:       ":9000",
+		HTTPAddr:       ":8080",
+		MinIOPort:      9000,
+		NATSStreamName: "repoindexer",
+		NATSSubject:    "notify",
+	}
+}
+
+func (s *SearchCommand) Flags(fs *cli.FlagSet) {
+	fs.String("index-dir", "Index directory").Var(&s.IndexDir)
+	fs.String("grpc-addr", "gRPC listen addr").Var(&s.GRPCAddr).Default(s.GRPCAddr)
+	fs.String("http-addr", "HTTP listen addr for JSON API").Var(&s.HTTPAddr).Default(s.HTTPAddr)
+	fs.Bool("subscribe", "Enable subscribe the stream").Var(&s.Subscribe)
+	fs.String("minio-endpoint", "The endpoint of MinIO").Var(&s.MinIOEndpoint)
+	fs.String("minio-region", "The region name").Var(&s.MinIORegion)
+	fs.String("minio-name", "The name of MinIO").Var(&s.MinIOName)
+	fs.String("minio-namespace", "The namespace of MinIO").Var(&s.MinIONamespace)
+	fs.Int("minio-port", "Port number of MinIO").Var(&s.MinIOPort).Default(s.MinIOPort)
+	fs.String("minio-access-key", "The access key for MinIO API").Var(&s.MinIOAccessKey)
+	fs.String("minio-secret-access-key", "The secret access key for MinIO API").Var(&s.MinIOSecretAccessKey)
+	fs.String("s3-endpoint", "The endpoint of s3. If you use the object storage has compatible s3 api not AWS S3, You can use this param").Var(&s.S3Endpoint)
+	fs.String("s3-region", "The region name").Var(&s.S3Region)
+	fs.String("s3-access-key", "The access key for S3 API").Var(&s.S3AccessKey)
+	fs.String("s3-secret-access-key", "The secret access key for S3 API").Var(&s.S3SecretAccessKey)
+	fs.String("s3-ca-file", "File path that contains the certificate of CA").Var(&s.S3CACertFile)
+	fs.String("bucket", "The bucket name").Var(&s.Bucket)
+	fs.String("nats-url", "The URL for nats-server").Var(&s.NATSURL)
+	fs.String("nats-stream-name", "The name of stream for JetStream").Var(&s.NATSStreamName).Default(s.NATSStreamName)
+	fs.String("nats-subject", "The subject of stream").Var(&s.NATSSubject).Default(s.NATSSubject)
+	fs.Bool("dev", "Development mode").Var(&s.Dev)
+}
+
+func (s *SearchCommand) ValidateFlags() error {
+	if s.IndexDir == "" {
+		return xerrors.Define("--index-dir is required").WithStack()
+	}
+	if s.Bucket == "" || (!s.canUseMinIO() && !s.canUseS3()) {
+		return xerrors.Define("the object storage is not configured").WithStack()
+	}
+	if s.Subscribe && s.NATSURL == "" {
+		return xerrors.Define("--nats-url is required for subscribing the stream").WithStack()
+	}
+
+	return nil
+}
+
+func (s *SearchCommand) Run(ctx context.Context) error {
+	if err := s.ValidateFlags(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.IndexDir, 0755); err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	storageClient, err := s.newStorageClient()
+	if err != nil {
+		return err
+	}
+	manifestManager := NewManifestManager(storageClient)
+	s.service = NewSearchService(NewObjectStorageIndexManager(storageClient, s.Bucket), s.IndexDir)
+	defer s.service.Close()
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if s.Subscribe {
+		n, err := NewNotify(s.NATSURL, s.NATSStreamName, s.NATSSubject)
+		if err != nil {
+			return err
+		}
+		sub, err := n.Subscribe(manifestManager)
+		if err != nil {
+			return err
+		}
+		defer sub.Close()
+		go s.service.Watch(ctx, sub)
+	}
+
+	manifest, err := manifestManager.GetLatest(ctx)
+	if err != nil {
+		// The index will be loaded when the new manifest is notified.
+		logger.Log.Warn("Failed to get the latest manifest", zap.Error(err))
+	} else if err := s.service.Load(ctx, manifest); err != nil {
+		return err
+	}
+
+	grpcServer := grpc.NewServer()
+	RegisterCodeSearchServer(grpcServer, s.service)
+	healthSvc := health.NewServer()
+	healthSvc.SetServingStatus("code-search", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthSvc)
+	lis, err := net.Listen("tcp", s.GRPCAddr)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	logger.Log.Info("Start listen gRPC", zap.String("addr", s.GRPCAddr))
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			logger.Log.Info("Error occurred in gRPC server", zap.Error(err))
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.Handle("/search", s.service)
+	mux.Handle("/repositories", s.service)
+	mux.HandleFunc("/readiness", func(w http.ResponseWriter, req *http.Request) {
+		if !s.service.Ready() {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+	})
+	mux.Handle("/metrics", promhttp.Handler())
+	httpServer := &http.Server{Addr: s.HTTPAddr, Handler: mux}
+	logger.Log.Info("Start listen HTTP", zap.String("addr", s.HTTPAddr))
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Log.Info("Error occurred in HTTP server", zap.Error(err))
+		}
+	}()
+
+	<-ctx.Done()
+	logger.Log.Debug("Got signal")
+
+	grpcServer.GracefulStop()
+	shutdownCtx, cancel := ctxutil.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (s *SearchCommand) canUseMinIO() bool {
+	return (s.MinIOName != "" && s.MinIONamespace != "") || s.MinIOEndpoint != ""
+}
+
+func (s *SearchCommand) canUseS3() bool {
+	return s.S3Endpoint != "" && s.S3AccessKey != "" && s.S3SecretAccessKey != "" && s.S3Region != ""
+}
+
+func (s *SearchCommand) newStorageClient() (StorageClient, error) {
+	if s.canUseMinIO() {
+		var opt storage.MinIOOptions
+		if s.MinIOName != "" && s.MinIONamespace != "" {
+			k8sClient, k8sConf, err := newK8sClient(s.Dev)
+			if err != nil {
+				return nil, xerrors.WithStack(err)
+			}
+			opt = storage.NewMinIOOptionsViaService(k8sClient, k8sConf, s.MinIOName, s.MinIONamespace, s.MinIOPort, s.MinIOAccessKey, s.MinIOSecretAccessKey, s.Dev)
+		} else if s.MinIOEndpoint != "" {
+			opt = storage.NewMinIOOptionsViaEndpoint(s.MinIOEndpoint, s.MinIORegion, s.MinIOAccessKey, s.MinIOSecretAccessKey)
+		}
+		opt.Retries = 3
+		return storage.NewMinIOStorage(s.Bucket, opt), nil
+	}
+	if s.canUseS3() {
+		var opt storage.S3Options
+		if s.S3Endpoint != "" {
+			opt = storage.NewS3OptionToExternal(s.S3Endpoint, s.S3Region, s.S3AccessKey, s.S3SecretAccessKey)
+		} else {
+			opt = storage.NewS3OptionToAWS(s.S3Region, s.S3AccessKey, s.S3SecretAccessKey)
+		}
+		opt.PathStyle = true
+		opt.CACertFile = s.S3CACertFile
+		opt.Retries = 3
+		return storage.NewS3(s.Bucket, opt), nil
+	}
+
+	return nil, nil
+}
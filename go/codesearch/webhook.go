@@ -0,0 +1,181 @@
+package codesearch
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/google/go-github/v49/github"
+	"go.f110.dev/xerrors"
+	"go.uber.org/zap"
+
+	"go.f110.dev/mono/go/ctxutil"
+	"go.f110.dev/mono/go/logger"
+)
+
+// debouncer calls the function once after the name is not added during the delay.
+type debouncer struct {
+	delay time.Duration
+	fn    func(name string)
+
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+}
+
+func newDebouncer(delay time.Duration, fn func(name string)) *debouncer {
+	return &debouncer{delay: delay, fn: fn, timers: make(map[string]*time.Timer)}
+}
+
+func (d *debouncer) Add(name string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if t, ok := d.timers[name]; ok && t.Stop() {
+		t.Reset(d.delay)
+		return
+	}
+
+	var t *time.Timer
+	t = time.AfterFunc(d.delay, func() {
+		d.mu.Lock()
+		if d.timers[name] == t {
+			delete(d.timers, name)
+		}
+		d.mu.Unlock()
+
+		d.fn(name)
+	})
+	d.timers[name] = t
+}
+
+func (d *debouncer) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for k, t := range d.timers {
+		t.Stop()
+		delete(d.timers, k)
+	}
+}
+
+// IsIndexedRef returns true if the reference of the remote repository is the target of the indexing.
+// ref is the full name of the reference in the remote repository (e.g. refs/heads/master).
+func (x *Repository) IsIndexedRef(ref string) bool {
+	name := plumbing.ReferenceName(ref)
+	if name.IsBranch() {
+		name = plumbing.NewRemoteReferenceName("origin", name.Short())
+	}
+	for _, v := range x.Refs {
+		if v == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *IndexerCommand) githubWebhook(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// ValidatePayload doesn't verify the signature if the secret is empty.
+	if r.WebhookSecret == "" {
+		http.NotFound(w, req)
+		return
+	}
+	payload, err := github.ValidatePayload(req, []byte(r.WebhookSecret))
+	if err != nil {
+		logger.Log.Info("Invalid payload", zap.Error(err))
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	event, err := github.ParseWebHook(github.WebHookType(req), payload)
+	if err != nil {
+		logger.Log.Info("Failed to parse the payload", zap.Error(err))
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	pushEvent, ok := event.(*github.PushEvent)
+	if !ok {
+		return
+	}
+
+	name := pushEvent.Repo.GetFullName()
+	repo := r.indexer.FindRepository(req.Context(), name)
+	if repo == nil {
+		logger.Log.Debug("Skip the push event because the repository is not indexed", zap.String("repo", name))
+		return
+	}
+	if !repo.IsIndexedRef(pushEvent.GetRef()) {
+		logger.Log.Debug("Skip the push event because the reference is not indexed", zap.String("repo", name), zap.String("ref", pushEvent.GetRef()))
+		return
+	}
+
+	logger.Log.Info("Got the push event", zap.String("repo", name), zap.String("ref", pushEvent.GetRef()))
+	r.debouncer.Add(name)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// reindexRepository builds the index of the repository and publishes the manifest which has the new index.
+// The indexes of other repositories are taken over from the latest manifest.
+func (r *IndexerCommand) reindexRepository(name string) error {
+	if !r.enableUpload() {
+		return xerrors.Define("the object storage is not configured").WithStack()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := ctxutil.WithCancel(context.Background())
+	defer func() {
+		r.indexer.Reset()
+		cancel()
+	}()
+	r.cancel = cancel
+
+	repo := r.indexer.FindRepository(ctx, name)
+	if repo == nil {
+		return xerrors.Definef("%s is not found", name).WithStack()
+	}
+	s, err := r.newStorageClient()
+	if err != nil {
+		return err
+	}
+	previous, err := NewManifestManager(s).GetLatest(ctx)
+	if err != nil {
+		return err
+	}
+	r.indexer.SetPreviousManifest(&previous)
+
+	if err := r.indexer.BuildRepositoryIndex(ctx, repo); err != nil {
+		return err
+	}
+	if len(r.indexer.Indexes) == 0 {
+		return xerrors.Definef("failed to build the index of %s", name).WithStack()
+	}
+	indexes := r.indexer.Indexes
+	for k, v := range previous.Indexes {
+		if k == name {
+			continue
+		}
+		indexes = append(indexes, &RepositoryIndex{Name: k, Commits: previous.Commits[k], IndexPath: v})
+	}
+
+	manifest, err := r.uploadIndex(ctx, indexes, r.Bucket, r.DisableObjectStorageCleanup)
+	if err != nil {
+		return err
+	}
+	logger.Log.Info("Published the manifest", zap.String("repo", name), zap.Uint64("key", manifest.ExecutionKey))
+	if r.natsNotify != nil {
+		if err := r.natsNotify.Notify(ctx, manifest); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package codesearch
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.f110.dev/mono/go/logger"
+)
+
+func TestDebouncer(t *testing.T) {
+	var mu sync.Mutex
+	called := make(map[string]int)
+	d := newDebouncer(100*time.Millisecond, func(name string) {
+		mu.Lock()
+		called[name]++
+		mu.Unlock()
+	})
+
+	for range 3 {
+		d.Add("f110/mono")
+		time.Sleep(10 * time.Millisecond)
+	}
+	d.Add("f110/tools")
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return called["f110/mono"] == 1 && called["f110/tools"] == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// The name can be added again after the function is called.
+	d.Add("f110/mono")
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return called["f110/mono"] == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestRepository_IsIndexedRef(t *testing.T) {
+	repo := NewRepository("f110/mono", "", []plumbing.ReferenceName{"refs/remotes/origin/master", "refs/tags/v1.0.0"}, false, nil)
+
+	assert.True(t, repo.IsIndexedRef("refs/heads/master"))
+	assert.True(t, repo.IsIndexedRef("refs/tags/v1.0.0"))
+	assert.False(t, repo.IsIndexedRef("refs/heads/develop"))
+	assert.False(t, repo.IsIndexedRef("refs/tags/v1.0.1"))
+}
+
+func TestIndexerCommand_GitHubWebhook(t *testing.T) {
+	logger.Init()
+	cmd := &IndexerCommand{
+		WebhookSecret: "secret",
+		indexer: &Indexer{
+			lister: &RepositoryLister{repositories: []*Repository{
+				NewRepository("f110/mono", "", []plumbing.ReferenceName{"refs/remotes/origin/master"}, false, nil),
+			}},
+		},
+	}
+	pushed := make(chan string, 1)
+	cmd.debouncer = newDebouncer(10*time.Millisecond, func(name string) {
+		pushed <- name
+	})
+
+	send := func(t *testing.T, payload, signature string) int {
+		req := httptest.NewRequest(http.MethodPost, "/webhook/github", bytes.NewBufferString(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-GitHub-Event", "push")
+		req.Header.Set("X-Hub-Signature-256", signature)
+		rec := httptest.NewRecorder()
+		cmd.githubWebhook(rec, req)
+		return rec.Code
+	}
+	sign := func(payload string) string {
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(payload))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	payload := `{"ref":"refs/heads/master","repository":{"full_name":"f110/mono"}}`
+	assert.Equal(t, http.StatusBadRequest, send(t, payload, "sha256=invalid"))
+	assert.Equal(t, http.StatusBadRequest, send(t, payload, ""))
+
+	require.Equal(t, http.StatusAccepted, send(t, payload, sign(payload)))
+	select {
+	case name := <-pushed:
+		assert.Equal(t, "f110/mono", name)
+	case <-time.After(time.Second):
+		require.Fail(t, "the repository is not reindexed")
+	}
+
+	// The branch is not indexed
+	payload = `{"ref":"refs/heads/develop","repository":{"full_name":"f110/mono"}}`
+	assert.Equal(t, http.StatusOK, send(t, payload, sign(payload)))
+	// The repository is not indexed
+	payload = `{"ref":"refs/heads/master","repository":{"full_name":"f110/tools"}}`
+	assert.Equal(t, http.StatusOK, send(t, payload, sign(payload)))
+	select {
+	case name := <-pushed:
+		assert.Failf(t, "unexpected reindexing", "%s is reindexed", name)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestIndexerCommand_GitHubWebhookWithoutSecret(t *testing.T) {
+	logger.Init()
+	cmd := &IndexerCommand{}
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook/github", bytes.NewBufferString(`{"ref":"refs/heads/master","repository":{"full_name":"f110/mono"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", "push")
+	rec := httptest.NewRecorder()
+	cmd.githubWebhook(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
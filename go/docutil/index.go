@@ -0,0 +1,330 @@
+package docutil
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// Parameters of BM25
+	bm25K1 = 1.2
+	bm25B  = 0.75
+	// titleBoost is the weight of the term which appears in the title.
+	titleBoost = 2.0
+
+	snippetLength      = 160
+	maxSnippetsPerPage = 3
+	defaultSearchLimit = 20
+)
+
+type indexKey struct {
+	Repo string
+	Path string
+}
+
+type indexedDoc struct {
+	Title   string
+	Content string
+	// Length is the number of tokens in the content.
+	Length int
+	// Terms is the set of terms in the title and the content.
+	Terms      map[string]struct{}
+	TitleTerms map[string]struct{}
+}
+
+type posting struct {
+	// Freq is the term frequency in the content.
+	Freq int
+}
+
+// invertedIndex is the in-process full-text index of pages.
+// Pages can be added and removed at any time. Thus, the index can be updated incrementally.
+type invertedIndex struct {
+	mu          sync.RWMutex
+	docs        map[indexKey]*indexedDoc
+	postings    map[string]map[indexKey]*posting
+	totalLength int
+}
+
+func newInvertedIndex() *invertedIndex {
+	return &invertedIndex{
+		docs:     make(map[indexKey]*indexedDoc),
+		postings: make(map[string]map[indexKey]*posting),
+	}
+}
+
+// Add adds the page to the index. If the page has already been indexed, the page is replaced.
+func (idx *invertedIndex) Add(repo, path, title, content string) {
+	key := indexKey{Repo: repo, Path: path}
+	doc := &indexedDoc{
+		Title:      title,
+		Content:    content,
+		Terms:      make(map[string]struct{}),
+		TitleTerms: make(map[string]struct{}),
+	}
+	freq := make(map[string]int)
+	for _, t := range tokenize(content) {
+		freq[t.Term]++
+		doc.Length++
+	}
+	for _, t := range tokenize(title) {
+		doc.TitleTerms[t.Term] = struct{}{}
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.remove(key)
+	for term, n := range freq {
+		doc.Terms[term] = struct{}{}
+		idx.addPosting(term, key, n)
+	}
+	for term := range doc.TitleTerms {
+		if _, ok := doc.Terms[term]; ok {
+			continue
+		}
+		doc.Terms[term] = struct{}{}
+		idx.addPosting(term, key, 0)
+	}
+	idx.docs[key] = doc
+	idx.totalLength += doc.Length
+}
+
+// Remove removes the page from the index.
+func (idx *invertedIndex) Remove(repo, path string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(indexKey{Repo: repo, Path: path})
+}
+
+// RemoveRepository removes all pages of the repository from the index.
+func (idx *invertedIndex) RemoveRepository(repo string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	for k := range idx.docs {
+		if k.Repo == repo {
+			idx.remove(k)
+		}
+	}
+}
+
+func (idx *invertedIndex) addPosting(term string, key indexKey, freq int) {
+	p, ok := idx.postings[term]
+	if !ok {
+		p = make(map[indexKey]*posting)
+		idx.postings[term] = p
+	}
+	p[key] = &posting{Freq: freq}
+}
+
+func (idx *invertedIndex) remove(key indexKey) {
+	doc, ok := idx.docs[key]
+	if !ok {
+		return
+	}
+	for term := range doc.Terms {
+		delete(idx.postings[term], key)
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	idx.totalLength -= doc.Length
+	delete(idx.docs, key)
+}
+
+// Len returns the number of indexed pages.
+func (idx *invertedIndex) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return len(idx.docs)
+}
+
+// Search returns the pages which contain all terms of the query.
+// The results are ranked by BM25 and the term in the title is boosted.
+// If repo is not empty, the results are filtered by the repository.
+// Search returns the total number of matched pages too.
+func (idx *invertedIndex) Search(query, repo string, limit int) ([]*SearchResult, int) {
+	var terms []string
+	seen := make(map[string]struct{})
+	for _, t := range tokenize(query) {
+		if _, ok := seen[t.Term]; ok {
+			continue
+		}
+		seen[t.Term] = struct{}{}
+		terms = append(terms, t.Term)
+	}
+	if len(terms) == 0 {
+		return nil, 0
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	// Start from the rarest term to reduce the candidates.
+	sort.Slice(terms, func(i, j int) bool { return len(idx.postings[terms[i]]) < len(idx.postings[terms[j]]) })
+	candidates := make(map[indexKey]struct{})
+	for k := range idx.postings[terms[0]] {
+		if repo != "" && k.Repo != repo {
+			continue
+		}
+		candidates[k] = struct{}{}
+	}
+	for _, term := range terms[1:] {
+		p := idx.postings[term]
+		for k := range candidates {
+			if _, ok := p[k]; !ok {
+				delete(candidates, k)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, 0
+	}
+
+	n := float64(len(idx.docs))
+	avgLength := float64(idx.totalLength) / n
+	if avgLength == 0 {
+		avgLength = 1
+	}
+	type scoredDoc struct {
+		Key   indexKey
+		Score float64
+	}
+	scored := make([]scoredDoc, 0, len(candidates))
+	for k := range candidates {
+		doc := idx.docs[k]
+		var score float64
+		for _, term := range terms {
+			p := idx.postings[term]
+			df := float64(len(p))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			tf := float64(p[k].Freq)
+			score += idf * (tf * (bm25K1 + 1)) / (tf + bm25K1*(1-bm25B+bm25B*float64(doc.Length)/avgLength))
+			if _, ok := doc.TitleTerms[term]; ok {
+				score += idf * titleBoost
+			}
+		}
+		scored = append(scored, scoredDoc{Key: k, Score: score})
+	}
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].Score == scored[j].Score {
+			if scored[i].Key.Repo == scored[j].Key.Repo {
+				return scored[i].Key.Path < scored[j].Key.Path
+			}
+			return scored[i].Key.Repo < scored[j].Key.Repo
+		}
+		return scored[i].Score > scored[j].Score
+	})
+
+	total := len(scored)
+	if len(scored) > limit {
+		scored = scored[:limit]
+	}
+	results := make([]*SearchResult, len(scored))
+	for i, v := range scored {
+		doc := idx.docs[v.Key]
+		results[i] = &SearchResult{
+			Repository: v.Key.Repo,
+			Path:       v.Key.Path,
+			Title:      doc.Title,
+			Score:      v.Score,
+			Snippets:   makeSnippets(doc.Content, seen),
+		}
+	}
+	return results, total
+}
+
+type token struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// tokenize splits the text into the lower-cased terms.
+// The term is a sequence of letters and digits. CJK characters are treated as a term by each character
+// because they aren't separated by a space.
+func tokenize(s string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range s {
+		switch {
+		case isCJK(r):
+			if start >= 0 {
+				tokens = append(tokens, token{Term: strings.ToLower(s[start:i]), Start: start, End: i})
+				start = -1
+			}
+			end := i + utf8.RuneLen(r)
+			tokens = append(tokens, token{Term: s[i:end], Start: i, End: end})
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if start < 0 {
+				start = i
+			}
+		default:
+			if start >= 0 {
+				tokens = append(tokens, token{Term: strings.ToLower(s[start:i]), Start: start, End: i})
+				start = -1
+			}
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{Term: strings.ToLower(s[start:]), Start: start, End: len(s)})
+	}
+	return tokens
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
+
+// makeSnippets returns the fragments of the content around the terms.
+// The offset of Highlight is the byte offset in the text of the snippet.
+func makeSnippets(content string, terms map[string]struct{}) []*Snippet {
+	var matches []token
+	for _, t := range tokenize(content) {
+		if _, ok := terms[t.Term]; ok {
+			matches = append(matches, t)
+		}
+	}
+
+	var snippets []*Snippet
+	for i := 0; i < len(matches) && len(snippets) < maxSnippetsPerPage; {
+		start := alignRuneStart(content, matches[i].Start-snippetLength/4)
+		end := alignRuneStart(content, start+snippetLength)
+		if end < matches[i].End {
+			end = matches[i].End
+		}
+
+		snippet := &Snippet{Text: content[start:end]}
+		for ; i < len(matches) && matches[i].End <= end; i++ {
+			snippet.Highlights = append(snippet.Highlights, &Highlight{
+				Start: int32(matches[i].Start - start),
+				End:   int32(matches[i].End - start),
+			})
+		}
+		snippets = append(snippets, snippet)
+	}
+	return snippets
+}
+
+// alignRuneStart returns the offset of the beginning of the rune at i.
+func alignRuneStart(s string, i int) int {
+	if i <= 0 {
+		return 0
+	}
+	if i >= len(s) {
+		return len(s)
+	}
+	for i > 0 && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return i
+}
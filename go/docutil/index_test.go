@@ -0,0 +1,75 @@
+package docutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	var terms []string
+	for _, v := range tokenize("Hello, World! go-git v5 検索") {
+		terms = append(terms, v.Term)
+	}
+	assert.Equal(t, []string{"hello", "world", "go", "git", "v5", "検", "索"}, terms)
+}
+
+func TestInvertedIndex(t *testing.T) {
+	idx := newInvertedIndex()
+	idx.Add("mono", "README.md", "Mono", "The repository contains many tools. Bazel is used to build tools.")
+	idx.Add("mono", "docs/bazel.md", "Bazel", "How to use Bazel in this repository.")
+	idx.Add("sandbox", "README.md", "Sandbox", "The sandbox for Bazel.")
+	require.Equal(t, 3, idx.Len())
+
+	t.Run("Ranking", func(t *testing.T) {
+		results, total := idx.Search("bazel", "", 0)
+		assert.Equal(t, 3, total)
+		require.Len(t, results, 3)
+		// The page which has the term in the title is ranked higher.
+		assert.Equal(t, "docs/bazel.md", results[0].Path)
+	})
+
+	t.Run("AllTerms", func(t *testing.T) {
+		results, total := idx.Search("bazel tools", "", 0)
+		assert.Equal(t, 1, total)
+		require.Len(t, results, 1)
+		assert.Equal(t, "README.md", results[0].Path)
+		assert.Equal(t, "mono", results[0].Repository)
+	})
+
+	t.Run("FilterByRepository", func(t *testing.T) {
+		results, _ := idx.Search("bazel", "sandbox", 0)
+		require.Len(t, results, 1)
+		assert.Equal(t, "sandbox", results[0].Repository)
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		results, total := idx.Search("bazel", "", 1)
+		assert.Equal(t, 3, total)
+		assert.Len(t, results, 1)
+	})
+
+	t.Run("Snippet", func(t *testing.T) {
+		results, _ := idx.Search("sandbox", "sandbox", 0)
+		require.Len(t, results, 1)
+		require.Len(t, results[0].Snippets, 1)
+		snippet := results[0].Snippets[0]
+		require.Len(t, snippet.Highlights, 1)
+		h := snippet.Highlights[0]
+		assert.Equal(t, "sandbox", snippet.Text[h.Start:h.End])
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		idx.Add("sandbox", "README.md", "Sandbox", "The sandbox for Go.")
+		results, _ := idx.Search("bazel", "sandbox", 0)
+		assert.Len(t, results, 0)
+		results, _ = idx.Search("go", "sandbox", 0)
+		assert.Len(t, results, 1)
+
+		idx.Remove("sandbox", "README.md")
+		results, _ = idx.Search("go", "sandbox", 0)
+		assert.Len(t, results, 0)
+		assert.Equal(t, 2, idx.Len())
+	})
+}
@@ -955,6 +955,313 @@ func (x *DirectoryEntry) GetIsDir() bool {
 	return false
 }
 
+type RequestSearch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Repo  string `protobuf:"bytes,2,opt,name=repo,proto3" json:"repo,omitempty"`
+	Limit int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *RequestSearch) Reset() {
+	*x = RequestSearch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_docutil_search_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestSearch) ProtoMessage() {}
+
+func (x *RequestSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_docutil_search_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestSearch.ProtoReflect.Descriptor instead.
+func (*RequestSearch) Descriptor() ([]byte, []int) {
+	return file_proto_docutil_search_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RequestSearch) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *RequestSearch) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *RequestSearch) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ResponseSearch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*SearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Total   int32           `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *ResponseSearch) Reset() {
+	*x = ResponseSearch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_docutil_search_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseSearch) ProtoMessage() {}
+
+func (x *ResponseSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_docutil_search_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseSearch.ProtoReflect.Descriptor instead.
+func (*ResponseSearch) Descriptor() ([]byte, []int) {
+	return file_proto_docutil_search_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ResponseSearch) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ResponseSearch) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Repository string     `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	Path       string     `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Title      string     `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Score      float64    `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+	Snippets   []*Snippet `protobuf:"bytes,5,rep,name=snippets,proto3" json:"snippets,omitempty"`
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_docutil_search_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_docutil_search_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_proto_docutil_search_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SearchResult) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *SearchResult) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SearchResult) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchResult) GetSnippets() []*Snippet {
+	if x != nil {
+		return x.Snippets
+	}
+	return nil
+}
+
+type Snippet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text       string       `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Highlights []*Highlight `protobuf:"bytes,2,rep,name=highlights,proto3" json:"highlights,omitempty"`
+}
+
+func (x *Snippet) Reset() {
+	*x = Snippet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_docutil_search_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snippet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snippet) ProtoMessage() {}
+
+func (x *Snippet) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_docutil_search_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snippet.ProtoReflect.Descriptor instead.
+func (*Snippet) Descriptor() ([]byte, []int) {
+	return file_proto_docutil_search_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Snippet) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Snippet) GetHighlights() []*Highlight {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+type Highlight struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start int32 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   int32 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *Highlight) Reset() {
+	*x = Highlight{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_docutil_search_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Highlight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Highlight) ProtoMessage() {}
+
+func (x *Highlight) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_docutil_search_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Highlight.ProtoReflect.Descriptor instead.
+func (*Highlight) Descriptor() ([]byte, []int) {
+	return file_proto_docutil_search_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Highlight) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Highlight) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
 var File_proto_docutil_search_proto protoreflect.FileDescriptor
 
 var file_proto_docutil_search_proto_rawDesc = []byte{
@@ -1044,7 +1351,37 @@ var file_proto_docutil_search_proto_rawDesc = []byte{
 	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x15, 0x0a, 0x06, 0x69,
 	0x73, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x44,
-	0x69, 0x72, 0x2a, 0x22, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16,
+	0x69, 0x72, 0x22, 0x4f, 0x0a, 0x0d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70,
+	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x22, 0x5c, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x34, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x6f, 0x6e, 0x6f, 0x2e, 0x64, 0x6f,
+	0x63, 0x75, 0x74, 0x69, 0x6c, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x22, 0xa1, 0x01, 0x0a, 0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f,
+	0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x12, 0x31, 0x0a, 0x08, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x6f, 0x6e, 0x6f, 0x2e, 0x64, 0x6f, 0x63, 0x75,
+	0x74, 0x69, 0x6c, 0x2e, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x52, 0x08, 0x73, 0x6e, 0x69,
+	0x70, 0x70, 0x65, 0x74, 0x73, 0x22, 0x56, 0x0a, 0x07, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x12, 0x37, 0x0a, 0x0a, 0x68, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x6f, 0x6e, 0x6f, 0x2e,
+	0x64, 0x6f, 0x63, 0x75, 0x74, 0x69, 0x6c, 0x2e, 0x48, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68,
+	0x74, 0x52, 0x0a, 0x68, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x73, 0x22, 0x33, 0x0a,
+	0x09, 0x48, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x65,
+	0x6e, 0x64, 0x2a, 0x22, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16,
 	0x0a, 0x12, 0x46, 0x49, 0x4c, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x41, 0x52, 0x4b,
 	0x44, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x2a, 0x62, 0x0a, 0x08, 0x4c, 0x69, 0x6e, 0x6b, 0x54, 0x79,
 	0x70, 0x65, 0x12, 0x16, 0x0a, 0x12, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
@@ -1052,7 +1389,7 @@ var file_proto_docutil_search_proto_rawDesc = []byte{
 	0x4e, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49, 0x4e, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x53,
 	0x49, 0x54, 0x4f, 0x52, 0x59, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x4c, 0x49, 0x4e, 0x4b, 0x5f,
 	0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x45, 0x49, 0x47, 0x48, 0x42, 0x4f, 0x52, 0x5f, 0x52, 0x45,
-	0x50, 0x4f, 0x53, 0x49, 0x54, 0x4f, 0x52, 0x59, 0x10, 0x02, 0x32, 0x92, 0x04, 0x0a, 0x09, 0x44,
+	0x50, 0x4f, 0x53, 0x49, 0x54, 0x4f, 0x52, 0x59, 0x10, 0x02, 0x32, 0xd7, 0x04, 0x0a, 0x09, 0x44,
 	0x6f, 0x63, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x64, 0x0a, 0x11, 0x41, 0x76, 0x61, 0x69,
 	0x6c, 0x61, 0x62, 0x6c, 0x65, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x26, 0x2e,
 	0x6d, 0x6f, 0x6e, 0x6f, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x74, 0x69, 0x6c, 0x2e, 0x52, 0x65, 0x71,
@@ -1085,10 +1422,14 @@ var file_proto_docutil_search_proto_rawDesc = []byte{
 	0x64, 0x6f, 0x63, 0x75, 0x74, 0x69, 0x6c, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x47,
 	0x65, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x1a, 0x22, 0x2e, 0x6d, 0x6f,
 	0x6e, 0x6f, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x74, 0x69, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x47, 0x65, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x42,
-	0x1d, 0x5a, 0x1b, 0x67, 0x6f, 0x2e, 0x66, 0x31, 0x31, 0x30, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x6d,
-	0x6f, 0x6e, 0x6f, 0x2f, 0x67, 0x6f, 0x2f, 0x64, 0x6f, 0x63, 0x75, 0x74, 0x69, 0x6c, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x73, 0x65, 0x47, 0x65, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12,
+	0x43, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x1b, 0x2e, 0x6d, 0x6f, 0x6e, 0x6f,
+	0x2e, 0x64, 0x6f, 0x63, 0x75, 0x74, 0x69, 0x6c, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x1a, 0x1c, 0x2e, 0x6d, 0x6f, 0x6e, 0x6f, 0x2e, 0x64, 0x6f,
+	0x63, 0x75, 0x74, 0x69, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x42, 0x1d, 0x5a, 0x1b, 0x67, 0x6f, 0x2e, 0x66, 0x31, 0x31, 0x30, 0x2e,
+	0x64, 0x65, 0x76, 0x2f, 0x6d, 0x6f, 0x6e, 0x6f, 0x2f, 0x67, 0x6f, 0x2f, 0x64, 0x6f, 0x63, 0x75,
+	0x74, 0x69, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1104,7 +1445,7 @@ func file_proto_docutil_search_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_docutil_search_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_docutil_search_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_proto_docutil_search_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_proto_docutil_search_proto_goTypes = []interface{}{
 	(FileType)(0),                     // 0: mono.docutil.FileType
 	(LinkType)(0),                     // 1: mono.docutil.LinkType
@@ -1123,6 +1464,11 @@ var file_proto_docutil_search_proto_goTypes = []interface{}{
 	(*RequestGetDirectory)(nil),       // 14: mono.docutil.RequestGetDirectory
 	(*ResponseGetDirectory)(nil),      // 15: mono.docutil.ResponseGetDirectory
 	(*DirectoryEntry)(nil),            // 16: mono.docutil.DirectoryEntry
+	(*RequestSearch)(nil),             // 17: mono.docutil.RequestSearch
+	(*ResponseSearch)(nil),            // 18: mono.docutil.ResponseSearch
+	(*SearchResult)(nil),              // 19: mono.docutil.SearchResult
+	(*Snippet)(nil),                   // 20: mono.docutil.Snippet
+	(*Highlight)(nil),                 // 21: mono.docutil.Highlight
 }
 var file_proto_docutil_search_proto_depIdxs = []int32{
 	0,  // 0: mono.docutil.ResponseAvailableFeatures.supported_file_type:type_name -> mono.docutil.FileType
@@ -1134,23 +1480,28 @@ var file_proto_docutil_search_proto_depIdxs = []int32{
 	11, // 6: mono.docutil.ResponsePageLink.in:type_name -> mono.docutil.PageLink
 	11, // 7: mono.docutil.ResponsePageLink.out:type_name -> mono.docutil.PageLink
 	16, // 8: mono.docutil.ResponseGetDirectory.entries:type_name -> mono.docutil.DirectoryEntry
-	2,  // 9: mono.docutil.DocSearch.AvailableFeatures:input_type -> mono.docutil.RequestAvailableFeatures
-	4,  // 10: mono.docutil.DocSearch.ListRepository:input_type -> mono.docutil.RequestListRepository
-	6,  // 11: mono.docutil.DocSearch.GetRepository:input_type -> mono.docutil.RequestGetRepository
-	9,  // 12: mono.docutil.DocSearch.GetPage:input_type -> mono.docutil.RequestGetPage
-	12, // 13: mono.docutil.DocSearch.PageLink:input_type -> mono.docutil.RequestPageLink
-	14, // 14: mono.docutil.DocSearch.GetDirectory:input_type -> mono.docutil.RequestGetDirectory
-	3,  // 15: mono.docutil.DocSearch.AvailableFeatures:output_type -> mono.docutil.ResponseAvailableFeatures
-	5,  // 16: mono.docutil.DocSearch.ListRepository:output_type -> mono.docutil.ResponseListRepository
-	7,  // 17: mono.docutil.DocSearch.GetRepository:output_type -> mono.docutil.ResponseGetRepository
-	10, // 18: mono.docutil.DocSearch.GetPage:output_type -> mono.docutil.ResponseGetPage
-	13, // 19: mono.docutil.DocSearch.PageLink:output_type -> mono.docutil.ResponsePageLink
-	15, // 20: mono.docutil.DocSearch.GetDirectory:output_type -> mono.docutil.ResponseGetDirectory
-	15, // [15:21] is the sub-list for method output_type
-	9,  // [9:15] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	19, // 9: mono.docutil.ResponseSearch.results:type_name -> mono.docutil.SearchResult
+	20, // 10: mono.docutil.SearchResult.snippets:type_name -> mono.docutil.Snippet
+	21, // 11: mono.docutil.Snippet.highlights:type_name -> mono.docutil.Highlight
+	2,  // 12: mono.docutil.DocSearch.AvailableFeatures:input_type -> mono.docutil.RequestAvailableFeatures
+	4,  // 13: mono.docutil.DocSearch.ListRepository:input_type -> mono.docutil.RequestListRepository
+	6,  // 14: mono.docutil.DocSearch.GetRepository:input_type -> mono.docutil.RequestGetRepository
+	9,  // 15: mono.docutil.DocSearch.GetPage:input_type -> mono.docutil.RequestGetPage
+	12, // 16: mono.docutil.DocSearch.PageLink:input_type -> mono.docutil.RequestPageLink
+	14, // 17: mono.docutil.DocSearch.GetDirectory:input_type -> mono.docutil.RequestGetDirectory
+	17, // 18: mono.docutil.DocSearch.Search:input_type -> mono.docutil.RequestSearch
+	3,  // 19: mono.docutil.DocSearch.AvailableFeatures:output_type -> mono.docutil.ResponseAvailableFeatures
+	5,  // 20: mono.docutil.DocSearch.ListRepository:output_type -> mono.docutil.ResponseListRepository
+	7,  // 21: mono.docutil.DocSearch.GetRepository:output_type -> mono.docutil.ResponseGetRepository
+	10, // 22: mono.docutil.DocSearch.GetPage:output_type -> mono.docutil.ResponseGetPage
+	13, // 23: mono.docutil.DocSearch.PageLink:output_type -> mono.docutil.ResponsePageLink
+	15, // 24: mono.docutil.DocSearch.GetDirectory:output_type -> mono.docutil.ResponseGetDirectory
+	18, // 25: mono.docutil.DocSearch.Search:output_type -> mono.docutil.ResponseSearch
+	19, // [19:26] is the sub-list for method output_type
+	12, // [12:19] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_proto_docutil_search_proto_init() }
@@ -1339,6 +1690,66 @@ func file_proto_docutil_search_proto_init() {
 				return nil
 			}
 		}
+		file_proto_docutil_search_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestSearch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_docutil_search_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseSearch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_docutil_search_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_docutil_search_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Snippet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_docutil_search_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Highlight); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1346,7 +1757,7 @@ func file_proto_docutil_search_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_docutil_search_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   15,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1379,6 +1790,7 @@ type DocSearchClient interface {
 	GetPage(ctx context.Context, in *RequestGetPage, opts ...grpc.CallOption) (*ResponseGetPage, error)
 	PageLink(ctx context.Context, in *RequestPageLink, opts ...grpc.CallOption) (*ResponsePageLink, error)
 	GetDirectory(ctx context.Context, in *RequestGetDirectory, opts ...grpc.CallOption) (*ResponseGetDirectory, error)
+	Search(ctx context.Context, in *RequestSearch, opts ...grpc.CallOption) (*ResponseSearch, error)
 }
 
 type docSearchClient struct {
@@ -1443,6 +1855,15 @@ func (c *docSearchClient) GetDirectory(ctx context.Context, in *RequestGetDirect
 	return out, nil
 }
 
+func (c *docSearchClient) Search(ctx context.Context, in *RequestSearch, opts ...grpc.CallOption) (*ResponseSearch, error) {
+	out := new(ResponseSearch)
+	err := c.cc.Invoke(ctx, "/mono.docutil.DocSearch/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DocSearchServer is the server API for DocSearch service.
 type DocSearchServer interface {
 	AvailableFeatures(context.Context, *RequestAvailableFeatures) (*ResponseAvailableFeatures, error)
@@ -1451,6 +1872,7 @@ type DocSearchServer interface {
 	GetPage(context.Context, *RequestGetPage) (*ResponseGetPage, error)
 	PageLink(context.Context, *RequestPageLink) (*ResponsePageLink, error)
 	GetDirectory(context.Context, *RequestGetDirectory) (*ResponseGetDirectory, error)
+	Search(context.Context, *RequestSearch) (*ResponseSearch, error)
 }
 
 // UnimplementedDocSearchServer can be embedded to have forward compatible implementations.
@@ -1475,6 +1897,9 @@ func (*UnimplementedDocSearchServer) PageLink(context.Context, *RequestPageLink)
 func (*UnimplementedDocSearchServer) GetDirectory(context.Context, *RequestGetDirectory) (*ResponseGetDirectory, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDirectory not implemented")
 }
+func (*UnimplementedDocSearchServer) Search(context.Context, *RequestSearch) (*ResponseSearch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
 
 func RegisterDocSearchServer(s *grpc.Server, srv DocSearchServer) {
 	s.RegisterService(&_DocSearch_serviceDesc, srv)
@@ -1588,6 +2013,24 @@ func _DocSearch_GetDirectory_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DocSearch_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestSearch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocSearchServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mono.docutil.DocSearch/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocSearchServer).Search(ctx, req.(*RequestSearch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DocSearch_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "mono.docutil.DocSearch",
 	HandlerType: (*DocSearchServer)(nil),
@@ -1616,6 +2059,10 @@ var _DocSearch_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetDirectory",
 			Handler:    _DocSearch_GetDirectory_Handler,
 		},
+		{
+			MethodName: "Search",
+			Handler:    _DocSearch_Search_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/docutil/search.proto",
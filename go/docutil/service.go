@@ -58,6 +58,7 @@ type page struct {
 	Title   string
 	Doc     string
 	Path    string
+	Sha     string
 	LinkIn  []*PageLink
 	LinkOut []*PageLink
 	RawURL  string
@@ -74,7 +75,9 @@ type DocSearchService struct {
 
 	repositories []*git.Repository
 	// data is a cache data. The key of map is a name of the repository.
-	data map[string]*docSet
+	data   map[string]*docSet
+	dataMu sync.RWMutex
+	index  *invertedIndex
 
 	mu          sync.Mutex
 	titleCaches map[string]*titleCache
@@ -95,6 +98,7 @@ func NewDocSearchService(client git.GitDataClient, b ObjectStorageInterface) *Do
 		storage:        b,
 		markdownParser: markdownParser,
 		data:           make(map[string]*docSet),
+		index:          newInvertedIndex(),
 		httpClient:     &http.Client{Transport: transport},
 		titleCaches:    make(map[string]*titleCache),
 	}
@@ -105,11 +109,15 @@ var _ DocSearchServer = &DocSearchService{}
 func (d *DocSearchService) AvailableFeatures(_ context.Context, _ *RequestAvailableFeatures) (*ResponseAvailableFeatures, error) {
 	return &ResponseAvailableFeatures{
 		PageLink:          true,
+		FullTextSearch:    true,
 		SupportedFileType: docSearchServiceSupportFileTypes,
 	}, nil
 }
 
 func (d *DocSearchService) ListRepository(_ context.Context, req *RequestListRepository) (*ResponseListRepository, error) {
+	d.dataMu.RLock()
+	defer d.dataMu.RUnlock()
+
 	repositories := make([]*Repository, 0, len(d.data))
 	for name, docs := range d.data {
 		repositories = append(repositories, &Repository{
@@ -122,6 +130,9 @@ func (d *DocSearchService) ListRepository(_ context.Context, req *RequestListRep
 }
 
 func (d *DocSearchService) GetRepository(_ context.Context, req *RequestGetRepository) (*ResponseGetRepository, error) {
+	d.dataMu.RLock()
+	defer d.dataMu.RUnlock()
+
 	docs, ok := d.data[req.Repo]
 	if !ok {
 		return nil, xerrors.New("repository is not found")
@@ -136,6 +147,9 @@ func (d *DocSearchService) GetRepository(_ context.Context, req *RequestGetRepos
 }
 
 func (d *DocSearchService) GetPage(_ context.Context, req *RequestGetPage) (*ResponseGetPage, error) {
+	d.dataMu.RLock()
+	defer d.dataMu.RUnlock()
+
 	docs, ok := d.data[req.Repo]
 	if !ok {
 		return nil, xerrors.New("repository not found")
@@ -164,6 +178,9 @@ func (d *DocSearchService) GetPage(_ context.Context, req *RequestGetPage) (*Res
 }
 
 func (d *DocSearchService) PageLink(_ context.Context, req *RequestPageLink) (*ResponsePageLink, error) {
+	d.dataMu.RLock()
+	defer d.dataMu.RUnlock()
+
 	docs, ok := d.data[req.Repo]
 	if !ok {
 		return nil, xerrors.New("repository not found")
@@ -176,6 +193,9 @@ func (d *DocSearchService) PageLink(_ context.Context, req *RequestPageLink) (*R
 }
 
 func (d *DocSearchService) GetDirectory(_ context.Context, req *RequestGetDirectory) (*ResponseGetDirectory, error) {
+	d.dataMu.RLock()
+	defer d.dataMu.RUnlock()
+
 	docs, ok := d.data[req.Repo]
 	if !ok {
 		return nil, xerrors.New("repository not found")
@@ -220,6 +240,33 @@ func (d *DocSearchService) GetDirectory(_ context.Context, req *RequestGetDirect
 	return &ResponseGetDirectory{Entries: entries}, nil
 }
 
+func (d *DocSearchService) Search(_ context.Context, req *RequestSearch) (*ResponseSearch, error) {
+	if strings.TrimSpace(req.Query) == "" {
+		detail := &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "query", Description: "query is empty"},
+			},
+		}
+		st := status.New(codes.InvalidArgument, "query is empty")
+		if rpcErr, err := st.WithDetails(detail); err != nil {
+			return nil, status.Error(codes.Internal, "")
+		} else {
+			return nil, rpcErr.Err()
+		}
+	}
+	if req.Repo != "" {
+		d.dataMu.RLock()
+		_, ok := d.data[req.Repo]
+		d.dataMu.RUnlock()
+		if !ok {
+			return nil, xerrors.New("repository not found")
+		}
+	}
+
+	results, total := d.index.Search(req.Query, req.Repo, int(req.Limit))
+	return &ResponseSearch{Results: results, Total: int32(total)}, nil
+}
+
 func (d *DocSearchService) Initialize(ctx context.Context, workers, maxConns int) error {
 	q := queue.NewSimple[*pageLinkItem]()
 
@@ -448,9 +495,167 @@ func (d *DocSearchService) scanRepositories(ctx context.Context, workers int) er
 }
 
 func (d *DocSearchService) scanRepository(ctx context.Context, repo *git.Repository, workers int) error {
-	var mu sync.Mutex
-	docs := &docSet{Pages: make(pages), Repository: repo}
+	tree, err := d.client.GetTree(ctx, &git.RequestGetTree{
+		Repo:      repo.Name,
+		Ref:       plumbing.NewBranchReferenceName(repo.DefaultBranch).String(),
+		Path:      "/",
+		Recursive: true,
+	})
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	pages, err := d.makePages(ctx, repo, documentEntries(tree.Tree), workers)
+	if err != nil {
+		return err
+	}
+	docs := &docSet{Pages: pages, Repository: repo, Ref: plumbing.NewHash(tree.Sha)}
+	d.dataMu.Lock()
 	d.data[repo.Name] = docs
+	d.dataMu.Unlock()
+
+	d.index.RemoveRepository(repo.Name)
+	for _, v := range pages {
+		d.index.Add(repo.Name, v.Path, v.Title, v.Doc)
+	}
+	return nil
+}
+
+// StartUpdating updates the pages of all repositories periodically until ctx is canceled.
+func (d *DocSearchService) StartUpdating(ctx context.Context, interval time.Duration, workers int) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := d.updateRepositories(ctx, workers); err != nil {
+				logger.Log.Warn("Failed to update repositories", logger.Error(err))
+			}
+		}
+	}
+}
+
+func (d *DocSearchService) updateRepositories(ctx context.Context, workers int) error {
+	repos, err := d.client.ListRepositories(ctx, &git.RequestListRepositories{})
+	if err != nil {
+		return xerrors.WithMessage(err, "Failed to get list of repository")
+	}
+
+	exists := make(map[string]struct{})
+	for _, v := range repos.Repositories {
+		exists[v.Name] = struct{}{}
+		if err := d.updateRepository(ctx, v, workers); err != nil {
+			logger.Log.Warn("Failed to update the repository", logger.Error(err), zap.String("repo", v.Name))
+		}
+	}
+
+	d.dataMu.Lock()
+	d.repositories = repos.Repositories
+	for name := range d.data {
+		if _, ok := exists[name]; !ok {
+			delete(d.data, name)
+			d.index.RemoveRepository(name)
+		}
+	}
+	d.dataMu.Unlock()
+	return nil
+}
+
+// updateRepository re-parses only the pages which have been changed since the last scan.
+// The index of the changed pages and the deleted pages are updated too.
+func (d *DocSearchService) updateRepository(ctx context.Context, repo *git.Repository, workers int) error {
+	d.dataMu.RLock()
+	current, ok := d.data[repo.Name]
+	d.dataMu.RUnlock()
+	if !ok {
+		return d.scanRepository(ctx, repo, workers)
+	}
+
+	tree, err := d.client.GetTree(ctx, &git.RequestGetTree{
+		Repo:      repo.Name,
+		Ref:       plumbing.NewBranchReferenceName(repo.DefaultBranch).String(),
+		Path:      "/",
+		Recursive: true,
+	})
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	if current.Ref.String() == tree.Sha {
+		return nil
+	}
+
+	newPages := make(pages)
+	var changed []*git.TreeEntry
+	for _, v := range documentEntries(tree.Tree) {
+		if p, ok := current.Pages[v.Path]; ok && p.Sha == v.Sha {
+			n := *p
+			n.LinkIn = nil
+			newPages[v.Path] = &n
+			continue
+		}
+		changed = append(changed, v)
+	}
+	changedPages, err := d.makePages(ctx, repo, changed, workers)
+	if err != nil {
+		return err
+	}
+	for k, v := range changedPages {
+		newPages[k] = v
+	}
+	var deleted []string
+	for k := range current.Pages {
+		if _, ok := newPages[k]; !ok {
+			deleted = append(deleted, k)
+		}
+	}
+
+	d.dataMu.Lock()
+	d.data[repo.Name] = &docSet{Pages: newPages, Repository: repo, Ref: plumbing.NewHash(tree.Sha)}
+	// The links to the page may be changed. Thus, the cited links of all pages are re-computed.
+	for _, docs := range d.data {
+		for _, v := range docs.Pages {
+			v.LinkIn = nil
+		}
+	}
+	d.interpolateCitedLinks()
+	for _, v := range changedPages {
+		for _, link := range v.LinkOut {
+			switch link.Type {
+			case LinkType_LINK_TYPE_IN_REPOSITORY, LinkType_LINK_TYPE_NEIGHBOR_REPOSITORY:
+				d.interpolateLinkTitleUnderRepository(repo.Name, link, v.Path)
+			}
+		}
+	}
+	d.dataMu.Unlock()
+
+	for _, v := range changedPages {
+		d.index.Add(repo.Name, v.Path, v.Title, v.Doc)
+	}
+	for _, v := range deleted {
+		d.index.Remove(repo.Name, v)
+	}
+	logger.Log.Debug("Update the repository", zap.String("repo", repo.Name), zap.Int("changed", len(changedPages)), zap.Int("deleted", len(deleted)))
+	return nil
+}
+
+// documentEntries returns the entries of the supported documents.
+func documentEntries(entries []*git.TreeEntry) []*git.TreeEntry {
+	var docs []*git.TreeEntry
+	for _, v := range entries {
+		switch filepath.Ext(v.Path) {
+		case ".md":
+			docs = append(docs, v)
+		}
+	}
+	return docs
+}
+
+func (d *DocSearchService) makePages(ctx context.Context, repo *git.Repository, entries []*git.TreeEntry, workers int) (pages, error) {
+	var mu sync.Mutex
+	result := make(pages)
 
 	ch := make(chan *git.TreeEntry, workers)
 	var wg sync.WaitGroup
@@ -469,35 +674,22 @@ func (d *DocSearchService) scanRepository(ctx context.Context, repo *git.Reposit
 					logger.Log.Error("Failed to make page", logger.Error(err))
 				} else {
 					mu.Lock()
-					docs.Pages[entry.Path] = page
+					result[entry.Path] = page
 					mu.Unlock()
 				}
 			}
 		}()
 	}
 
-	tree, err := d.client.GetTree(ctx, &git.RequestGetTree{
-		Repo:      repo.Name,
-		Ref:       plumbing.NewBranchReferenceName(repo.DefaultBranch).String(),
-		Path:      "/",
-		Recursive: true,
-	})
-	if err != nil {
-		return xerrors.WithStack(err)
-	}
-	docs.Ref = plumbing.NewHash(tree.Sha)
-
-	for _, v := range tree.Tree {
-		switch filepath.Ext(v.Path) {
-		case ".md":
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			default:
-			}
-
-			ch <- v
+	for _, v := range entries {
+		select {
+		case <-ctx.Done():
+			close(ch)
+			return nil, ctx.Err()
+		default:
 		}
+
+		ch <- v
 	}
 	close(ch)
 
@@ -510,9 +702,9 @@ func (d *DocSearchService) scanRepository(ctx context.Context, repo *git.Reposit
 
 	select {
 	case <-timeout:
-		return xerrors.New("timed out to scan the repository")
+		return nil, xerrors.New("timed out to scan the repository")
 	case <-done:
-		return nil
+		return result, nil
 	}
 }
 
@@ -527,6 +719,7 @@ func (d *DocSearchService) makePage(ctx context.Context, repo *git.Repository, p
 	if err != nil {
 		return nil, xerrors.WithMessage(err, "Failed to parse markdown")
 	}
+	page.Sha = sha
 
 	return page, nil
 }
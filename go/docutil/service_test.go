@@ -60,6 +60,63 @@ func TestCitedLink(t *testing.T) {
 	assert.Len(t, service.data["mono"].Pages["docs/page1.md"].LinkIn, 1)
 }
 
+func TestDocSearchService_Search(t *testing.T) {
+	mock := &mockGitClient{Blobs: map[string]string{
+		"README.md":      "# Mono\nThe repository contains many tools.\n",
+		"docs/bazel.md":  "# Bazel\nHow to use Bazel.\n",
+		"docs/remove.md": "# Remove\nThis page will be removed.\n",
+	}}
+	service := NewDocSearchService(mock, nil)
+	repo := &git.Repository{Name: "mono", DefaultBranch: "master"}
+	err := service.scanRepository(context.Background(), repo, 1)
+	require.NoError(t, err)
+
+	features, err := service.AvailableFeatures(context.Background(), &RequestAvailableFeatures{})
+	require.NoError(t, err)
+	assert.True(t, features.FullTextSearch)
+
+	res, err := service.Search(context.Background(), &RequestSearch{Query: "bazel"})
+	require.NoError(t, err)
+	require.Len(t, res.Results, 1)
+	assert.Equal(t, "docs/bazel.md", res.Results[0].Path)
+	assert.Equal(t, "Bazel", res.Results[0].Title)
+
+	_, err = service.Search(context.Background(), &RequestSearch{Query: " "})
+	assert.Error(t, err)
+	_, err = service.Search(context.Background(), &RequestSearch{Query: "bazel", Repo: "unknown"})
+	assert.Error(t, err)
+
+	// Update the page and remove the page
+	var entries []*git.TreeEntry
+	for _, v := range mock.treeEntry {
+		switch v.Path {
+		case "docs/bazel.md":
+			entries = append(entries, &git.TreeEntry{Path: v.Path, Sha: "updated"})
+		case "docs/remove.md":
+		default:
+			entries = append(entries, v)
+		}
+	}
+	mock.treeEntry = entries
+	mock.Blobs["docs/bazel.md"] = "# Build\nHow to use Go.\n"
+	readme := service.data["mono"].Pages["README.md"]
+	err = service.updateRepository(context.Background(), repo, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Build", service.data["mono"].Pages["docs/bazel.md"].Title)
+	assert.NotContains(t, service.data["mono"].Pages, "docs/remove.md")
+	assert.Equal(t, readme.Doc, service.data["mono"].Pages["README.md"].Doc)
+	res, err = service.Search(context.Background(), &RequestSearch{Query: "bazel"})
+	require.NoError(t, err)
+	assert.Len(t, res.Results, 0)
+	res, err = service.Search(context.Background(), &RequestSearch{Query: "removed"})
+	require.NoError(t, err)
+	assert.Len(t, res.Results, 0)
+	res, err = service.Search(context.Background(), &RequestSearch{Query: "go", Repo: "mono"})
+	require.NoError(t, err)
+	assert.Len(t, res.Results, 1)
+}
+
 type mockGitClient struct {
 	Blobs map[string]string
 
@@ -140,3 +197,18 @@ func (m *mockGitClient) Stat(ctx context.Context, in *git.RequestStat, opts ...g
 	// TODO: implement me
 	panic("implement me")
 }
+
+func (m *mockGitClient) ListCommits(ctx context.Context, in *git.RequestListCommits, opts ...grpc.CallOption) (*git.ResponseListCommits, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m *mockGitClient) GetDiff(ctx context.Context, in *git.RequestGetDiff, opts ...grpc.CallOption) (*git.ResponseGetDiff, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m *mockGitClient) GetBlame(ctx context.Context, in *git.RequestGetBlame, opts ...grpc.CallOption) (*git.ResponseGetBlame, error) {
+	//TODO implement me
+	panic("implement me")
+}
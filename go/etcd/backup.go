@@ -52,7 +52,12 @@ func NewBackup(ctx context.Context, endpoints []string, caCert *x509.Certificate
 	}
 
 	logger.Log.Info("Succeeded snapshot")
-	return &Backup{data: data, time: time.Now()}, nil
+	return NewBackupFromReader(data), nil
+}
+
+// NewBackupFromReader returns Backup which has the snapshot read from data.
+func NewBackupFromReader(data io.ReadCloser) *Backup {
+	return &Backup{data: data, time: time.Now()}
 }
 
 func (b *Backup) Compressed() (io.Reader, error) {
@@ -76,6 +81,9 @@ func (b *Backup) Compressed() (io.Reader, error) {
 	} else if n != len(buf) {
 		return nil, io.ErrShortWrite
 	}
+	if err := w.Close(); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
 
 	b.compressed = writeBuffer
 	return writeBuffer, nil
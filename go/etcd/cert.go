@@ -13,9 +13,21 @@ func ReadCACertificate(path string) (*x509.Certificate, error) {
 	if err != nil {
 		return nil, xerrors.WithStack(err)
 	}
+	cer, err := ParseCACertificate(b)
+	if err != nil {
+		return nil, xerrors.Definef("internal: %s: %v", path, err).WithStack()
+	}
+	return cer, nil
+}
+
+// ParseCACertificate parses the first certificate in PEM encoded b.
+func ParseCACertificate(b []byte) (*x509.Certificate, error) {
 	var data []byte
 	for {
 		block, rest := pem.Decode(b)
+		if block == nil {
+			break
+		}
 		b = rest
 		if block.Type == "CERTIFICATE" {
 			data = block.Bytes
@@ -26,7 +38,7 @@ func ReadCACertificate(path string) (*x509.Certificate, error) {
 		}
 	}
 	if data == nil {
-		return nil, xerrors.Definef("internal: %s not contain certificate", path).WithStack()
+		return nil, xerrors.Define("not contain certificate").WithStack()
 	}
 
 	cer, err := x509.ParseCertificate(data)
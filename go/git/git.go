@@ -52,6 +52,18 @@ func Clone(ctx context.Context, appId, installationId int64, privateKeyFile, dir
 	}
 }
 
+// CloneWithToken clones the repository with the access token.
+// The token is sent as the password of basic authentication. Gitea and GitLab accept the access token as the password.
+func CloneWithToken(ctx context.Context, tokenFile, dir, repo, commit string) error {
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	auth := &gogitHttp.BasicAuth{Username: "oauth2", Password: strings.TrimSpace(string(token))}
+
+	return cloneByGit(ctx, dir, repo, commit, 1, auth)
+}
+
 func checkoutCommit(ctx context.Context, dir, u, commit string, rt http.RoundTripper) error {
 	addr := u
 	if strings.HasSuffix(u, ".git") {
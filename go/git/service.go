@@ -6,11 +6,13 @@ import (
 	"io"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
 
 	goGit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"go.f110.dev/xerrors"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -21,6 +23,13 @@ import (
 	"go.f110.dev/mono/go/enumerable"
 )
 
+const (
+	defaultCommitsPageSize = 30
+	maxCommitsPageSize     = 100
+	// diffContextLines is the number of the unchanged lines around the changes in the hunk.
+	diffContextLines = 3
+)
+
 type DataService struct {
 	repo map[string]*goGit.Repository
 }
@@ -164,28 +173,7 @@ func (g *DataService) GetCommit(_ context.Context, req *RequestGetCommit) (*Resp
 		return nil, err
 	}
 
-	res := &ResponseGetCommit{
-		Commit: &Commit{
-			Sha:     commit.Hash.String(),
-			Message: commit.Message,
-			Committer: &Signature{
-				Name:  commit.Committer.Name,
-				Email: commit.Committer.Email,
-				When:  timestamppb.New(commit.Committer.When),
-			},
-			Author: &Signature{
-				Name:  commit.Author.Name,
-				Email: commit.Author.Email,
-				When:  timestamppb.New(commit.Author.When),
-			},
-			Tree: commit.TreeHash.String(),
-		},
-	}
-	if len(commit.ParentHashes) > 0 {
-		res.Commit.Parents = enumerable.Map(commit.ParentHashes, func(t plumbing.Hash) string { return t.String() })
-	}
-
-	return res, nil
+	return &ResponseGetCommit{Commit: newCommit(commit)}, nil
 }
 
 func (g *DataService) GetTree(_ context.Context, req *RequestGetTree) (*ResponseGetTree, error) {
@@ -484,3 +472,336 @@ func (g *DataService) ListBranch(_ context.Context, req *RequestListBranch) (*Re
 
 	return res, nil
 }
+
+func (g *DataService) ListCommits(ctx context.Context, req *RequestListCommits) (*ResponseListCommits, error) {
+	repo, ok := g.repo[req.Repo]
+	if !ok {
+		return nil, xerrors.New("repository not found")
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultCommitsPageSize
+	}
+	if pageSize > maxCommitsPageSize {
+		pageSize = maxCommitsPageSize
+	}
+
+	var from plumbing.Hash
+	var offset int
+	if req.PageToken != "" {
+		// The page token holds the commit hash of the first page and the offset.
+		// The hash is pinned because the ref may move between the requests.
+		h, o, err := parseCommitsPageToken(req.PageToken)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		from, offset = h, o
+	} else {
+		commit, err := resolveCommit(repo, req.Ref)
+		if err != nil {
+			return nil, err
+		}
+		from = commit.Hash
+	}
+
+	opt := &goGit.LogOptions{From: from}
+	if p := strings.Trim(req.Path, "/"); p != "" {
+		opt.PathFilter = func(s string) bool {
+			return s == p || strings.HasPrefix(s, p+"/")
+		}
+	}
+	iter, err := repo.Log(opt)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	defer iter.Close()
+
+	res := &ResponseListCommits{}
+	for i := 0; ; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		commit, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		if i < offset {
+			continue
+		}
+		if len(res.Commits) == pageSize {
+			res.NextPageToken = fmt.Sprintf("%s:%d", from.String(), i)
+			break
+		}
+		res.Commits = append(res.Commits, newCommit(commit))
+	}
+
+	return res, nil
+}
+
+func (g *DataService) GetDiff(ctx context.Context, req *RequestGetDiff) (*ResponseGetDiff, error) {
+	repo, ok := g.repo[req.Repo]
+	if !ok {
+		return nil, xerrors.New("repository not found")
+	}
+	if req.Head == "" {
+		return nil, xerrors.New("head field is required")
+	}
+
+	head, err := resolveCommit(repo, req.Head)
+	if err != nil {
+		return nil, err
+	}
+	var base *object.Commit
+	if req.Base != "" {
+		c, err := resolveCommit(repo, req.Base)
+		if err != nil {
+			return nil, err
+		}
+		base = c
+	} else if len(head.ParentHashes) > 0 {
+		c, err := repo.CommitObject(head.ParentHashes[0])
+		if err != nil {
+			return nil, xerrors.WithMessage(err, "failed to get the parent commit")
+		}
+		base = c
+	}
+
+	// baseTree remains nil if the head is the root commit. In that case, all files are added.
+	var baseTree *object.Tree
+	if base != nil {
+		t, err := base.Tree()
+		if err != nil {
+			return nil, xerrors.WithMessage(err, "failed to get tree")
+		}
+		baseTree = t
+	}
+	headTree, err := head.Tree()
+	if err != nil {
+		return nil, xerrors.WithMessage(err, "failed to get tree")
+	}
+	changes, err := object.DiffTreeWithOptions(ctx, baseTree, headTree, object.DefaultDiffTreeOptions)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	patch, err := changes.PatchContext(ctx)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	res := &ResponseGetDiff{Head: head.Hash.String()}
+	if base != nil {
+		res.Base = base.Hash.String()
+	}
+	for _, fp := range patch.FilePatches() {
+		res.Files = append(res.Files, newFileDiff(fp))
+	}
+	return res, nil
+}
+
+func (g *DataService) GetBlame(_ context.Context, req *RequestGetBlame) (*ResponseGetBlame, error) {
+	repo, ok := g.repo[req.Repo]
+	if !ok {
+		return nil, xerrors.New("repository not found")
+	}
+	p := strings.TrimPrefix(req.Path, "/")
+	if p == "" {
+		return nil, status.Error(codes.InvalidArgument, "path is required")
+	}
+
+	commit, err := resolveCommit(repo, req.Ref)
+	if err != nil {
+		return nil, err
+	}
+	result, err := goGit.Blame(commit, p)
+	if err != nil {
+		return nil, xerrors.WithMessagef(err, "failed to blame %s", p)
+	}
+
+	res := &ResponseGetBlame{Sha: result.Rev.String()}
+	for i, line := range result.Lines {
+		res.Lines = append(res.Lines, &BlameLine{
+			LineNumber: int32(i + 1),
+			Sha:        line.Hash.String(),
+			Author: &Signature{
+				Name:  line.AuthorName,
+				Email: line.Author,
+				When:  timestamppb.New(line.Date),
+			},
+			Content: line.Text,
+		})
+	}
+	return res, nil
+}
+
+// resolveCommit returns the commit object which is pointed by ref.
+// ref is a name of the reference or a commit hash.
+func resolveCommit(repo *goGit.Repository, ref string) (*object.Commit, error) {
+	var commitHash plumbing.Hash
+	if plumbing.IsHash(ref) {
+		commitHash = plumbing.NewHash(ref)
+	} else {
+		r, err := repo.Reference(plumbing.ReferenceName(ref), true)
+		if err != nil {
+			return nil, xerrors.New("ref is not found")
+		}
+		commitHash = r.Hash()
+	}
+
+	commit, err := repo.CommitObject(commitHash)
+	if err != nil {
+		return nil, xerrors.New("commit is not found")
+	}
+	return commit, nil
+}
+
+func parseCommitsPageToken(token string) (plumbing.Hash, int, error) {
+	h, o, ok := strings.Cut(token, ":")
+	if !ok || !plumbing.IsHash(h) {
+		return plumbing.ZeroHash, 0, xerrors.New("malformed token")
+	}
+	offset, err := strconv.Atoi(o)
+	if err != nil || offset < 0 {
+		return plumbing.ZeroHash, 0, xerrors.New("malformed token")
+	}
+	return plumbing.NewHash(h), offset, nil
+}
+
+func newCommit(commit *object.Commit) *Commit {
+	c := &Commit{
+		Sha:     commit.Hash.String(),
+		Message: commit.Message,
+		Committer: &Signature{
+			Name:  commit.Committer.Name,
+			Email: commit.Committer.Email,
+			When:  timestamppb.New(commit.Committer.When),
+		},
+		Author: &Signature{
+			Name:  commit.Author.Name,
+			Email: commit.Author.Email,
+			When:  timestamppb.New(commit.Author.When),
+		},
+		Tree: commit.TreeHash.String(),
+	}
+	if len(commit.ParentHashes) > 0 {
+		c.Parents = enumerable.Map(commit.ParentHashes, func(t plumbing.Hash) string { return t.String() })
+	}
+	return c
+}
+
+// newFileDiff converts the patch of the file to FileDiff.
+// The chunks of go-git are the runs of the same operation over the whole file,
+// so they are split into the hunks with diffContextLines lines of context like the unified diff.
+func newFileDiff(fp diff.FilePatch) *FileDiff {
+	fd := &FileDiff{IsBinary: fp.IsBinary()}
+	from, to := fp.Files()
+	if from != nil {
+		fd.From = from.Path()
+	}
+	if to != nil {
+		fd.To = to.Path()
+	}
+
+	var lines []*DiffLine
+	for _, chunk := range fp.Chunks() {
+		var t DiffLineType
+		switch chunk.Type() {
+		case diff.Add:
+			t = DiffLineType_DIFF_LINE_TYPE_ADD
+		case diff.Delete:
+			t = DiffLineType_DIFF_LINE_TYPE_DELETE
+		default:
+			t = DiffLineType_DIFF_LINE_TYPE_CONTEXT
+		}
+		for _, l := range splitLines(chunk.Content()) {
+			lines = append(lines, &DiffLine{Type: t, Content: l})
+			switch t {
+			case DiffLineType_DIFF_LINE_TYPE_ADD:
+				fd.Additions++
+			case DiffLineType_DIFF_LINE_TYPE_DELETE:
+				fd.Deletions++
+			}
+		}
+	}
+
+	var hunk *Hunk
+	// oldLine and newLine are the 1-origin line numbers of lines[i].
+	oldLine, newLine := 1, 1
+	// lastChange is the index of the last changed line in the current hunk.
+	lastChange := -1
+	for i, l := range lines {
+		if l.Type != DiffLineType_DIFF_LINE_TYPE_CONTEXT {
+			if hunk == nil {
+				start := i - diffContextLines
+				if start < 0 {
+					start = 0
+				}
+				hunk = &Hunk{OldStart: int32(oldLine - (i - start)), NewStart: int32(newLine - (i - start))}
+				for _, c := range lines[start:i] {
+					hunk.Lines = append(hunk.Lines, c)
+				}
+				hunk.OldLines, hunk.NewLines = int32(i-start), int32(i-start)
+			}
+			lastChange = i
+		}
+
+		if hunk != nil && i > lastChange {
+			if i-lastChange > 2*diffContextLines {
+				// The gap to the next change is too long. Close the hunk with the trailing context.
+				hunk.Lines = hunk.Lines[:len(hunk.Lines)-diffContextLines]
+				hunk.OldLines -= diffContextLines
+				hunk.NewLines -= diffContextLines
+				fd.Hunks = append(fd.Hunks, hunk)
+				hunk = nil
+			}
+		}
+		if hunk != nil {
+			hunk.Lines = append(hunk.Lines, l)
+			switch l.Type {
+			case DiffLineType_DIFF_LINE_TYPE_ADD:
+				hunk.NewLines++
+			case DiffLineType_DIFF_LINE_TYPE_DELETE:
+				hunk.OldLines++
+			default:
+				hunk.OldLines++
+				hunk.NewLines++
+			}
+		}
+
+		switch l.Type {
+		case DiffLineType_DIFF_LINE_TYPE_ADD:
+			newLine++
+		case DiffLineType_DIFF_LINE_TYPE_DELETE:
+			oldLine++
+		default:
+			oldLine++
+			newLine++
+		}
+	}
+	if hunk != nil {
+		if trailing := len(lines) - 1 - lastChange; trailing > diffContextLines {
+			n := trailing - diffContextLines
+			hunk.Lines = hunk.Lines[:len(hunk.Lines)-n]
+			hunk.OldLines -= int32(n)
+			hunk.NewLines -= int32(n)
+		}
+		fd.Hunks = append(fd.Hunks, hunk)
+	}
+
+	return fd
+}
+
+// splitLines splits s into the lines without the line feed.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
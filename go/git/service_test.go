@@ -2,9 +2,13 @@ package git
 
 import (
 	"context"
+	"fmt"
 	"net"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	goGit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -261,6 +265,136 @@ func TestStat(t *testing.T) {
 	}
 }
 
+func TestListCommits(t *testing.T) {
+	mockStorage := storage.NewMock()
+	repo := makeSourceRepository(t)
+	for i := 0; i < 3; i++ {
+		commitFile(t, repo, "README.md", fmt.Sprintf("Hello %d", i), fmt.Sprintf("Update README %d", i))
+	}
+	commitFile(t, repo, "docs/README.md", "Updated docs", "Update docs")
+	conn := startServer(t, mockStorage, map[string]*goGit.Repository{"test/test1": repo})
+	gitData := NewGitDataClient(conn)
+
+	ref := plumbing.NewBranchReferenceName("master").String()
+	res, err := gitData.ListCommits(context.Background(), &RequestListCommits{Repo: "test1", Ref: ref, PageSize: 3})
+	require.NoError(t, err)
+	if assert.Len(t, res.Commits, 3) {
+		assert.Equal(t, "Update docs", res.Commits[0].Message)
+		assert.Equal(t, "Update README 2", res.Commits[1].Message)
+	}
+	require.NotEmpty(t, res.NextPageToken)
+
+	// The new commit doesn't affect the next page.
+	commitFile(t, repo, "README.md", "Hello world", "Update README again")
+	res, err = gitData.ListCommits(context.Background(), &RequestListCommits{Repo: "test1", Ref: ref, PageSize: 3, PageToken: res.NextPageToken})
+	require.NoError(t, err)
+	if assert.Len(t, res.Commits, 2) {
+		assert.Equal(t, "Update README 0", res.Commits[0].Message)
+		assert.Equal(t, "Init", res.Commits[1].Message)
+	}
+	assert.Empty(t, res.NextPageToken)
+
+	res, err = gitData.ListCommits(context.Background(), &RequestListCommits{Repo: "test1", Ref: ref, Path: "docs"})
+	require.NoError(t, err)
+	if assert.Len(t, res.Commits, 2) {
+		assert.Equal(t, "Update docs", res.Commits[0].Message)
+		assert.Equal(t, "Init", res.Commits[1].Message)
+	}
+
+	_, err = gitData.ListCommits(context.Background(), &RequestListCommits{Repo: "test1", Ref: ref, PageToken: "invalid"})
+	assert.Error(t, err)
+}
+
+func TestGetDiff(t *testing.T) {
+	mockStorage := storage.NewMock()
+	repo := makeSourceRepository(t)
+	var lines []string
+	for i := 1; i <= 20; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	base := commitFile(t, repo, "file.txt", strings.Join(lines, "\n")+"\n", "Add file")
+	lines[1] = "changed 2"
+	lines[17] = "changed 18"
+	head := commitFile(t, repo, "file.txt", strings.Join(lines, "\n")+"\n", "Update file")
+	conn := startServer(t, mockStorage, map[string]*goGit.Repository{"test/test1": repo})
+	gitData := NewGitDataClient(conn)
+
+	res, err := gitData.GetDiff(context.Background(), &RequestGetDiff{Repo: "test1", Head: head.String()})
+	require.NoError(t, err)
+	assert.Equal(t, base.String(), res.Base)
+	require.Len(t, res.Files, 1)
+	file := res.Files[0]
+	assert.Equal(t, "file.txt", file.From)
+	assert.Equal(t, "file.txt", file.To)
+	assert.EqualValues(t, 2, file.Additions)
+	assert.EqualValues(t, 2, file.Deletions)
+	require.Len(t, file.Hunks, 2)
+	assert.Equal(t, &Hunk{OldStart: 1, OldLines: 5, NewStart: 1, NewLines: 5}, &Hunk{
+		OldStart: file.Hunks[0].OldStart, OldLines: file.Hunks[0].OldLines,
+		NewStart: file.Hunks[0].NewStart, NewLines: file.Hunks[0].NewLines,
+	})
+	assert.Equal(t, &Hunk{OldStart: 15, OldLines: 6, NewStart: 15, NewLines: 6}, &Hunk{
+		OldStart: file.Hunks[1].OldStart, OldLines: file.Hunks[1].OldLines,
+		NewStart: file.Hunks[1].NewStart, NewLines: file.Hunks[1].NewLines,
+	})
+	if assert.Len(t, file.Hunks[0].Lines, 6) {
+		assert.Equal(t, "line 1", file.Hunks[0].Lines[0].Content)
+		assert.Equal(t, DiffLineType_DIFF_LINE_TYPE_DELETE, file.Hunks[0].Lines[1].Type)
+		assert.Equal(t, "line 2", file.Hunks[0].Lines[1].Content)
+		assert.Equal(t, DiffLineType_DIFF_LINE_TYPE_ADD, file.Hunks[0].Lines[2].Type)
+		assert.Equal(t, "changed 2", file.Hunks[0].Lines[2].Content)
+	}
+
+	// The root commit is compared with the empty tree.
+	commits, err := gitData.ListCommits(context.Background(), &RequestListCommits{Repo: "test1", Ref: head.String()})
+	require.NoError(t, err)
+	root := commits.Commits[len(commits.Commits)-1]
+	res, err = gitData.GetDiff(context.Background(), &RequestGetDiff{Repo: "test1", Head: root.Sha})
+	require.NoError(t, err)
+	assert.Empty(t, res.Base)
+	assert.Len(t, res.Files, 3)
+	for _, v := range res.Files {
+		assert.Empty(t, v.From)
+		assert.NotEmpty(t, v.To)
+	}
+}
+
+func TestGetBlame(t *testing.T) {
+	mockStorage := storage.NewMock()
+	repo := makeSourceRepository(t)
+	first := commitFile(t, repo, "file.txt", "foo\nbar\n", "Add file")
+	second := commitFile(t, repo, "file.txt", "foo\nbaz\n", "Update file")
+	conn := startServer(t, mockStorage, map[string]*goGit.Repository{"test/test1": repo})
+	gitData := NewGitDataClient(conn)
+
+	res, err := gitData.GetBlame(context.Background(), &RequestGetBlame{Repo: "test1", Ref: plumbing.NewBranchReferenceName("master").String(), Path: "/file.txt"})
+	require.NoError(t, err)
+	assert.Equal(t, second.String(), res.Sha)
+	if assert.Len(t, res.Lines, 2) {
+		assert.EqualValues(t, 1, res.Lines[0].LineNumber)
+		assert.Equal(t, "foo", res.Lines[0].Content)
+		assert.Equal(t, first.String(), res.Lines[0].Sha)
+		assert.Equal(t, "baz", res.Lines[1].Content)
+		assert.Equal(t, second.String(), res.Lines[1].Sha)
+		assert.Equal(t, "test@localhost", res.Lines[1].Author.Email)
+	}
+}
+
+func commitFile(t *testing.T, repo *goGit.Repository, name, content, message string) plumbing.Hash {
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(wt.Filesystem.Root(), name), []byte(content), 0644)
+	require.NoError(t, err)
+	_, err = wt.Add(name)
+	require.NoError(t, err)
+	h, err := wt.Commit(message, &goGit.CommitOptions{
+		Author:    &object.Signature{Name: t.Name(), When: time.Now(), Email: "test@localhost"},
+		Committer: &object.Signature{Name: t.Name(), When: time.Now(), Email: "test@localhost"},
+	})
+	require.NoError(t, err)
+	return h
+}
+
 func startServer(t *testing.T, st *storage.Mock, repos map[string]*goGit.Repository) *grpc.ClientConn {
 	repo := make(map[string]*goGit.Repository)
 	for k, v := range repos {
@@ -0,0 +1,925 @@
+package git
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/format/pktline"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/sideband"
+	"go.f110.dev/xerrors"
+
+	"go.f110.dev/mono/go/logger"
+)
+
+const (
+	uploadPackService = "git-upload-pack"
+	smartHTTPAgent    = "agent=mono-git-data-service"
+	// packWindow is the size of the sliding window for the delta compression.
+	packWindow = 10
+)
+
+var uploadPackCapabilities = []string{
+	"multi_ack_detailed",
+	"side-band",
+	"side-band-64k",
+	"ofs-delta",
+	"shallow",
+	"deepen-since",
+	"deepen-not",
+	"deepen-relative",
+	"no-progress",
+	"allow-tip-sha1-in-want",
+	"allow-reachable-sha1-in-want",
+	"filter",
+}
+
+// SmartHTTPServer serves the repositories by the git smart HTTP protocol.
+// SmartHTTPServer is read-only. Thus, only git-upload-pack is supported and push is always rejected.
+//
+// The protocol version 0 is implemented. The client which requests the protocol version 2 will fall back to version 0.
+type SmartHTTPServer struct {
+	repo map[string]*goGit.Repository
+}
+
+var _ http.Handler = &SmartHTTPServer{}
+
+func NewSmartHTTPServer(repo map[string]*goGit.Repository) *SmartHTTPServer {
+	return &SmartHTTPServer{repo: repo}
+}
+
+func (s *SmartHTTPServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/info/refs"):
+		repo, ok := s.repository(strings.TrimSuffix(req.URL.Path, "/info/refs"))
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		if req.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		switch req.URL.Query().Get("service") {
+		case uploadPackService:
+		case "":
+			http.Error(w, "The dumb protocol is not supported", http.StatusForbidden)
+			return
+		default:
+			http.Error(w, "The repository is read-only", http.StatusForbidden)
+			return
+		}
+		s.advertiseRefs(w, repo)
+	case strings.HasSuffix(req.URL.Path, "/"+uploadPackService):
+		repo, ok := s.repository(strings.TrimSuffix(req.URL.Path, "/"+uploadPackService))
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		if req.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.uploadPack(w, req, repo)
+	case strings.HasSuffix(req.URL.Path, "/git-receive-pack"):
+		http.Error(w, "The repository is read-only", http.StatusForbidden)
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func (s *SmartHTTPServer) repository(p string) (*goGit.Repository, bool) {
+	name := strings.TrimSuffix(strings.TrimPrefix(p, "/"), ".git")
+	repo, ok := s.repo[name]
+	return repo, ok
+}
+
+func (s *SmartHTTPServer) advertiseRefs(w http.ResponseWriter, repo *goGit.Repository) {
+	refs, err := advertisedReferences(repo)
+	if err != nil {
+		logger.Log.Error("Failed to get references", logger.Error(err))
+		http.Error(w, "Failed to get references", http.StatusInternalServerError)
+		return
+	}
+
+	caps := append([]string{}, uploadPackCapabilities...)
+	if head, err := repo.Reference(plumbing.HEAD, false); err == nil && head.Type() == plumbing.SymbolicReference {
+		caps = append(caps, "symref=HEAD:"+head.Target().String())
+	}
+	caps = append(caps, smartHTTPAgent)
+
+	w.Header().Set("Content-Type", "application/x-git-upload-pack-advertisement")
+	w.Header().Set("Cache-Control", "no-cache")
+	e := pktline.NewEncoder(w)
+	if err := e.EncodeString("# service=" + uploadPackService + "\n"); err != nil {
+		return
+	}
+	if err := e.Flush(); err != nil {
+		return
+	}
+	if len(refs) == 0 {
+		// The repository has no reference. The capabilities are sent with the zero id.
+		if err := e.Encodef("%s capabilities^{}\x00%s\n", plumbing.ZeroHash.String(), strings.Join(caps, " ")); err != nil {
+			return
+		}
+		_ = e.Flush()
+		return
+	}
+	for i, ref := range refs {
+		if i == 0 {
+			err = e.Encodef("%s %s\x00%s\n", ref.Hash.String(), ref.Name, strings.Join(caps, " "))
+		} else {
+			err = e.Encodef("%s %s\n", ref.Hash.String(), ref.Name)
+		}
+		if err != nil {
+			return
+		}
+		if !ref.Peeled.IsZero() {
+			if err := e.Encodef("%s %s^{}\n", ref.Peeled.String(), ref.Name); err != nil {
+				return
+			}
+		}
+	}
+	_ = e.Flush()
+}
+
+type advertisedReference struct {
+	Name string
+	Hash plumbing.Hash
+	// Peeled is the hash of the object which is pointed by the annotated tag.
+	Peeled plumbing.Hash
+}
+
+// advertisedReferences returns HEAD and the references which are sorted by the name.
+func advertisedReferences(repo *goGit.Repository) ([]*advertisedReference, error) {
+	iter, err := repo.References()
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	var refs []*advertisedReference
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		r := &advertisedReference{Name: ref.Name().String(), Hash: ref.Hash()}
+		if ref.Name().IsTag() {
+			if tag, err := repo.TagObject(ref.Hash()); err == nil {
+				r.Peeled = tag.Target
+			}
+		}
+		refs = append(refs, r)
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].Name < refs[j].Name })
+
+	if head, err := repo.Head(); err == nil {
+		refs = append([]*advertisedReference{{Name: plumbing.HEAD.String(), Hash: head.Hash()}}, refs...)
+	}
+	return refs, nil
+}
+
+type uploadPackRequest struct {
+	Wants        []plumbing.Hash
+	Haves        []plumbing.Hash
+	Shallows     []plumbing.Hash
+	Depth        int
+	DeepenSince  time.Time
+	DeepenNot    []string
+	Filter       *objectFilter
+	Capabilities map[string]struct{}
+	Done         bool
+	// Negotiation is false when the request ends with the wants.
+	// The stateless client sends such request to get the shallow list before the negotiation.
+	Negotiation bool
+}
+
+func (r *uploadPackRequest) Supports(c string) bool {
+	_, ok := r.Capabilities[c]
+	return ok
+}
+
+func (r *uploadPackRequest) Deepen() bool {
+	return r.Depth > 0 || !r.DeepenSince.IsZero() || len(r.DeepenNot) > 0
+}
+
+func parseUploadPackRequest(r io.Reader) (*uploadPackRequest, error) {
+	req := &uploadPackRequest{Capabilities: make(map[string]struct{})}
+	s := pktline.NewScanner(r)
+	wantsEnded := false
+	for s.Scan() {
+		if wantsEnded {
+			req.Negotiation = true
+		}
+		line := strings.TrimSuffix(string(s.Bytes()), "\n")
+		if line == "" {
+			// flush-pkt
+			wantsEnded = true
+			continue
+		}
+		cmd, arg, _ := strings.Cut(line, " ")
+		switch cmd {
+		case "want":
+			h, caps, _ := strings.Cut(arg, " ")
+			if !plumbing.IsHash(h) {
+				return nil, xerrors.Definef("invalid want line: %s", line).WithStack()
+			}
+			req.Wants = append(req.Wants, plumbing.NewHash(h))
+			for _, c := range strings.Fields(caps) {
+				req.Capabilities[c] = struct{}{}
+			}
+		case "have":
+			if !plumbing.IsHash(arg) {
+				return nil, xerrors.Definef("invalid have line: %s", line).WithStack()
+			}
+			req.Haves = append(req.Haves, plumbing.NewHash(arg))
+		case "shallow":
+			if !plumbing.IsHash(arg) {
+				return nil, xerrors.Definef("invalid shallow line: %s", line).WithStack()
+			}
+			req.Shallows = append(req.Shallows, plumbing.NewHash(arg))
+		case "deepen":
+			depth, err := strconv.Atoi(arg)
+			if err != nil || depth < 0 {
+				return nil, xerrors.Definef("invalid deepen line: %s", line).WithStack()
+			}
+			req.Depth = depth
+		case "deepen-since":
+			ts, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				return nil, xerrors.Definef("invalid deepen-since line: %s", line).WithStack()
+			}
+			req.DeepenSince = time.Unix(ts, 0)
+		case "deepen-not":
+			req.DeepenNot = append(req.DeepenNot, arg)
+		case "filter":
+			f, err := parseObjectFilter(arg)
+			if err != nil {
+				return nil, err
+			}
+			req.Filter = f
+		case "done":
+			req.Done = true
+		default:
+			return nil, xerrors.Definef("unexpected line: %s", line).WithStack()
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	if len(req.Wants) == 0 {
+		return nil, xerrors.Define("no want").WithStack()
+	}
+
+	return req, nil
+}
+
+// objectFilter is the filter of the partial clone.
+// blob:none, blob:limit=<n> and tree:<depth> are supported.
+type objectFilter struct {
+	// BlobLimit is the maximum size of the blob. If BlobLimit is negative, the blob is not filtered by the size.
+	BlobLimit int64
+	// TreeDepth is the depth of the tree which is omitted. If TreeDepth is negative, the tree is not filtered.
+	TreeDepth int
+}
+
+func parseObjectFilter(spec string) (*objectFilter, error) {
+	f := &objectFilter{BlobLimit: -1, TreeDepth: -1}
+	switch {
+	case spec == "blob:none":
+		f.BlobLimit = 0
+	case strings.HasPrefix(spec, "blob:limit="):
+		v := strings.ToLower(strings.TrimPrefix(spec, "blob:limit="))
+		unit := int64(1)
+		switch {
+		case strings.HasSuffix(v, "k"):
+			unit = 1024
+		case strings.HasSuffix(v, "m"):
+			unit = 1024 * 1024
+		case strings.HasSuffix(v, "g"):
+			unit = 1024 * 1024 * 1024
+		}
+		n, err := strconv.ParseInt(strings.TrimRight(v, "kmg"), 10, 64)
+		if err != nil || n < 0 {
+			return nil, xerrors.Definef("invalid filter: %s", spec).WithStack()
+		}
+		// blob:limit=<n> omits the blob which is larger than or equal to n bytes.
+		f.BlobLimit = n*unit - 1
+		if f.BlobLimit < 0 {
+			f.BlobLimit = 0
+		}
+	case strings.HasPrefix(spec, "tree:"):
+		n, err := strconv.Atoi(strings.TrimPrefix(spec, "tree:"))
+		if err != nil || n < 0 {
+			return nil, xerrors.Definef("invalid filter: %s", spec).WithStack()
+		}
+		f.TreeDepth = n
+	default:
+		return nil, xerrors.Definef("unsupported filter: %s", spec).WithStack()
+	}
+	return f, nil
+}
+
+func (s *SmartHTTPServer) uploadPack(w http.ResponseWriter, req *http.Request, repo *goGit.Repository) {
+	body := req.Body
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		r, err := gzip.NewReader(req.Body)
+		if err != nil {
+			http.Error(w, "Invalid body", http.StatusBadRequest)
+			return
+		}
+		defer r.Close()
+		body = r
+	}
+	upReq, err := parseUploadPackRequest(body)
+	if err != nil {
+		logger.Log.Info("Invalid upload-pack request", logger.Error(err))
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-git-upload-pack-result")
+	w.Header().Set("Cache-Control", "no-cache")
+	e := pktline.NewEncoder(w)
+	up := &uploadPack{repo: repo, req: upReq}
+	if err := up.negotiate(e); err != nil {
+		logger.Log.Info("Failed to negotiate", logger.Error(err))
+		_ = e.EncodeString("ERR " + err.Error() + "\n")
+		return
+	}
+	if !upReq.Done || !upReq.Negotiation {
+		return
+	}
+
+	objects, err := up.objects()
+	if err != nil {
+		logger.Log.Error("Failed to collect objects", logger.Error(err))
+		_ = e.EncodeString("ERR failed to collect objects\n")
+		return
+	}
+	if err := up.writePack(w, objects); err != nil {
+		logger.Log.Warn("Failed to write packfile", logger.Error(err))
+	}
+}
+
+// uploadPack handles the single request of git-upload-pack.
+// The smart HTTP protocol is stateless, so the request has all wants and common haves which were found in the previous round.
+type uploadPack struct {
+	repo *goGit.Repository
+	req  *uploadPackRequest
+
+	// commons are the commits which both of the client and the server have.
+	commons []plumbing.Hash
+	// commits are the commits which will be sent.
+	commits []*object.Commit
+}
+
+func (u *uploadPack) negotiate(e *pktline.Encoder) error {
+	if err := u.checkWants(); err != nil {
+		return err
+	}
+	for _, h := range u.req.Haves {
+		if _, err := u.repo.CommitObject(h); err == nil {
+			u.commons = append(u.commons, h)
+		}
+	}
+
+	shallows, unshallows, err := u.selectCommits()
+	if err != nil {
+		return err
+	}
+	if u.req.Deepen() {
+		for _, h := range shallows {
+			if err := e.EncodeString("shallow " + h.String() + "\n"); err != nil {
+				return xerrors.WithStack(err)
+			}
+		}
+		for _, h := range unshallows {
+			if err := e.EncodeString("unshallow " + h.String() + "\n"); err != nil {
+				return xerrors.WithStack(err)
+			}
+		}
+		if err := e.Flush(); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	if !u.req.Negotiation {
+		return nil
+	}
+
+	multiAck := u.req.Supports("multi_ack_detailed")
+	for i, h := range u.commons {
+		if multiAck {
+			if err := e.EncodeString("ACK " + h.String() + " common\n"); err != nil {
+				return xerrors.WithStack(err)
+			}
+		} else if i == 0 {
+			if err := e.EncodeString("ACK " + h.String() + "\n"); err != nil {
+				return xerrors.WithStack(err)
+			}
+		}
+	}
+	if !u.req.Done {
+		if len(u.commons) == 0 || multiAck {
+			if err := e.EncodeString("NAK\n"); err != nil {
+				return xerrors.WithStack(err)
+			}
+		}
+		return nil
+	}
+
+	if len(u.commons) == 0 {
+		if err := e.EncodeString("NAK\n"); err != nil {
+			return xerrors.WithStack(err)
+		}
+	} else if multiAck {
+		if err := e.EncodeString("ACK " + u.commons[len(u.commons)-1].String() + "\n"); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// selectCommits finds the commits which will be sent to the client.
+// selectCommits returns the commits which become shallow and the commits which the client can unshallow.
+func (u *uploadPack) selectCommits() (shallows, unshallows []plumbing.Hash, err error) {
+	clientShallows := make(map[plumbing.Hash]struct{})
+	for _, h := range u.req.Shallows {
+		clientShallows[h] = struct{}{}
+	}
+
+	// The client has all ancestors of the common commits except beyond its shallow commits.
+	uninteresting := make(map[plumbing.Hash]struct{})
+	if err := walkAncestors(u.repo, u.commons, uninteresting, clientShallows); err != nil {
+		return nil, nil, err
+	}
+	excluded := make(map[plumbing.Hash]struct{})
+	for _, v := range u.req.DeepenNot {
+		var ref *plumbing.Reference
+		for _, rule := range plumbing.RefRevParseRules {
+			if r, err := u.repo.Reference(plumbing.ReferenceName(fmt.Sprintf(rule, v)), true); err == nil {
+				ref = r
+				break
+			}
+		}
+		if ref == nil {
+			return nil, nil, xerrors.Definef("deepen-not is not a ref: %s", v).WithStack()
+		}
+		c, err := u.wantCommit(ref.Hash())
+		if err != nil {
+			return nil, nil, err
+		}
+		if c == nil {
+			continue
+		}
+		if err := walkAncestors(u.repo, []plumbing.Hash{c.Hash}, excluded, nil); err != nil {
+			return nil, nil, err
+		}
+	}
+	isExcluded := func(c *object.Commit) bool {
+		if _, ok := excluded[c.Hash]; ok {
+			return true
+		}
+		return !u.req.DeepenSince.IsZero() && c.Committer.When.Before(u.req.DeepenSince)
+	}
+
+	// Depth of the item is the distance from the start commit. Zero means the depth is not limited.
+	type item struct {
+		Commit *object.Commit
+		Depth  int
+	}
+	relative := u.req.Depth > 0 && u.req.Supports("deepen-relative")
+	limit, depth := u.req.Depth, 0
+	switch {
+	case relative:
+		// The shallow commits of the client are at depth 1. Thus, the new shallow commits are at N+1.
+		limit = u.req.Depth + 1
+	case u.req.Depth > 0:
+		depth = 1
+	}
+	var queue []item
+	visited := make(map[plumbing.Hash]struct{})
+	for _, h := range u.req.Wants {
+		c, err := u.wantCommit(h)
+		if err != nil {
+			return nil, nil, err
+		}
+		if c == nil {
+			continue
+		}
+		if _, ok := visited[c.Hash]; ok {
+			continue
+		}
+		visited[c.Hash] = struct{}{}
+		queue = append(queue, item{Commit: c, Depth: depth})
+	}
+	if relative {
+		// The depth is counted from the shallow commits of the client.
+		for h := range clientShallows {
+			if _, ok := visited[h]; ok {
+				continue
+			}
+			c, err := u.repo.CommitObject(h)
+			if err != nil {
+				continue
+			}
+			visited[h] = struct{}{}
+			queue = append(queue, item{Commit: c, Depth: 1})
+		}
+	}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		c := v.Commit
+		_, isUninteresting := uninteresting[c.Hash]
+		if !isUninteresting {
+			u.commits = append(u.commits, c)
+		} else if !u.req.Deepen() || (relative && v.Depth == 0) {
+			continue
+		}
+
+		_, isClientShallow := clientShallows[c.Hash]
+		if v.Depth > 0 && v.Depth >= limit {
+			if len(c.ParentHashes) > 0 && !isClientShallow {
+				shallows = append(shallows, c.Hash)
+			}
+			continue
+		}
+		var parents []*object.Commit
+		boundary := false
+		for _, p := range c.ParentHashes {
+			pc, err := u.repo.CommitObject(p)
+			if err != nil {
+				return nil, nil, xerrors.WithStack(err)
+			}
+			if isExcluded(pc) {
+				boundary = true
+				break
+			}
+			parents = append(parents, pc)
+		}
+		if boundary {
+			if !isClientShallow {
+				shallows = append(shallows, c.Hash)
+			}
+			continue
+		}
+		if isClientShallow && len(parents) > 0 {
+			if !u.req.Deepen() {
+				// The client doesn't request deepening. Keep the history of the client shallow.
+				continue
+			}
+			unshallows = append(unshallows, c.Hash)
+		}
+		for _, p := range parents {
+			if _, ok := visited[p.Hash]; ok {
+				continue
+			}
+			visited[p.Hash] = struct{}{}
+			next := item{Commit: p}
+			if v.Depth > 0 {
+				next.Depth = v.Depth + 1
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	return shallows, unshallows, nil
+}
+
+// checkWants returns an error if any want is not reachable from the advertised references.
+// The object which is only reachable from the deleted reference must not be served even if it remains in the storage.
+func (u *uploadPack) checkWants() error {
+	refs, err := advertisedReferences(u.repo)
+	if err != nil {
+		return err
+	}
+	var tips []plumbing.Hash
+	for _, ref := range refs {
+		tips = append(tips, ref.Hash)
+		if !ref.Peeled.IsZero() {
+			tips = append(tips, ref.Peeled)
+		}
+	}
+
+	// Most of the wants are the tips of the references. The history is walked only for other wants.
+	pending := make(map[plumbing.Hash]struct{})
+	for _, h := range u.req.Wants {
+		pending[h] = struct{}{}
+	}
+	for _, h := range tips {
+		delete(pending, h)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	// The trees are walked only when the client wants the object which isn't a commit (e.g. the blob for the partial clone).
+	walkTree := false
+	for h := range pending {
+		obj, err := u.repo.Storer.EncodedObject(plumbing.AnyObject, h)
+		if err != nil {
+			return xerrors.Definef("not our ref %s", h.String()).WithStack()
+		}
+		if obj.Type() != plumbing.CommitObject {
+			walkTree = true
+		}
+	}
+
+	seen := make(map[plumbing.Hash]struct{})
+	queue := append([]plumbing.Hash{}, tips...)
+	for len(queue) > 0 && len(pending) > 0 {
+		h := queue[0]
+		queue = queue[1:]
+		if _, ok := seen[h]; ok {
+			continue
+		}
+		seen[h] = struct{}{}
+		delete(pending, h)
+
+		obj, err := u.repo.Storer.EncodedObject(plumbing.AnyObject, h)
+		if err != nil {
+			if errors.Is(err, plumbing.ErrObjectNotFound) {
+				// The object beyond the shallow boundary.
+				continue
+			}
+			return xerrors.WithStack(err)
+		}
+		switch obj.Type() {
+		case plumbing.CommitObject:
+			c, err := object.DecodeCommit(u.repo.Storer, obj)
+			if err != nil {
+				return xerrors.WithStack(err)
+			}
+			queue = append(queue, c.ParentHashes...)
+			if walkTree {
+				queue = append(queue, c.TreeHash)
+			}
+		case plumbing.TagObject:
+			tag, err := object.DecodeTag(u.repo.Storer, obj)
+			if err != nil {
+				return xerrors.WithStack(err)
+			}
+			queue = append(queue, tag.Target)
+		case plumbing.TreeObject:
+			tree, err := object.DecodeTree(u.repo.Storer, obj)
+			if err != nil {
+				return xerrors.WithStack(err)
+			}
+			for _, e := range tree.Entries {
+				switch e.Mode {
+				case filemode.Dir:
+					queue = append(queue, e.Hash)
+				case filemode.Submodule:
+					// The commit of the submodule is not in this repository.
+				default:
+					// The blob doesn't have any child. It's not necessary to read the blob.
+					delete(pending, e.Hash)
+				}
+			}
+		}
+	}
+	for h := range pending {
+		return xerrors.Definef("not our ref %s", h.String()).WithStack()
+	}
+	return nil
+}
+
+// wantCommit returns the commit which is pointed by h.
+// If h is a tag object, the commit which is pointed by the tag is returned.
+// If h is neither a commit nor a tag, wantCommit returns nil.
+func (u *uploadPack) wantCommit(h plumbing.Hash) (*object.Commit, error) {
+	obj, err := u.repo.Storer.EncodedObject(plumbing.AnyObject, h)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	switch obj.Type() {
+	case plumbing.CommitObject:
+		c, err := object.DecodeCommit(u.repo.Storer, obj)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		return c, nil
+	case plumbing.TagObject:
+		tag, err := object.DecodeTag(u.repo.Storer, obj)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		if tag.TargetType != plumbing.CommitObject {
+			return nil, nil
+		}
+		c, err := u.repo.CommitObject(tag.Target)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		return c, nil
+	}
+	return nil, nil
+}
+
+// walkAncestors adds the commits which are reachable from the start commits to the set.
+// The walk doesn't go beyond the commits in stop.
+func walkAncestors(repo *goGit.Repository, start []plumbing.Hash, set, stop map[plumbing.Hash]struct{}) error {
+	queue := append([]plumbing.Hash{}, start...)
+	for len(queue) > 0 {
+		h := queue[0]
+		queue = queue[1:]
+		if _, ok := set[h]; ok {
+			continue
+		}
+		c, err := repo.CommitObject(h)
+		if err != nil {
+			if errors.Is(err, plumbing.ErrObjectNotFound) {
+				continue
+			}
+			return xerrors.WithStack(err)
+		}
+		set[h] = struct{}{}
+		if _, ok := stop[h]; ok {
+			continue
+		}
+		queue = append(queue, c.ParentHashes...)
+	}
+	return nil
+}
+
+// objects returns the hash of the objects which will be packed.
+func (u *uploadPack) objects() ([]plumbing.Hash, error) {
+	w := &objectWalker{
+		repo:   u.repo,
+		filter: u.req.Filter,
+		seen:   make(map[plumbing.Hash]struct{}),
+	}
+	// The client has the trees of the common commits.
+	for _, h := range u.commons {
+		c, err := u.repo.CommitObject(h)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		if err := w.markSeen(c.TreeHash); err != nil {
+			return nil, err
+		}
+	}
+	w.objects = nil
+
+	for _, h := range u.req.Wants {
+		obj, err := u.repo.Storer.EncodedObject(plumbing.AnyObject, h)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		switch obj.Type() {
+		case plumbing.TagObject:
+			w.add(h)
+		case plumbing.TreeObject:
+			if err := w.walkTree(h, 0); err != nil {
+				return nil, err
+			}
+		case plumbing.BlobObject:
+			// The blob which is requested explicitly is not filtered. The client fetches the missing blob of the partial clone by this.
+			w.add(h)
+		}
+	}
+	for _, c := range u.commits {
+		w.add(c.Hash)
+		if err := w.walkTree(c.TreeHash, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	return w.objects, nil
+}
+
+func (u *uploadPack) writePack(w io.Writer, objects []plumbing.Hash) error {
+	var out io.Writer = w
+	var mux *sideband.Muxer
+	switch {
+	case u.req.Supports("side-band-64k"):
+		mux = sideband.NewMuxer(sideband.Sideband64k, w)
+	case u.req.Supports("side-band"):
+		mux = sideband.NewMuxer(sideband.Sideband, w)
+	}
+	var buf *bufio.Writer
+	if mux != nil {
+		buf = bufio.NewWriterSize(mux, sideband.MaxPackedSize64k)
+		out = buf
+		if !u.req.Supports("no-progress") {
+			if _, err := mux.WriteChannel(sideband.ProgressMessage, []byte(fmt.Sprintf("Enumerating objects: %d, done.\n", len(objects)))); err != nil {
+				return xerrors.WithStack(err)
+			}
+		}
+	}
+
+	enc := packfile.NewEncoder(out, u.repo.Storer, !u.req.Supports("ofs-delta"))
+	if _, err := enc.Encode(objects, packWindow); err != nil {
+		if mux != nil {
+			_, _ = mux.WriteChannel(sideband.ErrorMessage, []byte("failed to create packfile\n"))
+		}
+		return xerrors.WithStack(err)
+	}
+	if buf != nil {
+		if err := buf.Flush(); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	if mux != nil {
+		if err := pktline.NewEncoder(w).Flush(); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+type objectWalker struct {
+	repo   *goGit.Repository
+	filter *objectFilter
+	seen   map[plumbing.Hash]struct{}
+
+	objects []plumbing.Hash
+}
+
+func (w *objectWalker) add(h plumbing.Hash) {
+	if _, ok := w.seen[h]; ok {
+		return
+	}
+	w.seen[h] = struct{}{}
+	w.objects = append(w.objects, h)
+}
+
+// markSeen marks all objects in the tree as seen without applying the filter.
+func (w *objectWalker) markSeen(h plumbing.Hash) error {
+	f := w.filter
+	w.filter = nil
+	defer func() { w.filter = f }()
+
+	return w.walkTree(h, 0)
+}
+
+func (w *objectWalker) walkTree(h plumbing.Hash, depth int) error {
+	if _, ok := w.seen[h]; ok {
+		return nil
+	}
+	if w.filter != nil && w.filter.TreeDepth >= 0 && depth >= w.filter.TreeDepth {
+		return nil
+	}
+	tree, err := object.GetTree(w.repo.Storer, h)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	w.add(h)
+
+	for _, e := range tree.Entries {
+		switch e.Mode {
+		case filemode.Submodule:
+			continue
+		case filemode.Dir:
+			if err := w.walkTree(e.Hash, depth+1); err != nil {
+				return err
+			}
+		default:
+			if _, ok := w.seen[e.Hash]; ok {
+				continue
+			}
+			ok, err := w.includeBlob(e.Hash, depth+1)
+			if err != nil {
+				return err
+			}
+			if ok {
+				w.add(e.Hash)
+			}
+		}
+	}
+	return nil
+}
+
+func (w *objectWalker) includeBlob(h plumbing.Hash, depth int) (bool, error) {
+	if w.filter == nil {
+		return true, nil
+	}
+	if w.filter.TreeDepth >= 0 && depth >= w.filter.TreeDepth {
+		return false, nil
+	}
+	switch w.filter.BlobLimit {
+	case -1:
+		return true, nil
+	case 0:
+		return false, nil
+	}
+	size, err := w.repo.Storer.EncodedObjectSize(h)
+	if err != nil {
+		return false, xerrors.WithStack(err)
+	}
+	return size <= w.filter.BlobLimit, nil
+}
@@ -0,0 +1,165 @@
+package git
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSmartHTTPServer(t *testing.T) {
+	repo := makeSourceRepository(t)
+	commitFile(t, repo, "README.md", "Hello world", "Update README")
+	s := httptest.NewServer(NewSmartHTTPServer(map[string]*goGit.Repository{"test1": repo}))
+	t.Cleanup(s.Close)
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+
+	t.Run("Clone", func(t *testing.T) {
+		cloned, err := goGit.CloneContext(context.Background(), memory.NewStorage(), nil, &goGit.CloneOptions{URL: s.URL + "/test1.git"})
+		require.NoError(t, err)
+		clonedHead, err := cloned.Head()
+		require.NoError(t, err)
+		assert.Equal(t, head.Hash(), clonedHead.Hash())
+		assert.Equal(t, plumbing.NewBranchReferenceName("master"), clonedHead.Name())
+
+		commit, err := cloned.CommitObject(clonedHead.Hash())
+		require.NoError(t, err)
+		f, err := commit.File("docs/design/README.md")
+		require.NoError(t, err)
+		content, err := f.Contents()
+		require.NoError(t, err)
+		assert.Equal(t, "design", content)
+
+		// Fetch the new commit.
+		newCommit := commitFile(t, repo, "README.md", "Hello git", "Update README again")
+		err = cloned.FetchContext(context.Background(), &goGit.FetchOptions{})
+		require.NoError(t, err)
+		ref, err := cloned.Reference(plumbing.NewRemoteReferenceName("origin", "master"), true)
+		require.NoError(t, err)
+		assert.Equal(t, newCommit, ref.Hash())
+	})
+
+	t.Run("Shallow", func(t *testing.T) {
+		cloned, err := goGit.CloneContext(context.Background(), memory.NewStorage(), nil, &goGit.CloneOptions{URL: s.URL + "/test1", Depth: 1})
+		require.NoError(t, err)
+		shallows, err := cloned.Storer.Shallow()
+		require.NoError(t, err)
+		assert.Len(t, shallows, 1)
+		clonedHead, err := cloned.Head()
+		require.NoError(t, err)
+		commit, err := cloned.CommitObject(clonedHead.Hash())
+		require.NoError(t, err)
+		_, err = cloned.CommitObject(commit.ParentHashes[0])
+		assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+	})
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		res, err := http.Get(s.URL + "/test1.git/info/refs?service=git-receive-pack")
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+		res, err = http.Get(s.URL + "/unknown.git/info/refs?service=git-upload-pack")
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
+
+func TestUploadPack_CheckWants(t *testing.T) {
+	repo := makeSourceRepository(t)
+	initial, err := repo.Head()
+	require.NoError(t, err)
+	initialCommit, err := repo.CommitObject(initial.Hash())
+	require.NoError(t, err)
+	readme, err := initialCommit.File("README.md")
+	require.NoError(t, err)
+	second := commitFile(t, repo, "README.md", "Hello world", "Update README")
+	// The commit which was on the deleted reference remains in the storage.
+	hidden := commitFile(t, repo, "README.md", "secret", "Hidden commit")
+	hiddenCommit, err := repo.CommitObject(hidden)
+	require.NoError(t, err)
+	hiddenReadme, err := hiddenCommit.File("README.md")
+	require.NoError(t, err)
+	err = repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("master"), second))
+	require.NoError(t, err)
+
+	cases := []struct {
+		Name  string
+		Want  plumbing.Hash
+		Error bool
+	}{
+		{Name: "Tip", Want: second},
+		{Name: "Ancestor", Want: initial.Hash()},
+		{Name: "Blob", Want: readme.Hash},
+		{Name: "Unreachable", Want: hidden, Error: true},
+		{Name: "UnreachableBlob", Want: hiddenReadme.Hash, Error: true},
+		{Name: "NotFound", Want: plumbing.NewHash("0123456789012345678901234567890123456789"), Error: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			up := &uploadPack{repo: repo, req: &uploadPackRequest{Wants: []plumbing.Hash{tc.Want}}}
+			err := up.checkWants()
+			if tc.Error {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUploadPack_Filter(t *testing.T) {
+	repo := makeSourceRepository(t)
+	head, err := repo.Head()
+	require.NoError(t, err)
+
+	cases := []struct {
+		Filter string
+		Trees  int
+		Blobs  int
+	}{
+		{Filter: "", Trees: 3, Blobs: 3},
+		{Filter: "blob:none", Trees: 3, Blobs: 0},
+		{Filter: "blob:limit=6", Trees: 3, Blobs: 2},
+		{Filter: "tree:0", Trees: 0, Blobs: 0},
+		{Filter: "tree:1", Trees: 1, Blobs: 0},
+		{Filter: "tree:2", Trees: 2, Blobs: 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Filter, func(t *testing.T) {
+			req := &uploadPackRequest{Wants: []plumbing.Hash{head.Hash()}, Done: true}
+			if tc.Filter != "" {
+				f, err := parseObjectFilter(tc.Filter)
+				require.NoError(t, err)
+				req.Filter = f
+			}
+			up := &uploadPack{repo: repo, req: req}
+			_, _, err := up.selectCommits()
+			require.NoError(t, err)
+			objects, err := up.objects()
+			require.NoError(t, err)
+
+			count := make(map[plumbing.ObjectType]int)
+			for _, h := range objects {
+				obj, err := repo.Storer.EncodedObject(plumbing.AnyObject, h)
+				require.NoError(t, err)
+				count[obj.Type()]++
+			}
+			assert.Equal(t, 1, count[plumbing.CommitObject])
+			assert.Equal(t, tc.Trees, count[plumbing.TreeObject])
+			assert.Equal(t, tc.Blobs, count[plumbing.BlobObject])
+		})
+	}
+
+	_, err = parseObjectFilter("sparse:oid=master")
+	assert.Error(t, err)
+}
@@ -0,0 +1,400 @@
+package gomodule
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"go.f110.dev/xerrors"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"go.f110.dev/mono/go/logger"
+)
+
+var (
+	// ErrNoCredential is returned when the request doesn't have any credential.
+	ErrNoCredential = xerrors.Define("gomodule: no credential")
+	// ErrInvalidCredential is returned when the credential of the request is not valid.
+	ErrInvalidCredential = xerrors.Define("gomodule: invalid credential")
+)
+
+type User struct {
+	Name   string
+	Groups []string
+}
+
+func (u *User) InGroup(group string) bool {
+	for _, v := range u.Groups {
+		if v == group {
+			return true
+		}
+	}
+	return false
+}
+
+type userContextKey struct{}
+
+func withUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext returns the authenticated user.
+// UserFromContext returns nil if the authentication is disabled.
+func UserFromContext(ctx context.Context) *User {
+	if v, ok := ctx.Value(userContextKey{}).(*User); ok {
+		return v
+	}
+	return nil
+}
+
+type Authenticator interface {
+	// Authenticate returns the user of the request.
+	// Authenticate has to return ErrNoCredential if the request doesn't have the credential for the authenticator.
+	Authenticate(req *http.Request) (*User, error)
+}
+
+// AccessControl is the configuration of the authentication and the authorization of ProxyServer.
+type AccessControl struct {
+	Authenticators []Authenticator
+	// AdminUsers and AdminGroups can call the endpoints for the administrator (e.g. /flush_all).
+	AdminUsers  []string
+	AdminGroups []string
+}
+
+// Authenticate tries all authenticators and returns the first authenticated user.
+func (a *AccessControl) Authenticate(req *http.Request) (*User, error) {
+	for _, v := range a.Authenticators {
+		user, err := v.Authenticate(req)
+		if errors.Is(err, ErrNoCredential) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+
+	return nil, xerrors.WithStack(ErrNoCredential)
+}
+
+func (a *AccessControl) IsAdmin(user *User) bool {
+	if user == nil {
+		return false
+	}
+	for _, v := range a.AdminUsers {
+		if v == user.Name {
+			return true
+		}
+	}
+	for _, v := range a.AdminGroups {
+		if user.InGroup(v) {
+			return true
+		}
+	}
+	return false
+}
+
+type tokenEntry struct {
+	Name   string   `yaml:"name"`
+	Token  string   `yaml:"token"`
+	Groups []string `yaml:"groups"`
+}
+
+// TokenAuthenticator authenticates the request by the basic authentication.
+// The password of the basic authentication is the token of the user.
+// The client can use .netrc to send the credential.
+type TokenAuthenticator struct {
+	tokens []*tokenEntry
+}
+
+var _ Authenticator = &TokenAuthenticator{}
+
+// NewTokenAuthenticatorFromFile reads the list of the user from the file.
+// The file is YAML which has the list of the name, the token and the groups.
+func NewTokenAuthenticatorFromFile(path string) (*TokenAuthenticator, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	defer f.Close()
+
+	var tokens []*tokenEntry
+	if err := yaml.NewDecoder(f).Decode(&tokens); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	for _, v := range tokens {
+		if v.Name == "" || v.Token == "" {
+			return nil, xerrors.Define("name and token are required").WithStack()
+		}
+	}
+
+	return &TokenAuthenticator{tokens: tokens}, nil
+}
+
+func (a *TokenAuthenticator) Authenticate(req *http.Request) (*User, error) {
+	name, token, ok := req.BasicAuth()
+	if !ok {
+		return nil, xerrors.WithStack(ErrNoCredential)
+	}
+
+	for _, v := range a.tokens {
+		if v.Name != name {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(v.Token), []byte(token)) == 1 {
+			return &User{Name: v.Name, Groups: v.Groups}, nil
+		}
+		return nil, xerrors.WithStack(ErrInvalidCredential)
+	}
+
+	// The credential may be for other authenticator.
+	return nil, xerrors.WithStack(ErrNoCredential)
+}
+
+// OIDCAuthenticator authenticates the request by ID token of OpenID Connect.
+// The token is read from the bearer token or the password of the basic authentication.
+type OIDCAuthenticator struct {
+	issuer        string
+	audience      string
+	usernameClaim string
+	groupsClaim   string
+	jwksURL       string
+	client        *http.Client
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	lastFetched time.Time
+}
+
+var _ Authenticator = &OIDCAuthenticator{}
+
+// jwksMinRefreshInterval is the minimum interval to fetch the key set.
+// The key set is fetched again when the token is signed by an unknown key.
+const jwksMinRefreshInterval = 1 * time.Minute
+
+func NewOIDCAuthenticator(ctx context.Context, issuer, audience, usernameClaim, groupsClaim string) (*OIDCAuthenticator, error) {
+	a := &OIDCAuthenticator{
+		issuer:        issuer,
+		audience:      audience,
+		usernameClaim: usernameClaim,
+		groupsClaim:   groupsClaim,
+		client:        &http.Client{Timeout: 10 * time.Second},
+		keys:          make(map[string]crypto.PublicKey),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	res, err := a.client.Do(req)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, xerrors.Definef("failed to get the discovery document: %s", res.Status).WithStack()
+	}
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&discovery); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	if discovery.Issuer != issuer {
+		return nil, xerrors.Definef("issuer is mismatched: %s", discovery.Issuer).WithStack()
+	}
+	a.jwksURL = discovery.JWKSURI
+	if err := a.fetchKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *OIDCAuthenticator) Authenticate(req *http.Request) (*User, error) {
+	var rawToken string
+	if h := req.Header.Get("Authorization"); strings.HasPrefix(h, "Bearer ") {
+		rawToken = strings.TrimPrefix(h, "Bearer ")
+	} else if _, password, ok := req.BasicAuth(); ok && strings.Count(password, ".") == 2 {
+		rawToken = password
+	} else {
+		return nil, xerrors.WithStack(ErrNoCredential)
+	}
+
+	token, err := jwt.Parse(
+		rawToken,
+		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			return a.key(req.Context(), kid)
+		},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}),
+	)
+	if err != nil {
+		return nil, xerrors.WithMessage(ErrInvalidCredential, err.Error())
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, xerrors.WithStack(ErrInvalidCredential)
+	}
+	// jwt.Parse accepts the token which doesn't have exp. Such token never expires.
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, xerrors.WithMessage(ErrInvalidCredential, "exp claim is not found")
+	}
+	if !claims.VerifyIssuer(a.issuer, true) || !claims.VerifyAudience(a.audience, true) {
+		return nil, xerrors.WithStack(ErrInvalidCredential)
+	}
+
+	name, _ := claims[a.usernameClaim].(string)
+	if name == "" {
+		return nil, xerrors.WithMessagef(ErrInvalidCredential, "%s claim is not found", a.usernameClaim)
+	}
+	user := &User{Name: name}
+	if v, ok := claims[a.groupsClaim].([]interface{}); ok {
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				user.Groups = append(user.Groups, s)
+			}
+		}
+	}
+
+	return user, nil
+}
+
+func (a *OIDCAuthenticator) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if v, ok := a.keys[kid]; ok {
+		return v, nil
+	}
+	// The key may be rotated.
+	if time.Since(a.lastFetched) > jwksMinRefreshInterval {
+		if err := a.fetchKeysLocked(ctx); err != nil {
+			return nil, err
+		}
+		if v, ok := a.keys[kid]; ok {
+			return v, nil
+		}
+	}
+
+	return nil, xerrors.Definef("key %q is not found", kid).WithStack()
+}
+
+func (a *OIDCAuthenticator) fetchKeys(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.fetchKeysLocked(ctx)
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (a *OIDCAuthenticator) fetchKeysLocked(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.jwksURL, nil)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	res, err := a.client.Do(req)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return xerrors.Definef("failed to get the key set: %s", res.Status).WithStack()
+	}
+	var keySet struct {
+		Keys []*jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&keySet); err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, v := range keySet.Keys {
+		if v.Use != "" && v.Use != "sig" {
+			continue
+		}
+		// The key set may contain the keys which are not supported by the authenticator.
+		key, err := v.publicKey()
+		if err != nil {
+			logger.Log.Info("Skip the key", zap.String("kid", v.Kid), zap.String("kty", v.Kty), zap.Error(err))
+			continue
+		}
+		keys[v.Kid] = key
+	}
+	a.keys = keys
+	a.lastFetched = time.Now()
+	return nil
+}
+
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, xerrors.Definef("unsupported curve: %s", k.Crv).WithStack()
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, xerrors.Definef("unsupported curve: %s", k.Crv).WithStack()
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, xerrors.Definef("unsupported key type: %s", k.Kty).WithStack()
+	}
+}
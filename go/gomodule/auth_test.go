@@ -0,0 +1,200 @@
+package gomodule
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb/note"
+
+	"go.f110.dev/mono/go/storage"
+)
+
+func TestTokenAuthenticator(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "tokens.yaml")
+	err := os.WriteFile(tokenFile, []byte("- name: alice\n  token: secret\n  groups: [dev]\n"), 0644)
+	require.NoError(t, err)
+	a, err := NewTokenAuthenticatorFromFile(tokenFile)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	_, err = a.Authenticate(req)
+	assert.ErrorIs(t, err, ErrNoCredential)
+
+	req.SetBasicAuth("alice", "secret")
+	user, err := a.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, &User{Name: "alice", Groups: []string{"dev"}}, user)
+
+	req.SetBasicAuth("alice", "wrong")
+	_, err = a.Authenticate(req)
+	assert.ErrorIs(t, err, ErrInvalidCredential)
+
+	req.SetBasicAuth("bob", "secret")
+	_, err = a.Authenticate(req)
+	assert.ErrorIs(t, err, ErrNoCredential)
+}
+
+func TestOIDCAuthenticator(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"issuer": s.URL, "jwks_uri": s.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{
+				// The unsupported key is skipped.
+				{"kid": "symmetric", "kty": "oct", "k": "c2VjcmV0"},
+				{
+					"kid": "test",
+					"kty": "EC",
+					"use": "sig",
+					"crv": "P-256",
+					"x":   base64.RawURLEncoding.EncodeToString(privateKey.X.Bytes()),
+					"y":   base64.RawURLEncoding.EncodeToString(privateKey.Y.Bytes()),
+				},
+			},
+		})
+	})
+
+	a, err := NewOIDCAuthenticator(context.Background(), s.URL, "gomodule-proxy", "email", "groups")
+	require.NoError(t, err)
+
+	newToken := func(claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+		token.Header["kid"] = "test"
+		signed, err := token.SignedString(privateKey)
+		require.NoError(t, err)
+		return signed
+	}
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":    s.URL,
+			"aud":    "gomodule-proxy",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"email":  "alice@example.com",
+			"groups": []string{"dev"},
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	_, err = a.Authenticate(req)
+	assert.ErrorIs(t, err, ErrNoCredential)
+
+	req.Header.Set("Authorization", "Bearer "+newToken(validClaims()))
+	user, err := a.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, &User{Name: "alice@example.com", Groups: []string{"dev"}}, user)
+
+	// ID token in the password of the basic authentication
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.SetBasicAuth("alice", newToken(validClaims()))
+	user, err = a.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", user.Name)
+
+	for name, mutate := range map[string]func(jwt.MapClaims){
+		"Expired":         func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"NoExpiration":    func(c jwt.MapClaims) { delete(c, "exp") },
+		"UnknownAudience": func(c jwt.MapClaims) { c["aud"] = "other" },
+		"UnknownIssuer":   func(c jwt.MapClaims) { c["iss"] = "https://example.com" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			claims := validClaims()
+			mutate(claims)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+newToken(claims))
+			_, err := a.Authenticate(req)
+			assert.ErrorIs(t, err, ErrInvalidCredential)
+		})
+	}
+}
+
+func TestProxyServer_AccessControl(t *testing.T) {
+	confFile := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(confFile, []byte(`- module_name: go.example.com/.*
+  access_rules:
+    - module: go.example.com/secret.*
+      users: [alice]
+    - groups: [dev]
+`), 0644)
+	require.NoError(t, err)
+	conf, err := ReadConfig(confFile)
+	require.NoError(t, err)
+	tokenFile := filepath.Join(t.TempDir(), "tokens.yaml")
+	err = os.WriteFile(tokenFile, []byte(`- name: alice
+  token: alice-token
+- name: bob
+  token: bob-token
+  groups: [dev]
+`), 0644)
+	require.NoError(t, err)
+	tokenAuthenticator, err := NewTokenAuthenticatorFromFile(tokenFile)
+	require.NoError(t, err)
+
+	setting := conf[0]
+	assert.True(t, setting.IsAllowed("go.example.com/secret", &User{Name: "alice"}))
+	assert.False(t, setting.IsAllowed("go.example.com/public", &User{Name: "alice"}))
+	assert.True(t, setting.IsAllowed("go.example.com/public", &User{Name: "bob", Groups: []string{"dev"}}))
+	assert.False(t, setting.IsAllowed("go.example.com/public", nil))
+
+	proxy := NewModuleProxy(conf, t.TempDir(), nil, nil, nil, nil)
+	skey, _, err := note.GenerateKey(rand.Reader, "sum.example.com")
+	require.NoError(t, err)
+	db, err := newSumDB(context.Background(), skey, storage.NewMock(), fakeModuleSource{"go.example.com/secret": "module go.example.com/secret\n"})
+	require.NoError(t, err)
+	_, err = db.Lookup(context.Background(), module.Version{Path: "go.example.com/secret", Version: "v1.0.0"})
+	require.NoError(t, err)
+	ac := &AccessControl{Authenticators: []Authenticator{tokenAuthenticator}, AdminUsers: []string{"alice"}}
+	s := httptest.NewServer(NewProxyServer("", &url.URL{}, proxy, db, ac, "").s.Handler)
+	t.Cleanup(s.Close)
+
+	cases := []struct {
+		Name   string
+		Method string
+		Path   string
+		User   string
+		Token  string
+		Status int
+	}{
+		{Name: "Probe", Method: http.MethodGet, Path: "/_/liveness", Status: http.StatusOK},
+		{Name: "NoCredential", Method: http.MethodGet, Path: "/go.example.com/public/@v/list", Status: http.StatusUnauthorized},
+		{Name: "InvalidToken", Method: http.MethodGet, Path: "/go.example.com/public/@v/list", User: "alice", Token: "bob-token", Status: http.StatusUnauthorized},
+		{Name: "Forbidden", Method: http.MethodGet, Path: "/go.example.com/public/@v/list", User: "alice", Token: "alice-token", Status: http.StatusForbidden},
+		{Name: "FlushAllByNonAdmin", Method: http.MethodPost, Path: "/flush_all", User: "bob", Token: "bob-token", Status: http.StatusForbidden},
+		{Name: "HashTile", Method: http.MethodGet, Path: "/sumdb/sum.example.com/tile/8/0/000.p/1", User: "bob", Token: "bob-token", Status: http.StatusOK},
+		{Name: "DataTileByNonAdmin", Method: http.MethodGet, Path: "/sumdb/sum.example.com/tile/8/data/000.p/1", User: "bob", Token: "bob-token", Status: http.StatusForbidden},
+		{Name: "DataTileByAdmin", Method: http.MethodGet, Path: "/sumdb/sum.example.com/tile/8/data/000.p/1", User: "alice", Token: "alice-token", Status: http.StatusOK},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.Method, s.URL+tc.Path, nil)
+			require.NoError(t, err)
+			if tc.User != "" {
+				req.SetBasicAuth(tc.User, tc.Token)
+			}
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			res.Body.Close()
+			assert.Equal(t, tc.Status, res.StatusCode)
+		})
+	}
+}
@@ -7,13 +7,16 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"go.f110.dev/go-memcached/client"
 	merrors "go.f110.dev/go-memcached/errors"
 	"go.f110.dev/xerrors"
 	"go.uber.org/zap"
+	"golang.org/x/mod/module"
 
 	"go.f110.dev/mono/go/logger"
 	"go.f110.dev/mono/go/storage"
@@ -21,9 +24,21 @@ import (
 
 var CacheMiss = xerrors.Define("gomodule: cache hit miss")
 
+type ObjectStorageInterface interface {
+	Get(ctx context.Context, name string) (*storage.Object, error)
+	Put(ctx context.Context, name string, data []byte) error
+	List(ctx context.Context, prefix string) ([]*storage.Object, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// ModuleCache has two tiers.
+// The first tier is memcached and the second tier is the object storage.
+// The go.mod files and the info of the module are stored to both tiers.
+// When the item is not found in memcached, the item is read from the object storage and promoted to memcached.
+// The archive files are stored to only the object storage.
 type ModuleCache struct {
 	cachePool     *client.SinglePool
-	objectStorage *storage.S3
+	objectStorage ObjectStorageInterface
 }
 
 func NewModuleCache(cachePool *client.SinglePool, endpoint, region, bucket, accessKey, secretAccessKey, caCertFile string) *ModuleCache {
@@ -31,9 +46,13 @@ func NewModuleCache(cachePool *client.SinglePool, endpoint, region, bucket, acce
 	opt.PathStyle = true
 	opt.CACertFile = caCertFile
 	objStorage := storage.NewS3(bucket, opt)
+	return newModuleCache(cachePool, objStorage)
+}
+
+func newModuleCache(cachePool *client.SinglePool, objectStorage ObjectStorageInterface) *ModuleCache {
 	return &ModuleCache{
 		cachePool:     cachePool,
-		objectStorage: objStorage,
+		objectStorage: objectStorage,
 	}
 }
 
@@ -136,7 +155,8 @@ func (c *ModuleCache) SetRepoRoot(importPath, repoRoot, repoURL string) error {
 }
 
 type moduleRootCache struct {
-	Modules []*Module
+	RepositoryURL string
+	Modules       []*Module
 }
 
 func (c *ModuleCache) GetModuleRoot(repoRoot string, baseDir string, vcs *VCS) (*ModuleRoot, error) {
@@ -150,12 +170,16 @@ func (c *ModuleCache) GetModuleRoot(repoRoot string, baseDir string, vcs *VCS) (
 	}
 
 	moduleRoot := NewModuleRootFromCache(repoRoot, cachedItem.Modules, c, vcs, filepath.Join(baseDir, repoRoot))
+	if moduleRoot.RepositoryURL == "" {
+		moduleRoot.RepositoryURL = cachedItem.RepositoryURL
+	}
 	return moduleRoot, nil
 }
 
 func (c *ModuleCache) SetModuleRoot(moduleRoot *ModuleRoot) error {
 	item := &moduleRootCache{
-		Modules: moduleRoot.Modules,
+		RepositoryURL: moduleRoot.RepositoryURL,
+		Modules:       moduleRoot.Modules,
 	}
 	buf := new(bytes.Buffer)
 	if err := json.NewEncoder(buf).Encode(item); err != nil {
@@ -178,14 +202,34 @@ func (c *ModuleCache) SetModuleRoot(moduleRoot *ModuleRoot) error {
 
 func (c *ModuleCache) GetModFile(module, version string) ([]byte, error) {
 	item, err := c.cachePool.Get(fmt.Sprintf("goModFile/%s/%s", module, version))
-	if err != nil {
-		return nil, xerrors.WithStack(err)
+	if err == nil {
+		return item.Value, nil
 	}
 
-	return item.Value, nil
+	buf, sErr := c.getObject(context.Background(), module, version+".mod")
+	if sErr != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	logger.Log.Debug("Promote go.mod to memcached", zap.String("module", module), zap.String("version", version))
+	// The item has been read from the object storage. Thus, the failure of promotion is not an error.
+	if err := c.setModFileCache(module, version, buf); err != nil {
+		logger.Log.Info("Failed to promote go.mod to memcached", zap.Error(err), zap.String("module", module), zap.String("version", version))
+	}
+	return buf, nil
 }
 
 func (c *ModuleCache) SetModFile(module, version string, modFile []byte) error {
+	if err := c.putObject(context.Background(), module, version+".mod", modFile); err != nil {
+		return xerrors.WithStack(err)
+	}
+	if err := c.setModFileCache(module, version, modFile); err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (c *ModuleCache) setModFileCache(module, version string, modFile []byte) error {
 	err := c.cachePool.Set(&client.Item{
 		Key:        fmt.Sprintf("goModFile/%s/%s", module, version),
 		Value:      modFile,
@@ -208,7 +252,19 @@ func (c *ModuleCache) GetModInfo(module, sha string) (time.Time, error) {
 
 	item, err := c.cachePool.Get(fmt.Sprintf("modInfo/%s/%s", module, sha[:12]))
 	if err != nil {
-		return time.Time{}, xerrors.WithStack(err)
+		buf, sErr := c.getObject(context.Background(), module, sha[:12]+".info")
+		if sErr != nil {
+			return time.Time{}, xerrors.WithStack(err)
+		}
+		var info Info
+		if err := json.Unmarshal(buf, &info); err != nil {
+			return time.Time{}, xerrors.WithStack(err)
+		}
+		logger.Log.Debug("Promote mod info to memcached", zap.String("module", module), zap.String("sha", sha[:12]))
+		if err := c.setModInfoCache(module, sha, info.Time); err != nil {
+			logger.Log.Info("Failed to promote mod info to memcached", zap.Error(err), zap.String("module", module), zap.String("sha", sha[:12]))
+		}
+		return info.Time, nil
 	}
 
 	t, err := time.Parse(time.RFC3339, string(item.Value))
@@ -220,6 +276,21 @@ func (c *ModuleCache) GetModInfo(module, sha string) (time.Time, error) {
 }
 
 func (c *ModuleCache) SetModInfo(module, sha string, t time.Time) error {
+	buf, err := json.Marshal(&Info{Time: t})
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	if err := c.putObject(context.Background(), module, sha[:12]+".info", buf); err != nil {
+		return xerrors.WithStack(err)
+	}
+	if err := c.setModInfoCache(module, sha, t); err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (c *ModuleCache) setModInfoCache(module, sha string, t time.Time) error {
 	err := c.cachePool.Set(&client.Item{
 		Key:        fmt.Sprintf("modInfo/%s/%s", module, sha[:12]),
 		Value:      []byte(t.Format(time.RFC3339)),
@@ -302,10 +373,18 @@ func (c *ModuleCache) Archive(ctx context.Context, module, version string, w io.
 	if c.objectStorage == nil {
 		return CacheMiss
 	}
-	r, err := c.objectStorage.Get(ctx, fmt.Sprintf("%s@%s.zip", module, version))
+	name, err := objectName(module, version+".zip")
 	if err != nil {
 		return CacheMiss
 	}
+	r, err := c.objectStorage.Get(ctx, name)
+	if err != nil {
+		// Fallback to the object which has been stored in the legacy layout.
+		r, err = c.objectStorage.Get(ctx, legacyArchiveName(module, version))
+		if err != nil {
+			return CacheMiss
+		}
+	}
 	defer r.Body.Close()
 	if _, err := io.Copy(w, r.Body); err != nil {
 		return err
@@ -322,14 +401,180 @@ func (c *ModuleCache) SaveArchive(ctx context.Context, module, version string, d
 		return nil
 	}
 
-	err := c.objectStorage.Put(ctx, fmt.Sprintf("%s@%s.zip", module, version), data)
+	if err := c.putObject(ctx, module, version+".zip", data); err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	return nil
+}
+
+// StoredVersions returns the versions of the modules which are stored in the object storage.
+// The archive files which are stored in the legacy layout are also included.
+// The key of map is the module path.
+func (c *ModuleCache) StoredVersions(ctx context.Context) (map[string][]string, error) {
+	if c == nil || c.objectStorage == nil {
+		return nil, nil
+	}
+
+	objs, err := c.objectStorage.List(ctx, "")
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	versions := make(map[string][]string)
+	found := make(map[string]struct{})
+	for _, v := range objs {
+		var modPath, ver string
+		if escPath, file, ok := strings.Cut(v.Name, "/@v/"); ok {
+			p, err := module.UnescapePath(escPath)
+			if err != nil {
+				continue
+			}
+			ext := path.Ext(file)
+			if ext != ".zip" && ext != ".mod" {
+				continue
+			}
+			modPath, ver = p, strings.TrimSuffix(file, ext)
+		} else if p, file, ok := strings.Cut(v.Name, "@"); ok && path.Ext(file) == ".zip" {
+			modPath, ver = p, strings.TrimSuffix(file, ".zip")
+		} else {
+			continue
+		}
+		if _, ok := found[modPath+"@"+ver]; ok {
+			continue
+		}
+		found[modPath+"@"+ver] = struct{}{}
+		versions[modPath] = append(versions[modPath], ver)
+	}
+
+	return versions, nil
+}
+
+// StoredInfos returns the abbreviated commit hashes of the info which are stored in the object storage.
+// The key of map is the module path.
+func (c *ModuleCache) StoredInfos(ctx context.Context) (map[string][]string, error) {
+	if c == nil || c.objectStorage == nil {
+		return nil, nil
+	}
+
+	objs, err := c.objectStorage.List(ctx, "")
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	infos := make(map[string][]string)
+	for _, v := range objs {
+		escPath, file, ok := strings.Cut(v.Name, "/@v/")
+		if !ok || path.Ext(file) != ".info" {
+			continue
+		}
+		modPath, err := module.UnescapePath(escPath)
+		if err != nil {
+			continue
+		}
+		infos[modPath] = append(infos[modPath], strings.TrimSuffix(file, ".info"))
+	}
+
+	return infos, nil
+}
+
+// DeleteVersion deletes the archive file and go.mod of the version from both tiers.
+func (c *ModuleCache) DeleteVersion(ctx context.Context, module, version string) error {
+	if c == nil || c.objectStorage == nil {
+		return nil
+	}
+
+	names := []string{legacyArchiveName(module, version)}
+	for _, ext := range []string{".zip", ".mod"} {
+		name, err := objectName(module, version+ext)
+		if err != nil {
+			return err
+		}
+		names = append(names, name)
+	}
+	for _, name := range names {
+		if err := c.objectStorage.Delete(ctx, name); err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
+			return xerrors.WithStack(err)
+		}
+	}
+	if err := c.cachePool.Delete(fmt.Sprintf("goModFile/%s/%s", module, version)); err != nil && !errors.Is(err, merrors.ItemNotFound) {
+		return xerrors.WithStack(err)
+	}
+
+	return nil
+}
+
+// DeleteInfo deletes the info of the commit from both tiers.
+func (c *ModuleCache) DeleteInfo(ctx context.Context, module, sha string) error {
+	if c == nil || c.objectStorage == nil {
+		return nil
+	}
+
+	name, err := objectName(module, sha+".info")
+	if err != nil {
+		return err
+	}
+	if err := c.objectStorage.Delete(ctx, name); err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
+		return xerrors.WithStack(err)
+	}
+	if err := c.cachePool.Delete(fmt.Sprintf("modInfo/%s/%s", module, sha)); err != nil && !errors.Is(err, merrors.ItemNotFound) {
+		return xerrors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (c *ModuleCache) getObject(ctx context.Context, module, file string) ([]byte, error) {
+	if c.objectStorage == nil {
+		return nil, CacheMiss
+	}
+	name, err := objectName(module, file)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := c.objectStorage.Get(ctx, name)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	defer obj.Body.Close()
+	buf, err := io.ReadAll(obj.Body)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	return buf, nil
+}
+
+func (c *ModuleCache) putObject(ctx context.Context, module, file string, data []byte) error {
+	if c.objectStorage == nil {
+		return nil
+	}
+	name, err := objectName(module, file)
 	if err != nil {
+		return err
+	}
+	if err := c.objectStorage.Put(ctx, name, data); err != nil {
 		return xerrors.WithStack(err)
 	}
 
 	return nil
 }
 
+// objectName returns the name of the object.
+// The layout of the object storage is similar to GOPROXY (e.g. golang.org/x/mod/@v/v0.21.0.zip).
+func objectName(modulePath, file string) (string, error) {
+	escPath, err := module.EscapePath(modulePath)
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+
+	return path.Join(escPath, "@v", file), nil
+}
+
+// legacyArchiveName returns the name of the archive file in the legacy layout (e.g. golang.org/x/mod@v0.21.0.zip).
+// The archive files stored in the legacy layout are still readable and deleted by the garbage collection.
+func legacyArchiveName(modulePath, version string) string {
+	return fmt.Sprintf("%s@%s.zip", modulePath, version)
+}
+
 func (c *ModuleCache) Ping() error {
 	_, err := c.cachePool.Version()
 	if err != nil {
@@ -0,0 +1,223 @@
+package gomodule
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.f110.dev/go-memcached/client"
+	merrors "go.f110.dev/go-memcached/errors"
+
+	"go.f110.dev/mono/go/storage"
+)
+
+// fakeMemcachedServer is the in-memory implementation of client.Server.
+type fakeMemcachedServer struct {
+	mu     sync.Mutex
+	items  map[string]*client.Item
+	setErr error
+}
+
+var _ client.Server = &fakeMemcachedServer{}
+
+func newFakeMemcachedServer() *fakeMemcachedServer {
+	return &fakeMemcachedServer{items: make(map[string]*client.Item)}
+}
+
+func (s *fakeMemcachedServer) Name() string { return "fake" }
+func (s *fakeMemcachedServer) Close() error { return nil }
+
+func (s *fakeMemcachedServer) Get(key string) (*client.Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.items[key]; ok {
+		return v, nil
+	}
+	return nil, merrors.ItemNotFound
+}
+
+func (s *fakeMemcachedServer) GetMulti(keys ...string) ([]*client.Item, error) {
+	var items []*client.Item
+	for _, v := range keys {
+		if item, err := s.Get(v); err == nil {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (s *fakeMemcachedServer) Set(item *client.Item) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.setErr != nil {
+		return s.setErr
+	}
+	s.items[item.Key] = item
+	return nil
+}
+
+func (s *fakeMemcachedServer) Add(item *client.Item) error     { return s.Set(item) }
+func (s *fakeMemcachedServer) Replace(item *client.Item) error { return s.Set(item) }
+
+func (s *fakeMemcachedServer) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.items[key]; !ok {
+		return merrors.ItemNotFound
+	}
+	delete(s.items, key)
+	return nil
+}
+
+func (s *fakeMemcachedServer) Increment(string, int, int) (int64, error) { return 0, nil }
+func (s *fakeMemcachedServer) Decrement(string, int, int) (int64, error) { return 0, nil }
+func (s *fakeMemcachedServer) Touch(string, int) error                   { return nil }
+
+func (s *fakeMemcachedServer) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items = make(map[string]*client.Item)
+	return nil
+}
+
+func (s *fakeMemcachedServer) Version() (string, error) { return "fake", nil }
+func (s *fakeMemcachedServer) Reconnect() error         { return nil }
+
+func newTestModuleCache(t *testing.T) (*ModuleCache, *fakeMemcachedServer, *storage.Mock) {
+	server := newFakeMemcachedServer()
+	pool, err := client.NewSinglePool(server)
+	require.NoError(t, err)
+	objStorage := storage.NewMock()
+
+	return newModuleCache(pool, objStorage), server, objStorage
+}
+
+func TestModuleCache_ReadThrough(t *testing.T) {
+	cache, memcached, objStorage := newTestModuleCache(t)
+
+	t.Run("ModFile", func(t *testing.T) {
+		err := cache.SetModFile("go.example.com/Foo", "v1.0.0", []byte("module go.example.com/Foo\n"))
+		require.NoError(t, err)
+		_, err = objStorage.Get(context.Background(), "go.example.com/!foo/@v/v1.0.0.mod")
+		require.NoError(t, err)
+
+		// The item is read from the object storage and promoted to memcached.
+		require.NoError(t, memcached.Flush())
+		buf, err := cache.GetModFile("go.example.com/Foo", "v1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, "module go.example.com/Foo\n", string(buf))
+		item, err := memcached.Get("goModFile/go.example.com/Foo/v1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, buf, item.Value)
+
+		_, err = cache.GetModFile("go.example.com/Foo", "v2.0.0")
+		assert.ErrorIs(t, err, merrors.ItemNotFound)
+	})
+
+	t.Run("ModInfo", func(t *testing.T) {
+		now := time.Now().Truncate(time.Second).UTC()
+		err := cache.SetModInfo("go.example.com/foo", "0123456789abcdef", now)
+		require.NoError(t, err)
+
+		require.NoError(t, memcached.Flush())
+		got, err := cache.GetModInfo("go.example.com/foo", "0123456789abcdef")
+		require.NoError(t, err)
+		assert.True(t, now.Equal(got))
+		_, err = memcached.Get("modInfo/go.example.com/foo/0123456789ab")
+		require.NoError(t, err)
+	})
+
+	t.Run("Archive", func(t *testing.T) {
+		err := cache.SaveArchive(context.Background(), "go.example.com/foo", "v1.0.0", []byte("zip"))
+		require.NoError(t, err)
+		buf := new(bytes.Buffer)
+		err = cache.Archive(context.Background(), "go.example.com/foo", "v1.0.0", buf)
+		require.NoError(t, err)
+		assert.Equal(t, "zip", buf.String())
+
+		err = cache.Archive(context.Background(), "go.example.com/foo", "v2.0.0", buf)
+		assert.ErrorIs(t, err, CacheMiss)
+	})
+
+	t.Run("LegacyArchive", func(t *testing.T) {
+		err := objStorage.Put(context.Background(), "go.example.com/Legacy@v1.0.0.zip", []byte("legacy"))
+		require.NoError(t, err)
+		buf := new(bytes.Buffer)
+		err = cache.Archive(context.Background(), "go.example.com/Legacy", "v1.0.0", buf)
+		require.NoError(t, err)
+		assert.Equal(t, "legacy", buf.String())
+	})
+
+	t.Run("FailedToPromote", func(t *testing.T) {
+		require.NoError(t, cache.SetModFile("go.example.com/bar", "v1.0.0", []byte("module go.example.com/bar\n")))
+		now := time.Now().Truncate(time.Second).UTC()
+		require.NoError(t, cache.SetModInfo("go.example.com/bar", "0123456789abcdef", now))
+		require.NoError(t, memcached.Flush())
+
+		// The item is returned even if memcached is unavailable.
+		memcached.setErr = errors.New("memcached is unavailable")
+		defer func() { memcached.setErr = nil }()
+		buf, err := cache.GetModFile("go.example.com/bar", "v1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, "module go.example.com/bar\n", string(buf))
+		got, err := cache.GetModInfo("go.example.com/bar", "0123456789abcdef")
+		require.NoError(t, err)
+		assert.True(t, now.Equal(got))
+	})
+}
+
+func TestModuleCache_DeleteVersion(t *testing.T) {
+	cache, memcached, objStorage := newTestModuleCache(t)
+
+	ctx := context.Background()
+	require.NoError(t, cache.SetModFile("go.example.com/foo", "v1.0.0", []byte("module go.example.com/foo\n")))
+	require.NoError(t, cache.SaveArchive(ctx, "go.example.com/foo", "v1.0.0", []byte("zip")))
+	require.NoError(t, cache.SaveArchive(ctx, "go.example.com/foo", "v1.1.0", []byte("zip")))
+	require.NoError(t, cache.SaveArchive(ctx, "go.example.com/foo/bar", "v0.1.0", []byte("zip")))
+
+	stored, err := cache.StoredVersions(ctx)
+	require.NoError(t, err)
+	assert.Len(t, stored, 2)
+	assert.ElementsMatch(t, []string{"v1.0.0", "v1.1.0"}, stored["go.example.com/foo"])
+	assert.ElementsMatch(t, []string{"v0.1.0"}, stored["go.example.com/foo/bar"])
+
+	err = cache.DeleteVersion(ctx, "go.example.com/foo", "v1.0.0")
+	require.NoError(t, err)
+	stored, err = cache.StoredVersions(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"v1.1.0"}, stored["go.example.com/foo"])
+	_, err = memcached.Get("goModFile/go.example.com/foo/v1.0.0")
+	assert.ErrorIs(t, err, merrors.ItemNotFound)
+
+	t.Run("Legacy", func(t *testing.T) {
+		require.NoError(t, objStorage.Put(ctx, "go.example.com/Legacy@v1.0.0.zip", []byte("zip")))
+		stored, err := cache.StoredVersions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v1.0.0"}, stored["go.example.com/Legacy"])
+
+		err = cache.DeleteVersion(ctx, "go.example.com/Legacy", "v1.0.0")
+		require.NoError(t, err)
+		_, err = objStorage.Get(ctx, "go.example.com/Legacy@v1.0.0.zip")
+		assert.Error(t, err)
+	})
+
+	t.Run("Info", func(t *testing.T) {
+		require.NoError(t, cache.SetModInfo("go.example.com/foo", "0123456789abcdef", time.Now()))
+		infos, err := cache.StoredInfos(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"0123456789ab"}, infos["go.example.com/foo"])
+
+		err = cache.DeleteInfo(ctx, "go.example.com/foo", "0123456789ab")
+		require.NoError(t, err)
+		infos, err = cache.StoredInfos(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, infos["go.example.com/foo"])
+		_, err = memcached.Get("modInfo/go.example.com/foo/0123456789ab")
+		assert.ErrorIs(t, err, merrors.ItemNotFound)
+	})
+}
@@ -13,11 +13,58 @@ import (
 type ModuleSetting struct {
 	ModuleName string `yaml:"module_name"`
 	URLReplace string `yaml:"url_replace"`
+	// AccessRules is a list of the rule to restrict the user who can fetch the module.
+	// If AccessRules is empty, all authenticated users can fetch the module.
+	AccessRules []*AccessRule `yaml:"access_rules"`
 
 	match         *regexp.Regexp
 	replaceRegexp *regexputil.RegexpLiteral
 }
 
+// IsAllowed returns true if the user can fetch the module.
+func (s *ModuleSetting) IsAllowed(module string, user *User) bool {
+	if len(s.AccessRules) == 0 {
+		return true
+	}
+	if user == nil {
+		return false
+	}
+
+	for _, v := range s.AccessRules {
+		if v.match(module, user) {
+			return true
+		}
+	}
+	return false
+}
+
+type AccessRule struct {
+	// Module is a pattern of the module name. If Module is empty, the rule is applied to all modules of the setting.
+	Module string   `yaml:"module"`
+	Users  []string `yaml:"users"`
+	Groups []string `yaml:"groups"`
+
+	moduleMatch *regexp.Regexp
+}
+
+func (r *AccessRule) match(module string, user *User) bool {
+	if r.moduleMatch != nil && !r.moduleMatch.MatchString(module) {
+		return false
+	}
+
+	for _, v := range r.Users {
+		if v == user.Name {
+			return true
+		}
+	}
+	for _, v := range r.Groups {
+		if user.InGroup(v) {
+			return true
+		}
+	}
+	return false
+}
+
 type Config []*ModuleSetting
 
 func ReadConfig(path string) (Config, error) {
@@ -44,6 +91,17 @@ func ReadConfig(path string) (Config, error) {
 			}
 			v.replaceRegexp = regexpLiteral
 		}
+
+		for _, r := range v.AccessRules {
+			if r.Module == "" {
+				continue
+			}
+			re, err := regexp.Compile(r.Module)
+			if err != nil {
+				return nil, xerrors.WithStack(err)
+			}
+			r.moduleMatch = re
+		}
 	}
 
 	return conf, nil
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
@@ -182,6 +183,23 @@ func (m *ModuleRoot) SetCache() error {
 	return nil
 }
 
+// Refresh reads the modules and the versions from the repository again and updates the cache.
+func (m *ModuleRoot) Refresh(ctx context.Context) error {
+	modules, err := m.findModules(ctx)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	m.Modules = modules
+	if err := m.findVersions(); err != nil {
+		return xerrors.WithStack(err)
+	}
+	if err := m.SetCache(); err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	return nil
+}
+
 func (m *ModuleRoot) FindModule(module string) *Module {
 	for _, mod := range m.Modules {
 		if mod.Path == module {
@@ -657,8 +675,14 @@ func (vcs *VCS) Download(ctx context.Context, dir string) error {
 	}
 	err := vcs.gitRepo.FetchContext(ctx, &git.FetchOptions{
 		RemoteName: "origin",
-		Auth:       vcs.getAuthMethod(ctx),
-		CABundle:   vcs.caBundle},
+		// Fetch tags explicitly and prune them for following the deleted and the moved tags.
+		RefSpecs: []config.RefSpec{
+			"+refs/heads/*:refs/remotes/origin/*",
+			"+refs/tags/*:refs/tags/*",
+		},
+		Prune:    true,
+		Auth:     vcs.getAuthMethod(ctx),
+		CABundle: vcs.caBundle},
 	)
 	if err != nil && err != git.NoErrAlreadyUpToDate {
 		return xerrors.WithStack(err)
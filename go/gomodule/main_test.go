@@ -0,0 +1,12 @@
+package gomodule
+
+import (
+	"testing"
+
+	"go.f110.dev/mono/go/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.Init()
+	m.Run()
+}
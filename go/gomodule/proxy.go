@@ -17,6 +17,7 @@ import (
 	"go.f110.dev/xerrors"
 	"go.uber.org/zap"
 	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
 	modzip "golang.org/x/mod/zip"
 
 	"go.f110.dev/mono/go/githubutil"
@@ -252,6 +253,104 @@ func (m *ModuleProxy) FlushAllCache() error {
 	return nil
 }
 
+// GarbageCollect deletes the versions which are stored in the cache but no longer referenced by tags.
+// Pseudo-versions are not deleted because they are not referenced by tags.
+func (m *ModuleProxy) GarbageCollect(ctx context.Context) error {
+	stored, err := m.cache.StoredVersions(ctx)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	for modPath, vers := range stored {
+		conf := m.GetConfig(modPath)
+		if conf == nil {
+			continue
+		}
+		moduleRoot, err := m.fetcher.Get(ctx, modPath, conf)
+		if err != nil {
+			logger.Log.Info("Failed to get the module", zap.String("module", modPath), zap.Error(err))
+			continue
+		}
+		// The versions of ModuleRoot may be read from the cache. Update the versions by the repository.
+		if err := moduleRoot.Refresh(ctx); err != nil {
+			logger.Log.Info("Failed to refresh the module", zap.String("module", modPath), zap.Error(err))
+			continue
+		}
+		mod := moduleRoot.FindModule(modPath)
+		if mod == nil {
+			continue
+		}
+
+		for _, v := range unreferencedVersions(vers, mod.Versions) {
+			logger.Log.Info("Delete the version which is no longer referenced", zap.String("module", modPath), zap.String("version", v))
+			if err := m.cache.DeleteVersion(ctx, modPath, v); err != nil {
+				return xerrors.WithStack(err)
+			}
+		}
+	}
+
+	// The info is stored per commit. The info is no longer needed if no pseudo-version of the commit is stored.
+	infos, err := m.cache.StoredInfos(ctx)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	for modPath, shas := range infos {
+		for _, v := range unreferencedInfos(shas, stored[modPath]) {
+			logger.Log.Info("Delete the info which is no longer referenced", zap.String("module", modPath), zap.String("sha", v))
+			if err := m.cache.DeleteInfo(ctx, modPath, v); err != nil {
+				return xerrors.WithStack(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// unreferencedInfos returns the abbreviated commit hashes which are not the revision of the stored pseudo-versions.
+func unreferencedInfos(shas, vers []string) []string {
+	referenced := make(map[string]struct{})
+	for _, v := range vers {
+		if !module.IsPseudoVersion(v) {
+			continue
+		}
+		rev, err := module.PseudoVersionRev(v)
+		if err != nil {
+			continue
+		}
+		referenced[rev] = struct{}{}
+	}
+
+	var unreferenced []string
+	for _, v := range shas {
+		if _, ok := referenced[v]; ok {
+			continue
+		}
+		unreferenced = append(unreferenced, v)
+	}
+	return unreferenced
+}
+
+// unreferencedVersions returns the versions which are not in tags.
+// The version which is not a semantic version or a pseudo-version is ignored.
+func unreferencedVersions(vers []string, tags []*ModuleVersion) []string {
+	referenced := make(map[string]struct{})
+	for _, v := range tags {
+		referenced[v.Semver] = struct{}{}
+	}
+
+	var unreferenced []string
+	for _, v := range vers {
+		if !semver.IsValid(v) || module.IsPseudoVersion(v) {
+			continue
+		}
+		if _, ok := referenced[v]; ok {
+			continue
+		}
+		unreferenced = append(unreferenced, v)
+	}
+	return unreferenced
+}
+
 func (m *ModuleProxy) Ready() bool {
 	if err := m.cache.Ping(); err != nil {
 		logger.Log.Warn("ModuleCache is not ready", logger.Error(err))
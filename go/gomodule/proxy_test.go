@@ -38,3 +38,29 @@ func TestParsePseudoVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestUnreferencedVersions(t *testing.T) {
+	tags := []*ModuleVersion{
+		{Version: "v1.0.0", Semver: "v1.0.0"},
+		{Version: "sub/v0.1.0", Semver: "v0.1.0"},
+	}
+	vers := []string{
+		"v1.0.0",
+		"v0.1.0",
+		"v1.1.0",
+		"v0.0.0-20060102150405-abcdef123456",
+		"abcdef123456",
+	}
+
+	assert.Equal(t, []string{"v1.1.0"}, unreferencedVersions(vers, tags))
+}
+
+func TestUnreferencedInfos(t *testing.T) {
+	shas := []string{"abcdef123456", "0123456789ab"}
+	vers := []string{
+		"v1.0.0",
+		"v0.0.0-20060102150405-abcdef123456",
+	}
+
+	assert.Equal(t, []string{"0123456789ab"}, unreferencedInfos(shas, vers))
+}
@@ -8,11 +8,15 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/google/go-github/v49/github"
 	"github.com/gorilla/mux"
 	"go.f110.dev/xerrors"
 	"go.uber.org/zap"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb"
 
 	"go.f110.dev/mono/go/logger"
 )
@@ -22,9 +26,24 @@ type ProxyServer struct {
 	rr    *httputil.ReverseProxy
 	r     *mux.Router
 	proxy *ModuleProxy
+
+	sumDB       *SumDB
+	sumDBServer *sumdb.Server
+
+	accessControl *AccessControl
+	webhookSecret []byte
+	webhookQueue  chan *RepositoryEvent
+	cancel        context.CancelFunc
 }
 
-func NewProxyServer(addr string, upstream *url.URL, proxy *ModuleProxy) *ProxyServer {
+// webhookQueueSize is the maximum number of the events which are waiting for the update of the cache.
+const webhookQueueSize = 32
+
+// NewProxyServer returns the server of the module proxy.
+// If sumDB is not nil, the server also serves the checksum database.
+// If accessControl is nil, the server doesn't require the authentication.
+// If webhookSecret is empty, the endpoint of the webhook is disabled because the payload can't be verified.
+func NewProxyServer(addr string, upstream *url.URL, proxy *ModuleProxy, sumDB *SumDB, accessControl *AccessControl, webhookSecret string) *ProxyServer {
 	targetQuery := upstream.RawQuery
 	director := func(req *http.Request) {
 		req.URL.Scheme = upstream.Scheme
@@ -42,15 +61,27 @@ func NewProxyServer(addr string, upstream *url.URL, proxy *ModuleProxy) *ProxySe
 	}
 
 	s := &ProxyServer{
-		r:     mux.NewRouter(),
-		rr:    &httputil.ReverseProxy{Director: director},
-		proxy: proxy,
+		r:             mux.NewRouter(),
+		rr:            &httputil.ReverseProxy{Director: director},
+		proxy:         proxy,
+		accessControl: accessControl,
+	}
+	if webhookSecret != "" {
+		s.webhookSecret = []byte(webhookSecret)
+		s.webhookQueue = make(chan *RepositoryEvent, webhookQueueSize)
+	}
+	if sumDB != nil {
+		s.sumDB = sumDB
+		s.sumDBServer = sumdb.NewServer(sumDB)
 	}
 	s.s = &http.Server{
 		Addr:    addr,
 		Handler: s.r,
 	}
 
+	// Endpoints for checksum database
+	s.r.Methods(http.MethodGet).PathPrefix("/sumdb/").HandlerFunc(s.sumdb)
+
 	// Endpoints for Go module proxy
 	s.r.Methods(http.MethodGet).Path("/{module:.+}/@v/list").HandlerFunc(s.handle(s.list))
 	s.r.Methods(http.MethodGet).Path("/{module:.+}/@v/{version}.info").HandlerFunc(s.handle(s.info))
@@ -63,17 +94,29 @@ func NewProxyServer(addr string, upstream *url.URL, proxy *ModuleProxy) *ProxySe
 	s.r.Methods(http.MethodGet).Path("/{module:.+}/@v/invalidate").HandlerFunc(s.handle(s.invalidate))
 	s.r.Methods(http.MethodPost).Path("/flush_all").HandlerFunc(s.flushAll) // This endpoint is hidden.
 
+	// Endpoints for webhook
+	if s.webhookSecret != nil {
+		s.r.Methods(http.MethodPost).Path("/_/webhook/github").HandlerFunc(s.githubWebhook)
+	}
+
 	// Probes
 	s.r.Methods(http.MethodGet).Path("/_/liveness").HandlerFunc(func(w http.ResponseWriter, req *http.Request) {})
 	s.r.Methods(http.MethodGet).Path("/_/readiness").HandlerFunc(s.readiness)
 
 	s.r.Use(middlewareAccessLog)
 	s.r.Use(middlewareDebugInfo)
+	s.r.Use(s.middlewareAuth)
 
 	return s
 }
 
 func (s *ProxyServer) Start() error {
+	if s.webhookQueue != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		s.cancel = cancel
+		go s.processRepositoryEvents(ctx)
+	}
+
 	logger.Log.Info("Start proxy", zap.String("addr", s.s.Addr))
 	if err := s.s.ListenAndServe(); err != nil {
 		if err == http.ErrServerClosed {
@@ -87,6 +130,9 @@ func (s *ProxyServer) Start() error {
 }
 
 func (s *ProxyServer) Stop(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
 	return s.s.Shutdown(ctx)
 }
 
@@ -97,7 +143,14 @@ func (s *ProxyServer) handle(h func(w http.ResponseWriter, req *http.Request, mo
 			http.Error(w, "bad request", http.StatusBadRequest)
 			return
 		}
-		if s.proxy.IsProxy(vars["module"]) {
+		isProxy := s.proxy.IsProxy(vars["module"])
+		allowed := !isProxy || s.isAllowed(req, vars["module"])
+		s.audit(req, vars["module"], vars["version"], isProxy, allowed)
+		if !allowed {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		if isProxy {
 			h(w, req, vars["module"], vars["version"])
 			return
 		}
@@ -106,7 +159,137 @@ func (s *ProxyServer) handle(h func(w http.ResponseWriter, req *http.Request, mo
 	}
 }
 
-func (s *ProxyServer) index(w http.ResponseWriter, _ *http.Request) {
+// isAllowed returns true if the user of the request can fetch the module.
+func (s *ProxyServer) isAllowed(req *http.Request, module string) bool {
+	if s.accessControl == nil {
+		return true
+	}
+	conf := s.proxy.GetConfig(module)
+	if conf == nil {
+		return true
+	}
+
+	return conf.IsAllowed(module, UserFromContext(req.Context()))
+}
+
+// audit records who fetched the module and whether the request was allowed.
+func (s *ProxyServer) audit(req *http.Request, module, version string, isProxy, allowed bool) {
+	var userName string
+	if user := UserFromContext(req.Context()); user != nil {
+		userName = user.Name
+	}
+	logger.Log.Info("Audit",
+		zap.String("user", userName),
+		zap.String("module", module),
+		zap.String("version", version),
+		zap.String("path", req.URL.Path),
+		zap.Bool("upstream", !isProxy),
+		zap.Bool("allowed", allowed),
+		zap.String("remote_addr", req.RemoteAddr),
+	)
+}
+
+// middlewareAuth authenticates the request and stores the user into the context of the request.
+// The probes don't require the authentication.
+func (s *ProxyServer) middlewareAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if s.accessControl == nil || strings.HasPrefix(req.URL.Path, "/_/") {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		user, err := s.accessControl.Authenticate(req)
+		if err != nil {
+			logger.Log.Info("Failed to authenticate", zap.Error(err), zap.String("remote_addr", req.RemoteAddr))
+			w.Header().Set("WWW-Authenticate", `Basic realm="gomodule-proxy"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, req.WithContext(withUser(req.Context(), user)))
+	})
+}
+
+// sumdb serves the checksum database which is maintained by the proxy.
+// The requests for other checksum databases are forwarded to the upstream.
+func (s *ProxyServer) sumdb(w http.ResponseWriter, req *http.Request) {
+	name, p, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/sumdb/"), "/")
+	if s.sumDB == nil || name != s.sumDB.Name() {
+		s.rr.ServeHTTP(w, req)
+		return
+	}
+
+	if p == "supported" {
+		return
+	}
+	if s.accessControl != nil && strings.HasPrefix(p, "lookup/") {
+		escPath, _, _ := strings.Cut(strings.TrimPrefix(p, "lookup/"), "@")
+		if modPath, err := module.UnescapePath(escPath); err == nil && s.proxy.IsProxy(modPath) && !s.isAllowed(req, modPath) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+	}
+	// The data tiles contain the records of all modules including the private modules.
+	// The go command doesn't need the data tiles for verifying the module. Only the administrator can read them.
+	if s.accessControl != nil && isDataTile(p) && !s.accessControl.IsAdmin(UserFromContext(req.Context())) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	r := req.Clone(req.Context())
+	r.URL.Path = "/" + p
+	s.sumDBServer.ServeHTTP(w, r)
+}
+
+// isDataTile returns true if p is the path of the data tile (tile/H/data/...).
+func isDataTile(p string) bool {
+	s := strings.Split(p, "/")
+	return len(s) > 2 && s[0] == "tile" && s[2] == "data"
+}
+
+// githubWebhook updates the cache of the module by the event of GitHub.
+// The endpoint doesn't require the authentication because GitHub can't send the credential.
+// Instead, the signature of the payload is always verified.
+func (s *ProxyServer) githubWebhook(w http.ResponseWriter, req *http.Request) {
+	payload, err := github.ValidatePayload(req, s.webhookSecret)
+	if err != nil {
+		logger.Log.Info("Invalid payload", zap.Error(err))
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	e, err := ParseGitHubWebHook(github.WebHookType(req), payload)
+	if err != nil {
+		logger.Log.Info("Failed to parse the payload", zap.Error(err))
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	if e == nil {
+		return
+	}
+
+	// Updating the cache may take a long time. The event is processed by the worker.
+	select {
+	case s.webhookQueue <- e:
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		logger.Log.Warn("Drop the event because the queue is full", zap.String("repo", e.RepositoryURL), zap.String("ref", e.Ref))
+		http.Error(w, "too many events", http.StatusServiceUnavailable)
+	}
+}
+
+// processRepositoryEvents updates the cache by the events of the webhook one by one.
+func (s *ProxyServer) processRepositoryEvents(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-s.webhookQueue:
+			if err := s.proxy.UpdateByRepositoryEvent(ctx, e); err != nil {
+				logger.Log.Warn("Failed to update the cache", zap.String("repo", e.RepositoryURL), zap.String("ref", e.Ref), logger.Error(err))
+			}
+		}
+	}
+}
+
+func (s *ProxyServer) index(w http.ResponseWriter, req *http.Request) {
 	cachedModuleRoots, err := s.proxy.CachedModuleRoots()
 	if err != nil {
 		logger.Log.Info("failed to get a list of modules", zap.Error(err))
@@ -118,6 +301,9 @@ func (s *ProxyServer) index(w http.ResponseWriter, _ *http.Request) {
 	io.WriteString(w, "<h3>Cached modules</h3>\n")
 	io.WriteString(w, "<ul>")
 	for _, v := range cachedModuleRoots {
+		if !s.isAllowed(req, v.RootPath) {
+			continue
+		}
 		io.WriteString(w, "<li>")
 		fmt.Fprintf(w, "%s <a href=\"/%s/@v/invalidate\">[invalidate]</a>", v.RootPath, v.RootPath)
 		io.WriteString(w, "<ul>\n")
@@ -139,7 +325,11 @@ func (s *ProxyServer) invalidate(w http.ResponseWriter, req *http.Request, modul
 	http.Redirect(w, req, "/", http.StatusSeeOther)
 }
 
-func (s *ProxyServer) flushAll(w http.ResponseWriter, _ *http.Request) {
+func (s *ProxyServer) flushAll(w http.ResponseWriter, req *http.Request) {
+	if s.accessControl != nil && !s.accessControl.IsAdmin(UserFromContext(req.Context())) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
 	if err := s.proxy.FlushAllCache(); err != nil {
 		http.Error(w, fmt.Sprintf("failed flush all cache: %v", err), http.StatusInternalServerError)
 		return
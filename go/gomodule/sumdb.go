@@ -0,0 +1,570 @@
+package gomodule
+
+import (
+	"archive/zip"
+	"bytes"
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.f110.dev/xerrors"
+	"go.uber.org/zap"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb"
+	"golang.org/x/mod/sumdb/dirhash"
+	"golang.org/x/mod/sumdb/note"
+	"golang.org/x/mod/sumdb/tlog"
+
+	"go.f110.dev/mono/go/logger"
+	"go.f110.dev/mono/go/storage"
+)
+
+// DefaultUpstreamSumDBKey is the verifier key of sum.golang.org.
+const DefaultUpstreamSumDBKey = "sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ko0+sMdyxtSnh/Mtk"
+
+// upstreamCacheSize is the maximum number of the files (tiles and lookup results) of the upstream checksum database
+// that are kept in the memory.
+const upstreamCacheSize = 4096
+
+// moduleSource is the source of the module that is served by the proxy.
+// ModuleProxy satisfies this interface.
+type moduleSource interface {
+	IsProxy(module string) bool
+	GetGoMod(ctx context.Context, moduleName, version string) (string, error)
+	GetZip(ctx context.Context, w io.Writer, moduleName, version string) error
+}
+
+// RecordStorageInterface is the object storage which stores the records of the checksum database.
+// PutIfNotExist prevents the processes which share the object storage from writing the different records at the same id.
+type RecordStorageInterface interface {
+	ObjectStorageInterface
+	PutIfNotExist(ctx context.Context, name string, data []byte) error
+}
+
+// SumDB is the checksum database for the modules that are served by the proxy.
+// SumDB maintains the transparent log which is compatible with GOSUMDB.
+// The hashes of the private module are computed by SumDB itself.
+// The hashes of the public module are copied from the upstream checksum database after verifying it.
+type SumDB struct {
+	signer   note.Signer
+	storage  RecordStorageInterface
+	source   moduleSource
+	upstream *sumdb.Client
+
+	// appendMu serializes appending the record in the process. mu is held only while updating the tree in the memory.
+	appendMu sync.Mutex
+	mu       sync.RWMutex
+	records  [][]byte
+	hashes   []tlog.Hash
+	index    map[module.Version]int64
+	signed   []byte
+}
+
+var _ sumdb.ServerOps = &SumDB{}
+
+// NewSumDB returns the checksum database that is signed by signerKey.
+// If upstream is not nil, the record of the public module will be imported from upstream.
+func NewSumDB(ctx context.Context, signerKey string, s RecordStorageInterface, proxy *ModuleProxy, upstream *UpstreamSumDB) (*SumDB, error) {
+	db, err := newSumDB(ctx, signerKey, s, proxy)
+	if err != nil {
+		return nil, err
+	}
+	if upstream != nil {
+		db.upstream = sumdb.NewClient(upstream)
+	}
+
+	return db, nil
+}
+
+func newSumDB(ctx context.Context, signerKey string, s RecordStorageInterface, source moduleSource) (*SumDB, error) {
+	signer, err := note.NewSigner(strings.TrimSpace(signerKey))
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	db := &SumDB{
+		signer:  signer,
+		storage: s,
+		source:  source,
+		index:   make(map[module.Version]int64),
+	}
+	// The signed tree head of the empty tree
+	signed, err := db.sign(tlog.Tree{N: 0})
+	if err != nil {
+		return nil, err
+	}
+	db.signed = signed
+	if err := db.load(ctx); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Name returns the name of the checksum database.
+func (s *SumDB) Name() string {
+	return s.signer.Name()
+}
+
+// load reads all records from the object storage and rebuilds the tree.
+func (s *SumDB) load(ctx context.Context) error {
+	objs, err := s.storage.List(ctx, s.recordPrefix())
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	sort.Slice(objs, func(i, j int) bool { return objs[i].Name < objs[j].Name })
+
+	for i, v := range objs {
+		if v.Name != s.recordPath(int64(i)) {
+			return xerrors.Definef("the record %d is missing", i).WithStack()
+		}
+		obj, err := s.storage.Get(ctx, v.Name)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		buf, err := io.ReadAll(obj.Body)
+		obj.Body.Close()
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		if err := s.appendRecord(buf); err != nil {
+			return err
+		}
+	}
+	if len(s.records) > 0 {
+		logger.Log.Debug("Loaded the checksum database", zap.String("name", s.Name()), zap.Int("records", len(s.records)))
+	}
+
+	return nil
+}
+
+// loadAppended reads the records which have been appended by other processes since the last load.
+// The caller must hold appendMu.
+func (s *SumDB) loadAppended(ctx context.Context) error {
+	for loaded := 0; ; loaded++ {
+		id := int64(len(s.records))
+		obj, err := s.storage.Get(ctx, s.recordPath(id))
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			if loaded == 0 {
+				return xerrors.Definef("the record %d exists but couldn't be read", id).WithStack()
+			}
+			return nil
+		} else if err != nil {
+			return xerrors.WithStack(err)
+		}
+		buf, err := io.ReadAll(obj.Body)
+		obj.Body.Close()
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+
+		s.mu.Lock()
+		err = s.appendRecord(buf)
+		s.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Signed returns the signed hash of the latest tree.
+func (s *SumDB) Signed(_ context.Context) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.signed, nil
+}
+
+// ReadRecords returns the content for the n records id through id+n-1.
+func (s *SumDB) ReadRecords(_ context.Context, id, n int64) ([][]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if id < 0 || n < 0 || id+n > int64(len(s.records)) {
+		return nil, &fs.PathError{Op: "read", Path: fmt.Sprintf("record/%d", id), Err: fs.ErrNotExist}
+	}
+	return s.records[id : id+n], nil
+}
+
+// Lookup looks up a record for the given module, returning the record ID.
+// If the module is not in the tree yet, Lookup will compute the hashes of the module and append the record to the tree.
+func (s *SumDB) Lookup(ctx context.Context, m module.Version) (int64, error) {
+	s.mu.RLock()
+	id, ok := s.index[m]
+	s.mu.RUnlock()
+	if ok {
+		return id, nil
+	}
+
+	var record []byte
+	var err error
+	if s.source.IsProxy(m.Path) {
+		record, err = s.computeRecord(ctx, m)
+	} else {
+		record, err = s.importRecord(m)
+	}
+	if err != nil {
+		logger.Log.Info("Failed to get the hash of the module", zap.String("module", m.String()), zap.Error(err))
+		return 0, &fs.PathError{Op: "lookup", Path: m.String(), Err: fs.ErrNotExist}
+	}
+
+	s.appendMu.Lock()
+	defer s.appendMu.Unlock()
+	for {
+		// The record may be appended by an other request while computing the hash.
+		// Only the holder of appendMu modifies the tree, so the read lock is enough here.
+		s.mu.RLock()
+		id, ok = s.index[m]
+		n := int64(len(s.records))
+		s.mu.RUnlock()
+		if ok {
+			return id, nil
+		}
+		id = n
+
+		// The other process which shares the object storage may have written the record at the same id.
+		// In that case, the records written by the other process are read and the record is appended after them.
+		err = s.storage.PutIfNotExist(ctx, s.recordPath(id), record)
+		if errors.Is(err, storage.ErrObjectExists) {
+			if err := s.loadAppended(ctx); err != nil {
+				return 0, err
+			}
+			continue
+		} else if err != nil {
+			return 0, xerrors.WithStack(err)
+		}
+		break
+	}
+	s.mu.Lock()
+	err = s.appendRecord(record)
+	s.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	logger.Log.Info("Append the record to the checksum database", zap.String("module", m.String()), zap.Int64("id", id))
+
+	return id, nil
+}
+
+// ReadTileData reads the content of tile t.
+func (s *SumDB) ReadTileData(_ context.Context, t tlog.Tile) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, err := tlog.ReadTileData(t, s.hashReader())
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: t.Path(), Err: fs.ErrNotExist}
+	}
+	return data, nil
+}
+
+// appendRecord appends the record to the tree and updates the signed tree head.
+// The caller must hold the lock.
+func (s *SumDB) appendRecord(record []byte) error {
+	id := int64(len(s.records))
+	m, err := parseRecordModule(record)
+	if err != nil {
+		return err
+	}
+	hashes, err := tlog.StoredHashes(id, record, s.hashReader())
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	treeHash, err := tlog.TreeHash(id+1, tlog.HashReaderFunc(func(indexes []int64) ([]tlog.Hash, error) {
+		// The new hashes aren't appended to s.hashes yet.
+		// TreeHash reads only O(log n) hashes, so the hashes are looked up without concatenating them.
+		base := int64(len(s.hashes))
+		list := make([]tlog.Hash, len(indexes))
+		for i, v := range indexes {
+			if v >= base && v-base < int64(len(hashes)) {
+				list[i] = hashes[v-base]
+				continue
+			}
+			h, err := readHashes(s.hashes, []int64{v})
+			if err != nil {
+				return nil, err
+			}
+			list[i] = h[0]
+		}
+		return list, nil
+	}))
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	signed, err := s.sign(tlog.Tree{N: id + 1, Hash: treeHash})
+	if err != nil {
+		return err
+	}
+
+	s.records = append(s.records, record)
+	s.hashes = append(s.hashes, hashes...)
+	s.index[m] = id
+	s.signed = signed
+	return nil
+}
+
+func (s *SumDB) sign(tree tlog.Tree) ([]byte, error) {
+	signed, err := note.Sign(&note.Note{Text: string(tlog.FormatTree(tree))}, s.signer)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	return signed, nil
+}
+
+func (s *SumDB) hashReader() tlog.HashReader {
+	return tlog.HashReaderFunc(func(indexes []int64) ([]tlog.Hash, error) {
+		return readHashes(s.hashes, indexes)
+	})
+}
+
+// computeRecord computes the hashes of the module served by the proxy.
+func (s *SumDB) computeRecord(ctx context.Context, m module.Version) ([]byte, error) {
+	goMod, err := s.source.GetGoMod(ctx, m.Path, m.Version)
+	if err != nil {
+		return nil, err
+	}
+	modHash, err := dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(goMod)), nil
+	})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := s.source.GetZip(ctx, buf, m.Path, m.Version); err != nil {
+		return nil, err
+	}
+	zipHash, err := hashZip(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(fmt.Sprintf("%s %s %s\n%s %s/go.mod %s\n", m.Path, m.Version, zipHash, m.Path, m.Version, modHash)), nil
+}
+
+// importRecord reads the record of the public module from the upstream checksum database.
+func (s *SumDB) importRecord(m module.Version) ([]byte, error) {
+	if s.upstream == nil {
+		return nil, xerrors.Definef("%s is not served by the proxy", m.Path).WithStack()
+	}
+
+	var lines []string
+	for _, v := range []string{m.Version, m.Version + "/go.mod"} {
+		l, err := s.upstream.Lookup(m.Path, v)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		lines = append(lines, l...)
+	}
+	if len(lines) == 0 {
+		return nil, xerrors.Definef("%s is not found in the upstream", m.String()).WithStack()
+	}
+
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+func (s *SumDB) recordPrefix() string {
+	return path.Join("sumdb", s.Name(), "records")
+}
+
+func (s *SumDB) recordPath(id int64) string {
+	return fmt.Sprintf("%s/%016d", s.recordPrefix(), id)
+}
+
+func readHashes(hashes []tlog.Hash, indexes []int64) ([]tlog.Hash, error) {
+	list := make([]tlog.Hash, len(indexes))
+	for i, v := range indexes {
+		if v < 0 || v >= int64(len(hashes)) {
+			return nil, xerrors.Definef("hash %d is out of range", v).WithStack()
+		}
+		list[i] = hashes[v]
+	}
+	return list, nil
+}
+
+// parseRecordModule returns the module of the record.
+// The first line of the record is "<module> <version> <hash>".
+func parseRecordModule(record []byte) (module.Version, error) {
+	line, _, _ := bytes.Cut(record, []byte("\n"))
+	f := strings.Fields(string(line))
+	if len(f) != 3 {
+		return module.Version{}, xerrors.Definef("malformed record: %q", line).WithStack()
+	}
+	return module.Version{Path: f[0], Version: strings.TrimSuffix(f[1], "/go.mod")}, nil
+}
+
+// hashZip is the same as dirhash.HashZip but reads the zip from the memory.
+func hashZip(data []byte) (string, error) {
+	z, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	var files []string
+	zfiles := make(map[string]*zip.File)
+	for _, file := range z.File {
+		files = append(files, file.Name)
+		zfiles[file.Name] = file
+	}
+	h, err := dirhash.Hash1(files, func(name string) (io.ReadCloser, error) {
+		f := zfiles[name]
+		if f == nil {
+			return nil, xerrors.Definef("file %q not found in zip", name).WithStack()
+		}
+		return f.Open()
+	})
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	return h, nil
+}
+
+// UpstreamSumDB is the client side operations for the upstream checksum database.
+// UpstreamSumDB accesses the checksum database through the upstream module proxy.
+// The latest signed tree head is persisted to the object storage for detecting the fork of the upstream.
+type UpstreamSumDB struct {
+	key      string
+	name     string
+	baseURL  *url.URL
+	client   *http.Client
+	storage  ObjectStorageInterface
+	cacheMu  sync.Mutex
+	cache    map[string]*list.Element
+	lru      *list.List
+	configMu sync.Mutex
+}
+
+type upstreamCacheEntry struct {
+	file string
+	data []byte
+}
+
+var _ sumdb.ClientOps = &UpstreamSumDB{}
+
+func NewUpstreamSumDB(key string, proxyURL *url.URL, s ObjectStorageInterface) (*UpstreamSumDB, error) {
+	verifier, err := note.NewVerifier(key)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	u := *proxyURL
+	u.Path = path.Join(u.Path, "sumdb", verifier.Name())
+
+	return &UpstreamSumDB{
+		key:     key,
+		name:    verifier.Name(),
+		baseURL: &u,
+		client:  &http.Client{Transport: &httpTransport{}},
+		storage: s,
+		cache:   make(map[string]*list.Element),
+		lru:     list.New(),
+	}, nil
+}
+
+func (u *UpstreamSumDB) ReadRemote(p string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, u.baseURL.String()+p, nil)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	res, err := u.client.Do(req)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, xerrors.Definef("%s: unexpected status code: %d", p, res.StatusCode).WithStack()
+	}
+	buf, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	return buf, nil
+}
+
+func (u *UpstreamSumDB) ReadConfig(file string) ([]byte, error) {
+	if file == "key" {
+		return []byte(u.key), nil
+	}
+
+	obj, err := u.storage.Get(context.Background(), u.configPath(file))
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			// Start with an empty tree
+			return []byte{}, nil
+		}
+		return nil, xerrors.WithStack(err)
+	}
+	defer obj.Body.Close()
+	buf, err := io.ReadAll(obj.Body)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	return buf, nil
+}
+
+func (u *UpstreamSumDB) WriteConfig(file string, old, new []byte) error {
+	u.configMu.Lock()
+	defer u.configMu.Unlock()
+
+	current, err := u.ReadConfig(file)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(current, old) {
+		return sumdb.ErrWriteConflict
+	}
+	if err := u.storage.Put(context.Background(), u.configPath(file), new); err != nil {
+		return xerrors.WithStack(err)
+	}
+	return nil
+}
+
+func (u *UpstreamSumDB) ReadCache(file string) ([]byte, error) {
+	u.cacheMu.Lock()
+	defer u.cacheMu.Unlock()
+
+	if e, ok := u.cache[file]; ok {
+		u.lru.MoveToFront(e)
+		return e.Value.(*upstreamCacheEntry).data, nil
+	}
+	return nil, fs.ErrNotExist
+}
+
+// WriteCache stores the file in the memory.
+// The least recently used file is evicted when the number of the files exceeds upstreamCacheSize.
+func (u *UpstreamSumDB) WriteCache(file string, data []byte) {
+	u.cacheMu.Lock()
+	defer u.cacheMu.Unlock()
+
+	if e, ok := u.cache[file]; ok {
+		e.Value.(*upstreamCacheEntry).data = data
+		u.lru.MoveToFront(e)
+		return
+	}
+	u.cache[file] = u.lru.PushFront(&upstreamCacheEntry{file: file, data: data})
+	for u.lru.Len() > upstreamCacheSize {
+		e := u.lru.Back()
+		u.lru.Remove(e)
+		delete(u.cache, e.Value.(*upstreamCacheEntry).file)
+	}
+}
+
+func (u *UpstreamSumDB) Log(msg string) {
+	logger.Log.Debug(msg, zap.String("sumdb", u.name))
+}
+
+func (u *UpstreamSumDB) SecurityError(msg string) {
+	logger.Log.Error("Security error from the upstream checksum database", zap.String("sumdb", u.name), zap.String("msg", msg))
+}
+
+func (u *UpstreamSumDB) configPath(file string) string {
+	return path.Join("sumdb", "upstream", file)
+}
@@ -0,0 +1,201 @@
+package gomodule
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb"
+	"golang.org/x/mod/sumdb/dirhash"
+	"golang.org/x/mod/sumdb/note"
+
+	"go.f110.dev/mono/go/storage"
+)
+
+// fakeModuleSource serves the modules that have only go.mod.
+// The key of map is the module path.
+type fakeModuleSource map[string]string
+
+func (f fakeModuleSource) IsProxy(module string) bool {
+	_, ok := f[module]
+	return ok
+}
+
+func (f fakeModuleSource) GetGoMod(_ context.Context, moduleName, _ string) (string, error) {
+	if v, ok := f[moduleName]; ok {
+		return v, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (f fakeModuleSource) GetZip(_ context.Context, w io.Writer, moduleName, version string) error {
+	goMod, ok := f[moduleName]
+	if !ok {
+		return os.ErrNotExist
+	}
+	zw := zip.NewWriter(w)
+	fw, err := zw.Create(fmt.Sprintf("%s@%s/go.mod", moduleName, version))
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(fw, goMod); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func TestSumDB(t *testing.T) {
+	skey, vkey, err := note.GenerateKey(rand.Reader, "sum.example.com")
+	require.NoError(t, err)
+	source := fakeModuleSource{"example.com/private": "module example.com/private\n"}
+	objStorage := storage.NewMock()
+	db, err := newSumDB(context.Background(), skey, objStorage, source)
+	require.NoError(t, err)
+	assert.Equal(t, "sum.example.com", db.Name())
+
+	s := httptest.NewServer(NewProxyServer("", &url.URL{}, nil, db, nil, "").s.Handler)
+	t.Cleanup(s.Close)
+	proxyURL, err := url.Parse(s.URL)
+	require.NoError(t, err)
+
+	res, err := http.Get(s.URL + "/sumdb/sum.example.com/supported")
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	// Verify the records by the client of the checksum database
+	upstream, err := NewUpstreamSumDB(vkey, proxyURL, storage.NewMock())
+	require.NoError(t, err)
+	client := sumdb.NewClient(upstream)
+	lines, err := client.Lookup("example.com/private", "v1.0.0")
+	require.NoError(t, err)
+	buf := new(strings.Builder)
+	require.NoError(t, source.GetZip(context.Background(), buf, "example.com/private", "v1.0.0"))
+	zipHash, err := hashZip([]byte(buf.String()))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com/private v1.0.0 " + zipHash}, lines)
+	lines, err = client.Lookup("example.com/private", "v1.0.0/go.mod")
+	require.NoError(t, err)
+	modHash, err := dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(source["example.com/private"])), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com/private v1.0.0/go.mod " + modHash}, lines)
+	_, err = client.Lookup("example.com/private", "v1.1.0")
+	require.NoError(t, err)
+
+	// The public module is not found because the upstream is not configured
+	_, err = db.Lookup(context.Background(), module.Version{Path: "example.com/public", Version: "v1.0.0"})
+	assert.True(t, os.IsNotExist(err))
+
+	// The tree is restored from the object storage
+	restored, err := newSumDB(context.Background(), skey, objStorage, source)
+	require.NoError(t, err)
+	signed, err := db.Signed(context.Background())
+	require.NoError(t, err)
+	restoredSigned, err := restored.Signed(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, signed, restoredSigned)
+	id, err := restored.Lookup(context.Background(), module.Version{Path: "example.com/private", Version: "v1.1.0"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, id)
+
+	t.Run("Upstream", func(t *testing.T) {
+		skey, vkey, err := note.GenerateKey(rand.Reader, "sum.example.org")
+		require.NoError(t, err)
+		mirrorUpstream, err := NewUpstreamSumDB(upstream.key, proxyURL, storage.NewMock())
+		require.NoError(t, err)
+		mirror, err := newSumDB(context.Background(), skey, storage.NewMock(), fakeModuleSource{})
+		require.NoError(t, err)
+		mirror.upstream = sumdb.NewClient(mirrorUpstream)
+
+		// The requests for the other checksum database are forwarded to the upstream.
+		ms := httptest.NewServer(NewProxyServer("", proxyURL, nil, mirror, nil, "").s.Handler)
+		t.Cleanup(ms.Close)
+		res, err := http.Get(ms.URL + "/sumdb/sum.example.com/latest")
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		msURL, err := url.Parse(ms.URL)
+		require.NoError(t, err)
+		mirrorClientOps, err := NewUpstreamSumDB(vkey, msURL, storage.NewMock())
+		require.NoError(t, err)
+		mirrorClient := sumdb.NewClient(mirrorClientOps)
+		lines, err := mirrorClient.Lookup("example.com/private", "v1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"example.com/private v1.0.0 " + zipHash}, lines)
+		lines, err = mirrorClient.Lookup("example.com/private", "v1.0.0/go.mod")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"example.com/private v1.0.0/go.mod " + modHash}, lines)
+	})
+}
+
+func TestSumDB_SharedStorage(t *testing.T) {
+	skey, _, err := note.GenerateKey(rand.Reader, "sum.example.com")
+	require.NoError(t, err)
+	source := fakeModuleSource{"example.com/foo": "module example.com/foo\n", "example.com/bar": "module example.com/bar\n"}
+	objStorage := storage.NewMock()
+	// Both processes start with the empty tree.
+	db1, err := newSumDB(context.Background(), skey, objStorage, source)
+	require.NoError(t, err)
+	db2, err := newSumDB(context.Background(), skey, objStorage, source)
+	require.NoError(t, err)
+
+	id, err := db1.Lookup(context.Background(), module.Version{Path: "example.com/foo", Version: "v1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), id)
+	// db2 doesn't know the record 0 yet. The record must not be overwritten.
+	id, err = db2.Lookup(context.Background(), module.Version{Path: "example.com/bar", Version: "v1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+	id, err = db2.Lookup(context.Background(), module.Version{Path: "example.com/foo", Version: "v1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), id)
+
+	restored, err := newSumDB(context.Background(), skey, objStorage, source)
+	require.NoError(t, err)
+	signed, err := db2.Signed(context.Background())
+	require.NoError(t, err)
+	restoredSigned, err := restored.Signed(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, signed, restoredSigned)
+}
+
+func TestUpstreamSumDB_Cache(t *testing.T) {
+	_, vkey, err := note.GenerateKey(rand.Reader, "sum.example.com")
+	require.NoError(t, err)
+	u, err := NewUpstreamSumDB(vkey, &url.URL{}, storage.NewMock())
+	require.NoError(t, err)
+
+	for i := 0; i < upstreamCacheSize; i++ {
+		u.WriteCache(fmt.Sprintf("tile/%d", i), []byte{byte(i)})
+	}
+	// Touch the oldest file so that the second oldest file is evicted.
+	_, err = u.ReadCache("tile/0")
+	require.NoError(t, err)
+	u.WriteCache("lookup/example.com/foo@v1.0.0", []byte("foo"))
+
+	assert.Len(t, u.cache, upstreamCacheSize)
+	assert.Equal(t, upstreamCacheSize, u.lru.Len())
+	_, err = u.ReadCache("tile/1")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	buf, err := u.ReadCache("tile/0")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0}, buf)
+	buf, err = u.ReadCache("lookup/example.com/foo@v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("foo"), buf)
+}
@@ -0,0 +1,168 @@
+package gomodule
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/google/go-github/v49/github"
+	"go.f110.dev/xerrors"
+	"go.uber.org/zap"
+	"golang.org/x/mod/module"
+
+	"go.f110.dev/mono/go/logger"
+)
+
+// RepositoryEvent represents the change of the reference of the repository.
+type RepositoryEvent struct {
+	RepositoryURL string
+	// Ref is the full name of the reference (e.g. refs/heads/master, refs/tags/v1.0.0).
+	Ref     string
+	Deleted bool
+}
+
+// ParseGitHubWebHook converts the payload of the webhook to RepositoryEvent.
+// If the event doesn't change any reference, ParseGitHubWebHook returns nil.
+func ParseGitHubWebHook(messageType string, payload []byte) (*RepositoryEvent, error) {
+	event, err := github.ParseWebHook(messageType, payload)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	switch event := event.(type) {
+	case *github.PushEvent:
+		return &RepositoryEvent{
+			RepositoryURL: event.Repo.GetHTMLURL(),
+			Ref:           event.GetRef(),
+			Deleted:       event.GetDeleted(),
+		}, nil
+	case *github.CreateEvent:
+		if event.GetRefType() != "tag" {
+			return nil, nil
+		}
+		return &RepositoryEvent{
+			RepositoryURL: event.Repo.GetHTMLURL(),
+			Ref:           "refs/tags/" + event.GetRef(),
+		}, nil
+	case *github.DeleteEvent:
+		if event.GetRefType() != "tag" {
+			return nil, nil
+		}
+		return &RepositoryEvent{
+			RepositoryURL: event.Repo.GetHTMLURL(),
+			Ref:           "refs/tags/" + event.GetRef(),
+			Deleted:       true,
+		}, nil
+	case *github.ReleaseEvent:
+		// The tag may be created by publishing the release.
+		if event.GetAction() != "published" && event.GetAction() != "created" {
+			return nil, nil
+		}
+		return &RepositoryEvent{
+			RepositoryURL: event.Repo.GetHTMLURL(),
+			Ref:           "refs/tags/" + event.Release.GetTagName(),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// UpdateByRepositoryEvent updates the cache of the modules in the repository.
+// When the tag is created, the new version appears in the list immediately and the archive of the version is made in advance.
+// When the tag is deleted, the cache of the version is deleted.
+func (m *ModuleProxy) UpdateByRepositoryEvent(ctx context.Context, e *RepositoryEvent) error {
+	if m.cache == nil {
+		return nil
+	}
+
+	moduleRoots, err := m.cache.CachedModuleRoots()
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	for _, v := range moduleRoots {
+		if !isSameRepository(v.RepositoryURL, e.RepositoryURL) {
+			continue
+		}
+		if err := m.updateModuleRoot(ctx, v.RootPath, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *ModuleProxy) updateModuleRoot(ctx context.Context, rootPath string, e *RepositoryEvent) error {
+	conf := m.GetConfig(rootPath)
+	if conf == nil {
+		return nil
+	}
+	moduleRoot, err := m.fetcher.Get(ctx, rootPath, conf)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	tag, isTag := strings.CutPrefix(e.Ref, "refs/tags/")
+	var deleted []module.Version
+	if isTag && e.Deleted {
+		// Find the versions before refreshing because the tag will disappear.
+		deleted = moduleRoot.versionsByTag(tag)
+	}
+	if err := moduleRoot.Refresh(ctx); err != nil {
+		return xerrors.WithStack(err)
+	}
+	logger.Log.Info("Refreshed the module", zap.String("root", rootPath), zap.String("ref", e.Ref))
+	if !isTag {
+		return nil
+	}
+
+	if e.Deleted {
+		for _, v := range deleted {
+			logger.Log.Info("Delete the cache of the version", zap.String("module", v.Path), zap.String("version", v.Version))
+			if err := m.cache.DeleteVersion(ctx, v.Path, v.Version); err != nil {
+				return xerrors.WithStack(err)
+			}
+		}
+		return nil
+	}
+
+	for _, v := range moduleRoot.versionsByTag(tag) {
+		logger.Log.Info("Pre-warm the cache", zap.String("module", v.Path), zap.String("version", v.Version))
+		if _, err := m.GetGoMod(ctx, v.Path, v.Version); err != nil {
+			return xerrors.WithStack(err)
+		}
+		if err := m.GetZip(ctx, io.Discard, v.Path, v.Version); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+// versionsByTag returns the versions of modules which are pointed by the tag.
+func (m *ModuleRoot) versionsByTag(tag string) []module.Version {
+	var vers []module.Version
+	for _, mod := range m.Modules {
+		for _, v := range mod.Versions {
+			if v.Version == tag {
+				vers = append(vers, module.Version{Path: mod.Path, Version: v.Semver})
+			}
+		}
+	}
+	return vers
+}
+
+func isSameRepository(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	return normalizeRepositoryURL(a) == normalizeRepositoryURL(b)
+}
+
+func normalizeRepositoryURL(u string) string {
+	u = strings.ToLower(u)
+	if _, after, ok := strings.Cut(u, "://"); ok {
+		u = after
+	}
+	u = strings.TrimSuffix(u, "/")
+	return strings.TrimSuffix(u, ".git")
+}
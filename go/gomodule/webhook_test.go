@@ -0,0 +1,186 @@
+package gomodule
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGitHubWebHook(t *testing.T) {
+	cases := []struct {
+		Type     string
+		Payload  string
+		Expected *RepositoryEvent
+	}{
+		{
+			Type:     "push",
+			Payload:  `{"ref":"refs/heads/master","repository":{"html_url":"https://github.com/example/foo"}}`,
+			Expected: &RepositoryEvent{RepositoryURL: "https://github.com/example/foo", Ref: "refs/heads/master"},
+		},
+		{
+			Type:     "push",
+			Payload:  `{"ref":"refs/tags/v1.0.0","deleted":true,"repository":{"html_url":"https://github.com/example/foo"}}`,
+			Expected: &RepositoryEvent{RepositoryURL: "https://github.com/example/foo", Ref: "refs/tags/v1.0.0", Deleted: true},
+		},
+		{
+			Type:     "create",
+			Payload:  `{"ref":"v1.0.0","ref_type":"tag","repository":{"html_url":"https://github.com/example/foo"}}`,
+			Expected: &RepositoryEvent{RepositoryURL: "https://github.com/example/foo", Ref: "refs/tags/v1.0.0"},
+		},
+		{
+			Type:    "create",
+			Payload: `{"ref":"feature","ref_type":"branch","repository":{"html_url":"https://github.com/example/foo"}}`,
+		},
+		{
+			Type:     "delete",
+			Payload:  `{"ref":"v1.0.0","ref_type":"tag","repository":{"html_url":"https://github.com/example/foo"}}`,
+			Expected: &RepositoryEvent{RepositoryURL: "https://github.com/example/foo", Ref: "refs/tags/v1.0.0", Deleted: true},
+		},
+		{
+			Type:     "release",
+			Payload:  `{"action":"published","release":{"tag_name":"v1.0.0"},"repository":{"html_url":"https://github.com/example/foo"}}`,
+			Expected: &RepositoryEvent{RepositoryURL: "https://github.com/example/foo", Ref: "refs/tags/v1.0.0"},
+		},
+		{
+			Type:    "release",
+			Payload: `{"action":"deleted","release":{"tag_name":"v1.0.0"},"repository":{"html_url":"https://github.com/example/foo"}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Type, func(t *testing.T) {
+			e, err := ParseGitHubWebHook(tc.Type, []byte(tc.Payload))
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, e)
+		})
+	}
+}
+
+func TestModuleProxy_UpdateByRepositoryEvent(t *testing.T) {
+	srcDir := t.TempDir()
+	repo, err := git.PlainInit(srcDir, false)
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	addFile(t, wt, srcDir, "go.mod", []byte("module go.example.com/foo\n"))
+	tagCommit := func(tag string) {
+		hash, err := wt.Commit(tag, &git.CommitOptions{Author: &object.Signature{Email: "test@example.com", When: time.Now()}})
+		require.NoError(t, err)
+		_, err = repo.CreateTag(tag, hash, &git.CreateTagOptions{
+			Tagger:  &object.Signature{Email: "test@example.com", When: time.Now()},
+			Message: tag,
+		})
+		require.NoError(t, err)
+	}
+	tagCommit("v1.0.0")
+
+	confFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(confFile, []byte("- module_name: go.example.com/.*\n"), 0644))
+	conf, err := ReadConfig(confFile)
+	require.NoError(t, err)
+	cache, _, _ := newTestModuleCache(t)
+	// The repository of the module is the local directory.
+	require.NoError(t, cache.SetRepoRoot("go.example.com/foo", "go.example.com/foo", srcDir))
+	proxy := NewModuleProxy(conf, t.TempDir(), cache, nil, nil, nil)
+
+	ctx := context.Background()
+	vers, err := proxy.Versions(ctx, "go.example.com/foo")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0"}, vers)
+
+	// New tag
+	addFile(t, wt, srcDir, "foo.go", []byte("package foo\n"))
+	tagCommit("v1.1.0")
+	vers, err = proxy.Versions(ctx, "go.example.com/foo")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0"}, vers, "Versions should be read from the cache")
+
+	err = proxy.UpdateByRepositoryEvent(ctx, &RepositoryEvent{RepositoryURL: srcDir + ".git", Ref: "refs/tags/v1.1.0"})
+	require.NoError(t, err)
+	vers, err = proxy.Versions(ctx, "go.example.com/foo")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"v1.0.0", "v1.1.0"}, vers)
+	stored, err := cache.StoredVersions(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"v1.1.0"}, stored["go.example.com/foo"], "v1.1.0 should be pre-warmed")
+
+	// Delete the tag
+	require.NoError(t, repo.Storer.RemoveReference(plumbing.NewTagReferenceName("v1.1.0")))
+	err = proxy.UpdateByRepositoryEvent(ctx, &RepositoryEvent{RepositoryURL: srcDir, Ref: "refs/tags/v1.1.0", Deleted: true})
+	require.NoError(t, err)
+	vers, err = proxy.Versions(ctx, "go.example.com/foo")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0"}, vers)
+	stored, err = cache.StoredVersions(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, stored["go.example.com/foo"])
+}
+
+func TestProxyServer_GitHubWebhook(t *testing.T) {
+	payload := `{"ref":"v1.0.0","ref_type":"tag","repository":{"html_url":"https://github.com/example/foo"}}`
+	newRequest := func(secret string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/_/webhook/github", strings.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-GitHub-Event", "delete")
+		if secret != "" {
+			mac := hmac.New(sha256.New, []byte(secret))
+			mac.Write([]byte(payload))
+			req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		}
+		return req
+	}
+
+	t.Run("WithoutSecret", func(t *testing.T) {
+		s := NewProxyServer("", &url.URL{}, nil, nil, nil, "")
+		rec := httptest.NewRecorder()
+		s.s.Handler.ServeHTTP(rec, newRequest(""))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("Unsigned", func(t *testing.T) {
+		s := NewProxyServer("", &url.URL{}, nil, nil, nil, "secret")
+		rec := httptest.NewRecorder()
+		s.s.Handler.ServeHTTP(rec, newRequest(""))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Len(t, s.webhookQueue, 0)
+
+		rec = httptest.NewRecorder()
+		s.s.Handler.ServeHTTP(rec, newRequest("other"))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Len(t, s.webhookQueue, 0)
+	})
+
+	t.Run("Signed", func(t *testing.T) {
+		s := NewProxyServer("", &url.URL{}, nil, nil, nil, "secret")
+		rec := httptest.NewRecorder()
+		s.s.Handler.ServeHTTP(rec, newRequest("secret"))
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		require.Len(t, s.webhookQueue, 1)
+		assert.Equal(t, &RepositoryEvent{RepositoryURL: "https://github.com/example/foo", Ref: "refs/tags/v1.0.0", Deleted: true}, <-s.webhookQueue)
+	})
+
+	t.Run("QueueIsFull", func(t *testing.T) {
+		s := NewProxyServer("", &url.URL{}, nil, nil, nil, "secret")
+		for i := 0; i < webhookQueueSize; i++ {
+			s.webhookQueue <- &RepositoryEvent{}
+		}
+		rec := httptest.NewRecorder()
+		s.s.Handler.ServeHTTP(rec, newRequest("secret"))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
@@ -16,6 +16,10 @@ const (
 	userAgent = "mono-api-client"
 )
 
+var (
+	ErrNotFound = xerrors.Define("grafana: not found")
+)
+
 type Client struct {
 	host     string
 	user     string
@@ -135,6 +139,47 @@ func (c *Client) ChangeUserPermission(id int, admin bool) error {
 	return nil
 }
 
+func (c *Client) do(method, path string, body, out any) error {
+	u, err := url.Parse(c.host)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	u.Path = path
+
+	var r io.Reader
+	if body != nil {
+		buf := new(bytes.Buffer)
+		if err := json.NewEncoder(buf).Encode(body); err != nil {
+			return xerrors.WithStack(err)
+		}
+		r = buf
+	}
+	req, err := c.newRequest(method, u.String(), r)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return ErrNotFound.WithStack()
+	default:
+		return xerrors.Definef("%s %s: %s", method, path, res.Status).WithStack()
+	}
+	if out != nil {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
 func (c *Client) newRequest(method, url string, body io.Reader) (*http.Request, error) {
 	req, err := http.NewRequest(method, url, body)
 	if err != nil {
@@ -0,0 +1,122 @@
+package grafana
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.f110.dev/xerrors"
+)
+
+const (
+	PermissionView  = 1
+	PermissionEdit  = 2
+	PermissionAdmin = 4
+)
+
+type Folder struct {
+	ID    int    `json:"id"`
+	UID   string `json:"uid"`
+	Title string `json:"title"`
+}
+
+type Dashboard struct {
+	// Model is the JSON model of the dashboard.
+	Model map[string]any `json:"dashboard"`
+	Meta  *DashboardMeta `json:"meta,omitempty"`
+}
+
+type DashboardMeta struct {
+	FolderUID string `json:"folderUid"`
+	Version   int64  `json:"version"`
+	URL       string `json:"url"`
+}
+
+type SavedDashboard struct {
+	ID      int    `json:"id"`
+	UID     string `json:"uid"`
+	URL     string `json:"url"`
+	Version int64  `json:"version"`
+}
+
+type DashboardPermission struct {
+	UserID     int    `json:"userId,omitempty"`
+	UserEmail  string `json:"userEmail,omitempty"`
+	TeamID     int    `json:"teamId,omitempty"`
+	Role       string `json:"role,omitempty"`
+	Permission int    `json:"permission"`
+	Inherited  bool   `json:"inherited,omitempty"`
+}
+
+func (c *Client) Folders() ([]*Folder, error) {
+	folders := make([]*Folder, 0)
+	if err := c.do(http.MethodGet, "/api/folders", nil, &folders); err != nil {
+		return nil, err
+	}
+
+	return folders, nil
+}
+
+func (c *Client) CreateFolder(title string) (*Folder, error) {
+	folder := &Folder{}
+	if err := c.do(http.MethodPost, "/api/folders", &Folder{Title: title}, folder); err != nil {
+		return nil, err
+	}
+
+	return folder, nil
+}
+
+// Dashboard returns the dashboard which has uid. If the dashboard is not found, Dashboard returns ErrNotFound.
+func (c *Client) Dashboard(uid string) (*Dashboard, error) {
+	dashboard := &Dashboard{}
+	if err := c.do(http.MethodGet, fmt.Sprintf("/api/dashboards/uid/%s", uid), nil, dashboard); err != nil {
+		return nil, err
+	}
+
+	return dashboard, nil
+}
+
+// SaveDashboard creates or overwrites the dashboard. If the model has uid, the dashboard which has the same uid will be overwritten.
+func (c *Client) SaveDashboard(model map[string]any, folderUID string) (*SavedDashboard, error) {
+	body := struct {
+		Dashboard map[string]any `json:"dashboard"`
+		FolderUID string         `json:"folderUid,omitempty"`
+		Overwrite bool           `json:"overwrite"`
+	}{
+		Dashboard: model,
+		FolderUID: folderUID,
+		Overwrite: true,
+	}
+	saved := &SavedDashboard{}
+	if err := c.do(http.MethodPost, "/api/dashboards/db", body, saved); err != nil {
+		return nil, xerrors.WithMessage(err, "failed save dashboard")
+	}
+
+	return saved, nil
+}
+
+func (c *Client) DeleteDashboard(uid string) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/api/dashboards/uid/%s", uid), nil, nil)
+}
+
+func (c *Client) DashboardPermissions(uid string) ([]*DashboardPermission, error) {
+	permissions := make([]*DashboardPermission, 0)
+	if err := c.do(http.MethodGet, fmt.Sprintf("/api/dashboards/uid/%s/permissions", uid), nil, &permissions); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+// UpdateDashboardPermissions replaces all permissions of the dashboard except inherited permissions.
+func (c *Client) UpdateDashboardPermissions(uid string, permissions []*DashboardPermission) error {
+	body := struct {
+		Items []*DashboardPermission `json:"items"`
+	}{
+		Items: permissions,
+	}
+	if err := c.do(http.MethodPost, fmt.Sprintf("/api/dashboards/uid/%s/permissions", uid), body, nil); err != nil {
+		return xerrors.WithMessage(err, "failed update dashboard permissions")
+	}
+
+	return nil
+}
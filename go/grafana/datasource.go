@@ -0,0 +1,68 @@
+package grafana
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.f110.dev/xerrors"
+)
+
+type Datasource struct {
+	ID             int               `json:"id,omitempty"`
+	UID            string            `json:"uid,omitempty"`
+	Name           string            `json:"name"`
+	Type           string            `json:"type"`
+	URL            string            `json:"url"`
+	Access         string            `json:"access"`
+	IsDefault      bool              `json:"isDefault"`
+	BasicAuth      bool              `json:"basicAuth"`
+	BasicAuthUser  string            `json:"basicAuthUser,omitempty"`
+	JSONData       map[string]any    `json:"jsonData,omitempty"`
+	SecureJSONData map[string]string `json:"secureJsonData,omitempty"`
+}
+
+// Datasource returns the datasource which has uid. If the datasource is not found, Datasource returns ErrNotFound.
+func (c *Client) Datasource(uid string) (*Datasource, error) {
+	ds := &Datasource{}
+	if err := c.do(http.MethodGet, fmt.Sprintf("/api/datasources/uid/%s", uid), nil, ds); err != nil {
+		return nil, err
+	}
+
+	return ds, nil
+}
+
+// DatasourceByName returns the datasource which has name. If the datasource is not found, DatasourceByName returns ErrNotFound.
+func (c *Client) DatasourceByName(name string) (*Datasource, error) {
+	ds := &Datasource{}
+	if err := c.do(http.MethodGet, fmt.Sprintf("/api/datasources/name/%s", name), nil, ds); err != nil {
+		return nil, err
+	}
+
+	return ds, nil
+}
+
+func (c *Client) CreateDatasource(ds *Datasource) (*Datasource, error) {
+	res := struct {
+		Datasource *Datasource `json:"datasource"`
+	}{}
+	if err := c.do(http.MethodPost, "/api/datasources", ds, &res); err != nil {
+		return nil, xerrors.WithMessage(err, "failed create datasource")
+	}
+	if res.Datasource == nil {
+		return nil, xerrors.Define("failed create datasource: the response does not have the datasource").WithStack()
+	}
+
+	return res.Datasource, nil
+}
+
+func (c *Client) UpdateDatasource(ds *Datasource) error {
+	if err := c.do(http.MethodPut, fmt.Sprintf("/api/datasources/uid/%s", ds.UID), ds, nil); err != nil {
+		return xerrors.WithMessage(err, "failed update datasource")
+	}
+
+	return nil
+}
+
+func (c *Client) DeleteDatasource(uid string) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/api/datasources/uid/%s", uid), nil, nil)
+}
@@ -19,6 +19,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	"go.f110.dev/mono/go/api/consulv1alpha1"
+	"go.f110.dev/mono/go/api/etcdv1alpha1"
 	"go.f110.dev/mono/go/api/grafanav1alpha1"
 	"go.f110.dev/mono/go/api/harborv1alpha1"
 	"go.f110.dev/mono/go/api/miniov1alpha1"
@@ -33,6 +34,7 @@ var (
 
 var localSchemeBuilder = runtime.SchemeBuilder{
 	consulv1alpha1.AddToScheme,
+	etcdv1alpha1.AddToScheme,
 	grafanav1alpha1.AddToScheme,
 	harborv1alpha1.AddToScheme,
 	miniov1alpha1.AddToScheme,
@@ -42,6 +44,7 @@ var localSchemeBuilder = runtime.SchemeBuilder{
 func init() {
 	for _, v := range []func(*runtime.Scheme) error{
 		consulv1alpha1.AddToScheme,
+		etcdv1alpha1.AddToScheme,
 		grafanav1alpha1.AddToScheme,
 		harborv1alpha1.AddToScheme,
 		miniov1alpha1.AddToScheme,
@@ -64,6 +67,7 @@ type Backend interface {
 }
 type Set struct {
 	ConsulV1alpha1         *ConsulV1alpha1
+	EtcdV1alpha1           *EtcdV1alpha1
 	GrafanaV1alpha1        *GrafanaV1alpha1
 	HarborV1alpha1         *HarborV1alpha1
 	MinioV1alpha1          *MinioV1alpha1
@@ -83,6 +87,17 @@ func NewSet(cfg *rest.Config) (*Set, error) {
 		}
 		s.ConsulV1alpha1 = NewConsulV1alpha1Client(&restBackend{client: c})
 	}
+	{
+		conf := *cfg
+		conf.GroupVersion = &etcdv1alpha1.SchemaGroupVersion
+		conf.APIPath = "/apis"
+		conf.NegotiatedSerializer = Codecs.WithoutConversion()
+		c, err := rest.RESTClientFor(&conf)
+		if err != nil {
+			return nil, err
+		}
+		s.EtcdV1alpha1 = NewEtcdV1alpha1Client(&restBackend{client: c})
+	}
 	{
 		conf := *cfg
 		conf.GroupVersion = &grafanav1alpha1.SchemaGroupVersion
@@ -284,6 +299,110 @@ func (c *ConsulV1alpha1) WatchConsulBackup(ctx context.Context, namespace string
 	return c.backend.Watch(ctx, schema.GroupVersionResource{Group: "consul.f110.dev", Version: "v1alpha1", Resource: "consulbackups"}, namespace, opts)
 }
 
+func (c *ConsulV1alpha1) GetConsulRestore(ctx context.Context, namespace, name string, opts metav1.GetOptions) (*consulv1alpha1.ConsulRestore, error) {
+	result, err := c.backend.Get(ctx, "consulrestores", "ConsulRestore", namespace, name, opts, &consulv1alpha1.ConsulRestore{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*consulv1alpha1.ConsulRestore), nil
+}
+
+func (c *ConsulV1alpha1) CreateConsulRestore(ctx context.Context, v *consulv1alpha1.ConsulRestore, opts metav1.CreateOptions) (*consulv1alpha1.ConsulRestore, error) {
+	result, err := c.backend.Create(ctx, "consulrestores", "ConsulRestore", v, opts, &consulv1alpha1.ConsulRestore{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*consulv1alpha1.ConsulRestore), nil
+}
+
+func (c *ConsulV1alpha1) UpdateConsulRestore(ctx context.Context, v *consulv1alpha1.ConsulRestore, opts metav1.UpdateOptions) (*consulv1alpha1.ConsulRestore, error) {
+	result, err := c.backend.Update(ctx, "consulrestores", "ConsulRestore", v, opts, &consulv1alpha1.ConsulRestore{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*consulv1alpha1.ConsulRestore), nil
+}
+
+func (c *ConsulV1alpha1) UpdateStatusConsulRestore(ctx context.Context, v *consulv1alpha1.ConsulRestore, opts metav1.UpdateOptions) (*consulv1alpha1.ConsulRestore, error) {
+	result, err := c.backend.UpdateStatus(ctx, "consulrestores", "ConsulRestore", v, opts, &consulv1alpha1.ConsulRestore{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*consulv1alpha1.ConsulRestore), nil
+}
+
+func (c *ConsulV1alpha1) DeleteConsulRestore(ctx context.Context, namespace, name string, opts metav1.DeleteOptions) error {
+	return c.backend.Delete(ctx, schema.GroupVersionResource{Group: "consul.f110.dev", Version: "v1alpha1", Resource: "consulrestores"}, namespace, name, opts)
+}
+
+func (c *ConsulV1alpha1) ListConsulRestore(ctx context.Context, namespace string, opts metav1.ListOptions) (*consulv1alpha1.ConsulRestoreList, error) {
+	result, err := c.backend.List(ctx, "consulrestores", "ConsulRestore", namespace, opts, &consulv1alpha1.ConsulRestoreList{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*consulv1alpha1.ConsulRestoreList), nil
+}
+
+func (c *ConsulV1alpha1) WatchConsulRestore(ctx context.Context, namespace string, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.backend.Watch(ctx, schema.GroupVersionResource{Group: "consul.f110.dev", Version: "v1alpha1", Resource: "consulrestores"}, namespace, opts)
+}
+
+type EtcdV1alpha1 struct {
+	backend Backend
+}
+
+func NewEtcdV1alpha1Client(b Backend) *EtcdV1alpha1 {
+	return &EtcdV1alpha1{backend: b}
+}
+
+func (c *EtcdV1alpha1) GetEtcdBackup(ctx context.Context, namespace, name string, opts metav1.GetOptions) (*etcdv1alpha1.EtcdBackup, error) {
+	result, err := c.backend.Get(ctx, "etcdbackups", "EtcdBackup", namespace, name, opts, &etcdv1alpha1.EtcdBackup{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*etcdv1alpha1.EtcdBackup), nil
+}
+
+func (c *EtcdV1alpha1) CreateEtcdBackup(ctx context.Context, v *etcdv1alpha1.EtcdBackup, opts metav1.CreateOptions) (*etcdv1alpha1.EtcdBackup, error) {
+	result, err := c.backend.Create(ctx, "etcdbackups", "EtcdBackup", v, opts, &etcdv1alpha1.EtcdBackup{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*etcdv1alpha1.EtcdBackup), nil
+}
+
+func (c *EtcdV1alpha1) UpdateEtcdBackup(ctx context.Context, v *etcdv1alpha1.EtcdBackup, opts metav1.UpdateOptions) (*etcdv1alpha1.EtcdBackup, error) {
+	result, err := c.backend.Update(ctx, "etcdbackups", "EtcdBackup", v, opts, &etcdv1alpha1.EtcdBackup{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*etcdv1alpha1.EtcdBackup), nil
+}
+
+func (c *EtcdV1alpha1) UpdateStatusEtcdBackup(ctx context.Context, v *etcdv1alpha1.EtcdBackup, opts metav1.UpdateOptions) (*etcdv1alpha1.EtcdBackup, error) {
+	result, err := c.backend.UpdateStatus(ctx, "etcdbackups", "EtcdBackup", v, opts, &etcdv1alpha1.EtcdBackup{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*etcdv1alpha1.EtcdBackup), nil
+}
+
+func (c *EtcdV1alpha1) DeleteEtcdBackup(ctx context.Context, namespace, name string, opts metav1.DeleteOptions) error {
+	return c.backend.Delete(ctx, schema.GroupVersionResource{Group: "etcd.f110.dev", Version: "v1alpha1", Resource: "etcdbackups"}, namespace, name, opts)
+}
+
+func (c *EtcdV1alpha1) ListEtcdBackup(ctx context.Context, namespace string, opts metav1.ListOptions) (*etcdv1alpha1.EtcdBackupList, error) {
+	result, err := c.backend.List(ctx, "etcdbackups", "EtcdBackup", namespace, opts, &etcdv1alpha1.EtcdBackupList{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*etcdv1alpha1.EtcdBackupList), nil
+}
+
+func (c *EtcdV1alpha1) WatchEtcdBackup(ctx context.Context, namespace string, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.backend.Watch(ctx, schema.GroupVersionResource{Group: "etcd.f110.dev", Version: "v1alpha1", Resource: "etcdbackups"}, namespace, opts)
+}
+
 type GrafanaV1alpha1 struct {
 	backend Backend
 }
@@ -388,6 +507,102 @@ func (c *GrafanaV1alpha1) WatchGrafanaUser(ctx context.Context, namespace string
 	return c.backend.Watch(ctx, schema.GroupVersionResource{Group: "grafana.f110.dev", Version: "v1alpha1", Resource: "grafanausers"}, namespace, opts)
 }
 
+func (c *GrafanaV1alpha1) GetGrafanaDashboard(ctx context.Context, namespace, name string, opts metav1.GetOptions) (*grafanav1alpha1.GrafanaDashboard, error) {
+	result, err := c.backend.Get(ctx, "grafanadashboards", "GrafanaDashboard", namespace, name, opts, &grafanav1alpha1.GrafanaDashboard{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*grafanav1alpha1.GrafanaDashboard), nil
+}
+
+func (c *GrafanaV1alpha1) CreateGrafanaDashboard(ctx context.Context, v *grafanav1alpha1.GrafanaDashboard, opts metav1.CreateOptions) (*grafanav1alpha1.GrafanaDashboard, error) {
+	result, err := c.backend.Create(ctx, "grafanadashboards", "GrafanaDashboard", v, opts, &grafanav1alpha1.GrafanaDashboard{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*grafanav1alpha1.GrafanaDashboard), nil
+}
+
+func (c *GrafanaV1alpha1) UpdateGrafanaDashboard(ctx context.Context, v *grafanav1alpha1.GrafanaDashboard, opts metav1.UpdateOptions) (*grafanav1alpha1.GrafanaDashboard, error) {
+	result, err := c.backend.Update(ctx, "grafanadashboards", "GrafanaDashboard", v, opts, &grafanav1alpha1.GrafanaDashboard{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*grafanav1alpha1.GrafanaDashboard), nil
+}
+
+func (c *GrafanaV1alpha1) UpdateStatusGrafanaDashboard(ctx context.Context, v *grafanav1alpha1.GrafanaDashboard, opts metav1.UpdateOptions) (*grafanav1alpha1.GrafanaDashboard, error) {
+	result, err := c.backend.UpdateStatus(ctx, "grafanadashboards", "GrafanaDashboard", v, opts, &grafanav1alpha1.GrafanaDashboard{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*grafanav1alpha1.GrafanaDashboard), nil
+}
+
+func (c *GrafanaV1alpha1) DeleteGrafanaDashboard(ctx context.Context, namespace, name string, opts metav1.DeleteOptions) error {
+	return c.backend.Delete(ctx, schema.GroupVersionResource{Group: "grafana.f110.dev", Version: "v1alpha1", Resource: "grafanadashboards"}, namespace, name, opts)
+}
+
+func (c *GrafanaV1alpha1) ListGrafanaDashboard(ctx context.Context, namespace string, opts metav1.ListOptions) (*grafanav1alpha1.GrafanaDashboardList, error) {
+	result, err := c.backend.List(ctx, "grafanadashboards", "GrafanaDashboard", namespace, opts, &grafanav1alpha1.GrafanaDashboardList{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*grafanav1alpha1.GrafanaDashboardList), nil
+}
+
+func (c *GrafanaV1alpha1) WatchGrafanaDashboard(ctx context.Context, namespace string, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.backend.Watch(ctx, schema.GroupVersionResource{Group: "grafana.f110.dev", Version: "v1alpha1", Resource: "grafanadashboards"}, namespace, opts)
+}
+
+func (c *GrafanaV1alpha1) GetGrafanaDatasource(ctx context.Context, namespace, name string, opts metav1.GetOptions) (*grafanav1alpha1.GrafanaDatasource, error) {
+	result, err := c.backend.Get(ctx, "grafanadatasources", "GrafanaDatasource", namespace, name, opts, &grafanav1alpha1.GrafanaDatasource{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*grafanav1alpha1.GrafanaDatasource), nil
+}
+
+func (c *GrafanaV1alpha1) CreateGrafanaDatasource(ctx context.Context, v *grafanav1alpha1.GrafanaDatasource, opts metav1.CreateOptions) (*grafanav1alpha1.GrafanaDatasource, error) {
+	result, err := c.backend.Create(ctx, "grafanadatasources", "GrafanaDatasource", v, opts, &grafanav1alpha1.GrafanaDatasource{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*grafanav1alpha1.GrafanaDatasource), nil
+}
+
+func (c *GrafanaV1alpha1) UpdateGrafanaDatasource(ctx context.Context, v *grafanav1alpha1.GrafanaDatasource, opts metav1.UpdateOptions) (*grafanav1alpha1.GrafanaDatasource, error) {
+	result, err := c.backend.Update(ctx, "grafanadatasources", "GrafanaDatasource", v, opts, &grafanav1alpha1.GrafanaDatasource{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*grafanav1alpha1.GrafanaDatasource), nil
+}
+
+func (c *GrafanaV1alpha1) UpdateStatusGrafanaDatasource(ctx context.Context, v *grafanav1alpha1.GrafanaDatasource, opts metav1.UpdateOptions) (*grafanav1alpha1.GrafanaDatasource, error) {
+	result, err := c.backend.UpdateStatus(ctx, "grafanadatasources", "GrafanaDatasource", v, opts, &grafanav1alpha1.GrafanaDatasource{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*grafanav1alpha1.GrafanaDatasource), nil
+}
+
+func (c *GrafanaV1alpha1) DeleteGrafanaDatasource(ctx context.Context, namespace, name string, opts metav1.DeleteOptions) error {
+	return c.backend.Delete(ctx, schema.GroupVersionResource{Group: "grafana.f110.dev", Version: "v1alpha1", Resource: "grafanadatasources"}, namespace, name, opts)
+}
+
+func (c *GrafanaV1alpha1) ListGrafanaDatasource(ctx context.Context, namespace string, opts metav1.ListOptions) (*grafanav1alpha1.GrafanaDatasourceList, error) {
+	result, err := c.backend.List(ctx, "grafanadatasources", "GrafanaDatasource", namespace, opts, &grafanav1alpha1.GrafanaDatasourceList{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*grafanav1alpha1.GrafanaDatasourceList), nil
+}
+
+func (c *GrafanaV1alpha1) WatchGrafanaDatasource(ctx context.Context, namespace string, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.backend.Watch(ctx, schema.GroupVersionResource{Group: "grafana.f110.dev", Version: "v1alpha1", Resource: "grafanadatasources"}, namespace, opts)
+}
+
 type HarborV1alpha1 struct {
 	backend Backend
 }
@@ -787,10 +1002,18 @@ func (f *InformerFactory) InformerFor(obj runtime.Object) cache.SharedIndexInfor
 	switch obj.(type) {
 	case *consulv1alpha1.ConsulBackup:
 		return NewConsulV1alpha1Informer(f.cache, f.set.ConsulV1alpha1, f.namespace, f.resyncPeriod).ConsulBackupInformer()
+	case *consulv1alpha1.ConsulRestore:
+		return NewConsulV1alpha1Informer(f.cache, f.set.ConsulV1alpha1, f.namespace, f.resyncPeriod).ConsulRestoreInformer()
+	case *etcdv1alpha1.EtcdBackup:
+		return NewEtcdV1alpha1Informer(f.cache, f.set.EtcdV1alpha1, f.namespace, f.resyncPeriod).EtcdBackupInformer()
 	case *grafanav1alpha1.Grafana:
 		return NewGrafanaV1alpha1Informer(f.cache, f.set.GrafanaV1alpha1, f.namespace, f.resyncPeriod).GrafanaInformer()
 	case *grafanav1alpha1.GrafanaUser:
 		return NewGrafanaV1alpha1Informer(f.cache, f.set.GrafanaV1alpha1, f.namespace, f.resyncPeriod).GrafanaUserInformer()
+	case *grafanav1alpha1.GrafanaDashboard:
+		return NewGrafanaV1alpha1Informer(f.cache, f.set.GrafanaV1alpha1, f.namespace, f.resyncPeriod).GrafanaDashboardInformer()
+	case *grafanav1alpha1.GrafanaDatasource:
+		return NewGrafanaV1alpha1Informer(f.cache, f.set.GrafanaV1alpha1, f.namespace, f.resyncPeriod).GrafanaDatasourceInformer()
 	case *harborv1alpha1.HarborProject:
 		return NewHarborV1alpha1Informer(f.cache, f.set.HarborV1alpha1, f.namespace, f.resyncPeriod).HarborProjectInformer()
 	case *harborv1alpha1.HarborRobotAccount:
@@ -814,10 +1037,18 @@ func (f *InformerFactory) InformerForResource(gvr schema.GroupVersionResource) c
 	switch gvr {
 	case consulv1alpha1.SchemaGroupVersion.WithResource("consulbackups"):
 		return NewConsulV1alpha1Informer(f.cache, f.set.ConsulV1alpha1, f.namespace, f.resyncPeriod).ConsulBackupInformer()
+	case consulv1alpha1.SchemaGroupVersion.WithResource("consulrestores"):
+		return NewConsulV1alpha1Informer(f.cache, f.set.ConsulV1alpha1, f.namespace, f.resyncPeriod).ConsulRestoreInformer()
+	case etcdv1alpha1.SchemaGroupVersion.WithResource("etcdbackups"):
+		return NewEtcdV1alpha1Informer(f.cache, f.set.EtcdV1alpha1, f.namespace, f.resyncPeriod).EtcdBackupInformer()
 	case grafanav1alpha1.SchemaGroupVersion.WithResource("grafanas"):
 		return NewGrafanaV1alpha1Informer(f.cache, f.set.GrafanaV1alpha1, f.namespace, f.resyncPeriod).GrafanaInformer()
 	case grafanav1alpha1.SchemaGroupVersion.WithResource("grafanausers"):
 		return NewGrafanaV1alpha1Informer(f.cache, f.set.GrafanaV1alpha1, f.namespace, f.resyncPeriod).GrafanaUserInformer()
+	case grafanav1alpha1.SchemaGroupVersion.WithResource("grafanadashboards"):
+		return NewGrafanaV1alpha1Informer(f.cache, f.set.GrafanaV1alpha1, f.namespace, f.resyncPeriod).GrafanaDashboardInformer()
+	case grafanav1alpha1.SchemaGroupVersion.WithResource("grafanadatasources"):
+		return NewGrafanaV1alpha1Informer(f.cache, f.set.GrafanaV1alpha1, f.namespace, f.resyncPeriod).GrafanaDatasourceInformer()
 	case harborv1alpha1.SchemaGroupVersion.WithResource("harborprojects"):
 		return NewHarborV1alpha1Informer(f.cache, f.set.HarborV1alpha1, f.namespace, f.resyncPeriod).HarborProjectInformer()
 	case harborv1alpha1.SchemaGroupVersion.WithResource("harborrobotaccounts"):
@@ -883,6 +1114,68 @@ func (f *ConsulV1alpha1Informer) ConsulBackupLister() *ConsulV1alpha1ConsulBacku
 	return NewConsulV1alpha1ConsulBackupLister(f.ConsulBackupInformer().GetIndexer())
 }
 
+func (f *ConsulV1alpha1Informer) ConsulRestoreInformer() cache.SharedIndexInformer {
+	return f.cache.Write(&consulv1alpha1.ConsulRestore{}, func() cache.SharedIndexInformer {
+		return cache.NewSharedIndexInformer(
+			&cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					return f.client.ListConsulRestore(context.TODO(), f.namespace, metav1.ListOptions{})
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return f.client.WatchConsulRestore(context.TODO(), f.namespace, metav1.ListOptions{})
+				},
+			},
+			&consulv1alpha1.ConsulRestore{},
+			f.resyncPeriod,
+			f.indexers,
+		)
+	})
+}
+
+func (f *ConsulV1alpha1Informer) ConsulRestoreLister() *ConsulV1alpha1ConsulRestoreLister {
+	return NewConsulV1alpha1ConsulRestoreLister(f.ConsulRestoreInformer().GetIndexer())
+}
+
+type EtcdV1alpha1Informer struct {
+	cache        *InformerCache
+	client       *EtcdV1alpha1
+	namespace    string
+	resyncPeriod time.Duration
+	indexers     cache.Indexers
+}
+
+func NewEtcdV1alpha1Informer(c *InformerCache, client *EtcdV1alpha1, namespace string, resyncPeriod time.Duration) *EtcdV1alpha1Informer {
+	return &EtcdV1alpha1Informer{
+		cache:        c,
+		client:       client,
+		namespace:    namespace,
+		resyncPeriod: resyncPeriod,
+		indexers:     cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	}
+}
+
+func (f *EtcdV1alpha1Informer) EtcdBackupInformer() cache.SharedIndexInformer {
+	return f.cache.Write(&etcdv1alpha1.EtcdBackup{}, func() cache.SharedIndexInformer {
+		return cache.NewSharedIndexInformer(
+			&cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					return f.client.ListEtcdBackup(context.TODO(), f.namespace, metav1.ListOptions{})
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return f.client.WatchEtcdBackup(context.TODO(), f.namespace, metav1.ListOptions{})
+				},
+			},
+			&etcdv1alpha1.EtcdBackup{},
+			f.resyncPeriod,
+			f.indexers,
+		)
+	})
+}
+
+func (f *EtcdV1alpha1Informer) EtcdBackupLister() *EtcdV1alpha1EtcdBackupLister {
+	return NewEtcdV1alpha1EtcdBackupLister(f.EtcdBackupInformer().GetIndexer())
+}
+
 type GrafanaV1alpha1Informer struct {
 	cache        *InformerCache
 	client       *GrafanaV1alpha1
@@ -945,6 +1238,50 @@ func (f *GrafanaV1alpha1Informer) GrafanaUserLister() *GrafanaV1alpha1GrafanaUse
 	return NewGrafanaV1alpha1GrafanaUserLister(f.GrafanaUserInformer().GetIndexer())
 }
 
+func (f *GrafanaV1alpha1Informer) GrafanaDashboardInformer() cache.SharedIndexInformer {
+	return f.cache.Write(&grafanav1alpha1.GrafanaDashboard{}, func() cache.SharedIndexInformer {
+		return cache.NewSharedIndexInformer(
+			&cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					return f.client.ListGrafanaDashboard(context.TODO(), f.namespace, metav1.ListOptions{})
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return f.client.WatchGrafanaDashboard(context.TODO(), f.namespace, metav1.ListOptions{})
+				},
+			},
+			&grafanav1alpha1.GrafanaDashboard{},
+			f.resyncPeriod,
+			f.indexers,
+		)
+	})
+}
+
+func (f *GrafanaV1alpha1Informer) GrafanaDashboardLister() *GrafanaV1alpha1GrafanaDashboardLister {
+	return NewGrafanaV1alpha1GrafanaDashboardLister(f.GrafanaDashboardInformer().GetIndexer())
+}
+
+func (f *GrafanaV1alpha1Informer) GrafanaDatasourceInformer() cache.SharedIndexInformer {
+	return f.cache.Write(&grafanav1alpha1.GrafanaDatasource{}, func() cache.SharedIndexInformer {
+		return cache.NewSharedIndexInformer(
+			&cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					return f.client.ListGrafanaDatasource(context.TODO(), f.namespace, metav1.ListOptions{})
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return f.client.WatchGrafanaDatasource(context.TODO(), f.namespace, metav1.ListOptions{})
+				},
+			},
+			&grafanav1alpha1.GrafanaDatasource{},
+			f.resyncPeriod,
+			f.indexers,
+		)
+	})
+}
+
+func (f *GrafanaV1alpha1Informer) GrafanaDatasourceLister() *GrafanaV1alpha1GrafanaDatasourceLister {
+	return NewGrafanaV1alpha1GrafanaDatasourceLister(f.GrafanaDatasourceInformer().GetIndexer())
+}
+
 type HarborV1alpha1Informer struct {
 	cache        *InformerCache
 	client       *HarborV1alpha1
@@ -1180,6 +1517,60 @@ func (x *ConsulV1alpha1ConsulBackupLister) Get(namespace, name string) (*consulv
 	return obj.(*consulv1alpha1.ConsulBackup).DeepCopy(), nil
 }
 
+type ConsulV1alpha1ConsulRestoreLister struct {
+	indexer cache.Indexer
+}
+
+func NewConsulV1alpha1ConsulRestoreLister(indexer cache.Indexer) *ConsulV1alpha1ConsulRestoreLister {
+	return &ConsulV1alpha1ConsulRestoreLister{indexer: indexer}
+}
+
+func (x *ConsulV1alpha1ConsulRestoreLister) List(namespace string, selector labels.Selector) ([]*consulv1alpha1.ConsulRestore, error) {
+	var ret []*consulv1alpha1.ConsulRestore
+	err := cache.ListAllByNamespace(x.indexer, namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*consulv1alpha1.ConsulRestore).DeepCopy())
+	})
+	return ret, err
+}
+
+func (x *ConsulV1alpha1ConsulRestoreLister) Get(namespace, name string) (*consulv1alpha1.ConsulRestore, error) {
+	obj, exists, err := x.indexer.GetByKey(namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, k8serrors.NewNotFound(consulv1alpha1.SchemaGroupVersion.WithResource("consulrestore").GroupResource(), name)
+	}
+	return obj.(*consulv1alpha1.ConsulRestore).DeepCopy(), nil
+}
+
+type EtcdV1alpha1EtcdBackupLister struct {
+	indexer cache.Indexer
+}
+
+func NewEtcdV1alpha1EtcdBackupLister(indexer cache.Indexer) *EtcdV1alpha1EtcdBackupLister {
+	return &EtcdV1alpha1EtcdBackupLister{indexer: indexer}
+}
+
+func (x *EtcdV1alpha1EtcdBackupLister) List(namespace string, selector labels.Selector) ([]*etcdv1alpha1.EtcdBackup, error) {
+	var ret []*etcdv1alpha1.EtcdBackup
+	err := cache.ListAllByNamespace(x.indexer, namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*etcdv1alpha1.EtcdBackup).DeepCopy())
+	})
+	return ret, err
+}
+
+func (x *EtcdV1alpha1EtcdBackupLister) Get(namespace, name string) (*etcdv1alpha1.EtcdBackup, error) {
+	obj, exists, err := x.indexer.GetByKey(namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, k8serrors.NewNotFound(etcdv1alpha1.SchemaGroupVersion.WithResource("etcdbackup").GroupResource(), name)
+	}
+	return obj.(*etcdv1alpha1.EtcdBackup).DeepCopy(), nil
+}
+
 type GrafanaV1alpha1GrafanaLister struct {
 	indexer cache.Indexer
 }
@@ -1234,6 +1625,60 @@ func (x *GrafanaV1alpha1GrafanaUserLister) Get(namespace, name string) (*grafana
 	return obj.(*grafanav1alpha1.GrafanaUser).DeepCopy(), nil
 }
 
+type GrafanaV1alpha1GrafanaDashboardLister struct {
+	indexer cache.Indexer
+}
+
+func NewGrafanaV1alpha1GrafanaDashboardLister(indexer cache.Indexer) *GrafanaV1alpha1GrafanaDashboardLister {
+	return &GrafanaV1alpha1GrafanaDashboardLister{indexer: indexer}
+}
+
+func (x *GrafanaV1alpha1GrafanaDashboardLister) List(namespace string, selector labels.Selector) ([]*grafanav1alpha1.GrafanaDashboard, error) {
+	var ret []*grafanav1alpha1.GrafanaDashboard
+	err := cache.ListAllByNamespace(x.indexer, namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*grafanav1alpha1.GrafanaDashboard).DeepCopy())
+	})
+	return ret, err
+}
+
+func (x *GrafanaV1alpha1GrafanaDashboardLister) Get(namespace, name string) (*grafanav1alpha1.GrafanaDashboard, error) {
+	obj, exists, err := x.indexer.GetByKey(namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, k8serrors.NewNotFound(grafanav1alpha1.SchemaGroupVersion.WithResource("grafanadashboard").GroupResource(), name)
+	}
+	return obj.(*grafanav1alpha1.GrafanaDashboard).DeepCopy(), nil
+}
+
+type GrafanaV1alpha1GrafanaDatasourceLister struct {
+	indexer cache.Indexer
+}
+
+func NewGrafanaV1alpha1GrafanaDatasourceLister(indexer cache.Indexer) *GrafanaV1alpha1GrafanaDatasourceLister {
+	return &GrafanaV1alpha1GrafanaDatasourceLister{indexer: indexer}
+}
+
+func (x *GrafanaV1alpha1GrafanaDatasourceLister) List(namespace string, selector labels.Selector) ([]*grafanav1alpha1.GrafanaDatasource, error) {
+	var ret []*grafanav1alpha1.GrafanaDatasource
+	err := cache.ListAllByNamespace(x.indexer, namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*grafanav1alpha1.GrafanaDatasource).DeepCopy())
+	})
+	return ret, err
+}
+
+func (x *GrafanaV1alpha1GrafanaDatasourceLister) Get(namespace, name string) (*grafanav1alpha1.GrafanaDatasource, error) {
+	obj, exists, err := x.indexer.GetByKey(namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, k8serrors.NewNotFound(grafanav1alpha1.SchemaGroupVersion.WithResource("grafanadatasource").GroupResource(), name)
+	}
+	return obj.(*grafanav1alpha1.GrafanaDatasource).DeepCopy(), nil
+}
+
 type HarborV1alpha1HarborProjectLister struct {
 	indexer cache.Indexer
 }
@@ -39,6 +39,7 @@ func NewSet() *Set {
 	})
 
 	s.ConsulV1alpha1 = client.NewConsulV1alpha1Client(&fakerBackend{fake: &s.fake})
+	s.EtcdV1alpha1 = client.NewEtcdV1alpha1Client(&fakerBackend{fake: &s.fake})
 	s.GrafanaV1alpha1 = client.NewGrafanaV1alpha1Client(&fakerBackend{fake: &s.fake})
 	s.HarborV1alpha1 = client.NewHarborV1alpha1Client(&fakerBackend{fake: &s.fake})
 	s.MinioV1alpha1 = client.NewMinioV1alpha1Client(&fakerBackend{fake: &s.fake})
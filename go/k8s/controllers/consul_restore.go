@@ -0,0 +1,283 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"go.f110.dev/xerrors"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+
+	"go.f110.dev/mono/go/api/consulv1alpha1"
+	"go.f110.dev/mono/go/k8s/client"
+	"go.f110.dev/mono/go/k8s/controllers/controllerutil"
+	"go.f110.dev/mono/go/logger"
+	"go.f110.dev/mono/go/storage"
+)
+
+// ConsulRestoreController restores the snapshot which is taken by ConsulBackupController.
+// The restoring is executed only once per ConsulRestore.
+// If you want to restore again, you have to create a new ConsulRestore.
+type ConsulRestoreController struct {
+	*controllerutil.ControllerBase
+
+	client            *client.ConsulV1alpha1
+	coreClient        kubernetes.Interface
+	config            *rest.Config
+	runOutsideCluster bool
+
+	restoreLister *client.ConsulV1alpha1ConsulRestoreLister
+	backupLister  *client.ConsulV1alpha1ConsulBackupLister
+	secretLister  corev1listers.SecretLister
+
+	// for testing
+	transport http.RoundTripper
+}
+
+var _ controllerutil.Controller = &ConsulRestoreController{}
+
+func NewConsulRestoreController(
+	coreSharedInformerFactory kubeinformers.SharedInformerFactory,
+	factory *client.InformerFactory,
+	coreClient kubernetes.Interface,
+	apiClient *client.Set,
+	config *rest.Config,
+	runOutsideCluster bool,
+) (*ConsulRestoreController, error) {
+	serviceInformer := coreSharedInformerFactory.Core().V1().Services()
+	secretInformer := coreSharedInformerFactory.Core().V1().Secrets()
+
+	informers := client.NewConsulV1alpha1Informer(factory.Cache(), apiClient.ConsulV1alpha1, metav1.NamespaceAll, 30*time.Second)
+	restoreInformer := informers.ConsulRestoreInformer()
+	backupInformer := informers.ConsulBackupInformer()
+
+	r := &ConsulRestoreController{
+		client:            apiClient.ConsulV1alpha1,
+		coreClient:        coreClient,
+		config:            config,
+		runOutsideCluster: runOutsideCluster,
+		restoreLister:     informers.ConsulRestoreLister(),
+		backupLister:      informers.ConsulBackupLister(),
+		secretLister:      secretInformer.Lister(),
+	}
+	r.ControllerBase = controllerutil.NewBase(
+		"consul-restore-controller",
+		r,
+		coreClient,
+		[]cache.SharedIndexInformer{restoreInformer},
+		[]cache.SharedIndexInformer{backupInformer, serviceInformer.Informer(), secretInformer.Informer()},
+		[]string{},
+	)
+
+	return r, nil
+}
+
+func (r *ConsulRestoreController) ObjectToKeys(obj interface{}) []string {
+	switch v := obj.(type) {
+	case *consulv1alpha1.ConsulRestore:
+		key, err := cache.MetaNamespaceKeyFunc(v)
+		if err != nil {
+			return nil
+		}
+		return []string{key}
+	default:
+		return nil
+	}
+}
+
+func (r *ConsulRestoreController) GetObject(key string) (runtime.Object, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	restore, err := r.restoreLister.Get(namespace, name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, xerrors.WithStack(err)
+	}
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+
+	return restore, nil
+}
+
+func (r *ConsulRestoreController) UpdateObject(ctx context.Context, obj runtime.Object) (runtime.Object, error) {
+	restore, ok := obj.(*consulv1alpha1.ConsulRestore)
+	if !ok {
+		return nil, xerrors.Definef("unexpected object type: %T", obj).WithStack()
+	}
+
+	updatedRestore, err := r.client.UpdateConsulRestore(ctx, restore, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	return updatedRestore, nil
+}
+
+func (r *ConsulRestoreController) Reconcile(ctx context.Context, obj runtime.Object) error {
+	restore := obj.(*consulv1alpha1.ConsulRestore)
+	switch restore.Status.Phase {
+	case consulv1alpha1.RestorePhaseSucceeded, consulv1alpha1.RestorePhaseFailed:
+		return nil
+	}
+
+	backup, err := r.backupLister.Get(restore.Namespace, restore.Spec.BackupName)
+	if apierrors.IsNotFound(err) {
+		return r.fail(ctx, restore, fmt.Sprintf("ConsulBackup %s is not found", restore.Spec.BackupName))
+	} else if err != nil {
+		return xerrors.WithStack(err)
+	}
+	path, err := findSnapshotPath(backup, restore)
+	if err != nil {
+		return r.fail(ctx, restore, err.Error())
+	}
+
+	updated := restore.DeepCopy()
+	updated.Status.Phase = consulv1alpha1.RestorePhaseRestoring
+	updated.Status.Path = path
+	updated.Status.Message = ""
+	restore, err = r.client.UpdateStatusConsulRestore(ctx, updated, metav1.UpdateOptions{})
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	data, err := r.getSnapshot(ctx, backup, path)
+	if err != nil {
+		logger.Log.Info("Failed to get the snapshot", zap.String("name", restore.Name), zap.Error(err))
+		return r.fail(ctx, restore, fmt.Sprintf("failed to get the snapshot: %v", err))
+	}
+	defer data.Close()
+
+	consulClient, err := api.NewClient(&api.Config{
+		Address: fmt.Sprintf("http://%s.%s.svc:8500", backup.Spec.Service.Name, backup.Namespace),
+		HttpClient: &http.Client{
+			Transport: r.transport,
+		},
+	})
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	if err := consulClient.Snapshot().Restore(&api.WriteOptions{}, data); err != nil {
+		logger.Log.Info("Failed to restore the snapshot", zap.String("name", restore.Name), zap.Error(err))
+		return r.fail(ctx, restore, fmt.Sprintf("failed to restore the snapshot: %v", err))
+	}
+
+	now := metav1.Now()
+	updated = restore.DeepCopy()
+	updated.Status.Phase = consulv1alpha1.RestorePhaseSucceeded
+	updated.Status.RestoredTime = &now
+	if _, err := r.client.UpdateStatusConsulRestore(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (r *ConsulRestoreController) Finalize(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+// fail marks the restore as failed.
+// The failed restore is not retried because restoring the snapshot overwrites the state of the cluster.
+func (r *ConsulRestoreController) fail(ctx context.Context, restore *consulv1alpha1.ConsulRestore, message string) error {
+	updated := restore.DeepCopy()
+	updated.Status.Phase = consulv1alpha1.RestorePhaseFailed
+	updated.Status.Message = message
+	if _, err := r.client.UpdateStatusConsulRestore(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (r *ConsulRestoreController) getSnapshot(ctx context.Context, backup *consulv1alpha1.ConsulBackup, path string) (io.ReadCloser, error) {
+	switch {
+	case backup.Spec.Storage.MinIO != nil:
+		spec := backup.Spec.Storage.MinIO
+
+		accessKeySecret, err := r.secretLister.Secrets(backup.Namespace).Get(spec.Credential.AccessKeyID.Name)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		accessKey, ok := accessKeySecret.Data[spec.Credential.AccessKeyID.Key]
+		if !ok {
+			return nil, xerrors.Definef("access key %s not found in %s", spec.Credential.AccessKeyID.Key, accessKeySecret.Name).WithStack()
+		}
+		secretAccessKeySecret, err := r.secretLister.Secrets(backup.Namespace).Get(spec.Credential.SecretAccessKey.Name)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		secretAccessKey, ok := secretAccessKeySecret.Data[spec.Credential.SecretAccessKey.Key]
+		if !ok {
+			return nil, xerrors.Definef("secret access key %s not found in %s", spec.Credential.SecretAccessKey.Key, secretAccessKeySecret.Name).WithStack()
+		}
+
+		mcOpt := storage.NewMinIOOptionsViaService(r.coreClient, r.config, spec.Service.Name, spec.Service.Namespace, 9000, string(accessKey), string(secretAccessKey), r.runOutsideCluster)
+		mcOpt.Transport = r.transport
+		mc := storage.NewMinIOStorage(spec.Bucket, mcOpt)
+		obj, err := mc.Get(ctx, path)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+
+		return obj.Body, nil
+	case backup.Spec.Storage.GCS != nil:
+		spec := backup.Spec.Storage.GCS
+		credential, err := r.secretLister.Secrets(backup.Namespace).Get(spec.Credential.ServiceAccountJSON.Name)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		b, ok := credential.Data[spec.Credential.ServiceAccountJSON.Key]
+		if !ok {
+			return nil, xerrors.Definef("%s is not found in %s", spec.Credential.ServiceAccountJSON.Key, spec.Credential.ServiceAccountJSON.Name).WithStack()
+		}
+
+		gcsClient := storage.NewGCS(b, spec.Bucket, storage.GCSOptions{})
+		obj, err := gcsClient.Get(ctx, path)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+
+		return obj.Body, nil
+	default:
+		return nil, xerrors.New("Not configured a storage")
+	}
+}
+
+// findSnapshotPath returns the object path of the snapshot which will be restored.
+func findSnapshotPath(backup *consulv1alpha1.ConsulBackup, restore *consulv1alpha1.ConsulRestore) (string, error) {
+	if restore.Spec.Path != "" {
+		return restore.Spec.Path, nil
+	}
+
+	for i := len(backup.Status.BackupStatusHistory) - 1; i >= 0; i-- {
+		h := backup.Status.BackupStatusHistory[i]
+		if !h.Succeeded || h.Path == "" {
+			continue
+		}
+		if restore.Spec.BackupTime == nil {
+			return h.Path, nil
+		}
+		if h.ExecuteTime != nil && h.ExecuteTime.Unix() == restore.Spec.BackupTime.Unix() {
+			return h.Path, nil
+		}
+	}
+
+	if restore.Spec.BackupTime != nil {
+		return "", xerrors.Definef("the backup at %s is not found in %s", restore.Spec.BackupTime.Format(time.RFC3339), backup.Name).WithStack()
+	}
+	return "", xerrors.Definef("%s does not have any succeeded backup", backup.Name).WithStack()
+}
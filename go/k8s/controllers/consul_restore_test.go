@@ -0,0 +1,172 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"go.f110.dev/mono/go/api/consulv1alpha1"
+	"go.f110.dev/mono/go/k8s/k8sfactory"
+	"go.f110.dev/mono/go/storage/storagetest"
+)
+
+func TestConsulRestoreController_Reconcile(t *testing.T) {
+	t.Run("Normal", func(t *testing.T) {
+		runner, controller := newConsulRestoreController(t)
+		backup, fixtures := consulBackupControllerFixture()
+		backup.Status.BackupStatusHistory = append(backup.Status.BackupStatusHistory,
+			consulv1alpha1.ConsulBackupStatusHistory{Path: "test_1", Succeeded: true},
+			consulv1alpha1.ConsulBackupStatusHistory{Path: "test_2", Succeeded: true},
+			consulv1alpha1.ConsulBackupStatusHistory{Path: "test_3"},
+		)
+		runner.RegisterFixture(append(fixtures, backup)...)
+		target := k8sfactory.ConsulRestoreFactory(nil,
+			k8sfactory.Name("test"),
+			k8sfactory.DefaultNamespace,
+			k8sfactory.RestoreFrom(backup),
+		)
+
+		mockTransport := httpmock.NewMockTransport()
+		controller.transport = mockTransport
+		registerSnapshotObject(mockTransport, "backup", "test_2", "backup_data")
+		var restored string
+		mockTransport.RegisterResponder(
+			http.MethodPut,
+			"http://consul-server.default.svc:8500/v1/snapshot",
+			func(req *http.Request) (*http.Response, error) {
+				buf := make([]byte, 32)
+				n, _ := req.Body.Read(buf)
+				restored = string(buf[:n])
+				return httpmock.NewStringResponse(http.StatusOK, ""), nil
+			},
+		)
+
+		err := runner.Reconcile(controller, target)
+		require.NoError(t, err)
+
+		assert.Equal(t, "backup_data", restored)
+		updated, err := runner.Client.ConsulV1alpha1.GetConsulRestore(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, consulv1alpha1.RestorePhaseSucceeded, updated.Status.Phase)
+		assert.Equal(t, "test_2", updated.Status.Path)
+		assert.NotNil(t, updated.Status.RestoredTime)
+	})
+
+	t.Run("BackupNotFound", func(t *testing.T) {
+		runner, controller := newConsulRestoreController(t)
+		target := k8sfactory.ConsulRestoreFactory(nil,
+			k8sfactory.Name("test"),
+			k8sfactory.DefaultNamespace,
+		)
+		target.Spec.BackupName = "unknown"
+
+		err := runner.Reconcile(controller, target)
+		require.NoError(t, err)
+
+		updated, err := runner.Client.ConsulV1alpha1.GetConsulRestore(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, consulv1alpha1.RestorePhaseFailed, updated.Status.Phase)
+		assert.NotEmpty(t, updated.Status.Message)
+	})
+
+	t.Run("FailedToRestore", func(t *testing.T) {
+		runner, controller := newConsulRestoreController(t)
+		backup, fixtures := consulBackupControllerFixture()
+		runner.RegisterFixture(append(fixtures, backup)...)
+		target := k8sfactory.ConsulRestoreFactory(nil,
+			k8sfactory.Name("test"),
+			k8sfactory.DefaultNamespace,
+			k8sfactory.RestoreFrom(backup),
+		)
+		target.Spec.Path = "test_1"
+
+		mockTransport := httpmock.NewMockTransport()
+		controller.transport = mockTransport
+		registerSnapshotObject(mockTransport, "backup", "test_1", "backup_data")
+		mockTransport.RegisterResponder(
+			http.MethodPut,
+			"http://consul-server.default.svc:8500/v1/snapshot",
+			httpmock.NewStringResponder(http.StatusInternalServerError, "failed"),
+		)
+
+		err := runner.Reconcile(controller, target)
+		require.NoError(t, err)
+
+		updated, err := runner.Client.ConsulV1alpha1.GetConsulRestore(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, consulv1alpha1.RestorePhaseFailed, updated.Status.Phase)
+		assert.Equal(t, "test_1", updated.Status.Path)
+		assert.Contains(t, updated.Status.Message, "failed to restore the snapshot")
+	})
+
+	t.Run("AlreadyFinished", func(t *testing.T) {
+		runner, controller := newConsulRestoreController(t)
+		target := k8sfactory.ConsulRestoreFactory(nil,
+			k8sfactory.Name("test"),
+			k8sfactory.DefaultNamespace,
+		)
+		target.Status.Phase = consulv1alpha1.RestorePhaseSucceeded
+
+		err := runner.Reconcile(controller, target)
+		require.NoError(t, err)
+		runner.AssertNoUnexpectedAction(t)
+	})
+}
+
+func TestFindSnapshotPath(t *testing.T) {
+	now := time.Now()
+	backup := k8sfactory.ConsulBackupFactory(nil, k8sfactory.Name("test"))
+	backup.Status.BackupStatusHistory = []consulv1alpha1.ConsulBackupStatusHistory{
+		{Path: "test_1", Succeeded: true, ExecuteTime: &metav1.Time{Time: now.Add(-2 * time.Hour)}},
+		{Path: "test_2", Succeeded: true, ExecuteTime: &metav1.Time{Time: now.Add(-time.Hour)}},
+		{Path: "test_3", ExecuteTime: &metav1.Time{Time: now}},
+	}
+
+	cases := []struct {
+		Name   string
+		Spec   consulv1alpha1.ConsulRestoreSpec
+		Expect string
+		Err    bool
+	}{
+		{Name: "Latest", Expect: "test_2"},
+		{Name: "BackupTime", Spec: consulv1alpha1.ConsulRestoreSpec{BackupTime: &metav1.Time{Time: now.Add(-2 * time.Hour)}}, Expect: "test_1"},
+		{Name: "Path", Spec: consulv1alpha1.ConsulRestoreSpec{Path: "foo/bar", BackupTime: &metav1.Time{Time: now}}, Expect: "foo/bar"},
+		{Name: "FailedBackup", Spec: consulv1alpha1.ConsulRestoreSpec{BackupTime: &metav1.Time{Time: now}}, Err: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			path, err := findSnapshotPath(backup, &consulv1alpha1.ConsulRestore{Spec: tc.Spec})
+			if tc.Err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expect, path)
+		})
+	}
+}
+
+func registerSnapshotObject(mockTransport *httpmock.MockTransport, bucket, key, data string) {
+	mockMinio := storagetest.NewMockMinIO()
+	mockMinio.AddBucket(bucket)
+	mockMinio.Transport(mockTransport)
+	responder := func(req *http.Request) (*http.Response, error) {
+		res := httpmock.NewStringResponse(http.StatusOK, data)
+		res.Header.Set("Content-Length", strconv.Itoa(len(data)))
+		res.Header.Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		res.Header.Set("ETag", `"d41d8cd98f00b204e9800998ecf8427e"`)
+		return res, nil
+	}
+	path := regexp.MustCompile(fmt.Sprintf(`.*/%s/%s$`, bucket, key))
+	mockTransport.RegisterRegexpResponder(http.MethodHead, path, responder)
+	mockTransport.RegisterRegexpResponder(http.MethodGet, path, responder)
+}
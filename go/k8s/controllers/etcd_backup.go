@@ -0,0 +1,357 @@
+package controllers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.f110.dev/xerrors"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+
+	"go.f110.dev/mono/go/api/etcdv1alpha1"
+	"go.f110.dev/mono/go/etcd"
+	"go.f110.dev/mono/go/k8s/client"
+	"go.f110.dev/mono/go/k8s/controllers/controllerutil"
+	"go.f110.dev/mono/go/logger"
+	"go.f110.dev/mono/go/storage"
+)
+
+type EtcdBackupController struct {
+	*controllerutil.ControllerBase
+
+	client            *client.EtcdV1alpha1
+	coreClient        kubernetes.Interface
+	config            *rest.Config
+	runOutsideCluster bool
+
+	backupLister *client.EtcdV1alpha1EtcdBackupLister
+	secretLister corev1listers.SecretLister
+
+	// for testing
+	transport http.RoundTripper
+	newBackup func(ctx context.Context, endpoints []string, caCert *x509.Certificate, cert tls.Certificate) (*etcd.Backup, error)
+}
+
+var _ controllerutil.Controller = &EtcdBackupController{}
+
+// backupStorage is the storage for backup files.
+type backupStorage interface {
+	PutReader(ctx context.Context, name string, data io.Reader) error
+	List(ctx context.Context, prefix string) ([]*storage.Object, error)
+	Delete(ctx context.Context, name string) error
+}
+
+func NewEtcdBackupController(
+	coreSharedInformerFactory kubeinformers.SharedInformerFactory,
+	factory *client.InformerFactory,
+	coreClient kubernetes.Interface,
+	apiClient *client.Set,
+	config *rest.Config,
+	runOutsideCluster bool,
+) (*EtcdBackupController, error) {
+	serviceInformer := coreSharedInformerFactory.Core().V1().Services()
+	secretInformer := coreSharedInformerFactory.Core().V1().Secrets()
+
+	informers := client.NewEtcdV1alpha1Informer(factory.Cache(), apiClient.EtcdV1alpha1, metav1.NamespaceAll, 30*time.Second)
+	backupInformer := informers.EtcdBackupInformer()
+
+	b := &EtcdBackupController{
+		client:            apiClient.EtcdV1alpha1,
+		coreClient:        coreClient,
+		config:            config,
+		runOutsideCluster: runOutsideCluster,
+		backupLister:      informers.EtcdBackupLister(),
+		secretLister:      secretInformer.Lister(),
+		newBackup:         etcd.NewBackup,
+	}
+	b.ControllerBase = controllerutil.NewBase(
+		"etcd-backup-controller",
+		b,
+		coreClient,
+		[]cache.SharedIndexInformer{backupInformer},
+		[]cache.SharedIndexInformer{serviceInformer.Informer(), secretInformer.Informer()},
+		[]string{},
+	)
+
+	return b, nil
+}
+
+func (b *EtcdBackupController) ObjectToKeys(obj interface{}) []string {
+	switch v := obj.(type) {
+	case *etcdv1alpha1.EtcdBackup:
+		key, err := cache.MetaNamespaceKeyFunc(v)
+		if err != nil {
+			return nil
+		}
+		return []string{key}
+	default:
+		return nil
+	}
+}
+
+func (b *EtcdBackupController) GetObject(key string) (runtime.Object, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	backup, err := b.backupLister.Get(namespace, name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, xerrors.WithStack(err)
+	}
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+
+	return backup, nil
+}
+
+func (b *EtcdBackupController) UpdateObject(ctx context.Context, obj runtime.Object) (runtime.Object, error) {
+	backup, ok := obj.(*etcdv1alpha1.EtcdBackup)
+	if !ok {
+		return nil, xerrors.Definef("unexpected object type: %T", obj).WithStack()
+	}
+
+	updatedBackup, err := b.client.UpdateEtcdBackup(ctx, backup, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	return updatedBackup, nil
+}
+
+func (b *EtcdBackupController) Reconcile(ctx context.Context, obj runtime.Object) error {
+	backup := obj.(*etcdv1alpha1.EtcdBackup)
+	updated := backup.DeepCopy()
+	now := metav1.Now()
+
+	schedule, err := cron.ParseStandard(backup.Spec.Schedule)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	lastExecuteTime := backup.CreationTimestamp.Time
+	if len(backup.Status.BackupStatusHistory) > 0 {
+		last := backup.Status.BackupStatusHistory[len(backup.Status.BackupStatusHistory)-1]
+		if last.ExecuteTime != nil {
+			lastExecuteTime = last.ExecuteTime.Time
+		}
+	}
+	if now.Before(&metav1.Time{Time: schedule.Next(lastExecuteTime)}) {
+		return nil
+	}
+
+	history := &etcdv1alpha1.EtcdBackupStatusHistory{
+		ExecuteTime: &now,
+	}
+	if err := b.takeBackup(ctx, backup, history, now); err != nil {
+		logger.Log.Info("Failed to take the backup", zap.String("name", backup.Name), zap.Error(err))
+		history.Message = err.Error()
+	}
+	if history.Succeeded {
+		if err := b.rotateBackupFiles(ctx, backup); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+
+	if history.Succeeded {
+		updated.Status.Succeeded = true
+		updated.Status.LastSucceededTime = &now
+	}
+	updated.Status.BackupStatusHistory = append(updated.Status.BackupStatusHistory, *history)
+	succeededCount := 0
+	firstIndex := 0
+	for i := len(updated.Status.BackupStatusHistory) - 1; i >= 0; i-- {
+		if updated.Status.BackupStatusHistory[i].Succeeded {
+			succeededCount++
+			firstIndex = i
+		}
+		if succeededCount == updated.Spec.MaxBackups {
+			break
+		}
+	}
+	if succeededCount == updated.Spec.MaxBackups && firstIndex+1 < len(updated.Status.BackupStatusHistory) {
+		updated.Status.BackupStatusHistory = updated.Status.BackupStatusHistory[firstIndex:]
+	}
+	if !reflect.DeepEqual(backup.Status, updated.Status) {
+		_, err := b.client.UpdateStatusEtcdBackup(ctx, updated, metav1.UpdateOptions{})
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+func (b *EtcdBackupController) Finalize(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+func (b *EtcdBackupController) takeBackup(
+	ctx context.Context,
+	backup *etcdv1alpha1.EtcdBackup,
+	history *etcdv1alpha1.EtcdBackupStatusHistory,
+	t metav1.Time,
+) error {
+	caCert, clientCert, err := b.clientCredential(backup)
+	if err != nil {
+		return err
+	}
+	s, path, err := b.storageClient(backup)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := b.newBackup(ctx, backup.Spec.Endpoints, caCert, clientCert)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	data, err := snapshot.Compressed()
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	filename := filepath.Join(path, fmt.Sprintf("%s_%d.zlib", backup.Name, t.Unix()))
+	history.Path = filename
+	if err := s.PutReader(ctx, filename, data); err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	history.Succeeded = true
+	return nil
+}
+
+func (b *EtcdBackupController) clientCredential(backup *etcdv1alpha1.EtcdBackup) (*x509.Certificate, tls.Certificate, error) {
+	spec := backup.Spec.Credential
+	if spec == nil {
+		return nil, tls.Certificate{}, nil
+	}
+
+	var caCert *x509.Certificate
+	if spec.CACertificate != nil {
+		buf, err := b.secretValue(backup.Namespace, spec.CACertificate.Name, spec.CACertificate.Key)
+		if err != nil {
+			return nil, tls.Certificate{}, err
+		}
+		c, err := etcd.ParseCACertificate(buf)
+		if err != nil {
+			return nil, tls.Certificate{}, err
+		}
+		caCert = c
+	}
+
+	var clientCert tls.Certificate
+	if spec.Certificate != nil && spec.PrivateKey != nil {
+		certPEM, err := b.secretValue(backup.Namespace, spec.Certificate.Name, spec.Certificate.Key)
+		if err != nil {
+			return nil, tls.Certificate{}, err
+		}
+		keyPEM, err := b.secretValue(backup.Namespace, spec.PrivateKey.Name, spec.PrivateKey.Key)
+		if err != nil {
+			return nil, tls.Certificate{}, err
+		}
+		c, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, tls.Certificate{}, xerrors.WithStack(err)
+		}
+		clientCert = c
+	}
+
+	return caCert, clientCert, nil
+}
+
+func (b *EtcdBackupController) secretValue(namespace, name, key string) ([]byte, error) {
+	secret, err := b.secretLister.Secrets(namespace).Get(name)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	v, ok := secret.Data[key]
+	if !ok {
+		return nil, xerrors.Definef("%s is not found in %s", key, name).WithStack()
+	}
+
+	return v, nil
+}
+
+// storageClient returns the client of the storage and the path of backup files.
+func (b *EtcdBackupController) storageClient(backup *etcdv1alpha1.EtcdBackup) (backupStorage, string, error) {
+	switch {
+	case backup.Spec.Storage.MinIO != nil:
+		spec := backup.Spec.Storage.MinIO
+
+		accessKey, err := b.secretValue(backup.Namespace, spec.Credential.AccessKeyID.Name, spec.Credential.AccessKeyID.Key)
+		if err != nil {
+			return nil, "", err
+		}
+		secretAccessKey, err := b.secretValue(backup.Namespace, spec.Credential.SecretAccessKey.Name, spec.Credential.SecretAccessKey.Key)
+		if err != nil {
+			return nil, "", err
+		}
+
+		mcOpt := storage.NewMinIOOptionsViaService(b.coreClient, b.config, spec.Service.Name, spec.Service.Namespace, 9000, string(accessKey), string(secretAccessKey), b.runOutsideCluster)
+		mcOpt.Transport = b.transport
+		path := spec.Path
+		if len(path) > 0 && path[0] == '/' {
+			path = path[1:]
+		}
+		return storage.NewMinIOStorage(spec.Bucket, mcOpt), path, nil
+	case backup.Spec.Storage.GCS != nil:
+		spec := backup.Spec.Storage.GCS
+		if spec.Credential == nil || spec.Credential.ServiceAccountJSON == nil {
+			return nil, "", xerrors.Define("the credential of GCS is not configured").WithStack()
+		}
+		credential, err := b.secretValue(backup.Namespace, spec.Credential.ServiceAccountJSON.Name, spec.Credential.ServiceAccountJSON.Key)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return storage.NewGCS(credential, spec.Bucket, storage.GCSOptions{}), spec.Path, nil
+	default:
+		return nil, "", xerrors.New("Not configured a storage")
+	}
+}
+
+func (b *EtcdBackupController) rotateBackupFiles(ctx context.Context, backup *etcdv1alpha1.EtcdBackup) error {
+	if backup.Spec.MaxBackups == 0 {
+		// In this case, we don't have to rotate a backup file.
+		return nil
+	}
+
+	s, path, err := b.storageClient(backup)
+	if err != nil {
+		return err
+	}
+	files, err := s.List(ctx, path)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	if len(files) <= backup.Spec.MaxBackups {
+		return nil
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[j].Name < files[i].Name
+	})
+	purgeTargets := files[backup.Spec.MaxBackups:]
+	for _, v := range purgeTargets {
+		if err := s.Delete(ctx, v.Name); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+
+	return nil
+}
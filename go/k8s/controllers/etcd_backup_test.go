@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"go.f110.dev/mono/go/api/etcdv1alpha1"
+	"go.f110.dev/mono/go/etcd"
+	"go.f110.dev/mono/go/k8s/k8sfactory"
+	"go.f110.dev/mono/go/storage/storagetest"
+)
+
+func TestEtcdBackupController_Reconcile(t *testing.T) {
+	t.Run("Normal", func(t *testing.T) {
+		runner, controller := newEtcdBackupController(t)
+		target, fixtures := etcdBackupControllerFixture()
+		runner.RegisterFixture(fixtures...)
+
+		var endpoints []string
+		controller.newBackup = func(_ context.Context, e []string, _ *x509.Certificate, _ tls.Certificate) (*etcd.Backup, error) {
+			endpoints = e
+			return etcd.NewBackupFromReader(io.NopCloser(strings.NewReader("snapshot"))), nil
+		}
+		mockTransport := httpmock.NewMockTransport()
+		controller.transport = mockTransport
+		mockMinio := storagetest.NewMockMinIO()
+		mockMinio.AddBucket("backup")
+		mockMinio.Transport(mockTransport)
+
+		err := runner.Reconcile(controller, target)
+		require.NoError(t, err)
+
+		assert.Equal(t, target.Spec.Endpoints, endpoints)
+		expect, err := runner.Client.EtcdV1alpha1.GetEtcdBackup(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		runner.AssertUpdateAction(t, "status", expect)
+		runner.AssertNoUnexpectedAction(t)
+		assert.True(t, expect.Status.Succeeded)
+		require.Len(t, expect.Status.BackupStatusHistory, 1)
+		assert.Equal(t, expect.Status.LastSucceededTime, expect.Status.BackupStatusHistory[0].ExecuteTime)
+		assert.Equal(t, fmt.Sprintf("%s_%d.zlib", target.Name, expect.Status.LastSucceededTime.Unix()), expect.Status.BackupStatusHistory[0].Path)
+	})
+
+	t.Run("BeforeSchedule", func(t *testing.T) {
+		runner, controller := newEtcdBackupController(t)
+		target, fixtures := etcdBackupControllerFixture()
+		runner.RegisterFixture(fixtures...)
+		target.CreationTimestamp = metav1.Now()
+
+		err := runner.Reconcile(controller, target)
+		require.NoError(t, err)
+		runner.AssertNoUnexpectedAction(t)
+	})
+
+	t.Run("FailedSnapshot", func(t *testing.T) {
+		runner, controller := newEtcdBackupController(t)
+		target, fixtures := etcdBackupControllerFixture()
+		runner.RegisterFixture(fixtures...)
+
+		controller.newBackup = func(_ context.Context, _ []string, _ *x509.Certificate, _ tls.Certificate) (*etcd.Backup, error) {
+			return nil, fmt.Errorf("connection refused")
+		}
+
+		err := runner.Reconcile(controller, target)
+		require.NoError(t, err)
+
+		expect, err := runner.Client.EtcdV1alpha1.GetEtcdBackup(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.False(t, expect.Status.Succeeded)
+		require.Len(t, expect.Status.BackupStatusHistory, 1)
+		assert.False(t, expect.Status.BackupStatusHistory[0].Succeeded)
+		assert.Contains(t, expect.Status.BackupStatusHistory[0].Message, "connection refused")
+	})
+
+	t.Run("RotateHistory", func(t *testing.T) {
+		runner, controller := newEtcdBackupController(t)
+		target, fixtures := etcdBackupControllerFixture()
+		runner.RegisterFixture(fixtures...)
+		executeTime := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		for i := 1; i <= 5; i++ {
+			target.Status.BackupStatusHistory = append(target.Status.BackupStatusHistory,
+				etcdv1alpha1.EtcdBackupStatusHistory{Path: fmt.Sprintf("test_%d.zlib", i), Succeeded: true, ExecuteTime: &executeTime},
+			)
+		}
+
+		controller.newBackup = func(_ context.Context, _ []string, _ *x509.Certificate, _ tls.Certificate) (*etcd.Backup, error) {
+			return etcd.NewBackupFromReader(io.NopCloser(strings.NewReader("snapshot"))), nil
+		}
+		mockTransport := httpmock.NewMockTransport()
+		controller.transport = mockTransport
+		mockMinio := storagetest.NewMockMinIO()
+		mockMinio.AddBucket("backup")
+		// The uploaded file is not added to the mock because it is uploaded by multipart upload.
+		var objs []*minio.ObjectInfo
+		for i := 1; i <= 6; i++ {
+			objs = append(objs, &minio.ObjectInfo{Key: fmt.Sprintf("test_%d.zlib", i)})
+		}
+		mockMinio.AddObjects("backup", objs...)
+		mockMinio.Transport(mockTransport)
+
+		err := runner.Reconcile(controller, target)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"test_1.zlib"}, mockMinio.Removed("backup"))
+		expect, err := runner.Client.EtcdV1alpha1.GetEtcdBackup(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Len(t, expect.Status.BackupStatusHistory, expect.Spec.MaxBackups)
+	})
+}
+
+func etcdBackupControllerFixture() (*etcdv1alpha1.EtcdBackup, []runtime.Object) {
+	secret := k8sfactory.SecretFactory(nil,
+		k8sfactory.Name("access"),
+		k8sfactory.DefaultNamespace,
+		k8sfactory.Data("accesskey", []byte("test-accesskey")),
+		k8sfactory.Data("secret", []byte("test-secret-access-key")),
+	)
+	service := k8sfactory.ServiceFactory(nil,
+		k8sfactory.Name("minio"),
+		k8sfactory.DefaultNamespace,
+	)
+	target := k8sfactory.EtcdBackupFactory(nil,
+		k8sfactory.Name("test"),
+		k8sfactory.DefaultNamespace,
+		k8sfactory.Schedule("0 * * * *"),
+		k8sfactory.MaxBackup(5),
+		k8sfactory.EtcdEndpoints("https://etcd.default.svc:2379"),
+		k8sfactory.BackupStorage(&etcdv1alpha1.BackupStorageMinIOSpec{
+			Service: &etcdv1alpha1.ObjectReference{Name: service.Name, Namespace: service.Namespace},
+			Credential: etcdv1alpha1.AWSCredential{
+				AccessKeyID:     k8sfactory.SecretKeySelector(secret, "accesskey"),
+				SecretAccessKey: k8sfactory.SecretKeySelector(secret, "secret"),
+			},
+			Bucket: "backup",
+			Path:   "/",
+		}),
+	)
+	target.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+
+	return target, []runtime.Object{secret, service}
+}
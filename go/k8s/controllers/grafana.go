@@ -2,14 +2,20 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	"go.f110.dev/xerrors"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -33,10 +39,13 @@ type GrafanaController struct {
 
 	client *client.GrafanaV1alpha1
 
-	secretLister  corev1listers.SecretLister
-	serviceLister corev1listers.ServiceLister
-	appLister     *client.GrafanaV1alpha1GrafanaLister
-	userLister    *client.GrafanaV1alpha1GrafanaUserLister
+	secretLister     corev1listers.SecretLister
+	serviceLister    corev1listers.ServiceLister
+	configMapLister  corev1listers.ConfigMapLister
+	appLister        *client.GrafanaV1alpha1GrafanaLister
+	userLister       *client.GrafanaV1alpha1GrafanaUserLister
+	dashboardLister  *client.GrafanaV1alpha1GrafanaDashboardLister
+	datasourceLister *client.GrafanaV1alpha1GrafanaDatasourceLister
 
 	// for testing
 	transport http.RoundTripper
@@ -50,23 +59,29 @@ func NewGrafanaController(
 ) (*GrafanaController, error) {
 	secretInformer := coreSharedInformerFactory.Core().V1().Secrets()
 	serviceInformer := coreSharedInformerFactory.Core().V1().Services()
+	configMapInformer := coreSharedInformerFactory.Core().V1().ConfigMaps()
 	grafanaInformers := client.NewGrafanaV1alpha1Informer(factory.Cache(), apiClient.GrafanaV1alpha1, metav1.NamespaceAll, 30*time.Second)
 	appInformer := grafanaInformers.GrafanaInformer()
 	userInformer := grafanaInformers.GrafanaUserInformer()
+	dashboardInformer := grafanaInformers.GrafanaDashboardInformer()
+	datasourceInformer := grafanaInformers.GrafanaDatasourceInformer()
 
 	a := &GrafanaController{
-		client:        apiClient.GrafanaV1alpha1,
-		secretLister:  secretInformer.Lister(),
-		serviceLister: serviceInformer.Lister(),
-		appLister:     grafanaInformers.GrafanaLister(),
-		userLister:    grafanaInformers.GrafanaUserLister(),
+		client:           apiClient.GrafanaV1alpha1,
+		secretLister:     secretInformer.Lister(),
+		serviceLister:    serviceInformer.Lister(),
+		configMapLister:  configMapInformer.Lister(),
+		appLister:        grafanaInformers.GrafanaLister(),
+		userLister:       grafanaInformers.GrafanaUserLister(),
+		dashboardLister:  grafanaInformers.GrafanaDashboardLister(),
+		datasourceLister: grafanaInformers.GrafanaDatasourceLister(),
 	}
 	a.GenericControllerBase = controllerutil.NewGenericControllerBase[*grafanav1alpha1.Grafana](
 		"grafana-controller",
 		a.newReconciler,
 		coreClient,
-		[]cache.SharedIndexInformer{appInformer, userInformer},
-		[]cache.SharedIndexInformer{secretInformer.Informer(), serviceInformer.Informer()},
+		[]cache.SharedIndexInformer{appInformer, userInformer, dashboardInformer, datasourceInformer},
+		[]cache.SharedIndexInformer{secretInformer.Informer(), serviceInformer.Informer(), configMapInformer.Informer()},
 		[]string{grafanaControllerFinalizerName},
 		grafanaInformers.GrafanaLister().Get,
 		apiClient.GrafanaV1alpha1.UpdateGrafana,
@@ -77,20 +92,26 @@ func NewGrafanaController(
 
 func (u *GrafanaController) newReconciler() controllerutil.GenericReconciler[*grafanav1alpha1.Grafana] {
 	return &grafanaReconciler{
-		serviceLister: u.serviceLister,
-		secretLister:  u.secretLister,
-		userLister:    u.userLister,
-		client:        u.client,
-		logger:        u.Log(),
-		transport:     u.transport,
+		serviceLister:    u.serviceLister,
+		secretLister:     u.secretLister,
+		configMapLister:  u.configMapLister,
+		userLister:       u.userLister,
+		dashboardLister:  u.dashboardLister,
+		datasourceLister: u.datasourceLister,
+		client:           u.client,
+		logger:           u.Log(),
+		transport:        u.transport,
 	}
 }
 
 type grafanaReconciler struct {
-	serviceLister corev1listers.ServiceLister
-	secretLister  corev1listers.SecretLister
-	userLister    *client.GrafanaV1alpha1GrafanaUserLister
-	client        *client.GrafanaV1alpha1
+	serviceLister    corev1listers.ServiceLister
+	secretLister     corev1listers.SecretLister
+	configMapLister  corev1listers.ConfigMapLister
+	userLister       *client.GrafanaV1alpha1GrafanaUserLister
+	dashboardLister  *client.GrafanaV1alpha1GrafanaDashboardLister
+	datasourceLister *client.GrafanaV1alpha1GrafanaDatasourceLister
+	client           *client.GrafanaV1alpha1
 
 	logger    *zap.Logger
 	transport http.RoundTripper
@@ -109,12 +130,45 @@ func (u *grafanaReconciler) Reconcile(ctx context.Context, obj *grafanav1alpha1.
 		return xerrors.WithStack(err)
 	}
 
-	if err := u.ensureUsers(app, users); err != nil {
+	grafanaClient, err := u.newGrafanaClient(app)
+	if err != nil {
 		return xerrors.WithStack(err)
 	}
 
+	if err := u.ensureUsers(grafanaClient, users); err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	var dashboards []*grafanav1alpha1.GrafanaDashboard
+	if app.Spec.DashboardSelector != nil {
+		sel, err := metav1.LabelSelectorAsSelector(app.Spec.DashboardSelector)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		dashboards, err = u.dashboardLister.List(app.Namespace, sel)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	managedDashboards := u.ensureDashboards(ctx, app, grafanaClient, dashboards)
+
+	var datasources []*grafanav1alpha1.GrafanaDatasource
+	if app.Spec.DatasourceSelector != nil {
+		sel, err := metav1.LabelSelectorAsSelector(app.Spec.DatasourceSelector)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		datasources, err = u.datasourceLister.List(app.Namespace, sel)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	managedDatasources := u.ensureDatasources(ctx, app, grafanaClient, datasources)
+
 	newA := app.DeepCopy()
 	newA.Status.ObservedGeneration = app.ObjectMeta.Generation
+	newA.Status.Dashboards = managedDashboards
+	newA.Status.Datasources = managedDatasources
 	if !reflect.DeepEqual(newA.Status, app.Status) {
 		_, err = u.client.UpdateStatusGrafana(ctx, newA, metav1.UpdateOptions{})
 		if err != nil {
@@ -128,27 +182,30 @@ func (u *grafanaReconciler) Finalize(ctx context.Context, obj *grafanav1alpha1.G
 	return nil
 }
 
-func (u *grafanaReconciler) ensureUsers(app *grafanav1alpha1.Grafana, users []*grafanav1alpha1.GrafanaUser) error {
-	u.logger.Debug("users", zap.Int("len", len(users)))
+func (u *grafanaReconciler) newGrafanaClient(app *grafanav1alpha1.Grafana) (*grafana.Client, error) {
 	secret, err := u.secretLister.Secrets(app.Namespace).Get(app.Spec.AdminPasswordSecret.Name)
 	if err != nil {
-		return xerrors.WithStack(err)
+		return nil, xerrors.WithStack(err)
 	}
 	password, ok := secret.Data[app.Spec.AdminPasswordSecret.Key]
 	if !ok {
-		return xerrors.Definef("%s is not found in %s", app.Spec.AdminPasswordSecret.Key, app.Spec.AdminPasswordSecret.Name).WithStack()
+		return nil, xerrors.Definef("%s is not found in %s", app.Spec.AdminPasswordSecret.Key, app.Spec.AdminPasswordSecret.Name).WithStack()
 	}
 	svc, err := u.serviceLister.Services(app.Namespace).Get(app.Spec.Service.Name)
 	if err != nil {
-		return xerrors.WithStack(err)
+		return nil, xerrors.WithStack(err)
 	}
-	grafanaClient := grafana.NewClient(
+
+	return grafana.NewClient(
 		fmt.Sprintf("http://%s.%s.svc:%d", svc.Name, app.Namespace, 3000),
 		app.Spec.AdminUser,
 		string(password),
 		u.transport,
-	)
+	), nil
+}
 
+func (u *grafanaReconciler) ensureUsers(grafanaClient *grafana.Client, users []*grafanav1alpha1.GrafanaUser) error {
+	u.logger.Debug("users", zap.Int("len", len(users)))
 	allUsers := make(map[string]*grafanav1alpha1.GrafanaUser)
 	for _, v := range users {
 		allUsers[v.Spec.Email] = v
@@ -217,3 +274,405 @@ func (u *grafanaReconciler) ensureUsers(app *grafanav1alpha1.Grafana, users []*g
 
 	return nil
 }
+
+// ensureDashboards creates or updates the dashboards and deletes the dashboards which are no longer selected.
+// ensureDashboards returns UIDs of the dashboards which are managed by the controller.
+func (u *grafanaReconciler) ensureDashboards(ctx context.Context, app *grafanav1alpha1.Grafana, grafanaClient *grafana.Client, dashboards []*grafanav1alpha1.GrafanaDashboard) []string {
+	u.logger.Debug("dashboards", zap.Int("len", len(dashboards)))
+	managed := set.New()
+	for _, v := range dashboards {
+		uid, err := u.ensureDashboard(ctx, grafanaClient, v)
+		if err != nil {
+			u.logger.Warn("Failed ensure dashboard", zap.String("name", v.Name), zap.Error(err))
+			if v.Status.Message != err.Error() {
+				updated := v.DeepCopy()
+				updated.Status.Message = err.Error()
+				if _, err := u.client.UpdateStatusGrafanaDashboard(ctx, updated, metav1.UpdateOptions{}); err != nil {
+					u.logger.Warn("Failed update the status of dashboard", zap.String("name", v.Name), zap.Error(err))
+				}
+			}
+			if v.Status.UID != "" {
+				managed.Add(v.Status.UID)
+			}
+			continue
+		}
+		managed.Add(uid)
+	}
+
+	for _, uid := range app.Status.Dashboards {
+		if managed.Has(uid) {
+			continue
+		}
+
+		u.logger.Info("Delete dashboard", zap.String("uid", uid))
+		if err := grafanaClient.DeleteDashboard(uid); err != nil && !errors.Is(err, grafana.ErrNotFound) {
+			u.logger.Warn("Failed delete dashboard", zap.String("uid", uid), zap.Error(err))
+			managed.Add(uid)
+		}
+	}
+
+	return sortedStrings(managed)
+}
+
+func (u *grafanaReconciler) ensureDashboard(ctx context.Context, grafanaClient *grafana.Client, dashboard *grafanav1alpha1.GrafanaDashboard) (string, error) {
+	model, err := u.dashboardModel(dashboard)
+	if err != nil {
+		return "", err
+	}
+	uid := dashboard.Status.UID
+	if v, ok := model["uid"].(string); ok && v != "" {
+		uid = v
+	}
+
+	var folderUID string
+	if dashboard.Spec.Folder != "" {
+		folderUID, err = ensureGrafanaFolder(grafanaClient, dashboard.Spec.Folder)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var current *grafana.Dashboard
+	if uid != "" {
+		current, err = grafanaClient.Dashboard(uid)
+		if err != nil && !errors.Is(err, grafana.ErrNotFound) {
+			return "", err
+		}
+	}
+
+	var version int64
+	if current != nil && current.Meta != nil && current.Meta.FolderUID == folderUID && equalDashboardModel(model, current.Model) {
+		version = current.Meta.Version
+	} else {
+		delete(model, "id")
+		delete(model, "version")
+		if uid != "" {
+			model["uid"] = uid
+		}
+		u.logger.Info("Save dashboard", zap.String("name", dashboard.Name), zap.String("uid", uid))
+		saved, err := grafanaClient.SaveDashboard(model, folderUID)
+		if err != nil {
+			return "", err
+		}
+		uid = saved.UID
+		version = saved.Version
+	}
+
+	if err := u.ensureDashboardPermissions(grafanaClient, uid, dashboard.Spec.Permissions); err != nil {
+		return uid, err
+	}
+
+	updated := dashboard.DeepCopy()
+	updated.Status.ObservedGeneration = dashboard.Generation
+	updated.Status.UID = uid
+	updated.Status.Version = version
+	updated.Status.Message = ""
+	if !reflect.DeepEqual(updated.Status, dashboard.Status) {
+		if _, err := u.client.UpdateStatusGrafanaDashboard(ctx, updated, metav1.UpdateOptions{}); err != nil {
+			return uid, xerrors.WithStack(err)
+		}
+	}
+
+	return uid, nil
+}
+
+func (u *grafanaReconciler) dashboardModel(dashboard *grafanav1alpha1.GrafanaDashboard) (map[string]any, error) {
+	var buf []byte
+	switch {
+	case dashboard.Spec.JSON != "" && dashboard.Spec.ConfigMap != nil:
+		return nil, xerrors.Define("json and configMap can't be set together").WithStack()
+	case dashboard.Spec.JSON != "":
+		buf = []byte(dashboard.Spec.JSON)
+	case dashboard.Spec.ConfigMap != nil:
+		ref := dashboard.Spec.ConfigMap
+		configMap, err := u.configMapLister.ConfigMaps(dashboard.Namespace).Get(ref.Name)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		v, ok := configMap.Data[ref.Key]
+		if !ok {
+			return nil, xerrors.Definef("%s is not found in %s", ref.Key, ref.Name).WithStack()
+		}
+		buf = []byte(v)
+	default:
+		return nil, xerrors.Define("json or configMap is required").WithStack()
+	}
+
+	model := make(map[string]any)
+	if err := json.Unmarshal(buf, &model); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	return model, nil
+}
+
+func (u *grafanaReconciler) ensureDashboardPermissions(grafanaClient *grafana.Client, uid string, permissions []grafanav1alpha1.GrafanaDashboardPermission) error {
+	var users map[string]int
+	desired := make([]*grafana.DashboardPermission, 0, len(permissions))
+	for _, v := range permissions {
+		p := &grafana.DashboardPermission{Role: v.Role, Permission: grafanaPermission(v.Permission)}
+		if v.User != "" {
+			if users == nil {
+				currentUsers, err := grafanaClient.Users()
+				if err != nil {
+					return xerrors.WithStack(err)
+				}
+				users = make(map[string]int)
+				for _, user := range currentUsers {
+					users[user.Email] = user.Id
+				}
+			}
+			id, ok := users[v.User]
+			if !ok {
+				return xerrors.Definef("user %s is not found", v.User).WithStack()
+			}
+			p.UserID = id
+		}
+		desired = append(desired, p)
+	}
+
+	current, err := grafanaClient.DashboardPermissions(uid)
+	if err != nil {
+		return err
+	}
+	currentSet := set.New()
+	for _, v := range current {
+		if v.Inherited {
+			continue
+		}
+		currentSet.Add(dashboardPermissionKey(v))
+	}
+	desiredSet := set.New()
+	for _, v := range desired {
+		desiredSet.Add(dashboardPermissionKey(v))
+	}
+	if len(currentSet.Diff(desiredSet).ToSlice()) == 0 && len(desiredSet.Diff(currentSet).ToSlice()) == 0 {
+		return nil
+	}
+
+	u.logger.Info("Update dashboard permissions", zap.String("uid", uid))
+	return grafanaClient.UpdateDashboardPermissions(uid, desired)
+}
+
+// ensureDatasources creates or updates the datasources and deletes the datasources which are no longer selected.
+// ensureDatasources returns UIDs of the datasources which are managed by the controller.
+func (u *grafanaReconciler) ensureDatasources(ctx context.Context, app *grafanav1alpha1.Grafana, grafanaClient *grafana.Client, datasources []*grafanav1alpha1.GrafanaDatasource) []string {
+	u.logger.Debug("datasources", zap.Int("len", len(datasources)))
+	managed := set.New()
+	for _, v := range datasources {
+		uid, err := u.ensureDatasource(ctx, grafanaClient, v)
+		if err != nil {
+			u.logger.Warn("Failed ensure datasource", zap.String("name", v.Name), zap.Error(err))
+			if v.Status.Message != err.Error() {
+				updated := v.DeepCopy()
+				updated.Status.Message = err.Error()
+				if _, err := u.client.UpdateStatusGrafanaDatasource(ctx, updated, metav1.UpdateOptions{}); err != nil {
+					u.logger.Warn("Failed update the status of datasource", zap.String("name", v.Name), zap.Error(err))
+				}
+			}
+			if v.Status.UID != "" {
+				managed.Add(v.Status.UID)
+			}
+			continue
+		}
+		managed.Add(uid)
+	}
+
+	for _, uid := range app.Status.Datasources {
+		if managed.Has(uid) {
+			continue
+		}
+
+		u.logger.Info("Delete datasource", zap.String("uid", uid))
+		if err := grafanaClient.DeleteDatasource(uid); err != nil && !errors.Is(err, grafana.ErrNotFound) {
+			u.logger.Warn("Failed delete datasource", zap.String("uid", uid), zap.Error(err))
+			managed.Add(uid)
+		}
+	}
+
+	return sortedStrings(managed)
+}
+
+func (u *grafanaReconciler) ensureDatasource(ctx context.Context, grafanaClient *grafana.Client, datasource *grafanav1alpha1.GrafanaDatasource) (string, error) {
+	secureJSONData := make(map[string]string)
+	var secretVersions []string
+	readSecret := func(key string, sel *corev1.SecretKeySelector) error {
+		secret, err := u.secretLister.Secrets(datasource.Namespace).Get(sel.Name)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		v, ok := secret.Data[sel.Key]
+		if !ok {
+			return xerrors.Definef("%s is not found in %s", sel.Key, sel.Name).WithStack()
+		}
+		secureJSONData[key] = string(v)
+		secretVersions = append(secretVersions, fmt.Sprintf("%s=%s/%s", key, secret.Name, secret.ResourceVersion))
+		return nil
+	}
+	if datasource.Spec.BasicAuthPassword != nil {
+		if err := readSecret("basicAuthPassword", datasource.Spec.BasicAuthPassword); err != nil {
+			return "", err
+		}
+	}
+	for _, v := range datasource.Spec.SecureJSONData {
+		if err := readSecret(v.Key, &v.Secret); err != nil {
+			return "", err
+		}
+	}
+	sort.Strings(secretVersions)
+	h := sha256.Sum256([]byte(strings.Join(secretVersions, "\n")))
+	secretHash := hex.EncodeToString(h[:])
+
+	desired := &grafana.Datasource{
+		UID:           datasource.Status.UID,
+		Name:          datasource.Name,
+		Type:          datasource.Spec.Type,
+		URL:           datasource.Spec.URL,
+		Access:        datasource.Spec.Access,
+		IsDefault:     datasource.Spec.IsDefault,
+		BasicAuth:     datasource.Spec.BasicAuthUser != "",
+		BasicAuthUser: datasource.Spec.BasicAuthUser,
+	}
+	if desired.Access == "" {
+		desired.Access = "proxy"
+	}
+	if datasource.Spec.JSONData != "" {
+		if err := json.Unmarshal([]byte(datasource.Spec.JSONData), &desired.JSONData); err != nil {
+			return "", xerrors.WithStack(err)
+		}
+	}
+
+	var current *grafana.Datasource
+	if desired.UID != "" {
+		ds, err := grafanaClient.Datasource(desired.UID)
+		if err != nil && !errors.Is(err, grafana.ErrNotFound) {
+			return "", err
+		}
+		current = ds
+	}
+	// The UID is missing if the status couldn't be updated after creating the datasource, or stale if the datasource was re-created.
+	// The name of the datasource is unique in Grafana. Thus, the datasource is looked up by the name.
+	if current == nil {
+		ds, err := grafanaClient.DatasourceByName(desired.Name)
+		if err != nil && !errors.Is(err, grafana.ErrNotFound) {
+			return "", err
+		}
+		if ds != nil {
+			current = ds
+			desired.UID = ds.UID
+		}
+	}
+
+	switch {
+	case current == nil:
+		u.logger.Info("Create datasource", zap.String("name", datasource.Name))
+		desired.SecureJSONData = secureJSONData
+		created, err := grafanaClient.CreateDatasource(desired)
+		if err != nil {
+			return "", err
+		}
+		desired.UID = created.UID
+	case !equalDatasource(current, desired) || datasource.Status.SecretHash != secretHash:
+		u.logger.Info("Update datasource", zap.String("name", datasource.Name), zap.String("uid", desired.UID))
+		desired.ID = current.ID
+		desired.SecureJSONData = secureJSONData
+		if err := grafanaClient.UpdateDatasource(desired); err != nil {
+			return "", err
+		}
+	}
+
+	updated := datasource.DeepCopy()
+	updated.Status.ObservedGeneration = datasource.Generation
+	updated.Status.UID = desired.UID
+	updated.Status.SecretHash = secretHash
+	updated.Status.Message = ""
+	if !reflect.DeepEqual(updated.Status, datasource.Status) {
+		if _, err := u.client.UpdateStatusGrafanaDatasource(ctx, updated, metav1.UpdateOptions{}); err != nil {
+			return desired.UID, xerrors.WithStack(err)
+		}
+	}
+
+	return desired.UID, nil
+}
+
+func ensureGrafanaFolder(grafanaClient *grafana.Client, title string) (string, error) {
+	folders, err := grafanaClient.Folders()
+	if err != nil {
+		return "", err
+	}
+	for _, v := range folders {
+		if v.Title == title {
+			return v.UID, nil
+		}
+	}
+
+	folder, err := grafanaClient.CreateFolder(title)
+	if err != nil {
+		return "", err
+	}
+	return folder.UID, nil
+}
+
+// equalDashboardModel reports whether a and b are the same dashboard.
+// The fields which are changed by Grafana at every save are ignored.
+func equalDashboardModel(a, b map[string]any) bool {
+	strip := func(m map[string]any) map[string]any {
+		n := make(map[string]any, len(m))
+		for k, v := range m {
+			switch k {
+			case "id", "uid", "version":
+				continue
+			}
+			n[k] = v
+		}
+		return n
+	}
+
+	return reflect.DeepEqual(strip(a), strip(b))
+}
+
+func equalDatasource(current, desired *grafana.Datasource) bool {
+	if current.Name != desired.Name ||
+		current.Type != desired.Type ||
+		current.URL != desired.URL ||
+		current.Access != desired.Access ||
+		current.IsDefault != desired.IsDefault ||
+		current.BasicAuth != desired.BasicAuth ||
+		current.BasicAuthUser != desired.BasicAuthUser {
+		return false
+	}
+	if len(current.JSONData) == 0 && len(desired.JSONData) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(current.JSONData, desired.JSONData)
+}
+
+func grafanaPermission(p grafanav1alpha1.DashboardPermission) int {
+	switch p {
+	case grafanav1alpha1.DashboardPermissionEdit:
+		return grafana.PermissionEdit
+	case grafanav1alpha1.DashboardPermissionAdmin:
+		return grafana.PermissionAdmin
+	default:
+		return grafana.PermissionView
+	}
+}
+
+func dashboardPermissionKey(p *grafana.DashboardPermission) string {
+	switch {
+	case p.UserID != 0:
+		return fmt.Sprintf("user/%d/%d", p.UserID, p.Permission)
+	case p.TeamID != 0:
+		return fmt.Sprintf("team/%d/%d", p.TeamID, p.Permission)
+	default:
+		return fmt.Sprintf("role/%s/%d", p.Role, p.Permission)
+	}
+}
+
+func sortedStrings(s *set.Set) []string {
+	var l []string
+	for _, v := range s.ToSlice() {
+		l = append(l, v.(string))
+	}
+	sort.Strings(l)
+	return l
+}
@@ -1,11 +1,15 @@
 package controllers
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"go.f110.dev/mono/go/api/grafanav1alpha1"
@@ -58,6 +62,381 @@ func TestGrafanaUserController(t *testing.T) {
 	runner.AssertNoUnexpectedAction(t)
 }
 
+func TestGrafanaController_Dashboard(t *testing.T) {
+	t.Run("Create", func(t *testing.T) {
+		runner, controller := newGrafanaUserController(t)
+		target, fixtures := grafanaFixture()
+		target.Spec.DashboardSelector = &target.Spec.UserSelector
+
+		mockTransport := newGrafanaMockTransport()
+		controller.transport = mockTransport
+		mockTransport.RegisterResponder(
+			http.MethodGet,
+			"http://grafana.default.svc:3000/api/folders",
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, []grafana.Folder{{ID: 1, UID: "other", Title: "Other"}}),
+		)
+		mockTransport.RegisterResponder(
+			http.MethodPost,
+			"http://grafana.default.svc:3000/api/folders",
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, grafana.Folder{ID: 2, UID: "team", Title: "Team"}),
+		)
+		var saved map[string]any
+		mockTransport.RegisterResponder(
+			http.MethodPost,
+			"http://grafana.default.svc:3000/api/dashboards/db",
+			func(req *http.Request) (*http.Response, error) {
+				if err := json.NewDecoder(req.Body).Decode(&saved); err != nil {
+					return nil, err
+				}
+				return httpmock.NewJsonResponse(http.StatusOK, grafana.SavedDashboard{ID: 1, UID: "dashboard1", Version: 1})
+			},
+		)
+		mockTransport.RegisterResponder(
+			http.MethodGet,
+			"http://grafana.default.svc:3000/api/dashboards/uid/dashboard1/permissions",
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, []grafana.DashboardPermission{{Role: "Viewer", Permission: grafana.PermissionView, Inherited: true}}),
+		)
+		var permissions map[string][]*grafana.DashboardPermission
+		mockTransport.RegisterResponder(
+			http.MethodPost,
+			"http://grafana.default.svc:3000/api/dashboards/uid/dashboard1/permissions",
+			func(req *http.Request) (*http.Response, error) {
+				if err := json.NewDecoder(req.Body).Decode(&permissions); err != nil {
+					return nil, err
+				}
+				return httpmock.NewStringResponse(http.StatusOK, ""), nil
+			},
+		)
+
+		dashboard := k8sfactory.GrafanaDashboardFactory(nil,
+			k8sfactory.Name("dashboard1"),
+			k8sfactory.DefaultNamespace,
+			k8sfactory.Generation(1),
+			k8sfactory.Labels(target.Spec.UserSelector.MatchLabels),
+			k8sfactory.DashboardJSON(`{"title": "Test", "panels": []}`),
+			k8sfactory.Folder("Team"),
+			k8sfactory.RolePermission("Editor", grafanav1alpha1.DashboardPermissionEdit),
+		)
+		runner.RegisterFixture(dashboard)
+		runner.RegisterFixture(fixtures...)
+
+		err := runner.Reconcile(controller.newReconciler(), target)
+		require.NoError(t, err)
+
+		assert.Equal(t, "team", saved["folderUid"])
+		assert.Equal(t, map[string]any{"title": "Test", "panels": []any{}}, saved["dashboard"])
+		require.Len(t, permissions["items"], 1)
+		assert.Equal(t, "Editor", permissions["items"][0].Role)
+		assert.Equal(t, grafana.PermissionEdit, permissions["items"][0].Permission)
+
+		updatedDashboard, err := runner.Client.GrafanaV1alpha1.GetGrafanaDashboard(context.Background(), dashboard.Namespace, dashboard.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "dashboard1", updatedDashboard.Status.UID)
+		assert.Equal(t, int64(1), updatedDashboard.Status.Version)
+		assert.Equal(t, int64(1), updatedDashboard.Status.ObservedGeneration)
+		updated, err := runner.Client.GrafanaV1alpha1.GetGrafana(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"dashboard1"}, updated.Status.Dashboards)
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		runner, controller := newGrafanaUserController(t)
+		target, fixtures := grafanaFixture()
+		target.Spec.DashboardSelector = &target.Spec.UserSelector
+		target.Status.ObservedGeneration = target.Generation
+		target.Status.Dashboards = []string{"dashboard1"}
+
+		mockTransport := newGrafanaMockTransport()
+		controller.transport = mockTransport
+		mockTransport.RegisterResponder(
+			http.MethodGet,
+			"http://grafana.default.svc:3000/api/dashboards/uid/dashboard1",
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, grafana.Dashboard{
+				Model: map[string]any{"id": 1, "uid": "dashboard1", "version": 3, "title": "Test"},
+				Meta:  &grafana.DashboardMeta{Version: 3},
+			}),
+		)
+		mockTransport.RegisterResponder(
+			http.MethodGet,
+			"http://grafana.default.svc:3000/api/dashboards/uid/dashboard1/permissions",
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, []grafana.DashboardPermission{}),
+		)
+
+		dashboard := k8sfactory.GrafanaDashboardFactory(nil,
+			k8sfactory.Name("dashboard1"),
+			k8sfactory.DefaultNamespace,
+			k8sfactory.Generation(1),
+			k8sfactory.Labels(target.Spec.UserSelector.MatchLabels),
+			k8sfactory.DashboardJSON(`{"title": "Test"}`),
+		)
+		dashboard.Status = grafanav1alpha1.GrafanaDashboardStatus{ObservedGeneration: 1, UID: "dashboard1", Version: 3}
+		runner.RegisterFixture(dashboard)
+		runner.RegisterFixture(fixtures...)
+
+		err := runner.Reconcile(controller.newReconciler(), target)
+		require.NoError(t, err)
+
+		runner.AssertNoUnexpectedAction(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		runner, controller := newGrafanaUserController(t)
+		target, fixtures := grafanaFixture()
+		target.Spec.DashboardSelector = &target.Spec.UserSelector
+
+		mockTransport := newGrafanaMockTransport()
+		controller.transport = mockTransport
+
+		dashboard := k8sfactory.GrafanaDashboardFactory(nil,
+			k8sfactory.Name("dashboard1"),
+			k8sfactory.DefaultNamespace,
+			k8sfactory.Generation(1),
+			k8sfactory.Labels(target.Spec.UserSelector.MatchLabels),
+			k8sfactory.DashboardJSON(`{"title": `),
+		)
+		runner.RegisterFixture(dashboard)
+		runner.RegisterFixture(fixtures...)
+
+		err := runner.Reconcile(controller.newReconciler(), target)
+		require.NoError(t, err)
+
+		updatedDashboard, err := runner.Client.GrafanaV1alpha1.GetGrafanaDashboard(context.Background(), dashboard.Namespace, dashboard.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.NotEmpty(t, updatedDashboard.Status.Message)
+		assert.Empty(t, updatedDashboard.Status.UID)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		runner, controller := newGrafanaUserController(t)
+		target, fixtures := grafanaFixture()
+		target.Spec.DashboardSelector = &target.Spec.UserSelector
+		target.Status.Dashboards = []string{"dashboard1"}
+		runner.RegisterFixture(fixtures...)
+
+		mockTransport := newGrafanaMockTransport()
+		controller.transport = mockTransport
+		mockTransport.RegisterResponder(
+			http.MethodDelete,
+			"http://grafana.default.svc:3000/api/dashboards/uid/dashboard1",
+			httpmock.NewStringResponder(http.StatusOK, ""),
+		)
+
+		err := runner.Reconcile(controller.newReconciler(), target)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, mockTransport.GetCallCountInfo()["DELETE http://grafana.default.svc:3000/api/dashboards/uid/dashboard1"])
+		expect := target.DeepCopy()
+		expect.Status.ObservedGeneration = 2
+		expect.Status.Dashboards = nil
+		runner.AssertUpdateAction(t, "status", expect)
+		runner.AssertNoUnexpectedAction(t)
+	})
+}
+
+func TestGrafanaController_Datasource(t *testing.T) {
+	t.Run("Create", func(t *testing.T) {
+		runner, controller := newGrafanaUserController(t)
+		target, fixtures := grafanaFixture()
+		target.Spec.DatasourceSelector = &target.Spec.UserSelector
+
+		mockTransport := newGrafanaMockTransport()
+		controller.transport = mockTransport
+		mockTransport.RegisterResponder(
+			http.MethodGet,
+			"http://grafana.default.svc:3000/api/datasources/name/prometheus",
+			httpmock.NewStringResponder(http.StatusNotFound, ""),
+		)
+		var created grafana.Datasource
+		mockTransport.RegisterResponder(
+			http.MethodPost,
+			"http://grafana.default.svc:3000/api/datasources",
+			func(req *http.Request) (*http.Response, error) {
+				if err := json.NewDecoder(req.Body).Decode(&created); err != nil {
+					return nil, err
+				}
+				return httpmock.NewJsonResponse(http.StatusOK, map[string]any{"datasource": grafana.Datasource{ID: 1, UID: "datasource1"}})
+			},
+		)
+
+		secret := k8sfactory.SecretFactory(nil,
+			k8sfactory.Name("prometheus"),
+			k8sfactory.DefaultNamespace,
+			k8sfactory.Data("password", []byte("secret")),
+		)
+		datasource := k8sfactory.GrafanaDatasourceFactory(nil,
+			k8sfactory.Name("prometheus"),
+			k8sfactory.DefaultNamespace,
+			k8sfactory.Generation(1),
+			k8sfactory.Labels(target.Spec.UserSelector.MatchLabels),
+			k8sfactory.Datasource("prometheus", "http://prometheus.default.svc:9090"),
+			k8sfactory.BasicAuth("grafana", k8sfactory.SecretKeySelector(secret, "password")),
+		)
+		runner.RegisterFixture(datasource, secret)
+		runner.RegisterFixture(fixtures...)
+
+		err := runner.Reconcile(controller.newReconciler(), target)
+		require.NoError(t, err)
+
+		assert.Equal(t, "prometheus", created.Name)
+		assert.Equal(t, "prometheus", created.Type)
+		assert.Equal(t, "http://prometheus.default.svc:9090", created.URL)
+		assert.Equal(t, "proxy", created.Access)
+		assert.True(t, created.BasicAuth)
+		assert.Equal(t, "grafana", created.BasicAuthUser)
+		assert.Equal(t, map[string]string{"basicAuthPassword": "secret"}, created.SecureJSONData)
+
+		updatedDatasource, err := runner.Client.GrafanaV1alpha1.GetGrafanaDatasource(context.Background(), datasource.Namespace, datasource.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "datasource1", updatedDatasource.Status.UID)
+		assert.NotEmpty(t, updatedDatasource.Status.SecretHash)
+		updated, err := runner.Client.GrafanaV1alpha1.GetGrafana(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"datasource1"}, updated.Status.Datasources)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		runner, controller := newGrafanaUserController(t)
+		target, fixtures := grafanaFixture()
+		target.Spec.DatasourceSelector = &target.Spec.UserSelector
+		target.Status.Datasources = []string{"datasource1"}
+
+		mockTransport := newGrafanaMockTransport()
+		controller.transport = mockTransport
+		mockTransport.RegisterResponder(
+			http.MethodGet,
+			"http://grafana.default.svc:3000/api/datasources/uid/datasource1",
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, grafana.Datasource{
+				ID:     1,
+				UID:    "datasource1",
+				Name:   "loki",
+				Type:   "loki",
+				URL:    "http://loki.default.svc:3100",
+				Access: "proxy",
+			}),
+		)
+		var updated grafana.Datasource
+		mockTransport.RegisterResponder(
+			http.MethodPut,
+			"http://grafana.default.svc:3000/api/datasources/uid/datasource1",
+			func(req *http.Request) (*http.Response, error) {
+				if err := json.NewDecoder(req.Body).Decode(&updated); err != nil {
+					return nil, err
+				}
+				return httpmock.NewStringResponse(http.StatusOK, "{}"), nil
+			},
+		)
+
+		datasource := k8sfactory.GrafanaDatasourceFactory(nil,
+			k8sfactory.Name("loki"),
+			k8sfactory.DefaultNamespace,
+			k8sfactory.Labels(target.Spec.UserSelector.MatchLabels),
+			k8sfactory.Datasource("loki", "http://loki.monitoring.svc:3100"),
+		)
+		datasource.Status.UID = "datasource1"
+		runner.RegisterFixture(datasource)
+		runner.RegisterFixture(fixtures...)
+
+		err := runner.Reconcile(controller.newReconciler(), target)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, updated.ID)
+		assert.Equal(t, "datasource1", updated.UID)
+		assert.Equal(t, "http://loki.monitoring.svc:3100", updated.URL)
+	})
+
+	t.Run("FoundByName", func(t *testing.T) {
+		runner, controller := newGrafanaUserController(t)
+		target, fixtures := grafanaFixture()
+		target.Spec.DatasourceSelector = &target.Spec.UserSelector
+
+		mockTransport := newGrafanaMockTransport()
+		controller.transport = mockTransport
+		mockTransport.RegisterResponder(
+			http.MethodGet,
+			"http://grafana.default.svc:3000/api/datasources/uid/stale",
+			httpmock.NewStringResponder(http.StatusNotFound, ""),
+		)
+		mockTransport.RegisterResponder(
+			http.MethodGet,
+			"http://grafana.default.svc:3000/api/datasources/name/loki",
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, grafana.Datasource{
+				ID:     2,
+				UID:    "datasource2",
+				Name:   "loki",
+				Type:   "loki",
+				URL:    "http://loki.default.svc:3100",
+				Access: "proxy",
+			}),
+		)
+		var updated grafana.Datasource
+		mockTransport.RegisterResponder(
+			http.MethodPut,
+			"http://grafana.default.svc:3000/api/datasources/uid/datasource2",
+			func(req *http.Request) (*http.Response, error) {
+				if err := json.NewDecoder(req.Body).Decode(&updated); err != nil {
+					return nil, err
+				}
+				return httpmock.NewStringResponse(http.StatusOK, "{}"), nil
+			},
+		)
+
+		datasource := k8sfactory.GrafanaDatasourceFactory(nil,
+			k8sfactory.Name("loki"),
+			k8sfactory.DefaultNamespace,
+			k8sfactory.Labels(target.Spec.UserSelector.MatchLabels),
+			k8sfactory.Datasource("loki", "http://loki.monitoring.svc:3100"),
+		)
+		datasource.Status.UID = "stale"
+		runner.RegisterFixture(datasource)
+		runner.RegisterFixture(fixtures...)
+
+		err := runner.Reconcile(controller.newReconciler(), target)
+		require.NoError(t, err)
+
+		// The datasource is not created twice.
+		assert.Equal(t, 0, mockTransport.GetCallCountInfo()["POST http://grafana.default.svc:3000/api/datasources"])
+		assert.Equal(t, 2, updated.ID)
+		assert.Equal(t, "http://loki.monitoring.svc:3100", updated.URL)
+		updatedDatasource, err := runner.Client.GrafanaV1alpha1.GetGrafanaDatasource(context.Background(), datasource.Namespace, datasource.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "datasource2", updatedDatasource.Status.UID)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		runner, controller := newGrafanaUserController(t)
+		target, fixtures := grafanaFixture()
+		target.Status.Datasources = []string{"datasource1"}
+		runner.RegisterFixture(fixtures...)
+
+		mockTransport := newGrafanaMockTransport()
+		controller.transport = mockTransport
+		mockTransport.RegisterResponder(
+			http.MethodDelete,
+			"http://grafana.default.svc:3000/api/datasources/uid/datasource1",
+			httpmock.NewStringResponder(http.StatusNotFound, ""),
+		)
+
+		err := runner.Reconcile(controller.newReconciler(), target)
+		require.NoError(t, err)
+
+		expect := target.DeepCopy()
+		expect.Status.ObservedGeneration = 2
+		expect.Status.Datasources = nil
+		runner.AssertUpdateAction(t, "status", expect)
+		runner.AssertNoUnexpectedAction(t)
+	})
+}
+
+func newGrafanaMockTransport() *httpmock.MockTransport {
+	mockTransport := httpmock.NewMockTransport()
+	mockTransport.RegisterResponder(
+		http.MethodGet,
+		"http://grafana.default.svc:3000/api/users",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []grafana.User{}),
+	)
+	return mockTransport
+}
+
 func grafanaFixture() (*grafanav1alpha1.Grafana, []runtime.Object) {
 	secret := k8sfactory.SecretFactory(nil,
 		k8sfactory.Name("admin"),
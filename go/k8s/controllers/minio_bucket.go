@@ -9,8 +9,10 @@ import (
 	"strings"
 	"time"
 
+	madmin "github.com/minio/madmin-go/v3"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
 	"github.com/minio/minio-go/v7/pkg/policy"
 	miniocontrollerv1beta1 "github.com/minio/minio-operator/pkg/apis/miniocontroller/v1beta1"
 	"go.f110.dev/xerrors"
@@ -185,13 +187,23 @@ type BucketReconciler struct {
 	MinIOInstances []*miniocontrollerv1beta1.MinIOInstance
 	Secret         *corev1.Secret
 	MinIOClient    *minio.Client
+	AdminClient    *madmin.AdminClient
 	PortForwarder  *portforward.PortForwarder
+
+	// applied is true if the current spec has already been applied to the bucket.
+	// The difference between the spec and the bucket is regarded as a drift only if applied is true.
+	applied bool
+	drifts  []miniov1alpha1.MinIOBucketDrift
 }
 
 const (
 	bucketStateInit fsm.State = iota
 	bucketStateEnsureBucket
 	bucketStateEnsureBucketPolicy
+	bucketStateEnsureVersioning
+	bucketStateEnsureObjectLock
+	bucketStateEnsureLifecycle
+	bucketStateEnsureQuota
 	bucketStateEnsureIndexFile
 	bucketStateUpdateStatus
 	bucketStateCleanup
@@ -229,12 +241,17 @@ func (r *BucketReconciler) Reconcile(ctx context.Context, obj runtime.Object) er
 	r.Original = bucket
 	r.Obj = bucket.DeepCopy()
 	r.ctx = ctx
+	r.applied = bucket.Status.Ready && bucket.Status.ObservedGeneration == bucket.Generation
 
 	f := fsm.NewFSM(
 		map[fsm.State]fsm.StateFunc{
 			bucketStateInit:               r.init,
 			bucketStateEnsureBucket:       r.ensureBucket,
 			bucketStateEnsureBucketPolicy: r.ensureBucketPolicy,
+			bucketStateEnsureVersioning:   r.ensureVersioning,
+			bucketStateEnsureObjectLock:   r.ensureObjectLock,
+			bucketStateEnsureLifecycle:    r.ensureLifecycle,
+			bucketStateEnsureQuota:        r.ensureQuota,
 			bucketStateEnsureIndexFile:    r.ensureIndexFile,
 			bucketStateUpdateStatus:       r.updateStatus,
 			bucketStateCleanup:            r.cleanup,
@@ -356,6 +373,15 @@ func (r *BucketReconciler) init(_ context.Context) (fsm.State, error) {
 	}
 	r.MinIOClient = mc
 
+	adminClient, err := madmin.New(instanceEndpoint, string(creds.Data["accesskey"]), string(creds.Data["secretkey"]), false)
+	if err != nil {
+		return fsm.Error(xerrors.WithStack(err))
+	}
+	if r.transport != nil {
+		adminClient.SetCustomTransport(r.transport)
+	}
+	r.AdminClient = adminClient
+
 	return fsm.Next(bucketStateEnsureBucket)
 }
 
@@ -368,7 +394,8 @@ func (r *BucketReconciler) ensureBucket(_ context.Context) (fsm.State, error) {
 	}
 	r.logger.Debug("Created", zap.String("name", r.Obj.Name))
 
-	if err := r.MinIOClient.MakeBucket(r.ctx, r.Obj.Name, minio.MakeBucketOptions{}); err != nil {
+	opt := minio.MakeBucketOptions{ObjectLocking: r.Obj.Spec.ObjectLock != nil}
+	if err := r.MinIOClient.MakeBucket(r.ctx, r.Obj.Name, opt); err != nil {
 		return fsm.Error(xerrors.WithStack(err))
 	}
 
@@ -407,7 +434,7 @@ func (r *BucketReconciler) ensureBucketPolicy(ctx context.Context) (fsm.State, e
 	if len(p.Statements) > 0 && currentPolicy != nil {
 		if reflect.DeepEqual(p.Statements, currentPolicy.Statements) {
 			logger.Log.Debug("Skip set bucket policy because already set same policy")
-			return fsm.Next(bucketStateEnsureIndexFile)
+			return fsm.Next(bucketStateEnsureVersioning)
 		}
 	}
 
@@ -420,6 +447,146 @@ func (r *BucketReconciler) ensureBucketPolicy(ctx context.Context) (fsm.State, e
 		return fsm.Error(xerrors.WithStack(err))
 	}
 
+	return fsm.Next(bucketStateEnsureVersioning)
+}
+
+func (r *BucketReconciler) ensureVersioning(ctx context.Context) (fsm.State, error) {
+	// Object locking requires versioning.
+	// The versioning of the bucket which enabled object locking can't be suspended.
+	enable := r.Obj.Spec.Versioning == miniov1alpha1.BucketVersioningEnabled || r.Obj.Spec.ObjectLock != nil
+	suspend := !enable && r.Obj.Spec.Versioning == miniov1alpha1.BucketVersioningSuspended
+	if !enable && !suspend {
+		// The versioning is not managed.
+		return fsm.Next(bucketStateEnsureObjectLock)
+	}
+
+	current, err := r.MinIOClient.GetBucketVersioning(ctx, r.Obj.Name)
+	if err != nil {
+		return fsm.Error(xerrors.WithStack(err))
+	}
+	switch {
+	case enable && !current.Enabled():
+		r.detectDrift("versioning", "versioning is not enabled", true)
+		r.logger.Debug("Enable versioning", zap.String("name", r.Obj.Name))
+		if err := r.MinIOClient.EnableVersioning(ctx, r.Obj.Name); err != nil {
+			return fsm.Error(xerrors.WithStack(err))
+		}
+	case suspend && current.Enabled():
+		r.detectDrift("versioning", "versioning is enabled", true)
+		r.logger.Debug("Suspend versioning", zap.String("name", r.Obj.Name))
+		if err := r.MinIOClient.SuspendVersioning(ctx, r.Obj.Name); err != nil {
+			return fsm.Error(xerrors.WithStack(err))
+		}
+	}
+
+	return fsm.Next(bucketStateEnsureObjectLock)
+}
+
+func (r *BucketReconciler) ensureObjectLock(ctx context.Context) (fsm.State, error) {
+	if r.Obj.Spec.ObjectLock == nil {
+		// Object locking can't be disabled once it was enabled.
+		return fsm.Next(bucketStateEnsureLifecycle)
+	}
+
+	enabled, mode, validity, unit, err := r.MinIOClient.GetObjectLockConfig(ctx, r.Obj.Name)
+	if err != nil && minio.ToErrorResponse(err).Code != "ObjectLockConfigurationNotFoundError" {
+		return fsm.Error(xerrors.WithStack(err))
+	}
+	if enabled != "Enabled" {
+		// Object locking can't be enabled for the existing bucket.
+		// We are going to report it as a drift regardless of whether the spec has been applied or not.
+		r.logger.Info("Object locking is not enabled", zap.String("name", r.Obj.Name))
+		r.drifts = append(r.drifts, miniov1alpha1.MinIOBucketDrift{
+			Setting: "objectLock",
+			Message: "object locking is not enabled and it can't be enabled for the existing bucket",
+		})
+		return fsm.Next(bucketStateEnsureLifecycle)
+	}
+
+	desiredMode, desiredValidity, desiredUnit := objectLockRetention(r.Obj.Spec.ObjectLock)
+	current := formatRetention(mode, validity, unit)
+	if current == formatRetention(desiredMode, desiredValidity, desiredUnit) {
+		return fsm.Next(bucketStateEnsureLifecycle)
+	}
+
+	r.detectDrift("objectLock", fmt.Sprintf("the default retention is %q", current), true)
+	r.logger.Debug("SetObjectLockConfig", zap.String("name", r.Obj.Name))
+	if err := r.MinIOClient.SetObjectLockConfig(ctx, r.Obj.Name, desiredMode, desiredValidity, desiredUnit); err != nil {
+		return fsm.Error(xerrors.WithStack(err))
+	}
+
+	return fsm.Next(bucketStateEnsureLifecycle)
+}
+
+func (r *BucketReconciler) ensureLifecycle(ctx context.Context) (fsm.State, error) {
+	if r.Obj.Spec.Lifecycle == nil {
+		// The lifecycle configuration is not managed.
+		// An empty list is distinguished from nil because it means that the configuration should be removed.
+		return fsm.Next(bucketStateEnsureQuota)
+	}
+
+	current, err := r.MinIOClient.GetBucketLifecycle(ctx, r.Obj.Name)
+	if err != nil && minio.ToErrorResponse(err).Code != "NoSuchLifecycleConfiguration" {
+		return fsm.Error(xerrors.WithStack(err))
+	}
+	var currentRules []miniov1alpha1.MinIOBucketLifecycleRule
+	disabled := false
+	if current != nil {
+		for _, v := range current.Rules {
+			if v.Status != "Enabled" {
+				disabled = true
+			}
+			currentRules = append(currentRules, lifecycleRuleFromConfig(v))
+		}
+	}
+	if len(currentRules) == 0 && len(r.Obj.Spec.Lifecycle) == 0 {
+		return fsm.Next(bucketStateEnsureQuota)
+	}
+	if !disabled && reflect.DeepEqual(currentRules, r.Obj.Spec.Lifecycle) {
+		return fsm.Next(bucketStateEnsureQuota)
+	}
+
+	r.detectDrift("lifecycle", fmt.Sprintf("the bucket has %d lifecycle rules which differ from the spec", len(currentRules)), true)
+	config := lifecycle.NewConfiguration()
+	for _, v := range r.Obj.Spec.Lifecycle {
+		config.Rules = append(config.Rules, lifecycleRuleToConfig(v))
+	}
+	r.logger.Debug("SetBucketLifecycle", zap.String("name", r.Obj.Name), zap.Int("rules", len(config.Rules)))
+	if err := r.MinIOClient.SetBucketLifecycle(ctx, r.Obj.Name, config); err != nil {
+		return fsm.Error(xerrors.WithStack(err))
+	}
+
+	return fsm.Next(bucketStateEnsureQuota)
+}
+
+func (r *BucketReconciler) ensureQuota(ctx context.Context) (fsm.State, error) {
+	if r.Obj.Spec.Quota == 0 {
+		// The quota is not managed.
+		return fsm.Next(bucketStateEnsureIndexFile)
+	}
+
+	current, err := r.AdminClient.GetBucketQuota(ctx, r.Obj.Name)
+	if err != nil {
+		return fsm.Error(xerrors.WithStack(err))
+	}
+	currentSize := current.Size
+	if currentSize == 0 {
+		// Quota is the deprecated field. Old MinIO returns the size in this field.
+		currentSize = current.Quota
+	}
+
+	size := uint64(r.Obj.Spec.Quota) * 1024 * 1024 * 1024
+	if currentSize == size {
+		return fsm.Next(bucketStateEnsureIndexFile)
+	}
+
+	r.detectDrift("quota", fmt.Sprintf("the quota is %d bytes", currentSize), true)
+	r.logger.Debug("SetBucketQuota", zap.String("name", r.Obj.Name), zap.Uint64("size", size))
+	err = r.AdminClient.SetBucketQuota(ctx, r.Obj.Name, &madmin.BucketQuota{Quota: size, Size: size, Type: madmin.HardQuota})
+	if err != nil {
+		return fsm.Error(xerrors.WithStack(err))
+	}
+
 	return fsm.Next(bucketStateEnsureIndexFile)
 }
 
@@ -461,6 +628,8 @@ func (r *BucketReconciler) ensureIndexFile(_ context.Context) (fsm.State, error)
 
 func (r *BucketReconciler) updateStatus(_ context.Context) (fsm.State, error) {
 	r.Obj.Status.Ready = true
+	r.Obj.Status.ObservedGeneration = r.Obj.Generation
+	r.Obj.Status.Drifts = r.drifts
 
 	if !reflect.DeepEqual(r.Original.Status, r.Obj.Status) {
 		_, err := r.Client.UpdateStatusMinIOBucket(r.ctx, r.Obj, metav1.UpdateOptions{})
@@ -480,6 +649,18 @@ func (r *BucketReconciler) cleanup(_ context.Context) (fsm.State, error) {
 	return fsm.Finish()
 }
 
+// detectDrift records the difference between the spec and the bucket.
+// The difference is recorded only if the spec has already been applied.
+// Otherwise, the difference is caused by updating the spec.
+func (r *BucketReconciler) detectDrift(setting, message string, corrected bool) {
+	if !r.applied {
+		return
+	}
+
+	r.logger.Info("Detected drift", zap.String("name", r.Obj.Name), zap.String("setting", setting), zap.String("message", message))
+	r.drifts = append(r.drifts, miniov1alpha1.MinIOBucketDrift{Setting: setting, Message: message, Corrected: corrected})
+}
+
 func (r *BucketReconciler) getMinIOInstanceEndpoint(
 	ctx context.Context,
 	instance *miniocontrollerv1beta1.MinIOInstance,
@@ -562,3 +743,63 @@ func (r *BucketReconciler) portForward(_ context.Context, svc *corev1.Service, p
 
 	return pf, nil
 }
+
+func objectLockRetention(spec *miniov1alpha1.MinIOBucketObjectLock) (*minio.RetentionMode, *uint, *minio.ValidityUnit) {
+	if spec.Mode == "" {
+		return nil, nil, nil
+	}
+
+	var mode minio.RetentionMode
+	switch spec.Mode {
+	case miniov1alpha1.BucketRetentionModeGovernance:
+		mode = minio.Governance
+	case miniov1alpha1.BucketRetentionModeCompliance:
+		mode = minio.Compliance
+	}
+	validity, unit := uint(spec.Days), minio.Days
+	if spec.Years > 0 {
+		validity, unit = uint(spec.Years), minio.Years
+	}
+
+	return &mode, &validity, &unit
+}
+
+func formatRetention(mode *minio.RetentionMode, validity *uint, unit *minio.ValidityUnit) string {
+	if mode == nil || validity == nil || unit == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s %d %s", *mode, *validity, *unit)
+}
+
+func lifecycleRuleFromConfig(rule lifecycle.Rule) miniov1alpha1.MinIOBucketLifecycleRule {
+	prefix := rule.RuleFilter.Prefix
+	if prefix == "" {
+		prefix = rule.Prefix
+	}
+
+	return miniov1alpha1.MinIOBucketLifecycleRule{
+		ID:                       rule.ID,
+		Prefix:                   prefix,
+		ExpirationDays:           int(rule.Expiration.Days),
+		NoncurrentExpirationDays: int(rule.NoncurrentVersionExpiration.NoncurrentDays),
+		TransitionDays:           int(rule.Transition.Days),
+		TransitionStorageClass:   rule.Transition.StorageClass,
+	}
+}
+
+func lifecycleRuleToConfig(rule miniov1alpha1.MinIOBucketLifecycleRule) lifecycle.Rule {
+	return lifecycle.Rule{
+		ID:         rule.ID,
+		Status:     "Enabled",
+		RuleFilter: lifecycle.Filter{Prefix: rule.Prefix},
+		Expiration: lifecycle.Expiration{Days: lifecycle.ExpirationDays(rule.ExpirationDays)},
+		NoncurrentVersionExpiration: lifecycle.NoncurrentVersionExpiration{
+			NoncurrentDays: lifecycle.ExpirationDays(rule.NoncurrentExpirationDays),
+		},
+		Transition: lifecycle.Transition{
+			Days:         lifecycle.ExpirationDays(rule.TransitionDays),
+			StorageClass: rule.TransitionStorageClass,
+		},
+	}
+}
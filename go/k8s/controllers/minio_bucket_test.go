@@ -1,15 +1,19 @@
 package controllers
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"regexp"
-	"runtime/debug"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
 	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"go.f110.dev/mono/go/api/miniov1alpha1"
@@ -23,65 +27,232 @@ func TestMinIOBucketController(t *testing.T) {
 		target, fixtures := minioFixture()
 		runner.RegisterFixture(fixtures...)
 
-		mockTransport := httpmock.NewMockTransport()
+		mockTransport := newMinIOBucketMockTransport(false, "", 0)
 		controller.transport = mockTransport
-		mockTransport.RegisterRegexpResponder(
-			http.MethodGet,
-			regexp.MustCompile("./bucket1/\\?policy="),
-			httpmock.NewStringResponder(http.StatusOK, ""),
-		)
-		mockTransport.RegisterRegexpResponder(
-			http.MethodGet,
-			regexp.MustCompile(".*/bucket1/"),
-			httpmock.NewStringResponder(
-				http.StatusOK, `<?xml version="1.0" encoding="UTF-8"?>
-<LocationConstraint>
-   <LocationConstraint>us-east-1</LocationConstraint>
-</LocationConstraint>`,
-			),
+
+		err := runner.Reconcile(controller, target)
+		require.NoError(t, err)
+
+		updated := target.DeepCopy()
+		updated.Status.Ready = true
+		runner.AssertUpdateAction(t, "status", updated)
+		runner.AssertNoUnexpectedAction(t)
+	})
+
+	t.Run("ApplySpec", func(t *testing.T) {
+		runner := newRunner()
+		controller := newMinIOBucketController(t, runner)
+		target, fixtures := minioFixture()
+		target = k8sfactory.MinIOBucketFactory(target,
+			k8sfactory.LifecycleRule(miniov1alpha1.MinIOBucketLifecycleRule{ID: "expire", Prefix: "tmp/", ExpirationDays: 7}),
+			k8sfactory.ObjectLock(miniov1alpha1.BucketRetentionModeGovernance, 30),
 		)
+		target.Generation = 2
+		target.Status.Ready = true
+		target.Status.ObservedGeneration = 1
+		runner.RegisterFixture(fixtures...)
+
+		mockTransport := newMinIOBucketMockTransport(true, "", 0)
+		controller.transport = mockTransport
+		var versioning, lifecycleConfig string
 		mockTransport.RegisterRegexpResponder(
-			http.MethodHead,
-			regexp.MustCompile(".*/bucket1/$"),
-			httpmock.NewXmlResponderOrPanic(http.StatusNotFound, &minio.ErrorResponse{Code: "NoSuchBucket"}),
+			http.MethodPut,
+			regexp.MustCompile(".*/bucket1/\\?versioning=$"),
+			func(req *http.Request) (*http.Response, error) {
+				b, _ := io.ReadAll(req.Body)
+				versioning = string(b)
+				return httpmock.NewStringResponse(http.StatusOK, ""), nil
+			},
 		)
 		mockTransport.RegisterRegexpResponder(
 			http.MethodPut,
-			regexp.MustCompile(".*/bucket1/$"),
+			regexp.MustCompile(".*/bucket1/\\?lifecycle=$"),
 			func(req *http.Request) (*http.Response, error) {
+				b, _ := io.ReadAll(req.Body)
+				lifecycleConfig = string(b)
 				return httpmock.NewStringResponse(http.StatusOK, ""), nil
 			},
 		)
+
+		err := runner.Reconcile(controller, target)
+		require.NoError(t, err)
+
+		assert.Contains(t, versioning, "<Status>Enabled</Status>")
+		assert.Contains(t, lifecycleConfig, "<ID>expire</ID>")
+		assert.Contains(t, lifecycleConfig, "<Prefix>tmp/</Prefix>")
+		updated, err := runner.Client.MinioV1alpha1.GetMinIOBucket(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), updated.Status.ObservedGeneration)
+		// Object locking can't be enabled for the existing bucket.
+		// It is reported as a drift even though the spec has not been applied yet.
+		require.Len(t, updated.Status.Drifts, 1)
+		assert.Equal(t, "objectLock", updated.Status.Drifts[0].Setting)
+		assert.False(t, updated.Status.Drifts[0].Corrected)
+	})
+
+	t.Run("UnmanagedSettings", func(t *testing.T) {
+		runner := newRunner()
+		controller := newMinIOBucketController(t, runner)
+		target, fixtures := minioFixture()
+		target.Generation = 2
+		target.Status.Ready = true
+		target.Status.ObservedGeneration = 2
+		runner.RegisterFixture(fixtures...)
+
+		// The versioning and the quota have been configured out of band.
+		mockTransport := newMinIOBucketMockTransport(true, "Enabled", 10*1024*1024*1024)
+		controller.transport = mockTransport
 		mockTransport.RegisterRegexpResponder(
 			http.MethodPut,
-			regexp.MustCompile(".*/bucket1/\\?policy=$"),
-			httpmock.NewStringResponder(http.StatusNoContent, ""),
+			regexp.MustCompile(".*/bucket1/\\?(versioning|lifecycle)=$"),
+			httpmock.NewStringResponder(http.StatusOK, ""),
 		)
 		mockTransport.RegisterRegexpResponder(
 			http.MethodDelete,
-			regexp.MustCompile(".*/bucket1/\\?policy=$"),
+			regexp.MustCompile(".*/bucket1/\\?lifecycle=$"),
 			httpmock.NewStringResponder(http.StatusNoContent, ""),
 		)
 		mockTransport.RegisterRegexpResponder(
-			http.MethodDelete,
-			regexp.MustCompile(".*"),
-			func(request *http.Request) (*http.Response, error) {
-				t.Log(request.URL.String())
-				debug.PrintStack()
-				return nil, nil
+			http.MethodPut,
+			regexp.MustCompile(".*/minio/admin/v3/set-bucket-quota\\?bucket=bucket1$"),
+			httpmock.NewStringResponder(http.StatusOK, ""),
+		)
+
+		err := runner.Reconcile(controller, target)
+		require.NoError(t, err)
+
+		// The settings which are not declared in the spec must not be touched.
+		for k, v := range mockTransport.GetCallCountInfo() {
+			if regexp.MustCompile("versioning|lifecycle|bucket-quota").MatchString(k) {
+				assert.Equal(t, 0, v, k)
+			}
+		}
+		updated, err := runner.Client.MinioV1alpha1.GetMinIOBucket(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Len(t, updated.Status.Drifts, 0)
+	})
+
+	t.Run("DetectDrift", func(t *testing.T) {
+		runner := newRunner()
+		controller := newMinIOBucketController(t, runner)
+		target, fixtures := minioFixture()
+		target = k8sfactory.MinIOBucketFactory(target,
+			k8sfactory.EnableVersioning,
+			k8sfactory.Quota(10),
+		)
+		target.Generation = 2
+		target.Status.Ready = true
+		target.Status.ObservedGeneration = 2
+		runner.RegisterFixture(fixtures...)
+
+		mockTransport := newMinIOBucketMockTransport(true, "Suspended", 0)
+		controller.transport = mockTransport
+		mockTransport.RegisterRegexpResponder(
+			http.MethodPut,
+			regexp.MustCompile(".*/bucket1/\\?versioning=$"),
+			httpmock.NewStringResponder(http.StatusOK, ""),
+		)
+		var quota string
+		mockTransport.RegisterRegexpResponder(
+			http.MethodPut,
+			regexp.MustCompile(".*/minio/admin/v3/set-bucket-quota\\?bucket=bucket1$"),
+			func(req *http.Request) (*http.Response, error) {
+				b, _ := io.ReadAll(req.Body)
+				quota = string(b)
+				return httpmock.NewStringResponse(http.StatusOK, ""), nil
 			},
 		)
 
 		err := runner.Reconcile(controller, target)
 		require.NoError(t, err)
 
-		updated := target.DeepCopy()
-		updated.Status.Ready = true
-		runner.AssertUpdateAction(t, "status", updated)
-		runner.AssertNoUnexpectedAction(t)
+		assert.Contains(t, quota, `"size":10737418240`)
+		updated, err := runner.Client.MinioV1alpha1.GetMinIOBucket(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Len(t, updated.Status.Drifts, 2)
+		assert.Equal(t, "versioning", updated.Status.Drifts[0].Setting)
+		assert.True(t, updated.Status.Drifts[0].Corrected)
+		assert.Equal(t, "quota", updated.Status.Drifts[1].Setting)
+		assert.True(t, updated.Status.Drifts[1].Corrected)
 	})
 }
 
+// newMinIOBucketMockTransport returns the mock transport which responds as the bucket named bucket1.
+// The bucket doesn't have any policy, lifecycle rules and object locking.
+func newMinIOBucketMockTransport(exists bool, versioning string, quota uint64) *httpmock.MockTransport {
+	mockTransport := httpmock.NewMockTransport()
+	mockTransport.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile("./bucket1/\\?policy="),
+		httpmock.NewStringResponder(http.StatusOK, ""),
+	)
+	mockTransport.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile(".*/bucket1/\\?versioning=$"),
+		httpmock.NewStringResponder(
+			http.StatusOK,
+			fmt.Sprintf("<VersioningConfiguration><Status>%s</Status></VersioningConfiguration>", versioning),
+		),
+	)
+	mockTransport.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile(".*/bucket1/\\?lifecycle="),
+		httpmock.NewXmlResponderOrPanic(http.StatusNotFound, &minio.ErrorResponse{Code: "NoSuchLifecycleConfiguration"}),
+	)
+	mockTransport.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile(".*/bucket1/\\?object-lock=$"),
+		httpmock.NewXmlResponderOrPanic(http.StatusNotFound, &minio.ErrorResponse{Code: "ObjectLockConfigurationNotFoundError"}),
+	)
+	mockTransport.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile(".*/bucket1/"),
+		httpmock.NewStringResponder(
+			http.StatusOK, `<?xml version="1.0" encoding="UTF-8"?>
+<LocationConstraint>
+   <LocationConstraint>us-east-1</LocationConstraint>
+</LocationConstraint>`,
+		),
+	)
+	mockTransport.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile(".*/minio/admin/v3/get-bucket-quota\\?bucket=bucket1$"),
+		httpmock.NewStringResponder(http.StatusOK, fmt.Sprintf(`{"size":%d,"quotatype":"hard"}`, quota)),
+	)
+	if exists {
+		mockTransport.RegisterRegexpResponder(
+			http.MethodHead,
+			regexp.MustCompile(".*/bucket1/$"),
+			httpmock.NewStringResponder(http.StatusOK, ""),
+		)
+	} else {
+		mockTransport.RegisterRegexpResponder(
+			http.MethodHead,
+			regexp.MustCompile(".*/bucket1/$"),
+			httpmock.NewXmlResponderOrPanic(http.StatusNotFound, &minio.ErrorResponse{Code: "NoSuchBucket"}),
+		)
+	}
+	mockTransport.RegisterRegexpResponder(
+		http.MethodPut,
+		regexp.MustCompile(".*/bucket1/$"),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, ""), nil
+		},
+	)
+	mockTransport.RegisterRegexpResponder(
+		http.MethodPut,
+		regexp.MustCompile(".*/bucket1/\\?policy=$"),
+		httpmock.NewStringResponder(http.StatusNoContent, ""),
+	)
+	mockTransport.RegisterRegexpResponder(
+		http.MethodDelete,
+		regexp.MustCompile(".*/bucket1/\\?policy=$"),
+		httpmock.NewStringResponder(http.StatusNoContent, ""),
+	)
+
+	return mockTransport
+}
+
 func minioFixture() (*miniov1alpha1.MinIOBucket, []runtime.Object) {
 	secret := k8sfactory.SecretFactory(nil,
 		k8sfactory.Name("creds"),
@@ -2,9 +2,11 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
+	"sort"
 	"time"
 
 	madmin "github.com/minio/madmin-go/v3"
@@ -36,6 +38,10 @@ const (
 	minIOUserControllerFinalizerName = "minio-user-controller.minio.f110.dev/finalizer"
 	accessKeyLength                  = 16
 	secretKeyLength                  = 24
+
+	// The new access key is kept in the secret under these keys while rotating the access key.
+	nextAccessKeyName = "next-accesskey"
+	nextSecretKeyName = "next-secretkey"
 )
 
 type MinIOUserController struct {
@@ -213,21 +219,7 @@ func (u *minIOUserReconciler) makeUserForMinIOInstance(ctx context.Context, mini
 		adminClient.SetCustomTransport(u.transport)
 	}
 
-	secret, err := u.ensureUser(ctx, adminClient, minioUser)
-	if err != nil {
-		return err
-	}
-
-	if u.vaultClient != nil && minioUser.Spec.Path != "" && !minioUser.Status.Vault {
-		if err := u.saveAccessKeyToVault(minioUser, secret); err != nil {
-			return err
-		}
-	}
-
-	if err := u.setStatus(minioUser); err != nil {
-		return err
-	}
-	return nil
+	return u.reconcileUser(ctx, adminClient, minioUser)
 }
 
 func (u *minIOUserReconciler) makeUserForCluster(ctx context.Context, minioUser *miniov1alpha1.MinIOUser, cluster *miniov1alpha1.MinIOCluster) error {
@@ -252,37 +244,38 @@ func (u *minIOUserReconciler) makeUserForCluster(ctx context.Context, minioUser
 		adminClient.SetCustomTransport(u.transport)
 	}
 
+	return u.reconcileUser(ctx, adminClient, minioUser)
+}
+
+func (u *minIOUserReconciler) reconcileUser(ctx context.Context, adminClient *madmin.AdminClient, minioUser *miniov1alpha1.MinIOUser) error {
 	secret, err := u.ensureUser(ctx, adminClient, minioUser)
 	if err != nil {
 		return err
 	}
+	secret, err = u.rotateAccessKey(ctx, adminClient, minioUser, secret)
+	if err != nil {
+		return err
+	}
+	if err := u.ensurePolicy(ctx, adminClient, minioUser, string(secret.Data["accesskey"])); err != nil {
+		return err
+	}
+
 	if u.vaultClient != nil && minioUser.Spec.Path != "" && !minioUser.Status.Vault {
 		if err := u.saveAccessKeyToVault(minioUser, secret); err != nil {
 			return err
 		}
 	}
 
-	if err := u.setStatus(minioUser); err != nil {
-		return err
-	}
+	u.setStatus(minioUser, secret)
 	return nil
 }
 
-func (u *minIOUserReconciler) setStatus(user *miniov1alpha1.MinIOUser) error {
-	secret, err := u.secretLister.Secrets(user.Namespace).Get(fmt.Sprintf("%s-accesskey", user.Name))
-	if apierrors.IsNotFound(err) {
-		return nil
-	}
-	if err != nil {
-		return xerrors.WithStack(err)
-	}
+func (u *minIOUserReconciler) setStatus(user *miniov1alpha1.MinIOUser, secret *corev1.Secret) {
 	user.Status.Ready = true
 	user.Status.AccessKey = string(secret.Data["accesskey"])
 	if user.Spec.Path != "" {
 		user.Status.Vault = true
 	}
-
-	return nil
 }
 
 func (u *minIOUserReconciler) ensureUser(ctx context.Context, adminClient *madmin.AdminClient, user *miniov1alpha1.MinIOUser) (*corev1.Secret, error) {
@@ -315,10 +308,188 @@ func (u *minIOUserReconciler) ensureUser(ctx context.Context, adminClient *madmi
 	if err != nil {
 		return nil, xerrors.WithStack(err)
 	}
+	now := metav1.Now()
+	user.Status.LastRotatedTime = &now
 
 	return secret, nil
 }
 
+// rotateAccessKey creates a new access key if the current access key is older than the interval.
+// The previous access key remains valid during the overlap so that clients can switch to the new one.
+func (u *minIOUserReconciler) rotateAccessKey(ctx context.Context, adminClient *madmin.AdminClient, user *miniov1alpha1.MinIOUser, secret *corev1.Secret) (*corev1.Secret, error) {
+	overlap, err := accessKeyRotationOverlap(user)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := secret.Data[nextAccessKeyName]; ok {
+		// The previous rotation was interrupted. It has to be finished before anything else.
+		return u.finishRotation(ctx, adminClient, user, secret, overlap)
+	}
+
+	now := metav1.Now()
+	if user.Status.PreviousAccessKey != "" && user.Status.PreviousAccessKeyExpirationTime != nil &&
+		!now.Before(user.Status.PreviousAccessKeyExpirationTime) {
+		if err := u.removePreviousAccessKey(ctx, adminClient, user); err != nil {
+			return nil, err
+		}
+	}
+
+	if user.Spec.Rotation == nil {
+		return secret, nil
+	}
+	interval, err := time.ParseDuration(user.Spec.Rotation.Interval)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	if user.Status.LastRotatedTime == nil {
+		// The access key was created before enabling the rotation.
+		// We regard the access key as created now.
+		user.Status.LastRotatedTime = &now
+		return secret, nil
+	}
+	if now.Time.Before(user.Status.LastRotatedTime.Add(interval)) {
+		return secret, nil
+	}
+	if user.Status.PreviousAccessKey != "" {
+		// The previous access key is still in the overlap.
+		// We must not rotate the access key until the previous one is removed.
+		return secret, nil
+	}
+
+	// The new access key is saved to the secret before creating it in MinIO.
+	// Thus, the rotation can be resumed without leaking the user of MinIO even if it is interrupted.
+	updated := secret.DeepCopy()
+	updated.Data[nextAccessKeyName] = []byte(stringsutil.RandomString(accessKeyLength))
+	updated.Data[nextSecretKeyName] = []byte(stringsutil.RandomString(secretKeyLength))
+	updated, err = u.coreClient.CoreV1().Secrets(updated.Namespace).Update(ctx, updated, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	return u.finishRotation(ctx, adminClient, user, updated, overlap)
+}
+
+// finishRotation creates the new access key which is kept in the secret and replaces the current access key with it.
+// Each step can be run again. So that the rotation can be resumed from any step.
+func (u *minIOUserReconciler) finishRotation(ctx context.Context, adminClient *madmin.AdminClient, user *miniov1alpha1.MinIOUser, secret *corev1.Secret, overlap time.Duration) (*corev1.Secret, error) {
+	accessKey, secretKey := string(secret.Data[nextAccessKeyName]), string(secret.Data[nextSecretKeyName])
+	if err := adminClient.AddUser(ctx, accessKey, secretKey); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	u.logger.Info("Rotate the access key", zap.String("name", user.Name), zap.String("access_key", accessKey))
+
+	if user.Status.PreviousAccessKey == "" {
+		now := metav1.Now()
+		expiration := metav1.NewTime(now.Add(overlap))
+		user.Status.PreviousAccessKey = string(secret.Data["accesskey"])
+		user.Status.PreviousAccessKeyExpirationTime = &expiration
+		user.Status.LastRotatedTime = &now
+		// The policy has to be attached to the new access key and the new access key has to be saved to the vault.
+		user.Status.PolicyName = ""
+		user.Status.Vault = false
+		// The previous access key has to be recorded before replacing the access key of the secret.
+		// Otherwise, the previous access key is never removed if updating the status fails.
+		saved, err := u.mClient.UpdateStatusMinIOUser(ctx, user, metav1.UpdateOptions{})
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		user.ResourceVersion = saved.ResourceVersion
+	}
+
+	updated := secret.DeepCopy()
+	updated.Data = map[string][]byte{
+		"accesskey": []byte(accessKey),
+		"secretkey": []byte(secretKey),
+	}
+	updated, err := u.coreClient.CoreV1().Secrets(updated.Namespace).Update(ctx, updated, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	if overlap == 0 {
+		if err := u.removePreviousAccessKey(ctx, adminClient, user); err != nil {
+			return nil, err
+		}
+	}
+
+	return updated, nil
+}
+
+func accessKeyRotationOverlap(user *miniov1alpha1.MinIOUser) (time.Duration, error) {
+	if user.Spec.Rotation == nil || user.Spec.Rotation.Overlap == "" {
+		return 0, nil
+	}
+	overlap, err := time.ParseDuration(user.Spec.Rotation.Overlap)
+	if err != nil {
+		return 0, xerrors.WithStack(err)
+	}
+	return overlap, nil
+}
+
+func (u *minIOUserReconciler) removePreviousAccessKey(ctx context.Context, adminClient *madmin.AdminClient, user *miniov1alpha1.MinIOUser) error {
+	if err := adminClient.RemoveUser(ctx, user.Status.PreviousAccessKey); err != nil {
+		return xerrors.WithStack(err)
+	}
+	u.logger.Debug("Remove the previous access key", zap.String("name", user.Name), zap.String("access_key", user.Status.PreviousAccessKey))
+
+	user.Status.PreviousAccessKey = ""
+	user.Status.PreviousAccessKeyExpirationTime = nil
+	return nil
+}
+
+// ensurePolicy creates the canned policy from .Spec.Policies and attaches it to the access key.
+func (u *minIOUserReconciler) ensurePolicy(ctx context.Context, adminClient *madmin.AdminClient, user *miniov1alpha1.MinIOUser, accessKey string) error {
+	if len(user.Spec.Policies) == 0 {
+		if user.Status.PolicyName == "" {
+			return nil
+		}
+
+		if err := adminClient.RemoveCannedPolicy(ctx, user.Status.PolicyName); err != nil {
+			return xerrors.WithStack(err)
+		}
+		u.logger.Debug("Remove canned policy", zap.String("name", user.Status.PolicyName))
+		user.Status.PolicyName = ""
+		return nil
+	}
+
+	policyName := minIOUserPolicyName(user)
+	p := newMinIOUserPolicyDocument(user.Spec.Policies)
+	current, err := adminClient.InfoCannedPolicyV2(ctx, policyName)
+	if err != nil && madmin.ToErrorResponse(err).Code != "XMinioAdminNoSuchPolicy" {
+		return xerrors.WithStack(err)
+	}
+	changed := true
+	if current != nil {
+		currentPolicy := &minIOPolicyDocument{}
+		if err := json.Unmarshal(current.Policy, currentPolicy); err != nil {
+			return xerrors.WithStack(err)
+		}
+		changed = !reflect.DeepEqual(p, currentPolicy)
+	}
+	if changed {
+		b, err := json.Marshal(p)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		if err := adminClient.AddCannedPolicy(ctx, policyName, b); err != nil {
+			return xerrors.WithStack(err)
+		}
+		u.logger.Debug("Add canned policy", zap.String("name", policyName), zap.String("policy", string(b)))
+	}
+
+	if changed || user.Status.PolicyName != policyName {
+		// SetPolicy replaces the policies of the user with the canned policy.
+		// Thus, the user never has the policies other than the canned policy.
+		if err := adminClient.SetPolicy(ctx, policyName, accessKey, false); err != nil {
+			return xerrors.WithStack(err)
+		}
+		user.Status.PolicyName = policyName
+	}
+
+	return nil
+}
+
 func (u *minIOUserReconciler) saveAccessKeyToVault(user *miniov1alpha1.MinIOUser, secret *corev1.Secret) error {
 	data := map[string]string{
 		"accesskey": string(secret.Data["accesskey"]),
@@ -414,6 +585,9 @@ func (u *minIOUserReconciler) deleteUserFromInstance(ctx context.Context, minioU
 		return xerrors.WithStack(err)
 	}
 	u.logger.Debug("Remove minio user", zap.String("name", minioUser.Name))
+	if err := u.cleanupUser(ctx, adminClient, minioUser, secret); err != nil {
+		return err
+	}
 
 	if err := u.coreClient.CoreV1().Secrets(secret.Namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{}); err != nil {
 		return xerrors.WithStack(err)
@@ -455,6 +629,9 @@ func (u *minIOUserReconciler) deleteUserFromCluster(ctx context.Context, minioUs
 		return xerrors.WithStack(err)
 	}
 	u.logger.Debug("Remove minio user", zap.String("name", minioUser.Name))
+	if err := u.cleanupUser(ctx, adminClient, minioUser, accessKeySecret); err != nil {
+		return err
+	}
 
 	if err := u.coreClient.CoreV1().Secrets(accessKeySecret.Namespace).Delete(ctx, accessKeySecret.Name, metav1.DeleteOptions{}); err != nil {
 		return xerrors.WithStack(err)
@@ -462,6 +639,30 @@ func (u *minIOUserReconciler) deleteUserFromCluster(ctx context.Context, minioUs
 	return nil
 }
 
+// cleanupUser removes the previous access key, the access key in the middle of the rotation and the canned policy which are owned by the user.
+func (u *minIOUserReconciler) cleanupUser(ctx context.Context, adminClient *madmin.AdminClient, minioUser *miniov1alpha1.MinIOUser, secret *corev1.Secret) error {
+	if v, ok := secret.Data[nextAccessKeyName]; ok {
+		// The user of the new access key may not have been created yet.
+		if err := adminClient.RemoveUser(ctx, string(v)); err != nil && madmin.ToErrorResponse(err).Code != "XMinioAdminNoSuchUser" {
+			return xerrors.WithStack(err)
+		}
+	}
+	// The previous access key equals the current access key if the rotation was interrupted. It has been removed already.
+	if minioUser.Status.PreviousAccessKey != "" && minioUser.Status.PreviousAccessKey != string(secret.Data["accesskey"]) {
+		if err := u.removePreviousAccessKey(ctx, adminClient, minioUser); err != nil {
+			return err
+		}
+	}
+	if minioUser.Status.PolicyName != "" {
+		if err := adminClient.RemoveCannedPolicy(ctx, minioUser.Status.PolicyName); err != nil {
+			return xerrors.WithStack(err)
+		}
+		u.logger.Debug("Remove canned policy", zap.String("name", minioUser.Status.PolicyName))
+	}
+
+	return nil
+}
+
 func (u *minIOUserReconciler) getMinIOInstanceEndpoint(ctx context.Context, instance *miniocontrollerv1beta1.MinIOInstance) (string, *portforward.PortForwarder, error) {
 	svc, err := u.serviceLister.Services(instance.Namespace).Get(fmt.Sprintf("%s-hl-svc", instance.Name))
 	if err != nil {
@@ -567,3 +768,97 @@ func (u *minIOUserReconciler) portForward(ctx context.Context, svc *corev1.Servi
 
 	return pf, nil
 }
+
+func minIOUserPolicyName(user *miniov1alpha1.MinIOUser) string {
+	return fmt.Sprintf("%s-%s", user.Namespace, user.Name)
+}
+
+type minIOPolicyDocument struct {
+	Version   string                 `json:"Version"`
+	Statement []minIOPolicyStatement `json:"Statement"`
+}
+
+// add appends the statement unless the document already has the same statement.
+// MinIO drops duplicated statements, so the document has to be the same as the policy which is stored in MinIO.
+func (d *minIOPolicyDocument) add(s minIOPolicyStatement) {
+	for _, v := range d.Statement {
+		if reflect.DeepEqual(v, s) {
+			return
+		}
+	}
+	d.Statement = append(d.Statement, s)
+}
+
+type minIOPolicyStatement struct {
+	Effect    string                                  `json:"Effect"`
+	Action    minIOPolicyValues                       `json:"Action"`
+	Resource  minIOPolicyValues                       `json:"Resource"`
+	Condition map[string]map[string]minIOPolicyValues `json:"Condition,omitempty"`
+}
+
+// minIOPolicyValues is a list of values in the policy.
+// MinIO returns a single value as a string instead of an array.
+type minIOPolicyValues []string
+
+func (v *minIOPolicyValues) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*v = []string{s}
+		return nil
+	}
+
+	var l []string
+	if err := json.Unmarshal(b, &l); err != nil {
+		return xerrors.WithStack(err)
+	}
+	sort.Strings(l)
+	*v = l
+	return nil
+}
+
+func newMinIOUserPolicyDocument(policies []miniov1alpha1.MinIOUserPolicy) *minIOPolicyDocument {
+	doc := &minIOPolicyDocument{Version: "2012-10-17"}
+	for _, v := range policies {
+		bucketResource := fmt.Sprintf("arn:aws:s3:::%s", v.Bucket)
+		objectResource := fmt.Sprintf("arn:aws:s3:::%s/%s*", v.Bucket, v.Prefix)
+		read := v.Access == miniov1alpha1.PolicyAccessReadOnly || v.Access == miniov1alpha1.PolicyAccessReadWrite
+		write := v.Access == miniov1alpha1.PolicyAccessWriteOnly || v.Access == miniov1alpha1.PolicyAccessReadWrite
+
+		if read || write {
+			bucketActions := []string{"s3:GetBucketLocation"}
+			var objectActions []string
+			if read {
+				objectActions = append(objectActions, "s3:GetObject")
+			}
+			if write {
+				bucketActions = append(bucketActions, "s3:ListBucketMultipartUploads")
+				objectActions = append(objectActions, "s3:AbortMultipartUpload", "s3:DeleteObject", "s3:ListMultipartUploadParts", "s3:PutObject")
+			}
+			sort.Strings(bucketActions)
+			sort.Strings(objectActions)
+			doc.add(minIOPolicyStatement{Effect: "Allow", Action: bucketActions, Resource: []string{bucketResource}})
+			doc.add(minIOPolicyStatement{Effect: "Allow", Action: objectActions, Resource: []string{objectResource}})
+		}
+		if read {
+			// s3:ListBucket is separated from other actions because the condition of the prefix can't be applied to other actions.
+			s := minIOPolicyStatement{Effect: "Allow", Action: []string{"s3:ListBucket"}, Resource: []string{bucketResource}}
+			if v.Prefix != "" {
+				s.Condition = map[string]map[string]minIOPolicyValues{
+					"StringLike": {"s3:prefix": []string{v.Prefix + "*"}},
+				}
+			}
+			doc.add(s)
+		}
+		if len(v.Actions) > 0 {
+			actions := append([]string{}, v.Actions...)
+			sort.Strings(actions)
+			doc.add(minIOPolicyStatement{
+				Effect:   "Allow",
+				Action:   actions,
+				Resource: []string{bucketResource, objectResource},
+			})
+		}
+	}
+
+	return doc
+}
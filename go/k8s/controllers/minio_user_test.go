@@ -1,13 +1,20 @@
 package controllers
 
 import (
+	"context"
+	"encoding/json"
+	"io"
 	"net/http"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 	miniocontrollerv1beta1 "github.com/minio/minio-operator/pkg/apis/miniocontroller/v1beta1"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"go.f110.dev/mono/go/api/miniov1alpha1"
@@ -52,7 +59,12 @@ func TestMinIOUserController(t *testing.T) {
 			k8sfactory.Namef("%s-accesskey", target.Name),
 			k8sfactory.Namespace(target.Namespace),
 		))
+		updated, err := runner.Client.MinioV1alpha1.GetMinIOUser(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		runner.AssertUpdateAction(t, "status", updated)
 		runner.AssertNoUnexpectedAction(t)
+		assert.True(t, updated.Status.Ready)
+		assert.NotNil(t, updated.Status.LastRotatedTime)
 	})
 
 	t.Run("MinIOCluster", func(t *testing.T) {
@@ -93,8 +105,203 @@ func TestMinIOUserController(t *testing.T) {
 				k8sfactory.Namespace(target.Namespace),
 			),
 		)
+		updated, err := runner.Client.MinioV1alpha1.GetMinIOUser(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		runner.AssertUpdateAction(t, "status", updated)
 		runner.AssertNoUnexpectedAction(t)
 	})
+
+	t.Run("Policy", func(t *testing.T) {
+		runner := controllertest.NewGenericTestRunner[*miniov1alpha1.MinIOUser]()
+		controller, mockTransport := newMinIOUserController(t, runner)
+		mockTransport.RegisterResponder(
+			http.MethodPut,
+			"/minio/admin/v3/add-user",
+			httpmock.NewStringResponder(http.StatusOK, ""),
+		)
+		mockTransport.RegisterResponder(
+			http.MethodGet,
+			"/minio/admin/v3/info-canned-policy",
+			httpmock.NewJsonResponderOrPanic(http.StatusNotFound, map[string]string{"Code": "XMinioAdminNoSuchPolicy"}),
+		)
+		var policy []byte
+		mockTransport.RegisterResponder(
+			http.MethodPut,
+			"/minio/admin/v3/add-canned-policy",
+			func(req *http.Request) (*http.Response, error) {
+				policy, _ = io.ReadAll(req.Body)
+				return httpmock.NewStringResponse(http.StatusOK, ""), nil
+			},
+		)
+		var attached url.Values
+		mockTransport.RegisterResponder(
+			http.MethodPut,
+			"/minio/admin/v3/set-user-or-group-policy",
+			func(req *http.Request) (*http.Response, error) {
+				attached = req.URL.Query()
+				return httpmock.NewStringResponse(http.StatusOK, ""), nil
+			},
+		)
+		instance, depObjs := minIOInstanceFixtureForMinIOUser()
+		target := k8sfactory.MinIOUserFactory(nil,
+			k8sfactory.Name("test"),
+			k8sfactory.DefaultNamespace,
+			k8sfactory.MinIOSelector(k8sfactory.MatchLabel(instance.ObjectMeta.Labels)),
+			k8sfactory.UserPolicy(miniov1alpha1.MinIOUserPolicy{Bucket: "a", Access: miniov1alpha1.PolicyAccessReadOnly}),
+			k8sfactory.UserPolicy(miniov1alpha1.MinIOUserPolicy{Bucket: "b", Prefix: "data/", Access: miniov1alpha1.PolicyAccessReadWrite}),
+		)
+		runner.RegisterFixture(instance)
+		runner.RegisterFixture(depObjs...)
+
+		err := runner.Reconcile(controller.newReconciler(), target)
+		require.NoError(t, err)
+
+		doc := &minIOPolicyDocument{}
+		require.NoError(t, json.Unmarshal(policy, doc))
+		assert.Equal(t, newMinIOUserPolicyDocument(target.Spec.Policies), doc)
+		assert.Contains(t, string(policy), `"Resource":["arn:aws:s3:::b/data/*"]`)
+		assert.Contains(t, string(policy), `"StringLike":{"s3:prefix":["data/*"]}`)
+		updated, err := runner.Client.MinioV1alpha1.GetMinIOUser(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "default-test", updated.Status.PolicyName)
+		assert.Equal(t, "default-test", attached.Get("policyName"))
+		assert.Equal(t, updated.Status.AccessKey, attached.Get("userOrGroup"))
+	})
+
+	t.Run("RotateAccessKey", func(t *testing.T) {
+		runner := controllertest.NewGenericTestRunner[*miniov1alpha1.MinIOUser]()
+		controller, mockTransport := newMinIOUserController(t, runner)
+		mockTransport.RegisterResponder(
+			http.MethodPut,
+			"/minio/admin/v3/add-user",
+			httpmock.NewStringResponder(http.StatusOK, ""),
+		)
+		instance, depObjs := minIOInstanceFixtureForMinIOUser()
+		target := k8sfactory.MinIOUserFactory(nil,
+			k8sfactory.Name("test"),
+			k8sfactory.DefaultNamespace,
+			k8sfactory.MinIOSelector(k8sfactory.MatchLabel(instance.ObjectMeta.Labels)),
+			k8sfactory.AccessKeyRotation("1h", "30m"),
+		)
+		lastRotatedTime := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		target.Status.LastRotatedTime = &lastRotatedTime
+		secret := k8sfactory.SecretFactory(nil,
+			k8sfactory.Namef("%s-accesskey", target.Name),
+			k8sfactory.Namespace(target.Namespace),
+			k8sfactory.Data("accesskey", []byte("oldaccesskey")),
+			k8sfactory.Data("secretkey", []byte("oldsecretkey")),
+		)
+		runner.RegisterFixture(instance, secret)
+		runner.RegisterFixture(depObjs...)
+
+		err := runner.Reconcile(controller.newReconciler(), target)
+		require.NoError(t, err)
+
+		updatedSecret, err := runner.CoreClient.CoreV1().Secrets(secret.Namespace).Get(context.Background(), secret.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.NotEqual(t, "oldaccesskey", string(updatedSecret.Data["accesskey"]))
+		assert.NotContains(t, updatedSecret.Data, "next-accesskey")
+		updated, err := runner.Client.MinioV1alpha1.GetMinIOUser(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, string(updatedSecret.Data["accesskey"]), updated.Status.AccessKey)
+		assert.Equal(t, "oldaccesskey", updated.Status.PreviousAccessKey)
+		require.NotNil(t, updated.Status.PreviousAccessKeyExpirationTime)
+		assert.True(t, updated.Status.PreviousAccessKeyExpirationTime.After(time.Now()))
+		assert.True(t, updated.Status.LastRotatedTime.After(lastRotatedTime.Time))
+	})
+
+	t.Run("ResumeRotation", func(t *testing.T) {
+		runner := controllertest.NewGenericTestRunner[*miniov1alpha1.MinIOUser]()
+		controller, mockTransport := newMinIOUserController(t, runner)
+		var added []string
+		mockTransport.RegisterResponder(
+			http.MethodPut,
+			"/minio/admin/v3/add-user",
+			func(req *http.Request) (*http.Response, error) {
+				added = append(added, req.URL.Query().Get("accessKey"))
+				return httpmock.NewStringResponse(http.StatusOK, ""), nil
+			},
+		)
+		instance, depObjs := minIOInstanceFixtureForMinIOUser()
+		target := k8sfactory.MinIOUserFactory(nil,
+			k8sfactory.Name("test"),
+			k8sfactory.DefaultNamespace,
+			k8sfactory.MinIOSelector(k8sfactory.MatchLabel(instance.ObjectMeta.Labels)),
+			k8sfactory.AccessKeyRotation("1h", "30m"),
+		)
+		// The previous reconciliation was interrupted after saving the new access key to the secret.
+		lastRotatedTime := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		target.Status.LastRotatedTime = &lastRotatedTime
+		secret := k8sfactory.SecretFactory(nil,
+			k8sfactory.Namef("%s-accesskey", target.Name),
+			k8sfactory.Namespace(target.Namespace),
+			k8sfactory.Data("accesskey", []byte("oldaccesskey")),
+			k8sfactory.Data("secretkey", []byte("oldsecretkey")),
+			k8sfactory.Data("next-accesskey", []byte("newaccesskey")),
+			k8sfactory.Data("next-secretkey", []byte("newsecretkey")),
+		)
+		runner.RegisterFixture(instance, secret)
+		runner.RegisterFixture(depObjs...)
+
+		err := runner.Reconcile(controller.newReconciler(), target)
+		require.NoError(t, err)
+
+		// The access key in the secret has to be used. Otherwise, the user of MinIO is leaked.
+		assert.Equal(t, []string{"newaccesskey"}, added)
+		updatedSecret, err := runner.CoreClient.CoreV1().Secrets(secret.Namespace).Get(context.Background(), secret.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"accesskey": []byte("newaccesskey"), "secretkey": []byte("newsecretkey")}, updatedSecret.Data)
+		updated, err := runner.Client.MinioV1alpha1.GetMinIOUser(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "newaccesskey", updated.Status.AccessKey)
+		assert.Equal(t, "oldaccesskey", updated.Status.PreviousAccessKey)
+		require.NotNil(t, updated.Status.PreviousAccessKeyExpirationTime)
+		assert.True(t, updated.Status.PreviousAccessKeyExpirationTime.After(time.Now()))
+	})
+
+	t.Run("RemovePreviousAccessKey", func(t *testing.T) {
+		runner := controllertest.NewGenericTestRunner[*miniov1alpha1.MinIOUser]()
+		controller, mockTransport := newMinIOUserController(t, runner)
+		var removed string
+		mockTransport.RegisterResponder(
+			http.MethodDelete,
+			"/minio/admin/v3/remove-user",
+			func(req *http.Request) (*http.Response, error) {
+				removed = req.URL.Query().Get("accessKey")
+				return httpmock.NewStringResponse(http.StatusOK, ""), nil
+			},
+		)
+		instance, depObjs := minIOInstanceFixtureForMinIOUser()
+		target := k8sfactory.MinIOUserFactory(nil,
+			k8sfactory.Name("test"),
+			k8sfactory.DefaultNamespace,
+			k8sfactory.MinIOSelector(k8sfactory.MatchLabel(instance.ObjectMeta.Labels)),
+			k8sfactory.AccessKeyRotation("1h", "30m"),
+		)
+		lastRotatedTime := metav1.NewTime(time.Now().Add(-40 * time.Minute))
+		expiration := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+		target.Status.LastRotatedTime = &lastRotatedTime
+		target.Status.PreviousAccessKey = "oldaccesskey"
+		target.Status.PreviousAccessKeyExpirationTime = &expiration
+		secret := k8sfactory.SecretFactory(nil,
+			k8sfactory.Namef("%s-accesskey", target.Name),
+			k8sfactory.Namespace(target.Namespace),
+			k8sfactory.Data("accesskey", []byte("newaccesskey")),
+			k8sfactory.Data("secretkey", []byte("newsecretkey")),
+		)
+		runner.RegisterFixture(instance, secret)
+		runner.RegisterFixture(depObjs...)
+
+		err := runner.Reconcile(controller.newReconciler(), target)
+		require.NoError(t, err)
+
+		assert.Equal(t, "oldaccesskey", removed)
+		updated, err := runner.Client.MinioV1alpha1.GetMinIOUser(context.Background(), target.Namespace, target.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, updated.Status.PreviousAccessKey)
+		assert.Nil(t, updated.Status.PreviousAccessKeyExpirationTime)
+		assert.Equal(t, "newaccesskey", updated.Status.AccessKey)
+	})
 }
 
 func minIOInstanceFixtureForMinIOUser() (*miniocontrollerv1beta1.MinIOInstance, []runtime.Object) {
@@ -73,6 +73,36 @@ func newConsulBackupController(t *testing.T) (*controllertest.TestRunner, *Consu
 	return runner, controller
 }
 
+func newConsulRestoreController(t *testing.T) (*controllertest.TestRunner, *ConsulRestoreController) {
+	runner := controllertest.NewTestRunner()
+	controller, err := NewConsulRestoreController(
+		runner.CoreSharedInformerFactory,
+		runner.Factory,
+		runner.CoreClient,
+		&runner.Client.Set,
+		nil,
+		false,
+	)
+	require.NoError(t, err)
+
+	return runner, controller
+}
+
+func newEtcdBackupController(t *testing.T) (*controllertest.TestRunner, *EtcdBackupController) {
+	runner := controllertest.NewTestRunner()
+	controller, err := NewEtcdBackupController(
+		runner.CoreSharedInformerFactory,
+		runner.Factory,
+		runner.CoreClient,
+		&runner.Client.Set,
+		nil,
+		false,
+	)
+	require.NoError(t, err)
+
+	return runner, controller
+}
+
 func newGrafanaUserController(t *testing.T) (*controllertest.GenericTestRunner[*grafanav1alpha1.Grafana], *GrafanaController) {
 	runner := controllertest.NewGenericTestRunner[*grafanav1alpha1.Grafana]()
 	controller, err := NewGrafanaController(
@@ -8,6 +8,7 @@ import (
 	secretsstorev1 "sigs.k8s.io/secrets-store-csi-driver/apis/v1"
 
 	"go.f110.dev/mono/go/api/consulv1alpha1"
+	"go.f110.dev/mono/go/api/etcdv1alpha1"
 	"go.f110.dev/mono/go/api/grafanav1alpha1"
 	"go.f110.dev/mono/go/api/harborv1alpha1"
 	"go.f110.dev/mono/go/api/miniov1alpha1"
@@ -57,6 +58,8 @@ func MaxBackup(v int) Trait {
 		switch obj := object.(type) {
 		case *consulv1alpha1.ConsulBackup:
 			obj.Spec.MaxBackups = v
+		case *etcdv1alpha1.EtcdBackup:
+			obj.Spec.MaxBackups = v
 		}
 	}
 }
@@ -80,6 +83,13 @@ func BackupStorage(v any) Trait {
 			case *consulv1alpha1.BackupStorageGCSSpec:
 				obj.Spec.Storage.GCS = s
 			}
+		case *etcdv1alpha1.EtcdBackup:
+			switch s := v.(type) {
+			case *etcdv1alpha1.BackupStorageMinIOSpec:
+				obj.Spec.Storage.MinIO = s
+			case *etcdv1alpha1.BackupStorageGCSSpec:
+				obj.Spec.Storage.GCS = s
+			}
 		}
 	}
 }
@@ -172,6 +182,58 @@ func ObjectReference(obj metav1.Object) *consulv1alpha1.ObjectReference {
 	return &consulv1alpha1.ObjectReference{Name: obj.GetName(), Namespace: obj.GetNamespace()}
 }
 
+func ConsulRestoreFactory(base *consulv1alpha1.ConsulRestore, traits ...Trait) *consulv1alpha1.ConsulRestore {
+	var s *consulv1alpha1.ConsulRestore
+	if base == nil {
+		s = &consulv1alpha1.ConsulRestore{}
+	} else {
+		s = base.DeepCopy()
+	}
+
+	setGVK(s, client.Scheme)
+
+	for _, v := range traits {
+		v(s)
+	}
+
+	return s
+}
+
+func RestoreFrom(backup *consulv1alpha1.ConsulBackup) Trait {
+	return func(object any) {
+		switch obj := object.(type) {
+		case *consulv1alpha1.ConsulRestore:
+			obj.Spec.BackupName = backup.Name
+		}
+	}
+}
+
+func EtcdBackupFactory(base *etcdv1alpha1.EtcdBackup, traits ...Trait) *etcdv1alpha1.EtcdBackup {
+	var s *etcdv1alpha1.EtcdBackup
+	if base == nil {
+		s = &etcdv1alpha1.EtcdBackup{}
+	} else {
+		s = base.DeepCopy()
+	}
+
+	setGVK(s, client.Scheme)
+
+	for _, v := range traits {
+		v(s)
+	}
+
+	return s
+}
+
+func EtcdEndpoints(endpoints ...string) Trait {
+	return func(object any) {
+		switch obj := object.(type) {
+		case *etcdv1alpha1.EtcdBackup:
+			obj.Spec.Endpoints = endpoints
+		}
+	}
+}
+
 func MinIOBucketFactory(base *miniov1alpha1.MinIOBucket, traits ...Trait) *miniov1alpha1.MinIOBucket {
 	var s *miniov1alpha1.MinIOBucket
 	if base == nil {
@@ -214,6 +276,47 @@ func DisableCreatingIndexFile(object any) {
 	}
 }
 
+func EnableVersioning(object any) {
+	switch obj := object.(type) {
+	case *miniov1alpha1.MinIOBucket:
+		obj.Spec.Versioning = miniov1alpha1.BucketVersioningEnabled
+	}
+}
+
+func SuspendVersioning(object any) {
+	switch obj := object.(type) {
+	case *miniov1alpha1.MinIOBucket:
+		obj.Spec.Versioning = miniov1alpha1.BucketVersioningSuspended
+	}
+}
+
+func LifecycleRule(rule miniov1alpha1.MinIOBucketLifecycleRule) Trait {
+	return func(object any) {
+		switch obj := object.(type) {
+		case *miniov1alpha1.MinIOBucket:
+			obj.Spec.Lifecycle = append(obj.Spec.Lifecycle, rule)
+		}
+	}
+}
+
+func ObjectLock(mode miniov1alpha1.BucketRetentionMode, days int) Trait {
+	return func(object any) {
+		switch obj := object.(type) {
+		case *miniov1alpha1.MinIOBucket:
+			obj.Spec.ObjectLock = &miniov1alpha1.MinIOBucketObjectLock{Mode: mode, Days: days}
+		}
+	}
+}
+
+func Quota(n int) Trait {
+	return func(object any) {
+		switch obj := object.(type) {
+		case *miniov1alpha1.MinIOBucket:
+			obj.Spec.Quota = n
+		}
+	}
+}
+
 func MinIOUserFactory(base *miniov1alpha1.MinIOUser, traits ...Trait) *miniov1alpha1.MinIOUser {
 	var s *miniov1alpha1.MinIOUser
 	if base == nil {
@@ -241,6 +344,24 @@ func VaultPath(mountPath, path string) Trait {
 	}
 }
 
+func UserPolicy(policy miniov1alpha1.MinIOUserPolicy) Trait {
+	return func(object any) {
+		switch obj := object.(type) {
+		case *miniov1alpha1.MinIOUser:
+			obj.Spec.Policies = append(obj.Spec.Policies, policy)
+		}
+	}
+}
+
+func AccessKeyRotation(interval, overlap string) Trait {
+	return func(object any) {
+		switch obj := object.(type) {
+		case *miniov1alpha1.MinIOUser:
+			obj.Spec.Rotation = &miniov1alpha1.MinIOUserKeyRotation{Interval: interval, Overlap: overlap}
+		}
+	}
+}
+
 func GrafanaFactory(base *grafanav1alpha1.Grafana, traits ...Trait) *grafanav1alpha1.Grafana {
 	var s *grafanav1alpha1.Grafana
 	if base == nil {
@@ -276,6 +397,24 @@ func AdminPasswordSecret(v *corev1.SecretKeySelector) Trait {
 	}
 }
 
+func DashboardSelector(v metav1.LabelSelector) Trait {
+	return func(object any) {
+		switch obj := object.(type) {
+		case *grafanav1alpha1.Grafana:
+			obj.Spec.DashboardSelector = &v
+		}
+	}
+}
+
+func DatasourceSelector(v metav1.LabelSelector) Trait {
+	return func(object any) {
+		switch obj := object.(type) {
+		case *grafanav1alpha1.Grafana:
+			obj.Spec.DatasourceSelector = &v
+		}
+	}
+}
+
 func GrafanaUserFactory(base *grafanav1alpha1.GrafanaUser, traits ...Trait) *grafanav1alpha1.GrafanaUser {
 	var s *grafanav1alpha1.GrafanaUser
 	if base == nil {
@@ -302,6 +441,96 @@ func UserEmail(v string) Trait {
 	}
 }
 
+func GrafanaDashboardFactory(base *grafanav1alpha1.GrafanaDashboard, traits ...Trait) *grafanav1alpha1.GrafanaDashboard {
+	var s *grafanav1alpha1.GrafanaDashboard
+	if base == nil {
+		s = &grafanav1alpha1.GrafanaDashboard{}
+	} else {
+		s = base.DeepCopy()
+	}
+
+	setGVK(s, client.Scheme)
+
+	for _, v := range traits {
+		v(s)
+	}
+
+	return s
+}
+
+func DashboardJSON(v string) Trait {
+	return func(object any) {
+		switch obj := object.(type) {
+		case *grafanav1alpha1.GrafanaDashboard:
+			obj.Spec.JSON = v
+		}
+	}
+}
+
+func DashboardConfigMap(v *corev1.ConfigMapKeySelector) Trait {
+	return func(object any) {
+		switch obj := object.(type) {
+		case *grafanav1alpha1.GrafanaDashboard:
+			obj.Spec.ConfigMap = v
+		}
+	}
+}
+
+func Folder(v string) Trait {
+	return func(object any) {
+		switch obj := object.(type) {
+		case *grafanav1alpha1.GrafanaDashboard:
+			obj.Spec.Folder = v
+		}
+	}
+}
+
+func RolePermission(role string, permission grafanav1alpha1.DashboardPermission) Trait {
+	return func(object any) {
+		switch obj := object.(type) {
+		case *grafanav1alpha1.GrafanaDashboard:
+			obj.Spec.Permissions = append(obj.Spec.Permissions, grafanav1alpha1.GrafanaDashboardPermission{Role: role, Permission: permission})
+		}
+	}
+}
+
+func GrafanaDatasourceFactory(base *grafanav1alpha1.GrafanaDatasource, traits ...Trait) *grafanav1alpha1.GrafanaDatasource {
+	var s *grafanav1alpha1.GrafanaDatasource
+	if base == nil {
+		s = &grafanav1alpha1.GrafanaDatasource{}
+	} else {
+		s = base.DeepCopy()
+	}
+
+	setGVK(s, client.Scheme)
+
+	for _, v := range traits {
+		v(s)
+	}
+
+	return s
+}
+
+func Datasource(typ, url string) Trait {
+	return func(object any) {
+		switch obj := object.(type) {
+		case *grafanav1alpha1.GrafanaDatasource:
+			obj.Spec.Type = typ
+			obj.Spec.URL = url
+		}
+	}
+}
+
+func BasicAuth(user string, password *corev1.SecretKeySelector) Trait {
+	return func(object any) {
+		switch obj := object.(type) {
+		case *grafanav1alpha1.GrafanaDatasource:
+			obj.Spec.BasicAuthUser = user
+			obj.Spec.BasicAuthPassword = password
+		}
+	}
+}
+
 func HarborProjectFactory(base *harborv1alpha1.HarborProject, traits ...Trait) *harborv1alpha1.HarborProject {
 	var s *harborv1alpha1.HarborProject
 	if base == nil {
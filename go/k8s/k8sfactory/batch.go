@@ -4,6 +4,8 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	"k8s.io/client-go/kubernetes/scheme"
+
+	"go.f110.dev/mono/go/api/etcdv1alpha1"
 )
 
 func CronJobFactory(base *batchv1.CronJob, traits ...Trait) *batchv1.CronJob {
@@ -30,6 +32,8 @@ func Schedule(v string) Trait {
 			obj.Spec.Schedule = v
 		case *batchv1.CronJob:
 			obj.Spec.Schedule = v
+		case *etcdv1alpha1.EtcdBackup:
+			obj.Spec.Schedule = v
 		}
 	}
 }
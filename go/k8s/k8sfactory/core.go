@@ -674,6 +674,15 @@ func SecretKeySelector(secret *corev1.Secret, key string) *corev1.SecretKeySelec
 	}
 }
 
+func ConfigMapKeySelector(configMap *corev1.ConfigMap, key string) *corev1.ConfigMapKeySelector {
+	return &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: configMap.Name,
+		},
+		Key: key,
+	}
+}
+
 func LocalObjectReference(obj metav1.Object) corev1.LocalObjectReference {
 	return corev1.LocalObjectReference{Name: obj.GetName()}
 }
@@ -10,6 +10,7 @@ import (
 
 var (
 	ErrObjectNotFound = xerrors.New("storage: object not found")
+	ErrObjectExists   = xerrors.New("storage: object already exists")
 )
 
 // storageInterface defines common interface for the object storage.
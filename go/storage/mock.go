@@ -194,6 +194,14 @@ func (m *Mock) Put(_ context.Context, name string, data []byte) error {
 	return nil
 }
 
+// PutIfNotExist puts the object only if the object doesn't exist.
+func (m *Mock) PutIfNotExist(ctx context.Context, name string, data []byte) error {
+	if m.findNode(name) != nil {
+		return xerrors.WithStack(ErrObjectExists)
+	}
+	return m.Put(ctx, name, data)
+}
+
 func (m *Mock) PutReader(ctx context.Context, name string, data io.Reader) error {
 	buf, err := io.ReadAll(data)
 	if err != nil {
@@ -17,6 +17,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"go.f110.dev/xerrors"
 	"go.uber.org/zap"
 
@@ -281,6 +282,42 @@ func (s *S3) PutReader(ctx context.Context, name string, r io.Reader) error {
 	}
 }
 
+// PutIfNotExist puts the object only if the object doesn't exist.
+// If the object already exists, PutIfNotExist returns ErrObjectExists.
+// Note that ErrObjectExists is also returned when the previous attempt of the retry has succeeded.
+func (s *S3) PutIfNotExist(ctx context.Context, name string, data []byte) error {
+	c, err := s.opt.Client()
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	retryCount := 1
+	for {
+		_, err = c.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(name),
+			Body:   bytes.NewReader(data),
+		}, s3.WithAPIOptions(smithyhttp.AddHeaderValue("If-None-Match", "*")))
+		if err != nil {
+			var apiErr smithy.APIError
+			if errors.As(err, &apiErr) {
+				switch apiErr.ErrorCode() {
+				case "PreconditionFailed", "ConditionalRequestConflict":
+					return ErrObjectExists
+				}
+			}
+			if s.opt.Retries > 0 && retryCount < s.opt.Retries {
+				logger.Log.Info("Retrying put a object", zap.Int("retryCount", retryCount), zap.String("key", name))
+				retryCount++
+				continue
+			}
+			return xerrors.WithStack(err)
+		}
+
+		return nil
+	}
+}
+
 func (s *S3) Delete(ctx context.Context, name string) error {
 	c, err := s.opt.Client()
 	if err != nil {
@@ -1,3 +1,3 @@
 package database
 
-const SchemaHash = "3013060b13689ee957439100932558aa43bf8d480de23c3ae6042c4d10d91150"
+const SchemaHash = "61634f15e372b454eebdbf00e8a7eada9a80087724387b0863154faf994985b1"
@@ -10,6 +10,7 @@ CREATE TABLE `source_repository` (
 	`clone_url` VARCHAR(255) NOT NULL,
 	`name` VARCHAR(100) NOT NULL,
 	`private` TINYINT(1) NOT NULL,
+	`artifact_retention_days` INTEGER NOT NULL,
 	`created_at` DATETIME NOT NULL,
 	`updated_at` DATETIME NULL,
 	PRIMARY KEY(`id`)
@@ -40,6 +41,15 @@ CREATE TABLE `task` (
 	`succeeded_tests_count` INTEGER NOT NULL,
 	`start_at` DATETIME NULL,
 	`finished_at` DATETIME NULL,
+	`canceled` TINYINT(1) NOT NULL,
+	`lines_found` INTEGER NOT NULL,
+	`lines_hit` INTEGER NOT NULL,
+	`pull_request` INTEGER NOT NULL,
+	`superseded_by` INTEGER NOT NULL,
+	`attempt` INTEGER NOT NULL,
+	`retry_of` INTEGER NOT NULL,
+	`matrix` VARCHAR(255) NOT NULL,
+	`group_id` INTEGER NOT NULL,
 	`created_at` DATETIME NOT NULL,
 	`updated_at` DATETIME NULL,
 	INDEX `idx_repo` (`repository_id`),
@@ -51,6 +61,7 @@ CREATE TABLE `trusted_user` (
 	`id` INTEGER NOT NULL AUTO_INCREMENT,
 	`github_id` BIGINT NOT NULL,
 	`username` VARCHAR(255) NOT NULL,
+	`host` VARCHAR(255) NOT NULL DEFAULT "github.com",
 	`created_at` DATETIME NOT NULL,
 	`updated_at` DATETIME NULL,
 	PRIMARY KEY(`id`)
@@ -61,6 +72,7 @@ CREATE TABLE `permit_pull_request` (
 	`id` INTEGER NOT NULL AUTO_INCREMENT,
 	`repository` VARCHAR(255) NOT NULL,
 	`number` INTEGER NOT NULL,
+	`host` VARCHAR(255) NOT NULL DEFAULT "github.com",
 	`created_at` DATETIME NOT NULL,
 	`updated_at` DATETIME NULL,
 	PRIMARY KEY(`id`)
@@ -80,4 +92,55 @@ CREATE TABLE `test_report` (
 	PRIMARY KEY(`id`)
 ) Engine=InnoDB;
 
+DROP TABLE IF EXISTS `flaky_test`;
+CREATE TABLE `flaky_test` (
+	`id` INTEGER NOT NULL AUTO_INCREMENT,
+	`repository_id` INTEGER NOT NULL,
+	`label` VARCHAR(255) NOT NULL,
+	`revision` VARCHAR(255) NOT NULL,
+	`created_at` DATETIME NOT NULL,
+	`updated_at` DATETIME NULL,
+	INDEX `idx_repository_label` (`repository_id`, `label`),
+	PRIMARY KEY(`id`)
+) Engine=InnoDB;
+
+DROP TABLE IF EXISTS `coverage_report`;
+CREATE TABLE `coverage_report` (
+	`id` INTEGER NOT NULL AUTO_INCREMENT,
+	`repository_id` INTEGER NOT NULL,
+	`task_id` INTEGER NOT NULL,
+	`path` TEXT NOT NULL,
+	`lines_found` INTEGER NOT NULL,
+	`lines_hit` INTEGER NOT NULL,
+	INDEX `idx_task_id` (`task_id`),
+	PRIMARY KEY(`id`)
+) Engine=InnoDB;
+
+DROP TABLE IF EXISTS `artifact`;
+CREATE TABLE `artifact` (
+	`id` INTEGER NOT NULL AUTO_INCREMENT,
+	`repository_id` INTEGER NOT NULL,
+	`task_id` INTEGER NOT NULL,
+	`name` TEXT NOT NULL,
+	`path` TEXT NOT NULL,
+	`size` BIGINT NOT NULL,
+	INDEX `idx_task_id` (`task_id`),
+	PRIMARY KEY(`id`)
+) Engine=InnoDB;
+
+DROP TABLE IF EXISTS `job_schedule`;
+CREATE TABLE `job_schedule` (
+	`id` INTEGER NOT NULL AUTO_INCREMENT,
+	`repository_id` INTEGER NOT NULL,
+	`job_name` VARCHAR(255) NOT NULL,
+	`schedule` VARCHAR(255) NOT NULL,
+	`last_task_id` INTEGER NOT NULL,
+	`last_run_at` DATETIME NULL,
+	`next_run_at` DATETIME NULL,
+	`created_at` DATETIME NOT NULL,
+	`updated_at` DATETIME NULL,
+	INDEX `idx_repository_job` (`repository_id`, `job_name`),
+	PRIMARY KEY(`id`)
+) Engine=InnoDB;
+
 SET foreign_key_checks=1;